@@ -0,0 +1,89 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/pingcap/tidb-operator/pkg/start"
+	"github.com/pingcap/tidb-operator/pkg/version"
+	"k8s.io/component-base/logs"
+	"k8s.io/klog/v2"
+)
+
+var (
+	printVersion bool
+	configPath   string
+	configData   string
+	installPath  string
+)
+
+func init() {
+	klog.InitFlags(nil)
+	flag.BoolVar(&printVersion, "V", false, "Show version and quit")
+	flag.BoolVar(&printVersion, "version", false, "Show version and quit")
+	flag.StringVar(&configPath, "config", "", "The path of the start config of the component")
+	flag.StringVar(&configData, "config-data", "", "The start config of the component, it is used if -config is not set")
+	flag.StringVar(&installPath, "install", "", "Copy this binary to the given path and quit, it is used by the init container")
+	flag.Parse()
+}
+
+func main() {
+	if printVersion {
+		version.PrintVersionInfo()
+		os.Exit(0)
+	}
+
+	logs.InitLogs()
+	defer logs.FlushLogs()
+
+	if installPath != "" {
+		if err := start.Install(installPath); err != nil {
+			klog.ErrorS(err, "Failed to install the start binary", "path", installPath)
+			logs.FlushLogs()
+			os.Exit(1)
+		}
+		klog.InfoS("Installed the start binary", "path", installPath)
+		return
+	}
+
+	data := []byte(configData)
+	if configPath != "" {
+		var err error
+		data, err = os.ReadFile(configPath)
+		if err != nil {
+			klog.ErrorS(err, "Failed to read the start config", "path", configPath)
+			logs.FlushLogs()
+			os.Exit(1)
+		}
+	}
+	cfg, err := start.Unmarshal(data)
+	if err != nil {
+		klog.ErrorS(err, "Failed to parse the start config")
+		logs.FlushLogs()
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	if err := start.Run(ctx, cfg); err != nil {
+		klog.ErrorS(err, "Failed to start the component", "component", cfg.Component)
+		logs.FlushLogs()
+		os.Exit(1)
+	}
+}
@@ -34,19 +34,23 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/controller/dmcluster"
 	"github.com/pingcap/tidb-operator/pkg/controller/restore"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbcluster"
+	"github.com/pingcap/tidb-operator/pkg/controller/tidbclusterscalingschedule"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbdashboard"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbinitializer"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbmonitor"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbngmonitoring"
+	"github.com/pingcap/tidb-operator/pkg/controller/tidbtenant"
 	"github.com/pingcap/tidb-operator/pkg/features"
 	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/scheme"
+	"github.com/pingcap/tidb-operator/pkg/tracing"
 	"github.com/pingcap/tidb-operator/pkg/upgrader"
 	"github.com/pingcap/tidb-operator/pkg/version"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/leaderelection"
@@ -83,6 +87,20 @@ func main() {
 
 	logCustomPorts()
 
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		Endpoint:    cliCfg.TracingOTLPEndpoint,
+		Insecure:    cliCfg.TracingOTLPInsecure,
+		SampleRatio: cliCfg.TracingSampleRatio,
+	})
+	if err != nil {
+		klog.Fatalf("failed to init tracing: %v", err)
+	}
+	defer func() {
+		if err2 := shutdownTracing(context.Background()); err2 != nil {
+			klog.Errorf("failed to shutdown tracing: %v", err2)
+		}
+	}()
+
 	hostName, err := os.Hostname()
 	if err != nil {
 		klog.Fatalf("failed to get hostname: %v", err)
@@ -121,6 +139,10 @@ func main() {
 	if err != nil {
 		klog.Fatalf("failed to get kubernetes Clientset: %v", err)
 	}
+	metadataCli, err := metadata.NewForConfig(cfg)
+	if err != nil {
+		klog.Fatalf("failed to get kubernetes metadata Clientset: %v", err)
+	}
 	asCli, err := asclientset.NewForConfig(cfg)
 	if err != nil {
 		klog.Fatalf("failed to get advanced-statefulset Clientset: %v", err)
@@ -145,11 +167,30 @@ func main() {
 		kubeCli = helper.NewHijackClient(kubeCli, asCli)
 	}
 
-	deps, err := controller.NewDependencies(ns, cliCfg, cli, kubeCli, genericCli)
+	deps, err := controller.NewDependencies(ns, cliCfg, cli, kubeCli, metadataCli, genericCli)
 	if err != nil {
 		klog.Fatalf("failed to create Dependencies: %s", err)
 	}
 
+	endPointsName := "tidb-controller-manager"
+	if helmRelease != "" {
+		endPointsName += "-" + helmRelease
+	}
+	shardID := cliCfg.ShardID
+	if shardID == "" && cliCfg.ShardPodName != "" {
+		shardID, err = controller.ShardIDOfPod(cliCfg.ShardPodName)
+		if err != nil {
+			klog.Fatalf("failed to get the shard id: %v", err)
+		}
+	}
+	var sharder *controller.LeaseSharder
+	if shardID != "" {
+		// every shard elects its own leader, and the leaders of all shards are active
+		sharder = controller.NewLeaseSharder(kubeCli, ns, endPointsName, shardID, cliCfg.LeaseDuration)
+		deps.Sharder = sharder
+		endPointsName = controller.ShardLeaseName(endPointsName, shardID)
+	}
+
 	onStarted := func(ctx context.Context) {
 		// Upgrade before running any controller logic. If it fails, we wait
 		// for process supervisor to restart it again.
@@ -157,6 +198,12 @@ func main() {
 			klog.Fatalf("failed to upgrade: %v", err)
 		}
 
+		if sharder != nil {
+			if err := sharder.Start(ctx, cliCfg.RetryPeriod); err != nil {
+				klog.Fatalf("failed to start shard %s: %v", shardID, err)
+			}
+		}
+
 		// Define some nested types to simplify the codebase
 		type Controller interface {
 			Run(int, <-chan struct{})
@@ -189,6 +236,8 @@ func main() {
 			tidbmonitor.NewController(deps),
 			tidbngmonitoring.NewController(deps),
 			tidbdashboard.NewController(deps),
+			tidbtenant.NewController(deps),
+			tidbclusterscalingschedule.NewController(deps),
 		}
 
 		// Start informer factories after all controllers are initialized.
@@ -196,6 +245,7 @@ func main() {
 			deps.InformerFactory,
 			deps.KubeInformerFactory,
 			deps.LabelFilterKubeInformerFactory,
+			deps.SecretInformerFactory,
 		}
 		for _, f := range informerFactories {
 			f.Start(ctx.Done())
@@ -205,6 +255,12 @@ func main() {
 				}
 			}
 		}
+		deps.MetadataInformerFactory.Start(ctx.Done())
+		for v, synced := range deps.MetadataInformerFactory.WaitForCacheSync(wait.NeverStop) {
+			if !synced {
+				klog.Fatalf("error syncing metadata informer for %v", v)
+			}
+		}
 		klog.Info("cache of informer factories sync successfully")
 
 		// Start syncLoop for all controllers
@@ -218,10 +274,6 @@ func main() {
 		klog.Fatal("leader election lost")
 	}
 
-	endPointsName := "tidb-controller-manager"
-	if helmRelease != "" {
-		endPointsName += "-" + helmRelease
-	}
 	// leader election for multiple tidb-controller-manager instances
 	go wait.Forever(func() {
 		lock, err := resourcelock.New(cliCfg.ResourceLock,
@@ -261,6 +313,14 @@ func main() {
 	go func() {
 		sig := <-sc
 		klog.Infof("got signal %s to exit", sig)
+		if sharder != nil {
+			// hand off the objects of this shard to the other shards before exiting
+			ctx, cancel := context.WithTimeout(context.Background(), cliCfg.LeaseDuration)
+			if err := sharder.Leave(ctx, cliCfg.RetryPeriod); err != nil {
+				klog.Errorf("failed to leave shard %s, its objects are handed off once its lease expires: %v", shardID, err)
+			}
+			cancel()
+		}
 		if err2 := srv.Shutdown(context.Background()); err2 != nil {
 			klog.Fatal("fail to shutdown the HTTP server", err2)
 		}
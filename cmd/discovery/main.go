@@ -43,9 +43,10 @@ import (
 )
 
 var (
-	printVersion bool
-	port         int
-	proxyPort    int
+	printVersion  bool
+	port          int
+	proxyPort     int
+	activatorPort int
 )
 
 func init() {
@@ -54,6 +55,7 @@ func init() {
 	flag.BoolVar(&printVersion, "version", false, "Show version and quit")
 	flag.IntVar(&port, "port", 10261, "The port that the tidb discovery's http service runs on (default 10261)")
 	flag.IntVar(&proxyPort, "proxy-port", 10262, "The port that the tidb discovery's proxy service runs on (default 10262)")
+	flag.IntVar(&activatorPort, "activator-port", 10263, "The port that the tidb discovery's activator for the idle TiDB runs on (default 10263)")
 	flag.Parse()
 }
 
@@ -121,6 +123,14 @@ func main() {
 		proxyServer := server.NewProxyServer(tcName, tcTls)
 		proxyServer.ListenAndServe(addr)
 	}, 5*time.Second)
+	if os.Getenv("TC_TIDB_ACTIVATOR_ENABLED") == strconv.FormatBool(true) {
+		go wait.Forever(func() {
+			addr := fmt.Sprintf("0.0.0.0:%d", activatorPort)
+			klog.Infof("starting TiDB activator server, listening on %s", addr)
+			activatorServer := server.NewActivatorServer(tcName, os.Getenv("MY_POD_NAMESPACE"), cli)
+			activatorServer.ListenAndServe(addr)
+		}, 5*time.Second)
+	}
 
 	srv := http.Server{Addr: ":6060"}
 	sc := make(chan os.Signal, 1)
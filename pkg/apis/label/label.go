@@ -19,6 +19,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 )
 
 const (
@@ -46,6 +47,15 @@ const (
 	StoreIDLabelKey string = "tidb.pingcap.com/store-id"
 	// MemberIDLabelKey is member id label key
 	MemberIDLabelKey string = "tidb.pingcap.com/member-id"
+	// TiDBGroupLabelKey is the label key of the TiDB group a TiDB pod belongs to
+	TiDBGroupLabelKey string = "tidb.pingcap.com/tidb-group"
+	// TiDBDefaultGroupLabelKey is the label key of the pods of the default TiDB, it is set
+	// when the cluster has TiDB groups so that the services of the default TiDB can exclude the group pods
+	TiDBDefaultGroupLabelKey string = "tidb.pingcap.com/tidb-default-group"
+	// TiKVGroupLabelKey is the label key of the TiKV group a TiKV pod belongs to
+	TiKVGroupLabelKey string = "tidb.pingcap.com/tikv-group"
+	// TiFlashRoleLabelKey is the label key of the role of a TiFlash pod in the disaggregated architecture
+	TiFlashRoleLabelKey string = "tidb.pingcap.com/tiflash-role"
 
 	// InitLabelKey is the key for TiDB initializer
 	InitLabelKey string = "tidb.pingcap.com/initializer"
@@ -90,6 +100,40 @@ const (
 	AnnForceUpgradeKey = "tidb.pingcap.com/force-upgrade"
 	// AnnPDDeferDeleting is pd pod annotation key  in pod for defer for deleting pod
 	AnnPDDeferDeleting = "tidb.pingcap.com/pd-defer-deleting"
+	// AnnPDMode is pd pod annotation key to indicate the mode that pd runs in, it is only changed
+	// when pd is switched between pd and pd microservices so that the pd pods are restarted in the new mode
+	AnnPDMode = "tidb.pingcap.com/pd-mode"
+	// AnnTLSClusterCertSerial is pod annotation key to indicate the serial number of the certificate issued by
+	// the operator, it restarts the components that can not reload certificates when the certificate is renewed
+	AnnTLSClusterCertSerial = "tidb.pingcap.com/tls-cluster-cert-serial"
+	// AnnTiDBWakeUp is tc annotation key to wake up TiDB that is scaled to zero by the idle policy, its value is
+	// the time in RFC3339 format when TiDB is requested to wake up, it is set by the activator on new connections
+	AnnTiDBWakeUp = "tidb.pingcap.com/tidb-wake-up"
+	// AnnTiKVForceScaleIn is tc annotation key to skip the capacity and placement feasibility check before scaling in TiKV,
+	// the check is skipped if its value is "true"
+	AnnTiKVForceScaleIn = "tidb.pingcap.com/tikv-force-scale-in"
+	// AnnAllowPDQuorumUnsafeScaleIn is tc annotation key to allow scaling in PD by a majority of the current replicas
+	// in one step, which is refused by the admission webhook if its value is not "true"
+	AnnAllowPDQuorumUnsafeScaleIn = "tidb.pingcap.com/allow-pd-quorum-unsafe-scale-in"
+	// AnnAllowTiKVBelowMaxReplicas is tc annotation key to allow scaling in TiKV to fewer replicas than the max-replicas
+	// of PD, which is refused by the admission webhook if its value is not "true"
+	AnnAllowTiKVBelowMaxReplicas = "tidb.pingcap.com/allow-tikv-below-max-replicas"
+	// AnnAllowVersionDowngrade is tc annotation key to allow downgrading the version of the cluster, which is refused
+	// by the admission webhook if its value is not "true"
+	AnnAllowVersionDowngrade = "tidb.pingcap.com/allow-version-downgrade"
+	// AnnAllowStorageShrink is tc annotation key to allow shrinking the storage requests of the components, which is
+	// refused by the admission webhook if its value is not "true"
+	AnnAllowStorageShrink = "tidb.pingcap.com/allow-storage-shrink"
+	// AnnAllowTLSClusterChange is tc annotation key to allow enabling or disabling TLSCluster on a running cluster,
+	// which is refused by the admission webhook if its value is not "true"
+	AnnAllowTLSClusterChange = "tidb.pingcap.com/allow-tls-cluster-change"
+	// AnnChangePlan is tc annotation key to enable the plan mode if its value is "true", the operator computes the
+	// changes to the StatefulSets and the volumes in the status and applies them only after the plan is approved,
+	// the failover and the scaling in progress are not held by the plan
+	AnnChangePlan = "tidb.pingcap.com/change-plan"
+	// AnnChangePlanApproved is tc annotation key to approve the change plan in the plan mode, its value is the ID
+	// of the plan in the status
+	AnnChangePlanApproved = "tidb.pingcap.com/change-plan-approved"
 	// AnnSysctlInit is pod annotation key to indicate whether configuring sysctls with init container
 	AnnSysctlInit = "tidb.pingcap.com/sysctl-init"
 	// AnnEvictLeaderBeginTime is pod annotation key to indicate the begin time for evicting region leader
@@ -98,6 +142,9 @@ const (
 	AnnTiCDCGracefulShutdownBeginTime = "tidb.pingcap.com/ticdc-graceful-shutdown-begin-time"
 	// AnnStsLastSyncTimestamp is sts annotation key to indicate the last timestamp the operator sync the sts
 	AnnStsLastSyncTimestamp = "tidb.pingcap.com/sync-timestamp"
+	// AnnStsSpecReplicas is sts annotation key to record the replicas in the spec applied to the sts in the plan mode,
+	// the replicas added or removed by the operator, e.g. for failover, are not included
+	AnnStsSpecReplicas = "tidb.pingcap.com/spec-replicas"
 	// AnnTiflashMountCMInTiflashContainer is tiflash pod annotation key to indicate whether directly mount ConfigMap
 	// in tiflash container instead of init container for tiflash. With it annotated, the tiflash container will directly
 	// read config from files mounted by ConfigMap and that enables tiflash support hot-reload config.
@@ -159,6 +206,23 @@ const (
 	// NOTE: this annotation should only be used for existing TiFlash v7.1.0+ clusters with ports config items.
 	AnnoTiFlash710KeepPortsKey = "tidb.pingcap.com/tiflash-710-keep-ports"
 
+	// AnnTiDBGroupKey is the annotation key set on the per-group view of a TidbCluster
+	// built by the TiDB group member manager, its value is the name of the TiDB group.
+	// It is only used in memory and must not be set on TidbCluster objects.
+	AnnTiDBGroupKey = "tidb.pingcap.com/tidb-group"
+	// AnnTiKVGroupKey is the annotation key set on the per-group view of a TidbCluster
+	// built by the TiKV group member manager, its value is the name of the TiKV group.
+	// It is only used in memory and must not be set on TidbCluster objects.
+	AnnTiKVGroupKey = "tidb.pingcap.com/tikv-group"
+	// AnnTiFlashRoleKey is the annotation key set on the TiFlash compute view of a TidbCluster
+	// built by the TiFlash compute member manager, its value is the role of the TiFlash nodes.
+	// It is only used in memory and must not be set on TidbCluster objects.
+	AnnTiFlashRoleKey = "tidb.pingcap.com/tiflash-role"
+	// AnnChangePlanIDKey is the annotation key set on the views of a TidbCluster in the plan mode, its value
+	// is the ID of the change plan of the TidbCluster the view is built from.
+	// It is only used in memory and must not be set on TidbCluster objects.
+	AnnChangePlanIDKey = "tidb.pingcap.com/change-plan-id"
+
 	// AnnTiKVNoActiveStoreSince is the annotation key to indicate the time since a pod does not have a valid store
 	// Listed from store status, but has a store id in label. This is an alternate way to detect tombstone stores.
 	AnnTiKVNoActiveStoreSince = "tidb.pingcap.com/tikv-no-active-store-since"
@@ -175,6 +239,8 @@ const (
 	TiKVLabelVal string = "tikv"
 	// TiFlashLabelVal is TiFlash label value
 	TiFlashLabelVal string = "tiflash"
+	// TiFlashComputeRoleVal is the role value of TiFlash compute nodes
+	TiFlashComputeRoleVal string = "compute"
 	// TiCDCLabelVal is TiCDC label value
 	TiCDCLabelVal string = "ticdc"
 	// TiProxyLabelVal is TiProxy label value
@@ -189,6 +255,9 @@ const (
 	// TiDBMonitorProtectionFinalizer is the name of finalizer on TidbMonitors
 	TiDBMonitorProtectionFinalizer string = "tidb.pingcap.com/monitor-protection"
 
+	// TiDBTenantProtectionFinalizer is the name of finalizer on TidbTenants
+	TiDBTenantProtectionFinalizer string = "tidb.pingcap.com/tenant-protection"
+
 	// CleanJobLabelVal is clean job label value
 	CleanJobLabelVal string = "clean"
 	// RestoreJobLabelVal is restore job label value
@@ -525,6 +594,30 @@ func (l Label) TiDB() Label {
 	return l.Component(TiDBLabelVal)
 }
 
+// TiDBGroup assigns the TiDB group name to the tidb group key in label,
+// nothing is done for the default TiDB group, whose name is empty
+func (l Label) TiDBGroup(name string) Label {
+	if name != "" {
+		l[TiDBGroupLabelKey] = name
+	}
+	return l
+}
+
+// TiDBDefaultGroup assigns true to the tidb default group key in label
+func (l Label) TiDBDefaultGroup() Label {
+	l[TiDBDefaultGroupLabelKey] = "true"
+	return l
+}
+
+// TiDBGroupRequirement returns the selector requirement of the TiDB pods that belong to the TiDB group,
+// the pods without the TiDB group label are selected for the default TiDB group, whose name is empty
+func TiDBGroupRequirement(name string) (*labels.Requirement, error) {
+	if name == "" {
+		return labels.NewRequirement(TiDBGroupLabelKey, selection.DoesNotExist, nil)
+	}
+	return labels.NewRequirement(TiDBGroupLabelKey, selection.Equals, []string{name})
+}
+
 // IsTiDB returns whether label is a TiDB component
 func (l Label) IsTiDB() bool {
 	return l[ComponentLabelKey] == TiDBLabelVal
@@ -535,6 +628,24 @@ func (l Label) TiKV() Label {
 	return l.Component(TiKVLabelVal)
 }
 
+// TiKVGroup assigns the TiKV group name to the tikv group key in label,
+// nothing is done for the default TiKV group, whose name is empty
+func (l Label) TiKVGroup(name string) Label {
+	if name != "" {
+		l[TiKVGroupLabelKey] = name
+	}
+	return l
+}
+
+// TiKVGroupRequirement returns the selector requirement of the TiKV pods that belong to the TiKV group,
+// the pods without the TiKV group label are selected for the default TiKV group, whose name is empty
+func TiKVGroupRequirement(name string) (*labels.Requirement, error) {
+	if name == "" {
+		return labels.NewRequirement(TiKVGroupLabelKey, selection.DoesNotExist, nil)
+	}
+	return labels.NewRequirement(TiKVGroupLabelKey, selection.Equals, []string{name})
+}
+
 // IsTiKV returns whether label is a TiKV component
 func (l Label) IsTiKV() bool {
 	return l[ComponentLabelKey] == TiKVLabelVal
@@ -545,6 +656,24 @@ func (l Label) TiFlash() Label {
 	return l.Component(TiFlashLabelVal)
 }
 
+// TiFlashRole assigns the TiFlash role to the tiflash role key in label,
+// nothing is done for the write nodes, whose role is empty
+func (l Label) TiFlashRole(role string) Label {
+	if role != "" {
+		l[TiFlashRoleLabelKey] = role
+	}
+	return l
+}
+
+// TiFlashRoleRequirement returns the selector requirement of the TiFlash pods with the role,
+// the pods without the TiFlash role label are selected for the write nodes, whose role is empty
+func TiFlashRoleRequirement(role string) (*labels.Requirement, error) {
+	if role == "" {
+		return labels.NewRequirement(TiFlashRoleLabelKey, selection.DoesNotExist, nil)
+	}
+	return labels.NewRequirement(TiFlashRoleLabelKey, selection.Equals, []string{role})
+}
+
 // IsTiFlash returns whether label is a TiFlash component
 func (l Label) IsTiFlash() bool {
 	return l[ComponentLabelKey] == TiFlashLabelVal
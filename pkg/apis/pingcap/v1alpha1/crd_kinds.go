@@ -57,6 +57,14 @@ const (
 	TiDBDashboardKind    = "TidbDashboard"
 	TiDBDashboardKindKey = "tidbdashboard"
 
+	TiDBTenantName    = "tidbtenants"
+	TiDBTenantKind    = "TidbTenant"
+	TiDBTenantKindKey = "tidbtenant"
+
+	TiDBClusterScalingScheduleName    = "tidbclusterscalingschedules"
+	TiDBClusterScalingScheduleKind    = "TidbClusterScalingSchedule"
+	TiDBClusterScalingScheduleKindKey = "tidbclusterscalingschedule"
+
 	SpecPath = "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1."
 )
 
@@ -0,0 +1,63 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package defaulting
+
+import (
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+// SetBackupDefault sets the default values of Backup which can not be defaulted by the CRD.
+func SetBackupDefault(backup *v1alpha1.Backup) {
+	setBackupSpecDefault(&backup.Spec, backup.Namespace)
+}
+
+// SetRestoreDefault sets the default values of Restore which can not be defaulted by the CRD.
+func SetRestoreDefault(restore *v1alpha1.Restore) {
+	setBRConfigDefault(restore.Spec.BR, restore.Namespace)
+	if restore.Spec.BR != nil && restore.Spec.Type == "" &&
+		(restore.Spec.Mode == "" || restore.Spec.Mode == v1alpha1.RestoreModeSnapshot) {
+		restore.Spec.Type = v1alpha1.BackupTypeFull
+	}
+}
+
+// SetBackupScheduleDefault sets the default values of the templates of BackupSchedule.
+func SetBackupScheduleDefault(bs *v1alpha1.BackupSchedule) {
+	setBackupSpecDefault(&bs.Spec.BackupTemplate, bs.Namespace)
+	if bs.Spec.LogBackupTemplate != nil {
+		setBackupSpecDefault(bs.Spec.LogBackupTemplate, bs.Namespace)
+	}
+	if bs.Spec.CompactBackupTemplate != nil {
+		setBRConfigDefault(bs.Spec.CompactBackupTemplate.BR, bs.Namespace)
+	}
+}
+
+// SetCompactBackupDefault sets the default values of CompactBackup which can not be defaulted by the CRD.
+func SetCompactBackupDefault(compact *v1alpha1.CompactBackup) {
+	setBRConfigDefault(compact.Spec.BR, compact.Namespace)
+}
+
+func setBackupSpecDefault(spec *v1alpha1.BackupSpec, ns string) {
+	setBRConfigDefault(spec.BR, ns)
+	if spec.BR != nil && spec.Type == "" && (spec.Mode == "" || spec.Mode == v1alpha1.BackupModeSnapshot) {
+		spec.Type = v1alpha1.BackupTypeFull
+	}
+}
+
+// setBRConfigDefault sets the namespace of the cluster to the namespace of the object, which is assumed
+// by the backup manager if it is not set.
+func setBRConfigDefault(br *v1alpha1.BRConfig, ns string) {
+	if br != nil && br.ClusterNamespace == "" {
+		br.ClusterNamespace = ns
+	}
+}
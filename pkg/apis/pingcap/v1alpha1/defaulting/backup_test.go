@@ -0,0 +1,65 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package defaulting
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetBackupDefault(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	backup := &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns"},
+		Spec: v1alpha1.BackupSpec{
+			BR: &v1alpha1.BRConfig{Cluster: "basic"},
+		},
+	}
+	SetBackupDefault(backup)
+	g.Expect(backup.Spec.BR.ClusterNamespace).To(Equal("ns"))
+	g.Expect(backup.Spec.Type).To(Equal(v1alpha1.BackupTypeFull))
+
+	// the type is not used by log backup
+	backup = &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns"},
+		Spec: v1alpha1.BackupSpec{
+			Mode: v1alpha1.BackupModeLog,
+			BR:   &v1alpha1.BRConfig{Cluster: "basic", ClusterNamespace: "another"},
+		},
+	}
+	SetBackupDefault(backup)
+	g.Expect(backup.Spec.BR.ClusterNamespace).To(Equal("another"))
+	g.Expect(backup.Spec.Type).To(BeEmpty())
+}
+
+func TestSetBackupScheduleDefault(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bs := &v1alpha1.BackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns"},
+		Spec: v1alpha1.BackupScheduleSpec{
+			BackupTemplate:        v1alpha1.BackupSpec{BR: &v1alpha1.BRConfig{Cluster: "basic"}},
+			LogBackupTemplate:     &v1alpha1.BackupSpec{Mode: v1alpha1.BackupModeLog, BR: &v1alpha1.BRConfig{Cluster: "basic"}},
+			CompactBackupTemplate: &v1alpha1.CompactSpec{BR: &v1alpha1.BRConfig{Cluster: "basic"}},
+		},
+	}
+	SetBackupScheduleDefault(bs)
+	g.Expect(bs.Spec.BackupTemplate.BR.ClusterNamespace).To(Equal("ns"))
+	g.Expect(bs.Spec.LogBackupTemplate.BR.ClusterNamespace).To(Equal("ns"))
+	g.Expect(bs.Spec.CompactBackupTemplate.BR.ClusterNamespace).To(Equal("ns"))
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package defaulting
+
+import (
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"k8s.io/utils/pointer"
+)
+
+func SetTidbTenantDefault(tt *v1alpha1.TidbTenant) {
+	if tt.Spec.Cluster.Namespace == "" {
+		tt.Spec.Cluster.Namespace = tt.Namespace
+	}
+	if tt.Spec.KeyspaceReclaimPolicy == "" {
+		tt.Spec.KeyspaceReclaimPolicy = v1alpha1.KeyspaceReclaimRetain
+	}
+
+	tidb := &tt.Spec.TiDB
+	if tidb.BaseImage == "" && tidb.Image == "" {
+		tidb.BaseImage = defaultTiDBImage
+	}
+	if tidb.MaxFailoverCount == nil {
+		tidb.MaxFailoverCount = pointer.Int32Ptr(3)
+	}
+	// the TiDB servers of a tenant always have their own ConfigMap
+	if tidb.Config == nil {
+		tidb.Config = v1alpha1.NewTiDBConfig()
+	}
+	backupKey := "log.file.max-backups"
+	if v := tidb.Config.Get(backupKey); v == nil {
+		tidb.Config.Set(backupKey, tidbLogMaxBackups)
+	}
+}
@@ -44,9 +44,15 @@ func SetTidbClusterDefault(tc *v1alpha1.TidbCluster) {
 	if tc.Spec.TiKV != nil {
 		setTikvSpecDefault(tc)
 	}
+	if tc.Spec.TiKVGroups != nil {
+		setTiKVGroupsSpecDefault(tc)
+	}
 	if tc.Spec.TiDB != nil {
 		setTidbSpecDefault(tc)
 	}
+	if tc.Spec.TiDBGroups != nil {
+		setTiDBGroupsSpecDefault(tc)
+	}
 	if tc.Spec.Pump != nil {
 		setPumpSpecDefault(tc)
 	}
@@ -106,6 +112,30 @@ func setTidbSpecDefault(tc *v1alpha1.TidbCluster) {
 	}
 }
 
+func setTiDBGroupsSpecDefault(tc *v1alpha1.TidbCluster) {
+	for _, group := range tc.Spec.TiDBGroups {
+		if group == nil {
+			continue
+		}
+		if len(tc.Spec.Version) > 0 || group.Version != nil {
+			if group.BaseImage == "" {
+				group.BaseImage = defaultTiDBImage
+			}
+		}
+		if group.MaxFailoverCount == nil {
+			group.MaxFailoverCount = pointer.Int32Ptr(3)
+		}
+		// TiDB groups always have their own ConfigMap
+		if group.Config == nil {
+			group.Config = v1alpha1.NewTiDBConfig()
+		}
+		backupKey := "log.file.max-backups"
+		if v := group.Config.Get(backupKey); v == nil {
+			group.Config.Set(backupKey, tidbLogMaxBackups)
+		}
+	}
+}
+
 func setTikvSpecDefault(tc *v1alpha1.TidbCluster) {
 	if len(tc.Spec.Version) > 0 || tc.Spec.TiKV.Version != nil {
 		if tc.Spec.TiKV.BaseImage == "" {
@@ -120,6 +150,29 @@ func setTikvSpecDefault(tc *v1alpha1.TidbCluster) {
 	}
 }
 
+func setTiKVGroupsSpecDefault(tc *v1alpha1.TidbCluster) {
+	for _, group := range tc.Spec.TiKVGroups {
+		if group == nil {
+			continue
+		}
+		if len(tc.Spec.Version) > 0 || group.Version != nil {
+			if group.BaseImage == "" {
+				group.BaseImage = defaultTiKVImage
+			}
+		}
+		if group.MaxFailoverCount == nil {
+			group.MaxFailoverCount = pointer.Int32Ptr(3)
+		}
+		if group.SpareVolReplaceReplicas == nil {
+			group.SpareVolReplaceReplicas = pointer.Int32Ptr(1)
+		}
+		// TiKV groups always have their own ConfigMap
+		if group.Config == nil {
+			group.Config = v1alpha1.NewTiKVConfig()
+		}
+	}
+}
+
 func setPdSpecDefault(tc *v1alpha1.TidbCluster) {
 	if len(tc.Spec.Version) > 0 || tc.Spec.PD.Version != nil {
 		if tc.Spec.PD.BaseImage == "" {
@@ -38,6 +38,14 @@ func (dc *DMCluster) Timezone() string {
 	return tz
 }
 
+// DiscoveryReplicas returns the desired replicas of the discovery service
+func (dc *DMCluster) DiscoveryReplicas() int32 {
+	if dc.Spec.Discovery.Replicas == nil {
+		return 1
+	}
+	return *dc.Spec.Discovery.Replicas
+}
+
 func (dc *DMCluster) IsPVReclaimEnabled() bool {
 	enabled := dc.Spec.EnablePVReclaim
 	if enabled == nil {
@@ -50,6 +58,16 @@ func (dc *DMCluster) IsTLSClusterEnabled() bool {
 	return dc.Spec.TLSCluster != nil && dc.Spec.TLSCluster.Enabled
 }
 
+// IsCertificateExpired returns whether any certificate consumed by the dm cluster is expired
+func (dc *DMCluster) IsCertificateExpired() bool {
+	for _, c := range dc.Status.Conditions {
+		if c.Type == DMClusterCertificateExpiringSoon {
+			return c.Reason == CertificateExpiredReason
+		}
+	}
+	return false
+}
+
 func (dc *DMCluster) MasterAllMembersReady() bool {
 	if int(dc.MasterStsDesiredReplicas()) != len(dc.Status.Master.Members) {
 		return false
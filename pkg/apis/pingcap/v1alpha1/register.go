@@ -51,6 +51,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&TidbCluster{},
 		&TidbClusterList{},
+		&TidbClusterScalingSchedule{},
+		&TidbClusterScalingScheduleList{},
 		&Backup{},
 		&BackupList{},
 		&CompactBackup{},
@@ -71,6 +73,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&TidbNGMonitoringList{},
 		&TidbDashboard{},
 		&TidbDashboardList{},
+		&TidbTenant{},
+		&TidbTenantList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -21,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
@@ -34,6 +35,8 @@ const (
 	defaultTimeZone           = "UTC"
 	defaultExposeStatus       = true
 	defaultSeparateSlowLog    = true
+	defaultTiDBIdleTimeout    = 30 * time.Minute
+	defaultTiDBWakeOnConnect  = true
 	defaultSeparateRocksDBLog = false
 	defaultSeparateRaftLog    = false
 	defaultEnablePVReclaim    = false
@@ -47,6 +50,12 @@ const (
 	defaultTiCDCGracefulShutdownTimeout = 10 * time.Minute
 	defaultPDStartTimeout               = 30
 	defaultPDInitWaitTime               = 0
+	// defaultTLSClusterCertDuration and defaultTLSClusterCertRenewBefore are the defaults
+	// of the certificates issued by the operator
+	defaultTLSClusterCertDuration    = 365 * 24 * time.Hour
+	defaultTLSClusterCertRenewBefore = 30 * 24 * time.Hour
+	// operationHistoryLimit is the max number of the operation records kept in the status
+	operationHistoryLimit = 20
 
 	// the latest version
 	versionLatest = "latest"
@@ -851,7 +860,36 @@ func (tc *TidbCluster) TiCDCDeployDesiredReplicas() int32 {
 		return 0
 	}
 
-	return tc.Spec.TiCDC.Replicas
+	return tc.Spec.TiCDC.Replicas + int32(len(tc.Status.TiCDC.FailureMembers))
+}
+
+func (tc *TidbCluster) TiCDCStsActualReplicas() int32 {
+	stsStatus := tc.Status.TiCDC.StatefulSet
+	if stsStatus == nil {
+		return 0
+	}
+	return stsStatus.Replicas
+}
+
+// TiCDCAllPodsStarted return whether all pods of TiCDC are started.
+//
+// If TiCDC isn't specified, return false.
+func (tc *TidbCluster) TiCDCAllPodsStarted() bool {
+	if tc.Spec.TiCDC == nil {
+		return false
+	}
+	return tc.TiCDCDeployDesiredReplicas() == tc.TiCDCStsActualReplicas()
+}
+
+func (tc *TidbCluster) TiCDCStsDesiredOrdinals(excludeFailover bool) sets.Int32 {
+	if tc.Spec.TiCDC == nil {
+		return sets.Int32{}
+	}
+	replicas := tc.Spec.TiCDC.Replicas
+	if !excludeFailover {
+		replicas = tc.TiCDCDeployDesiredReplicas()
+	}
+	return GetPodOrdinalsFromReplicasAndDeleteSlots(replicas, tc.getDeleteSlots(label.TiCDCLabelVal))
 }
 
 // TiDBAllPodsStarted return whether all pods of TiDB are started.
@@ -886,12 +924,33 @@ func (tc *TidbCluster) TiDBAllMembersReady() bool {
 }
 
 func (tc *TidbCluster) TiDBStsDesiredReplicas() int32 {
-	if tc.Spec.TiDB == nil {
+	if tc.Spec.TiDB == nil || tc.TiDBIdle() {
 		return 0
 	}
 	return tc.Spec.TiDB.Replicas + int32(len(tc.Status.TiDB.FailureMembers))
 }
 
+// TiDBIdlePhase returns the phase of TiDB under the idle policy.
+//
+// If the idle policy isn't specified, return TiDBIdlePhaseActive.
+func (tc *TidbCluster) TiDBIdlePhase() TiDBIdlePhase {
+	if tc.Spec.TiDB == nil || tc.Spec.TiDB.Idle == nil || tc.Status.TiDB.Idle == nil {
+		return TiDBIdlePhaseActive
+	}
+	return tc.Status.TiDB.Idle.Phase
+}
+
+// TiDBIdle returns whether TiDB is scaled to zero by the idle policy.
+func (tc *TidbCluster) TiDBIdle() bool {
+	return tc.TiDBIdlePhase() == TiDBIdlePhaseIdle
+}
+
+// TiDBWakeOnConnect returns whether the connections to the TiDB service are held by
+// the activator in the discovery service.
+func (tc *TidbCluster) TiDBWakeOnConnect() bool {
+	return tc.TiDBIdlePhase() != TiDBIdlePhaseActive && tc.Spec.TiDB.Idle.IsWakeOnConnectEnabled()
+}
+
 func (tc *TidbCluster) TiDBStsActualReplicas() int32 {
 	stsStatus := tc.Status.TiDB.StatefulSet
 	if stsStatus == nil {
@@ -905,12 +964,197 @@ func (tc *TidbCluster) TiDBStsDesiredOrdinals(excludeFailover bool) sets.Int32 {
 		return sets.Int32{}
 	}
 	replicas := tc.Spec.TiDB.Replicas
-	if !excludeFailover {
+	if !excludeFailover || tc.TiDBIdle() {
 		replicas = tc.TiDBStsDesiredReplicas()
 	}
 	return GetPodOrdinalsFromReplicasAndDeleteSlots(replicas, tc.getDeleteSlots(label.TiDBLabelVal))
 }
 
+// TiDBGroup returns the spec of the TiDB group with the given name.
+//
+// If the group isn't specified, return nil.
+func (tc *TidbCluster) TiDBGroup(name string) *TiDBGroupSpec {
+	for _, group := range tc.Spec.TiDBGroups {
+		if group != nil && group.Name == name {
+			return group
+		}
+	}
+	return nil
+}
+
+// TiDBGroupName returns the name of the TiDB group if tc is a view of the group
+// built by TiDBGroupView, otherwise return empty string.
+func (tc *TidbCluster) TiDBGroupName() string {
+	return tc.GetAnnotations()[label.AnnTiDBGroupKey]
+}
+
+// TiDBGroupView returns a copy of tc whose `.spec.tidb` and `.status.tidb` are
+// replaced by the spec and status of the given TiDB group, so the TiDB group
+// can be synced in the same way as the default TiDB.
+func (tc *TidbCluster) TiDBGroupView(group *TiDBGroupSpec) *TidbCluster {
+	view := tc.DeepCopy()
+	view.Spec.TiDB = &group.DeepCopy().TiDBSpec
+	view.Status.TiDB = TiDBStatus{}
+	if status := tc.Status.TiDBGroups[group.Name]; status != nil {
+		view.Status.TiDB = *status.TiDBStatus.DeepCopy()
+	}
+	if view.Annotations == nil {
+		view.Annotations = map[string]string{}
+	}
+	// delete slots and the in-use ConfigMap of the default TiDB are not applied to TiDB groups
+	delete(view.Annotations, label.AnnTiDBDeleteSlots)
+	delete(view.Annotations, label.AnnoKeyOfConfigMapNameForNewSTS(string(TiDBMemberType)))
+	tc.setChangePlanIDOfView(view)
+	view.Annotations[label.AnnTiDBGroupKey] = group.Name
+	return view
+}
+
+// TiDBGroupsAllMembersReady return whether all members of all TiDB groups are ready.
+func (tc *TidbCluster) TiDBGroupsAllMembersReady() bool {
+	for _, group := range tc.Spec.TiDBGroups {
+		if group == nil {
+			continue
+		}
+		status := tc.Status.TiDBGroups[group.Name]
+		if status == nil {
+			if group.Replicas == 0 {
+				continue
+			}
+			return false
+		}
+		if int(group.Replicas)+len(status.FailureMembers) != len(status.Members) {
+			return false
+		}
+		for _, member := range status.Members {
+			if !member.Health {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// TiKVGroup returns the spec of the TiKV group with the given name.
+//
+// If the group isn't specified, return nil.
+func (tc *TidbCluster) TiKVGroup(name string) *TiKVGroupSpec {
+	for _, group := range tc.Spec.TiKVGroups {
+		if group != nil && group.Name == name {
+			return group
+		}
+	}
+	return nil
+}
+
+// TiKVGroupName returns the name of the TiKV group if tc is a view of the group
+// built by TiKVGroupView, otherwise return empty string.
+func (tc *TidbCluster) TiKVGroupName() string {
+	return tc.GetAnnotations()[label.AnnTiKVGroupKey]
+}
+
+// TiKVGroupView returns a copy of tc whose `.spec.tikv` and `.status.tikv` are
+// replaced by the spec and status of the given TiKV group, so the TiKV group
+// can be synced in the same way as the default TiKV.
+func (tc *TidbCluster) TiKVGroupView(group *TiKVGroupSpec) *TidbCluster {
+	view := tc.DeepCopy()
+	view.Spec.TiKV = &group.DeepCopy().TiKVSpec
+	view.Status.TiKV = TiKVStatus{}
+	if status := tc.Status.TiKVGroups[group.Name]; status != nil {
+		view.Status.TiKV = *status.TiKVStatus.DeepCopy()
+	}
+	if view.Annotations == nil {
+		view.Annotations = map[string]string{}
+	}
+	// delete slots and the in-use ConfigMap of the default TiKV are not applied to TiKV groups
+	delete(view.Annotations, label.AnnTiKVDeleteSlots)
+	delete(view.Annotations, label.AnnoKeyOfConfigMapNameForNewSTS(string(TiKVMemberType)))
+	tc.setChangePlanIDOfView(view)
+	view.Annotations[label.AnnTiKVGroupKey] = group.Name
+	return view
+}
+
+// TiKVGroupsNormal returns whether none of the TiKV groups is upgrading or scaling.
+func (tc *TidbCluster) TiKVGroupsNormal() bool {
+	for _, status := range tc.Status.TiKVGroups {
+		if status != nil && (status.Phase == UpgradePhase || status.Phase == ScalePhase) {
+			return false
+		}
+	}
+	return true
+}
+
+// TiKVGroupsAllStoresReady return whether all stores of all TiKV groups are up.
+func (tc *TidbCluster) TiKVGroupsAllStoresReady() bool {
+	for _, group := range tc.Spec.TiKVGroups {
+		if group == nil {
+			continue
+		}
+		if !tc.TiKVGroupView(group).TiKVAllStoresReady() {
+			return false
+		}
+	}
+	return true
+}
+
+// TiFlashComputeSpec returns the spec of TiFlash compute nodes.
+//
+// If TiFlash isn't disaggregated or the compute nodes aren't specified, return nil.
+func (tc *TidbCluster) TiFlashComputeSpec() *TiFlashComputeSpec {
+	if tc.Spec.TiFlash == nil || tc.Spec.TiFlash.Disaggregated == nil {
+		return nil
+	}
+	return tc.Spec.TiFlash.Disaggregated.Compute
+}
+
+// TiFlashRole returns the role of the TiFlash nodes if tc is a view of TiFlash compute
+// nodes built by TiFlashComputeView, otherwise return empty string.
+func (tc *TidbCluster) TiFlashRole() string {
+	return tc.GetAnnotations()[label.AnnTiFlashRoleKey]
+}
+
+// TiFlashComputeView returns a copy of tc whose `.spec.tiflash` and `.status.tiflash` are
+// replaced by the spec and status of TiFlash compute nodes, so the compute nodes can be
+// synced in the same way as the TiFlash write nodes.
+//
+// If the compute nodes aren't specified, the view stands for the removed compute nodes
+// with no replicas.
+func (tc *TidbCluster) TiFlashComputeView() *TidbCluster {
+	view := tc.DeepCopy()
+	compute := tc.TiFlashComputeSpec().DeepCopy()
+	if compute == nil {
+		compute = &TiFlashComputeSpec{}
+	}
+	if view.Spec.TiFlash == nil {
+		view.Spec.TiFlash = &TiFlashSpec{}
+	}
+	spec := view.Spec.TiFlash
+	spec.ComponentSpec = compute.ComponentSpec
+	spec.ResourceRequirements = compute.ResourceRequirements
+	spec.Replicas = compute.Replicas
+	if compute.Config != nil {
+		spec.Config = compute.Config
+	}
+	// compute nodes are stateless, they have no persistent volumes and are not failed over
+	spec.StorageClaims = nil
+	spec.MaxFailoverCount = nil
+	spec.RecoverFailover = false
+	spec.Failover = nil
+
+	view.Status.TiFlash = TiFlashStatus{}
+	if tc.Status.TiFlashCompute != nil {
+		view.Status.TiFlash = *tc.Status.TiFlashCompute.DeepCopy()
+	}
+	if view.Annotations == nil {
+		view.Annotations = map[string]string{}
+	}
+	// delete slots and the in-use ConfigMap of the TiFlash write nodes are not applied to compute nodes
+	delete(view.Annotations, label.AnnTiFlashDeleteSlots)
+	delete(view.Annotations, label.AnnoKeyOfConfigMapNameForNewSTS(string(TiFlashMemberType)))
+	tc.setChangePlanIDOfView(view)
+	view.Annotations[label.AnnTiFlashRoleKey] = label.TiFlashComputeRoleVal
+	return view
+}
+
 // PDIsAvailable return whether PD is available.
 //
 // If PD isn't specified, return true.
@@ -1030,10 +1274,179 @@ func (tc *TidbCluster) GetClusterID() string {
 	return tc.Status.ClusterID
 }
 
+// IsChangePlanEnabled returns whether the plan mode is enabled, in which the changes to the StatefulSets and
+// the volumes are applied only after the plan is approved
+func (tc *TidbCluster) IsChangePlanEnabled() bool {
+	return tc.Annotations[label.AnnChangePlan] == "true"
+}
+
+// ChangePlanID returns the ID of the change plan, which is the hash of the spec. A view of the
+// TidbCluster returns the ID of the TidbCluster it is built from.
+func (tc *TidbCluster) ChangePlanID() string {
+	if tc.isView() {
+		return tc.Annotations[label.AnnChangePlanIDKey]
+	}
+	data, err := json.Marshal(tc.Spec)
+	if err != nil {
+		return ""
+	}
+	return HashContents(data)
+}
+
+// isView returns whether tc is a view of a TiDB group, a TiKV group or the TiFlash compute nodes
+func (tc *TidbCluster) isView() bool {
+	return tc.TiDBGroupName() != "" || tc.TiKVGroupName() != "" || tc.TiFlashRole() != ""
+}
+
+// setChangePlanIDOfView makes the view share the change plan ID of tc, so that the plan approved
+// for tc is also approved for the view
+func (tc *TidbCluster) setChangePlanIDOfView(view *TidbCluster) {
+	if tc.IsChangePlanEnabled() {
+		view.Annotations[label.AnnChangePlanIDKey] = tc.ChangePlanID()
+	}
+}
+
+// IsChangePlanApproved returns whether the change plan of the current spec is approved
+func (tc *TidbCluster) IsChangePlanApproved() bool {
+	id := tc.ChangePlanID()
+	return id != "" && tc.Annotations[label.AnnChangePlanApproved] == id
+}
+
+// IsChangePlanPending returns whether there are planned changes waiting for approval in the plan mode
+func (tc *TidbCluster) IsChangePlanPending() bool {
+	plan := tc.Status.ChangePlan
+	return tc.IsChangePlanEnabled() && plan != nil && len(plan.Components) > 0 && !tc.IsChangePlanApproved()
+}
+
+// StartOperation records a lifecycle operation of the component in the status. If the same operation of the
+// component is in progress, the ordinals are added to it instead.
+func (tc *TidbCluster) StartOperation(typ OperationType, component MemberType, trigger OperationTrigger, ordinals ...int32) {
+	if op := tc.InProgressOperation(typ, component); op != nil {
+		existing := sets.NewInt32(op.Ordinals...)
+		for _, ordinal := range ordinals {
+			if !existing.Has(ordinal) {
+				op.Ordinals = append(op.Ordinals, ordinal)
+			}
+		}
+		return
+	}
+
+	tc.Status.Operations = append(tc.Status.Operations, OperationRecord{
+		Type:      typ,
+		Component: component,
+		Group:     tc.operationGroup(component),
+		Trigger:   trigger,
+		StartTime: metav1.Now(),
+		Ordinals:  ordinals,
+		Outcome:   OperationInProgress,
+	})
+	if len(tc.Status.Operations) > operationHistoryLimit {
+		tc.Status.Operations = tc.Status.Operations[len(tc.Status.Operations)-operationHistoryLimit:]
+	}
+}
+
+// FinishOperation marks the in-progress operation of the component as finished with the outcome,
+// it does nothing if the operation is not in progress
+func (tc *TidbCluster) FinishOperation(typ OperationType, component MemberType, outcome OperationOutcome, message string) {
+	op := tc.InProgressOperation(typ, component)
+	if op == nil {
+		return
+	}
+	now := metav1.Now()
+	op.EndTime = &now
+	op.Outcome = outcome
+	op.Message = message
+}
+
+// RecordOperation records a lifecycle operation of the component that is finished at once
+func (tc *TidbCluster) RecordOperation(typ OperationType, component MemberType, trigger OperationTrigger, message string, ordinals ...int32) {
+	tc.StartOperation(typ, component, trigger, ordinals...)
+	tc.FinishOperation(typ, component, OperationSucceeded, message)
+}
+
+// InProgressOperation returns the in-progress operation of the component, or nil if there is none
+func (tc *TidbCluster) InProgressOperation(typ OperationType, component MemberType) *OperationRecord {
+	group := tc.operationGroup(component)
+	for i := len(tc.Status.Operations) - 1; i >= 0; i-- {
+		op := &tc.Status.Operations[i]
+		if op.Type == typ && op.Component == component && op.Group == group && op.Outcome == OperationInProgress {
+			return op
+		}
+	}
+	return nil
+}
+
+// LastOperation returns the latest operation of the component, or nil if there is none
+func (tc *TidbCluster) LastOperation(component MemberType) *OperationRecord {
+	group := tc.operationGroup(component)
+	for i := len(tc.Status.Operations) - 1; i >= 0; i-- {
+		if op := &tc.Status.Operations[i]; op.Component == component && op.Group == group {
+			return op
+		}
+	}
+	return nil
+}
+
+// LastOperationOfType returns the latest operation of the type of the component, or nil if there is none
+func (tc *TidbCluster) LastOperationOfType(typ OperationType, component MemberType) *OperationRecord {
+	group := tc.operationGroup(component)
+	for i := len(tc.Status.Operations) - 1; i >= 0; i-- {
+		if op := &tc.Status.Operations[i]; op.Type == typ && op.Component == component && op.Group == group {
+			return op
+		}
+	}
+	return nil
+}
+
+// operationGroup returns the group of the component the operations are recorded for,
+// which is not empty if tc is a view of a TiDB group, a TiKV group or TiFlash compute nodes.
+func (tc *TidbCluster) operationGroup(component MemberType) string {
+	switch component {
+	case TiDBMemberType:
+		return tc.TiDBGroupName()
+	case TiKVMemberType:
+		return tc.TiKVGroupName()
+	case TiFlashMemberType:
+		return tc.TiFlashRole()
+	}
+	return ""
+}
+
 func (tc *TidbCluster) IsTLSClusterEnabled() bool {
 	return tc.Spec.TLSCluster != nil && tc.Spec.TLSCluster.Enabled
 }
 
+// IsCertificateExpired returns whether any certificate consumed by the tidb cluster is expired
+func (tc *TidbCluster) IsCertificateExpired() bool {
+	for _, c := range tc.Status.Conditions {
+		if c.Type == TidbClusterCertificateExpiringSoon {
+			return c.Reason == CertificateExpiredReason
+		}
+	}
+	return false
+}
+
+// IsTLSClusterIssuedByOperator returns whether the certificates of TLSCluster are issued by the operator
+func (tc *TidbCluster) IsTLSClusterIssuedByOperator() bool {
+	return tc.IsTLSClusterEnabled() && tc.Spec.TLSCluster.Issuer != nil
+}
+
+// GetDuration returns the validity duration of the certificates issued by the operator
+func (i *TLSClusterIssuer) GetDuration() time.Duration {
+	if i.Duration != nil {
+		return i.Duration.Duration
+	}
+	return defaultTLSClusterCertDuration
+}
+
+// GetRenewBefore returns how long before the expiry the certificates issued by the operator are renewed
+func (i *TLSClusterIssuer) GetRenewBefore() time.Duration {
+	if i.RenewBefore != nil {
+		return i.RenewBefore.Duration
+	}
+	return defaultTLSClusterCertRenewBefore
+}
+
 func (tc *TidbCluster) IsRecoveryMode() bool {
 	return tc.Spec.RecoveryMode
 }
@@ -1057,6 +1470,14 @@ func (tc *TidbCluster) Timezone() string {
 	return tz
 }
 
+// DiscoveryReplicas returns the desired replicas of the discovery service
+func (tc *TidbCluster) DiscoveryReplicas() int32 {
+	if tc.Spec.Discovery.Replicas == nil {
+		return 1
+	}
+	return *tc.Spec.Discovery.Replicas
+}
+
 func (tc *TidbCluster) IsPVReclaimEnabled() bool {
 	enabled := tc.Spec.EnablePVReclaim
 	if enabled == nil {
@@ -1098,6 +1519,22 @@ func (tidb *TiDBSpec) IsTLSClientEnabled() bool {
 	return tidb.TLSClient != nil && tidb.TLSClient.Enabled
 }
 
+// GetTimeout returns how long TiDB has no client connections before it is scaled to zero
+func (p *TiDBIdlePolicy) GetTimeout() time.Duration {
+	if p.Timeout == nil {
+		return defaultTiDBIdleTimeout
+	}
+	return p.Timeout.Duration
+}
+
+// IsWakeOnConnectEnabled returns whether the activator wakes TiDB up on new connections
+func (p *TiDBIdlePolicy) IsWakeOnConnectEnabled() bool {
+	if p.WakeOnConnect == nil {
+		return defaultTiDBWakeOnConnect
+	}
+	return *p.WakeOnConnect
+}
+
 func (tidb *TiDBSpec) ShouldSeparateSlowLog() bool {
 	separateSlowLog := tidb.SeparateSlowLog
 	if separateSlowLog == nil {
@@ -1372,9 +1809,44 @@ func (tc *TidbCluster) IsComponentLeaderEvicting(compType MemberType) bool {
 	return meta.IsStatusConditionTrue(conds, ConditionTypeLeaderEvicting)
 }
 
+// PDTargetMode returns the mode that PD is expected to run in, the mode of PD microservices
+// takes effect only if both `.spec.pd.mode` and `.spec.pdms` are set.
+func (tc *TidbCluster) PDTargetMode() string {
+	if tc.Spec.PD != nil && tc.Spec.PD.Mode == PDModeMS && tc.Spec.PDMS != nil {
+		return PDModeMS
+	}
+	return ""
+}
+
+// PDMSMigrationStage returns the stage of the migration between PD and PD microservices,
+// an empty string is returned if there is no migration in progress.
+func (tc *TidbCluster) PDMSMigrationStage() string {
+	cond := meta.FindStatusCondition(tc.Status.PD.Conditions, ConditionTypePDMSMigrating)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return ""
+	}
+	return cond.Reason
+}
+
+// PDMSEnabled returns whether the PD microservices should be running. When migrating
+// back to PD, they keep running until the PD servers no longer depend on them.
+func (tc *TidbCluster) PDMSEnabled() bool {
+	if tc.Spec.PD == nil || tc.Spec.PDMS == nil {
+		return false
+	}
+	return tc.PDTargetMode() == PDModeMS || tc.Status.PD.Mode == PDModeMS ||
+		tc.PDMSMigrationStage() == PDMSMigrationSwitchingPD
+}
+
+// PDInMSMode returns whether the PD servers should run in the mode of PD microservices.
+// When migrating to PD microservices, the PD servers are switched after the PD microservices are started.
+func (tc *TidbCluster) PDInMSMode() bool {
+	return tc.PDTargetMode() == PDModeMS && tc.PDMSMigrationStage() != PDMSMigrationStartingServices
+}
+
 func (tc *TidbCluster) StartScriptVersion() StartScriptVersion {
 	switch tc.Spec.StartScriptVersion {
-	case StartScriptV1, StartScriptV2:
+	case StartScriptV1, StartScriptV2, StartScriptV3:
 		return tc.Spec.StartScriptVersion
 	default:
 		return StartScriptV1
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	apps "k8s.io/api/apps/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -813,6 +814,152 @@ func TestTiCDCGracefulShutdownTimeout(t *testing.T) {
 	g.Expect(tc.TiCDCGracefulShutdownTimeout()).To(Equal(time.Minute))
 }
 
+func TestTiDBGroupView(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	tc.Annotations = map[string]string{label.AnnTiDBDeleteSlots: "[1]"}
+	tc.Spec.TiDBGroups = []*TiDBGroupSpec{
+		{Name: "olap", TiDBSpec: TiDBSpec{Replicas: 2}},
+	}
+	tc.Status.TiDBGroups = map[string]*TiDBGroupStatus{
+		"olap": {Name: "olap", TiDBStatus: TiDBStatus{Phase: ScalePhase}},
+	}
+	g.Expect(tc.TiDBGroupName()).To(Equal(""))
+	g.Expect(tc.TiDBGroup("oltp")).To(BeNil())
+
+	view := tc.TiDBGroupView(tc.TiDBGroup("olap"))
+	g.Expect(view.Name).To(Equal(tc.Name))
+	g.Expect(view.UID).To(Equal(tc.UID))
+	g.Expect(view.TiDBGroupName()).To(Equal("olap"))
+	g.Expect(view.Spec.TiDB.Replicas).To(Equal(int32(2)))
+	g.Expect(view.Status.TiDB.Phase).To(Equal(ScalePhase))
+	g.Expect(view.Annotations).NotTo(HaveKey(label.AnnTiDBDeleteSlots))
+
+	// the view must not change tc
+	view.Spec.TiDB.Replicas = 3
+	view.Status.TiDB.Phase = NormalPhase
+	g.Expect(tc.Spec.TiDBGroups[0].Replicas).To(Equal(int32(2)))
+	g.Expect(tc.Status.TiDBGroups["olap"].Phase).To(Equal(ScalePhase))
+	g.Expect(tc.Spec.TiDB.Replicas).To(Equal(int32(1)))
+	g.Expect(tc.Annotations).To(HaveKey(label.AnnTiDBDeleteSlots))
+}
+
+func TestTiDBGroupsAllMembersReady(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	g.Expect(tc.TiDBGroupsAllMembersReady()).To(BeTrue())
+
+	tc.Spec.TiDBGroups = []*TiDBGroupSpec{
+		{Name: "olap", TiDBSpec: TiDBSpec{Replicas: 1}},
+	}
+	g.Expect(tc.TiDBGroupsAllMembersReady()).To(BeFalse())
+
+	tc.Status.TiDBGroups = map[string]*TiDBGroupStatus{
+		"olap": {Name: "olap", TiDBStatus: TiDBStatus{Members: map[string]TiDBMember{
+			"test-pd-tidb-olap-0": {Name: "test-pd-tidb-olap-0", Health: false},
+		}}},
+	}
+	g.Expect(tc.TiDBGroupsAllMembersReady()).To(BeFalse())
+
+	tc.Status.TiDBGroups["olap"].Members["test-pd-tidb-olap-0"] = TiDBMember{Name: "test-pd-tidb-olap-0", Health: true}
+	g.Expect(tc.TiDBGroupsAllMembersReady()).To(BeTrue())
+}
+
+func TestTiKVGroupView(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	tc.Annotations = map[string]string{label.AnnTiKVDeleteSlots: "[1]"}
+	tc.Spec.TiKVGroups = []*TiKVGroupSpec{
+		{Name: "nvme", TiKVSpec: TiKVSpec{Replicas: 2}},
+	}
+	tc.Status.TiKVGroups = map[string]*TiKVGroupStatus{
+		"nvme": {Name: "nvme", TiKVStatus: TiKVStatus{Phase: ScalePhase}},
+	}
+	g.Expect(tc.TiKVGroupName()).To(Equal(""))
+	g.Expect(tc.TiKVGroup("hdd")).To(BeNil())
+
+	view := tc.TiKVGroupView(tc.TiKVGroup("nvme"))
+	g.Expect(view.Name).To(Equal(tc.Name))
+	g.Expect(view.TiKVGroupName()).To(Equal("nvme"))
+	g.Expect(view.TiDBGroupName()).To(Equal(""))
+	g.Expect(view.Spec.TiKV.Replicas).To(Equal(int32(2)))
+	g.Expect(view.Status.TiKV.Phase).To(Equal(ScalePhase))
+	g.Expect(view.Annotations).NotTo(HaveKey(label.AnnTiKVDeleteSlots))
+
+	// the view must not change tc
+	view.Spec.TiKV.Replicas = 3
+	view.Status.TiKV.Phase = NormalPhase
+	g.Expect(tc.Spec.TiKVGroups[0].Replicas).To(Equal(int32(2)))
+	g.Expect(tc.Status.TiKVGroups["nvme"].Phase).To(Equal(ScalePhase))
+	g.Expect(tc.Annotations).To(HaveKey(label.AnnTiKVDeleteSlots))
+}
+
+func TestTiFlashComputeView(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	tc.Annotations = map[string]string{label.AnnTiFlashDeleteSlots: "[1]"}
+	tc.Spec.TiFlash = &TiFlashSpec{
+		Replicas:         3,
+		BaseImage:        "pingcap/tiflash",
+		MaxFailoverCount: pointer.Int32Ptr(3),
+		StorageClaims:    []StorageClaim{{}},
+		Disaggregated: &TiFlashDisaggregatedSpec{
+			Compute: &TiFlashComputeSpec{Replicas: 2},
+		},
+	}
+	tc.Status.TiFlashCompute = &TiFlashStatus{Phase: ScalePhase}
+	g.Expect(tc.TiFlashRole()).To(Equal(""))
+
+	view := tc.TiFlashComputeView()
+	g.Expect(view.Name).To(Equal(tc.Name))
+	g.Expect(view.TiFlashRole()).To(Equal(label.TiFlashComputeRoleVal))
+	g.Expect(view.Spec.TiFlash.Replicas).To(Equal(int32(2)))
+	g.Expect(view.Spec.TiFlash.BaseImage).To(Equal("pingcap/tiflash"))
+	g.Expect(view.Spec.TiFlash.StorageClaims).To(BeEmpty())
+	g.Expect(view.Spec.TiFlash.MaxFailoverCount).To(BeNil())
+	g.Expect(view.Status.TiFlash.Phase).To(Equal(ScalePhase))
+	g.Expect(view.Annotations).NotTo(HaveKey(label.AnnTiFlashDeleteSlots))
+
+	// the view must not change tc
+	view.Spec.TiFlash.Replicas = 3
+	view.Status.TiFlash.Phase = NormalPhase
+	g.Expect(tc.Spec.TiFlash.Disaggregated.Compute.Replicas).To(Equal(int32(2)))
+	g.Expect(tc.Spec.TiFlash.StorageClaims).To(HaveLen(1))
+	g.Expect(tc.Status.TiFlashCompute.Phase).To(Equal(ScalePhase))
+	g.Expect(tc.Annotations).To(HaveKey(label.AnnTiFlashDeleteSlots))
+
+	// the view of the removed compute nodes has no replicas
+	tc.Spec.TiFlash = nil
+	g.Expect(tc.TiFlashComputeSpec()).To(BeNil())
+	g.Expect(tc.TiFlashComputeView().Spec.TiFlash.Replicas).To(Equal(int32(0)))
+}
+
+func TestTiKVGroupsAllStoresReady(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	g.Expect(tc.TiKVGroupsAllStoresReady()).To(BeTrue())
+
+	tc.Spec.TiKVGroups = []*TiKVGroupSpec{
+		{Name: "nvme", TiKVSpec: TiKVSpec{Replicas: 1}},
+	}
+	g.Expect(tc.TiKVGroupsAllStoresReady()).To(BeFalse())
+
+	tc.Status.TiKVGroups = map[string]*TiKVGroupStatus{
+		"nvme": {Name: "nvme", TiKVStatus: TiKVStatus{Stores: map[string]TiKVStore{
+			"1": {ID: "1", PodName: "test-pd-tikv-nvme-0", Group: "nvme", State: TiKVStateDown},
+		}}},
+	}
+	g.Expect(tc.TiKVGroupsAllStoresReady()).To(BeFalse())
+
+	tc.Status.TiKVGroups["nvme"].Stores["1"] = TiKVStore{ID: "1", PodName: "test-pd-tikv-nvme-0", Group: "nvme", State: TiKVStateUp}
+	g.Expect(tc.TiKVGroupsAllStoresReady()).To(BeTrue())
+}
+
 func TestComponentFunc(t *testing.T) {
 	t.Run("ComponentIsNormal", func(t *testing.T) {
 		g := NewGomegaWithT(t)
@@ -980,6 +1127,57 @@ func TestComponentFunc(t *testing.T) {
 	})
 }
 
+func TestOperationHistory(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := newTidbCluster()
+
+	tc.StartOperation(OperationScaleOut, TiKVMemberType, OperationTriggerSpecChange, 3)
+	tc.StartOperation(OperationScaleOut, TiKVMemberType, OperationTriggerSpecChange, 3, 4)
+	g.Expect(tc.Status.Operations).To(HaveLen(1))
+	op := tc.InProgressOperation(OperationScaleOut, TiKVMemberType)
+	g.Expect(op).NotTo(BeNil())
+	g.Expect(op.Ordinals).To(Equal([]int32{3, 4}))
+	g.Expect(tc.InProgressOperation(OperationScaleOut, PDMemberType)).To(BeNil())
+
+	tc.FinishOperation(OperationScaleOut, TiKVMemberType, OperationSucceeded, "")
+	g.Expect(tc.InProgressOperation(OperationScaleOut, TiKVMemberType)).To(BeNil())
+	g.Expect(tc.Status.Operations[0].Outcome).To(Equal(OperationSucceeded))
+	g.Expect(tc.Status.Operations[0].EndTime).NotTo(BeNil())
+
+	// a new record is started after the previous one is finished
+	tc.StartOperation(OperationScaleOut, TiKVMemberType, OperationTriggerFailover, 5)
+	g.Expect(tc.Status.Operations).To(HaveLen(2))
+
+	tc.RecordOperation(OperationFailover, TiKVMemberType, OperationTriggerFailover, "store is Down", 1)
+	g.Expect(tc.Status.Operations).To(HaveLen(3))
+	last := tc.LastOperation(TiKVMemberType)
+	g.Expect(last.Type).To(Equal(OperationFailover))
+	g.Expect(last.Outcome).To(Equal(OperationSucceeded))
+	g.Expect(last.Message).To(Equal("store is Down"))
+	g.Expect(tc.LastOperation(PDMemberType)).To(BeNil())
+
+	// the oldest records are removed
+	for i := 0; i < operationHistoryLimit; i++ {
+		tc.RecordOperation(OperationUpgrade, TiDBMemberType, OperationTriggerSpecChange, "")
+	}
+	g.Expect(tc.Status.Operations).To(HaveLen(operationHistoryLimit))
+	g.Expect(tc.LastOperation(TiKVMemberType)).To(BeNil())
+
+	// the operations of a TiKV group are recorded apart from the default TiKV
+	tc.StartOperation(OperationUpgrade, TiKVMemberType, OperationTriggerSpecChange, 2)
+	view := tc.TiKVGroupView(&TiKVGroupSpec{Name: "nvme"})
+	g.Expect(view.InProgressOperation(OperationUpgrade, TiKVMemberType)).To(BeNil())
+	view.StartOperation(OperationUpgrade, TiKVMemberType, OperationTriggerSpecChange, 1)
+	view.FinishOperation(OperationUpgrade, TiKVMemberType, OperationSucceeded, "")
+	g.Expect(view.LastOperation(TiKVMemberType).Group).To(Equal("nvme"))
+	g.Expect(view.InProgressOperation(OperationUpgrade, TiKVMemberType)).To(BeNil())
+	tc.Status.Operations = view.Status.Operations
+	op = tc.InProgressOperation(OperationUpgrade, TiKVMemberType)
+	g.Expect(op).NotTo(BeNil())
+	g.Expect(op.Group).To(BeEmpty())
+	g.Expect(op.Ordinals).To(Equal([]int32{2}))
+}
+
 func newTidbCluster() *TidbCluster {
 	return &TidbCluster{
 		TypeMeta: metav1.TypeMeta{
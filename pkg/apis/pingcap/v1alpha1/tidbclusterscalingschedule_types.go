@@ -0,0 +1,169 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TidbClusterScalingSchedule scales the components of a TiDB cluster on a schedule.
+// The replicas in the spec of the TidbCluster are set to the targets of the active
+// scaling window, and then the components are scaled by the TidbCluster controller
+// as if the spec is edited by hand.
+//
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="tcss"
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.cluster.name`,description="The TiDB cluster to scale"
+// +kubebuilder:printcolumn:name="Active",type=string,JSONPath=`.status.activeWindows`,description="The active scaling windows"
+// +kubebuilder:printcolumn:name="LastScale",type=date,JSONPath=`.status.lastScaleTime`,description="The last time the cluster is scaled"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type TidbClusterScalingSchedule struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec contains all spec about the scaling schedule.
+	Spec TidbClusterScalingScheduleSpec `json:"spec"`
+
+	// Status is most recently observed status of the scaling schedule.
+	//
+	// +k8s:openapi-gen=false
+	Status TidbClusterScalingScheduleStatus `json:"status,omitempty"`
+}
+
+// TidbClusterScalingScheduleList is a TidbClusterScalingSchedule list.
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TidbClusterScalingScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TidbClusterScalingSchedule `json:"items"`
+}
+
+// TidbClusterScalingScheduleSpec is spec of a scaling schedule.
+//
+// +k8s:openapi-gen=true
+type TidbClusterScalingScheduleSpec struct {
+	// Cluster references the TiDB cluster to scale.
+	// The TidbClusterScalingSchedule must be in the same namespace as the TidbCluster.
+	Cluster TidbClusterRef `json:"cluster"`
+
+	// Timezone is the IANA time zone name used to evaluate the schedules of the windows, e.g. Asia/Shanghai.
+	// Optional: Defaults to UTC
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// Suspend stops scaling the cluster, the replicas in the spec of the TidbCluster are kept as they are.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Windows are the scaling windows. When multiple windows are active at the same time, the target of
+	// each component is taken from the window with the highest priority, and then from the window that
+	// started last.
+	Windows []ScalingWindow `json:"windows"`
+
+	// Default is the replica targets applied when no window sets the target of a component.
+	// The replicas of the components not set here are kept after the windows end.
+	// +optional
+	Default *ScalingTargets `json:"default,omitempty"`
+
+	// HistoryLimit is the max number of the scaling records kept in the status.
+	// Optional: Defaults to 10
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+}
+
+// ScalingWindow is a period of time in which the replica targets are applied to the cluster.
+//
+// +k8s:openapi-gen=true
+type ScalingWindow struct {
+	// Name is the unique name of the window.
+	Name string `json:"name"`
+
+	// Schedule is the cron string of the start time of the window, in the same format as the schedule of BackupSchedule.
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window lasts after it starts.
+	Duration metav1.Duration `json:"duration"`
+
+	// Priority resolves the conflicts when the window overlaps with other windows, the higher one wins.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Replicas is the replica targets applied when the window is active.
+	Replicas ScalingTargets `json:"replicas"`
+}
+
+// ScalingTargets is the replica targets of the components, the components not set are not scaled.
+//
+// +k8s:openapi-gen=true
+type ScalingTargets struct {
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TiDB *int32 `json:"tidb,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TiKV *int32 `json:"tikv,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TiFlash *int32 `json:"tiflash,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TiCDC *int32 `json:"ticdc,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TiProxy *int32 `json:"tiproxy,omitempty"`
+}
+
+// TidbClusterScalingScheduleStatus is status of a scaling schedule.
+type TidbClusterScalingScheduleStatus struct {
+	// ActiveWindows are the names of the windows that are active at the last evaluation.
+	// +optional
+	ActiveWindows []string `json:"activeWindows,omitempty"`
+	// LastEvaluateTime is the last time the windows are evaluated.
+	// +optional
+	LastEvaluateTime *metav1.Time `json:"lastEvaluateTime,omitempty"`
+	// NextEvaluateTime is the next time any window starts or ends.
+	// +optional
+	NextEvaluateTime *metav1.Time `json:"nextEvaluateTime,omitempty"`
+	// LastScaleTime is the last time the replicas of the cluster are changed by the schedule.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+	// History is the recent scaling records, the latest one is the last.
+	// +optional
+	History []ScalingRecord `json:"history,omitempty"`
+}
+
+// ScalingRecord is a record of scaling a component by the schedule.
+type ScalingRecord struct {
+	// Time is when the replicas are changed.
+	Time metav1.Time `json:"time"`
+	// Window is the name of the window that sets the target, empty means the default targets.
+	// +optional
+	Window string `json:"window,omitempty"`
+	// Component is the scaled component.
+	Component MemberType `json:"component"`
+	// From is the replicas before scaling.
+	From int32 `json:"from"`
+	// To is the replicas after scaling.
+	To int32 `json:"to"`
+}
@@ -126,4 +126,9 @@ type TidbDashboardStatus struct {
 	Phase  MemberPhase `json:"phase,omitempty"`
 
 	StatefulSet *apps.StatefulSetStatus `json:"statefulSet,omitempty"`
+
+	// Represents the latest available observations of the TidbDashboard's state.
+	// +optional
+	// +nullable
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
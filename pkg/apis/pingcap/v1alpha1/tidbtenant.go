@@ -0,0 +1,75 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+)
+
+// GetKeyspaceName returns the name of the keyspace of the tenant.
+func (tt *TidbTenant) GetKeyspaceName() string {
+	if tt.Spec.KeyspaceName != "" {
+		return tt.Spec.KeyspaceName
+	}
+	return tt.Name
+}
+
+// GetKeyspaceReclaimPolicy returns the reclaim policy of the keyspace of the tenant.
+func (tt *TidbTenant) GetKeyspaceReclaimPolicy() KeyspaceReclaimPolicy {
+	if tt.Spec.KeyspaceReclaimPolicy == "" {
+		return KeyspaceReclaimRetain
+	}
+	return tt.Spec.KeyspaceReclaimPolicy
+}
+
+// TiDBGroupName returns the name of the TiDB group that serves the tenant.
+func (tt *TidbTenant) TiDBGroupName() string {
+	return tt.Name
+}
+
+// GetInstanceName returns the instance label value of the TiDB servers of the
+// tenant. It differs from the instance of the TidbCluster so that the TiDB servers
+// of the tenant are not selected by the service and controllers of the cluster.
+func (tt *TidbTenant) GetInstanceName(tc *TidbCluster) string {
+	return fmt.Sprintf("%s-tenant-%s", tc.GetInstanceName(), tt.Name)
+}
+
+// TidbClusterView returns a copy of tc that describes the TiDB servers of the
+// tenant as a TiDB group bound to the keyspace of the tenant, so the TiDB servers
+// can be synced in the same way as the TiDB groups of tc.
+func (tt *TidbTenant) TidbClusterView(tc *TidbCluster) *TidbCluster {
+	group := &TiDBGroupSpec{
+		Name:     tt.TiDBGroupName(),
+		TiDBSpec: *tt.Spec.TiDB.DeepCopy(),
+	}
+	if group.Config == nil {
+		group.Config = NewTiDBConfig()
+	}
+	group.Config.Set("keyspace-name", tt.GetKeyspaceName())
+
+	view := tc.TiDBGroupView(group)
+	view.Status.TiDB = *tt.Status.TiDB.DeepCopy()
+	// the spec of the tenant is not a part of the change plan of tc, whose status is not
+	// written by the tenant controller, so the plan mode does not gate the tenant
+	delete(view.Annotations, label.AnnChangePlan)
+	delete(view.Annotations, label.AnnChangePlanIDKey)
+	view.Status.ChangePlan = nil
+	if view.Labels == nil {
+		view.Labels = map[string]string{}
+	}
+	view.Labels[label.InstanceLabelKey] = tt.GetInstanceName(tc)
+	return view
+}
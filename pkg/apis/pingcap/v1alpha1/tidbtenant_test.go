@@ -0,0 +1,56 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTidbTenantView(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	tt := &TidbTenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: tc.Namespace},
+		Spec: TidbTenantSpec{
+			Cluster: TidbClusterRef{Name: tc.Name},
+			TiDB:    TiDBSpec{Replicas: 2},
+		},
+		Status: TidbTenantStatus{
+			TiDB: TiDBStatus{Phase: ScalePhase},
+		},
+	}
+	g.Expect(tt.GetKeyspaceName()).To(Equal("tenant"))
+	g.Expect(tt.GetKeyspaceReclaimPolicy()).To(Equal(KeyspaceReclaimRetain))
+	tt.Spec.KeyspaceName = "keyspace"
+	g.Expect(tt.GetKeyspaceName()).To(Equal("keyspace"))
+
+	view := tt.TidbClusterView(tc)
+	g.Expect(view.Name).To(Equal(tc.Name))
+	g.Expect(view.TiDBGroupName()).To(Equal("tenant"))
+	g.Expect(view.GetInstanceName()).To(Equal(tc.Name + "-tenant-tenant"))
+	g.Expect(view.Spec.TiDB.Replicas).To(Equal(int32(2)))
+	g.Expect(view.Spec.TiDB.Config.Get("keyspace-name").MustString()).To(Equal("keyspace"))
+	g.Expect(view.Status.TiDB.Phase).To(Equal(ScalePhase))
+
+	// the view must change neither tc nor the tenant
+	g.Expect(tc.GetInstanceName()).To(Equal(tc.Name))
+	g.Expect(tc.Spec.TiDB.Replicas).To(Equal(int32(1)))
+	g.Expect(tt.Spec.TiDB.Config).To(BeNil())
+	g.Expect(tc.Labels).NotTo(HaveKey(label.InstanceLabelKey))
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TidbTenant is a tenant of a TiDB cluster. A tenant owns a keyspace in the PD
+// and TiKV clusters of the TiDB cluster and a dedicated group of TiDB servers
+// bound to the keyspace.
+//
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="tt"
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.cluster.name`,description="The TiDB cluster of the tenant"
+// +kubebuilder:printcolumn:name="Keyspace",type=string,JSONPath=`.status.keyspaceName`,description="The keyspace of the tenant"
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.keyspaceState`,description="The state of the keyspace"
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.tidb.statefulSet.readyReplicas`,description="The ready replicas of the TiDB servers of the tenant"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type TidbTenant struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec contains all spec about the tenant.
+	Spec TidbTenantSpec `json:"spec"`
+
+	// Status is most recently observed status of the tenant.
+	//
+	// +k8s:openapi-gen=false
+	Status TidbTenantStatus `json:"status,omitempty"`
+}
+
+// TidbTenantList is a TidbTenant list.
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TidbTenantList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TidbTenant `json:"items"`
+}
+
+// KeyspaceReclaimPolicy is the policy of the keyspace of a deleted TidbTenant.
+type KeyspaceReclaimPolicy string
+
+const (
+	// KeyspaceReclaimRetain keeps the keyspace as it is.
+	KeyspaceReclaimRetain KeyspaceReclaimPolicy = "Retain"
+	// KeyspaceReclaimArchive disables and archives the keyspace.
+	KeyspaceReclaimArchive KeyspaceReclaimPolicy = "Archive"
+	// KeyspaceReclaimDelete archives the keyspace and marks it as tombstone,
+	// the data of the keyspace is then garbage collected by TiKV.
+	KeyspaceReclaimDelete KeyspaceReclaimPolicy = "Delete"
+)
+
+// TidbTenantSpec is spec of a TiDB tenant.
+//
+// +k8s:openapi-gen=true
+type TidbTenantSpec struct {
+	// Cluster references the TiDB cluster whose PD and TiKV clusters are shared by tenants.
+	// The TidbTenant must be in the same namespace as the TidbCluster. The keyspace API of
+	// PD is required, and TiKV must be started with `storage.api-version = 2`.
+	Cluster TidbClusterRef `json:"cluster"`
+
+	// KeyspaceName is the name of the keyspace of the tenant.
+	// Optional: Defaults to the name of the TidbTenant
+	// +optional
+	KeyspaceName string `json:"keyspaceName,omitempty"`
+
+	// KeyspaceConfig is the config of the keyspace, it is only applied when the keyspace is created.
+	// +optional
+	KeyspaceConfig map[string]string `json:"keyspaceConfig,omitempty"`
+
+	// Archived archives the tenant. The TiDB servers of the tenant are scaled in to 0
+	// before the keyspace is disabled and archived.
+	// Note that an archived keyspace can not be enabled again.
+	// +optional
+	Archived bool `json:"archived,omitempty"`
+
+	// KeyspaceReclaimPolicy is the policy of the keyspace when the TidbTenant is deleted.
+	// Optional: Defaults to Retain
+	// +kubebuilder:validation:Enum=Retain;Archive;Delete
+	// +kubebuilder:default=Retain
+	// +optional
+	KeyspaceReclaimPolicy KeyspaceReclaimPolicy `json:"keyspaceReclaimPolicy,omitempty"`
+
+	// TiDB is the spec of the TiDB servers of the tenant, which are started with the
+	// `keyspace-name` of the tenant. The fields that are not set inherit the cluster-level
+	// settings of the TidbCluster, e.g. version and imagePullSecrets.
+	TiDB TiDBSpec `json:"tidb"`
+}
+
+// TidbTenantStatus is status of a TiDB tenant.
+type TidbTenantStatus struct {
+	// KeyspaceName is the name of the keyspace of the tenant
+	// +optional
+	KeyspaceName string `json:"keyspaceName,omitempty"`
+	// KeyspaceID is the ID of the keyspace of the tenant
+	// +optional
+	KeyspaceID uint32 `json:"keyspaceID,omitempty"`
+	// KeyspaceState is the state of the keyspace, one of ENABLED, DISABLED, ARCHIVED and TOMBSTONE
+	// +optional
+	KeyspaceState string `json:"keyspaceState,omitempty"`
+
+	// TiDB is the status of the TiDB servers of the tenant
+	// +optional
+	TiDB TiDBStatus `json:"tidb,omitempty"`
+}
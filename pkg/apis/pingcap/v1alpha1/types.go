@@ -36,6 +36,8 @@ const (
 	TiKVStateOffline string = "Offline"
 	// TiKVStateTombstone represents status of Tombstone of TiKV
 	TiKVStateTombstone string = "Tombstone"
+	// TiKVStateDisconnected represents status of Disconnected of TiKV, the store does not send heartbeats for a while
+	TiKVStateDisconnected string = "Disconnected"
 
 	// DMWorkerStateFree represents status of free of dm-worker
 	DMWorkerStateFree string = "free"
@@ -152,6 +154,9 @@ type StartScriptVersion string
 const (
 	StartScriptV1 StartScriptVersion = "v1"
 	StartScriptV2 StartScriptVersion = "v2"
+	// StartScriptV3 starts the components by the start binary of tidb-operator instead of the shell scripts,
+	// the binary is copied to the pods by an init container with the discovery image
+	StartScriptV3 StartScriptVersion = "v3"
 )
 
 type StartScriptV2FeatureFlag string
@@ -242,10 +247,30 @@ type TidbClusterSpec struct {
 	// +optional
 	TiDB *TiDBSpec `json:"tidb,omitempty"`
 
+	// TiDBGroups are the TiDB groups of the cluster, each group has its own
+	// StatefulSet, configuration, resources and services, and joins the same
+	// PD and TiKV clusters as `.spec.tidb`.
+	// Note that the pods of `.spec.tidb` are rolled with an extra label once TiDB groups
+	// are added, and the services of `.spec.tidb` exclude the pods of TiDB groups after that.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	TiDBGroups []*TiDBGroupSpec `json:"tidbGroups,omitempty"`
+
 	// TiKV cluster spec
 	// +optional
 	TiKV *TiKVSpec `json:"tikv,omitempty"`
 
+	// TiKVGroups are the TiKV groups of the cluster, each group has its own
+	// StatefulSet, storage, resources, store labels and scheduling, and its stores
+	// join the same PD cluster as `.spec.tikv`.
+	// Note that the storage volumes of TiKV groups are not resized after creation,
+	// and TiKV groups are not supported by the volume snapshot backup and restore.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	TiKVGroups []*TiKVGroupSpec `json:"tikvGroups,omitempty"`
+
 	// TiFlash cluster spec
 	// +optional
 	TiFlash *TiFlashSpec `json:"tiflash,omitempty"`
@@ -413,11 +438,12 @@ type TidbClusterSpec struct {
 	TopologySpreadConstraints []TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 
 	// StartScriptVersion is the version of start script
-	// When PD enables microservice mode, pd and pd microservice component will use start script v2.
+	// When PD enables microservice mode, pd and pd microservice component will use start script v2 unless v3 is used.
+	// The version v3 starts the components by the start binary of tidb-operator instead of the shell scripts.
 	//
 	// default to "v1"
 	// +optional
-	// +kubebuilder:validation:Enum:="";"v1";"v2"
+	// +kubebuilder:validation:Enum:="";"v1";"v2";"v3"
 	StartScriptVersion StartScriptVersion `json:"startScriptVersion,omitempty"`
 
 	// SuspendAction defines the suspend actions for all component.
@@ -427,11 +453,17 @@ type TidbClusterSpec struct {
 	// PreferIPv6 indicates whether to prefer IPv6 addresses for all components.
 	PreferIPv6 bool `json:"preferIPv6,omitempty"`
 
-	// Feature flags used by v2 startup script to enable various features.
+	// Feature flags used by v2 and v3 startup script to enable various features.
 	// Examples of supported feature flags:
 	// - WaitForDnsNameIpMatch indicates whether PD and TiKV has to wait until local IP address matches the one published to external DNS
 	// - PreferPDAddressesOverDiscovery advises start script to use TidbClusterSpec.PDAddresses (if supplied) as argument for pd-server, tikv-server and tidb-server commands
 	StartScriptV2FeatureFlags []StartScriptV2FeatureFlag `json:"startScriptV2FeatureFlags,omitempty"`
+
+	// ConfigDriftDetection detects the drift between the config in the spec and the config of the running
+	// PD, TiKV, TiDB and TiFlash servers, which may be changed at runtime by `SET CONFIG` or pd-ctl.
+	// Optional: Defaults to nil, which disables the detection
+	// +optional
+	ConfigDriftDetection *ConfigDriftDetection `json:"configDriftDetection,omitempty"`
 }
 
 // TidbClusterStatus represents the current status of a tidb cluster.
@@ -449,6 +481,35 @@ type TidbClusterStatus struct {
 	// +optional
 	// +nullable
 	Conditions []TidbClusterCondition `json:"conditions,omitempty"`
+
+	// TiDBGroups is the status of TiDB groups, the key is the name of the group
+	// +optional
+	TiDBGroups map[string]*TiDBGroupStatus `json:"tidbGroups,omitempty"`
+
+	// TiKVGroups is the status of TiKV groups, the key is the name of the group
+	// +optional
+	TiKVGroups map[string]*TiKVGroupStatus `json:"tikvGroups,omitempty"`
+
+	// TiFlashCompute is the status of TiFlash compute nodes
+	// +optional
+	TiFlashCompute *TiFlashStatus `json:"tiflashCompute,omitempty"`
+
+	// TLSCluster is the status of the certificates issued by the operator
+	// +optional
+	TLSCluster *TLSClusterStatus `json:"tlsCluster,omitempty"`
+
+	// ConfigDrift is the drift between the config in the spec and the config of the running servers
+	// +optional
+	ConfigDrift *ConfigDriftStatus `json:"configDrift,omitempty"`
+
+	// ChangePlan is the plan of the changes to the components in the plan mode
+	// +optional
+	ChangePlan *ChangePlanStatus `json:"changePlan,omitempty"`
+
+	// Operations is the history of the recent lifecycle operations of the components, e.g. upgrades,
+	// scaling, failovers and volume changes, the latest one is the last
+	// +optional
+	Operations []OperationRecord `json:"operations,omitempty"`
 }
 
 // TidbClusterCondition describes the state of a tidb cluster at a certain point.
@@ -484,8 +545,21 @@ const (
 	// - All TiKV stores are up.
 	// - All TiFlash stores are up.
 	TidbClusterReady TidbClusterConditionType = "Ready"
+	// TidbClusterCertificateExpiringSoon indicates that any certificate in the TLS secrets
+	// consumed by the tidb cluster expires soon or is already expired.
+	TidbClusterCertificateExpiringSoon TidbClusterConditionType = "CertificateExpiringSoon"
+	// TidbClusterConfigDrift indicates that the config of any running server drifts from the
+	// config in the spec.
+	TidbClusterConfigDrift TidbClusterConditionType = "ConfigDrift"
+	// TidbClusterTiKVScaleInBlocked indicates that scaling in TiKV is refused because the remaining
+	// stores can not hold the data or satisfy the location constraints.
+	TidbClusterTiKVScaleInBlocked TidbClusterConditionType = "TiKVScaleInBlocked"
 )
 
+// CertificateExpiredReason is the reason of the `CertificateExpiringSoon` condition when any
+// certificate is already expired, the pods are not rolled until the certificate is renewed.
+const CertificateExpiredReason = "CertificateExpired"
+
 // The `Type` of the component condition
 const (
 	// ComponentVolumeResizing indicates that any volume of this component is resizing.
@@ -504,6 +578,13 @@ type DiscoverySpec struct {
 	// for other components, the auto failover feature may be used instead.
 	// +optional
 	LivenessProbe *Probe `json:"livenessProbe,omitempty"`
+
+	// Replicas is the number of the discovery pods. The bootstrap states of PD are persisted
+	// in a ConfigMap, so that multiple replicas can serve behind the discovery service.
+	// Optional: Defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -589,7 +670,8 @@ type PDSpec struct {
 	// +kubebuilder:default=0
 	InitWaitTime int `json:"initWaitTime,omitempty"`
 
-	// Mode is the mode of PD cluster
+	// Mode is the mode of PD cluster. Changing the mode of a running cluster migrates it between
+	// PD and PD microservices online, the progress is tracked by the `PDMSMigrating` condition of PD.
 	// +optional
 	// +kubebuilder:validation:Enum:="";"ms"
 	Mode string `json:"mode,omitempty"`
@@ -793,6 +875,17 @@ type TiKVSpec struct {
 	SpareVolReplaceReplicas *int32 `json:"spareVolReplaceReplicas,omitempty"`
 }
 
+// TiKVGroupSpec contains details of a group of TiKV members
+// +k8s:openapi-gen=true
+type TiKVGroupSpec struct {
+	// Name of the TiKV group, it must be unique in the TidbCluster and is used as
+	// the suffix of the names of the StatefulSet and services of the group
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	TiKVSpec `json:",inline"`
+}
+
 // TiFlashSpec contains details of TiFlash members
 // +k8s:openapi-gen=true
 type TiFlashSpec struct {
@@ -851,6 +944,58 @@ type TiFlashSpec struct {
 	// ScalePolicy is the scale configuration for TiFlash
 	// +optional
 	ScalePolicy ScalePolicy `json:"scalePolicy,omitempty"`
+
+	// Disaggregated enables the disaggregated storage and compute architecture of TiFlash,
+	// the TiFlash nodes specified by this spec work as write nodes and upload their data to S3.
+	// It requires TiFlash v7.0.0+ and the StartScriptVersion v2 or v3.
+	// +optional
+	Disaggregated *TiFlashDisaggregatedSpec `json:"disaggregated,omitempty"`
+}
+
+// TiFlashDisaggregatedSpec contains details of the disaggregated TiFlash
+// +k8s:openapi-gen=true
+type TiFlashDisaggregatedSpec struct {
+	// S3 is the S3 compatible storage shared by the TiFlash write and compute nodes
+	S3 TiFlashS3Spec `json:"s3"`
+
+	// Compute is the spec of the TiFlash compute nodes, which are stateless and
+	// read the data from S3 with a local cache
+	// +optional
+	Compute *TiFlashComputeSpec `json:"compute,omitempty"`
+}
+
+// TiFlashS3Spec is the S3 compatible storage of the disaggregated TiFlash
+// +k8s:openapi-gen=true
+type TiFlashS3Spec struct {
+	// Endpoint of S3 compatible storage service
+	Endpoint string `json:"endpoint"`
+	// Bucket in which to store the TiFlash data
+	Bucket string `json:"bucket"`
+	// Root is the path prefix of the TiFlash data in the bucket
+	// +optional
+	Root string `json:"root,omitempty"`
+	// SecretName is the name of secret which stores
+	// S3 compliant storage access key and secret key.
+	// If not set, the credentials are provided by the environment, e.g. IAM role of the service account
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// TiFlashComputeSpec contains details of TiFlash compute nodes, the fields that
+// are not in this spec, e.g. baseImage and serviceAccount, are the same as TiFlash write nodes
+// +k8s:openapi-gen=true
+type TiFlashComputeSpec struct {
+	ComponentSpec               `json:",inline"`
+	corev1.ResourceRequirements `json:",inline"`
+
+	// The desired ready replicas
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
+
+	// Config is the Configuration of TiFlash compute nodes
+	// Optional: Defaults to the config of TiFlash write nodes
+	// +optional
+	Config *TiFlashConfigWraper `json:"config,omitempty"`
 }
 
 // TiCDCSpec contains details of TiCDC members
@@ -896,6 +1041,16 @@ type TiCDCSpec struct {
 	// Defaults to 10m
 	// +optional
 	GracefulShutdownTimeout *metav1.Duration `json:"gracefulShutdownTimeout,omitempty"`
+
+	// MaxFailoverCount limit the max replicas could be added in failover, 0 means no failover.
+	// Optional: Defaults to 0
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxFailoverCount *int32 `json:"maxFailoverCount,omitempty"`
+
+	// RecoverFailover indicates that Operator can recover the failover Pods
+	// +optional
+	RecoverFailover bool `json:"recoverFailover,omitempty"`
 }
 
 // TiCDCConfig is the configuration of tidbcdc
@@ -1122,6 +1277,32 @@ type TiDBSpec struct {
 	//  - zone, topology.kubernetes.io/zone
 	//  - host
 	ServerLabels map[string]string `json:"serverLabels,omitempty"`
+
+	// Idle scales TiDB to zero after it has no client connections for a period of time, and scales it
+	// back up when it is woken up. It is intended for the clusters that are idle most of the time, e.g.
+	// the clusters for development and testing. Only the TiDB of a TidbCluster supports it.
+	// Optional: Defaults to nil
+	// +optional
+	Idle *TiDBIdlePolicy `json:"idle,omitempty"`
+
+	// HotConfigSecretName is the name of the secret that contains the `user` and `password` of the
+	// account used by the operator to change the instance-scoped system variables of TiDB online when
+	// the HotConfigApply feature is enabled, the account needs the SYSTEM_VARIABLES_ADMIN or SUPER privilege.
+	// The config changes of TiDB are applied by the rolling update if it is not set.
+	// Optional: Defaults to nil
+	// +optional
+	HotConfigSecretName *string `json:"hotConfigSecretName,omitempty"`
+}
+
+// TiDBGroupSpec contains details of a group of TiDB members
+// +k8s:openapi-gen=true
+type TiDBGroupSpec struct {
+	// Name of the TiDB group, it must be unique in the TidbCluster and is used as
+	// the suffix of the names of the StatefulSet and services of the group
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	TiDBSpec `json:",inline"`
 }
 
 type CustomizedProbe struct {
@@ -1540,6 +1721,29 @@ type PDStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// Indicates that a Volume replace using VolumeReplacing feature is in progress.
 	VolReplaceInProgress bool `json:"volReplaceInProgress,omitempty"`
+	// Mode is the mode that the PD servers are running in, it is changed to `.spec.pd.mode`
+	// when a migration between PD and PD microservices is done.
+	// +optional
+	Mode string `json:"mode,omitempty"`
+	// HotConfig is the status of the config changes that are applied online without restarting
+	// the servers, it is only set when the HotConfigApply feature is enabled.
+	// +optional
+	HotConfig *HotConfigStatus `json:"hotConfig,omitempty"`
+}
+
+// HotConfigStatus is the status of the config changes that are applied online
+type HotConfigStatus struct {
+	// AppliedKeys are the config keys of the last config change that are applied online.
+	// +optional
+	AppliedKeys []string `json:"appliedKeys,omitempty"`
+	// PendingKeys are the config keys of the last config change that can not be applied online,
+	// they take effect after the servers are restarted by the rolling update.
+	// +optional
+	PendingKeys []string `json:"pendingKeys,omitempty"`
+	// LastTransitionTime is the last time the config change is handled.
+	// +optional
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
 // PDMSStatus is PD microservice status
@@ -1616,6 +1820,20 @@ type TiDBStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// Indicates that a Volume replace using VolumeReplacing feature is in progress.
 	VolReplaceInProgress bool `json:"volReplaceInProgress,omitempty"`
+	// HotConfig is the status of the config changes that are applied online without restarting
+	// the servers, it is only set when the HotConfigApply feature is enabled.
+	// +optional
+	HotConfig *HotConfigStatus `json:"hotConfig,omitempty"`
+	// Idle is the status of TiDB under the idle policy, it is only set when the idle policy is set.
+	// +optional
+	Idle *TiDBIdleStatus `json:"idle,omitempty"`
+}
+
+// TiDBGroupStatus is TiDB group status
+type TiDBGroupStatus struct {
+	Name string `json:"name"`
+
+	TiDBStatus `json:",inline"`
 }
 
 // TiDBMember is TiDB member
@@ -1701,6 +1919,30 @@ const (
 	// Normally we only allow one pod evicts leader.
 	// TODO: set this condition before all leader eviction behavior
 	ConditionTypeLeaderEvicting = "LeaderEvicting"
+
+	// ConditionTypePDMSMigrating indicates that PD is migrating between PD and PD microservices,
+	// the reason of the condition is the current stage of the migration.
+	ConditionTypePDMSMigrating = "PDMSMigrating"
+
+	// ConditionTypeCertificateExpiringSoon indicates that any certificate in the TLS secrets
+	// consumed by the TidbMonitor or TidbDashboard expires soon or is already expired.
+	ConditionTypeCertificateExpiringSoon = "CertificateExpiringSoon"
+)
+
+// PDModeMS is the mode of PD microservices
+const PDModeMS = "ms"
+
+// The stages of the migration between PD and PD microservices, they are used as the
+// reason of the PDMSMigrating condition
+const (
+	// PDMSMigrationStartingServices means that the PD microservices are being started
+	PDMSMigrationStartingServices = "StartingMicroservices"
+	// PDMSMigrationSwitchingPD means that the PD servers are being restarted in the target mode
+	PDMSMigrationSwitchingPD = "SwitchingPDMode"
+	// PDMSMigrationStoppingServices means that the PD microservices are being stopped
+	PDMSMigrationStoppingServices = "StoppingMicroservices"
+	// PDMSMigrationCompleted means that the migration is done
+	PDMSMigrationCompleted = "Completed"
 )
 
 // TiKVStatus is TiKV status
@@ -1724,6 +1966,21 @@ type TiKVStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// Indicates that a Volume replace using VolumeReplacing feature is in progress.
 	VolReplaceInProgress bool `json:"volReplaceInProgress,omitempty"`
+	// HotConfig is the status of the config changes that are applied online without restarting
+	// the servers, it is only set when the HotConfigApply feature is enabled.
+	// +optional
+	HotConfig *HotConfigStatus `json:"hotConfig,omitempty"`
+	// ScaleAcceleration is the status of the acceleration of PD scheduling while TiKV is being
+	// scaled or failed over, it is only set when the acceleration is in progress.
+	// +optional
+	ScaleAcceleration *ScaleAccelerationStatus `json:"scaleAcceleration,omitempty"`
+}
+
+// TiKVGroupStatus is TiKV group status
+type TiKVGroupStatus struct {
+	Name string `json:"name"`
+
+	TiKVStatus `json:",inline"`
 }
 
 // TiFlashStatus is TiFlash status
@@ -1779,6 +2036,9 @@ type TiCDCStatus struct {
 	Phase       MemberPhase             `json:"phase,omitempty"`
 	StatefulSet *apps.StatefulSetStatus `json:"statefulSet,omitempty"`
 	Captures    map[string]TiCDCCapture `json:"captures,omitempty"`
+	// FailureMembers are the captures that are failed over, a replacement capture is added for each of them
+	// +optional
+	FailureMembers map[string]TiCDCFailureMember `json:"failureMembers,omitempty"`
 	// Volumes contains the status of all volumes.
 	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
 	// Represents the latest available observations of a component's state.
@@ -1794,6 +2054,16 @@ type TiCDCCapture struct {
 	Version string `json:"version,omitempty"`
 	IsOwner bool   `json:"isOwner,omitempty"`
 	Ready   bool   `json:"ready,omitempty"`
+	// Last time the ready status transitioned from one to another.
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// TiCDCFailureMember is the TiCDC capture that is failed over
+type TiCDCFailureMember struct {
+	PodName string `json:"podName,omitempty"`
+	// +nullable
+	CreatedAt metav1.Time `json:"createdAt,omitempty"`
 }
 
 // TiKVStores is either Up/Down/Offline/Tombstone
@@ -1813,6 +2083,52 @@ type TiKVStore struct {
 	// It is set when evicting leader and used to wait for most leaders to transfer back after upgrade.
 	// It is unset after leader transfer is completed.
 	LeaderCountBeforeUpgrade *int32 `json:"leaderCountBeforeUpgrade,omitempty"`
+	// Group is the name of the TiKV group the store belongs to,
+	// it is empty for the stores of `.spec.tikv`.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Migration is the progress of migrating the data out of the store when it is scaled in,
+	// or into the store when it is scaled out.
+	// It is unset after the migration is completed.
+	// +optional
+	Migration *TiKVStoreMigration `json:"migration,omitempty"`
+}
+
+// TiKVStoreMigrationDirection is the direction of the data migration of a TiKV store
+type TiKVStoreMigrationDirection string
+
+const (
+	// TiKVStoreMigrationOut means the data is migrated out of the store that is scaled in
+	TiKVStoreMigrationOut TiKVStoreMigrationDirection = "Out"
+	// TiKVStoreMigrationIn means the data is rebalanced into the store that is scaled out
+	TiKVStoreMigrationIn TiKVStoreMigrationDirection = "In"
+)
+
+// TiKVStoreMigration is the progress of the data migration of a TiKV store, it is sampled from PD
+// every time the TidbCluster is synced.
+type TiKVStoreMigration struct {
+	// Direction is Out when the store is scaled in, or In when the store is scaled out
+	Direction TiKVStoreMigrationDirection `json:"direction"`
+	// StartTime is the time when the migration is observed first
+	StartTime metav1.Time `json:"startTime"`
+	// LastSampleTime is the time of the last sample from PD
+	LastSampleTime metav1.Time `json:"lastSampleTime"`
+	// RemainingRegions is the number of the regions to be migrated
+	RemainingRegions int32 `json:"remainingRegions"`
+	// RemainingLeaders is the number of the leaders to be migrated
+	RemainingLeaders int32 `json:"remainingLeaders"`
+	// RemainingBytes is the approximate size of the regions to be migrated
+	RemainingBytes int64 `json:"remainingBytes"`
+	// RegionRate is the moving average of the number of the regions migrated per second
+	// +optional
+	RegionRate *resource.Quantity `json:"regionRate,omitempty"`
+	// ByteRate is the moving average of the bytes migrated per second
+	// +optional
+	ByteRate *resource.Quantity `json:"byteRate,omitempty"`
+	// EstimatedCompletionTime is the estimated time when the migration is completed, it is
+	// unset until the migration rate is known
+	// +optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
 }
 
 // TiKVFailureStore is the tikv failure store information
@@ -1852,6 +2168,49 @@ type PumpStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// TiDBIdlePolicy is the policy of scaling TiDB to zero when it is idle
+// +k8s:openapi-gen=true
+type TiDBIdlePolicy struct {
+	// Timeout is how long TiDB has no client connections before it is scaled to zero.
+	// Optional: Defaults to 30m
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// WakeOnConnect points the TiDB service to an activator in the discovery service when TiDB is scaled
+	// to zero. The activator holds the incoming connections, wakes TiDB up, and forwards the connections
+	// to TiDB once it is ready. If it is disabled, TiDB is woken up by setting the annotation
+	// `tidb.pingcap.com/tidb-wake-up` of the TidbCluster to the current time in RFC3339 format.
+	// Optional: Defaults to true
+	// +optional
+	WakeOnConnect *bool `json:"wakeOnConnect,omitempty"`
+}
+
+// TiDBIdlePhase is the phase of TiDB under the idle policy
+type TiDBIdlePhase string
+
+const (
+	// TiDBIdlePhaseActive means TiDB is running
+	TiDBIdlePhaseActive TiDBIdlePhase = "Active"
+	// TiDBIdlePhaseIdle means TiDB is scaled to zero
+	TiDBIdlePhaseIdle TiDBIdlePhase = "Idle"
+	// TiDBIdlePhaseWaking means TiDB is woken up and is scaling back up
+	TiDBIdlePhaseWaking TiDBIdlePhase = "Waking"
+)
+
+// TiDBIdleStatus is the status of TiDB under the idle policy
+type TiDBIdleStatus struct {
+	// Phase is the phase of TiDB under the idle policy.
+	Phase TiDBIdlePhase `json:"phase"`
+	// LastActiveTime is the last time TiDB is observed to have client connections, or is woken up.
+	// +optional
+	// +nullable
+	LastActiveTime metav1.Time `json:"lastActiveTime,omitempty"`
+	// LastTransitionTime is the last time the phase changes.
+	// +optional
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
 // TiDBTLSClient can enable TLS connection between TiDB server and MySQL client
 // +k8s:openapi-gen=true
 type TiDBTLSClient struct {
@@ -1904,6 +2263,223 @@ type TLSCluster struct {
 	//        Same for other components.
 	// +optional
 	Enabled bool `json:"enabled,omitempty"`
+
+	// Issuer makes the operator generate a CA for the cluster and issue the certificates of
+	// all components and the client, instead of requiring the Secrets above to be created.
+	// The issued certificates are renewed before they expire.
+	// Secrets that already exist and are not created by the operator are left untouched.
+	// Only supported by TidbCluster.
+	// +optional
+	Issuer *TLSClusterIssuer `json:"issuer,omitempty"`
+}
+
+// TLSClusterIssuer is the config of the certificates issued by the operator
+type TLSClusterIssuer struct {
+	// Duration is the validity duration of the issued certificates
+	// Optional: Defaults to 8760h (1 year)
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is how long before the expiry the issued certificates are renewed.
+	// Components that can not reload certificates are restarted after renewal.
+	// Optional: Defaults to 720h (30 days)
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// TLSClusterStatus is the status of the certificates issued by the operator
+type TLSClusterStatus struct {
+	// Certificates contains the issued certificates, the key is the name of the component
+	// or `client` for the client certificate
+	// +optional
+	Certificates map[string]IssuedCertificate `json:"certificates,omitempty"`
+}
+
+// ConfigDriftDetection is the config of detecting the drift between the config in the spec and
+// the config of the running servers
+type ConfigDriftDetection struct {
+	// Interval is the interval of checking the config of the running servers
+	// Optional: Defaults to 10m
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Enforce reapplies the config in the spec to the servers whose config drifts.
+	// Only the config items that can be changed online are reapplied, the others are
+	// only reported.
+	// +optional
+	Enforce bool `json:"enforce,omitempty"`
+}
+
+// ConfigDriftStatus is the drift between the config in the spec and the config of the running servers
+type ConfigDriftStatus struct {
+	// LastCheckTime is the last time the config of the running servers is checked
+	// +nullable
+	LastCheckTime metav1.Time `json:"lastCheckTime,omitempty"`
+	// Items are the config items that drift
+	// +optional
+	Items []ConfigDriftItem `json:"items,omitempty"`
+}
+
+// ConfigDriftItem is a config item of a server that drifts from the config in the spec
+type ConfigDriftItem struct {
+	// Component is the component of the server, e.g. pd, tikv, tidb or tiflash
+	Component MemberType `json:"component"`
+	// Instance is the name of the pod of the server, it is empty if the config is
+	// shared by the servers of the component, e.g. the config of PD
+	// +optional
+	Instance string `json:"instance,omitempty"`
+	// Key is the dotted path of the config item
+	Key string `json:"key"`
+	// Expected is the value of the config item in the spec
+	Expected string `json:"expected"`
+	// Actual is the value of the config item of the running server
+	Actual string `json:"actual"`
+}
+
+// ChangePlanStatus is the plan of the changes to the components, the changes are applied only after
+// the plan is approved in the plan mode
+type ChangePlanStatus struct {
+	// ID identifies the spec the plan is computed for, the plan is approved by setting the
+	// annotation tidb.pingcap.com/change-plan-approved of the TidbCluster to it
+	ID string `json:"id"`
+	// Approved is whether the plan is approved
+	// +optional
+	Approved bool `json:"approved,omitempty"`
+	// LastUpdateTime is the last time the planned changes are updated
+	// +nullable
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+	// Components are the planned changes of the components
+	// +optional
+	Components []ComponentChangePlan `json:"components,omitempty"`
+}
+
+// ComponentChangePlan is the planned changes of the StatefulSet of a component
+type ComponentChangePlan struct {
+	// Component is the component, e.g. pd, tikv, tidb or tiflash
+	Component MemberType `json:"component"`
+	// StatefulSet is the name of the StatefulSet of the component
+	StatefulSet string `json:"statefulSet"`
+	// TemplateChanges are the changed fields of the pod template
+	// +optional
+	TemplateChanges []string `json:"templateChanges,omitempty"`
+	// UpgradeOrdinals are the ordinals of the pods to restart, in the order they are restarted
+	// +optional
+	UpgradeOrdinals []int32 `json:"upgradeOrdinals,omitempty"`
+	// ScaleOutOrdinals are the ordinals of the pods to create
+	// +optional
+	ScaleOutOrdinals []int32 `json:"scaleOutOrdinals,omitempty"`
+	// ScaleInOrdinals are the ordinals of the pods to delete
+	// +optional
+	ScaleInOrdinals []int32 `json:"scaleInOrdinals,omitempty"`
+	// VolumeChanges are the planned changes of the PVCs
+	// +optional
+	VolumeChanges []VolumeChangePlan `json:"volumeChanges,omitempty"`
+}
+
+// VolumeChangeAction is the action to change a PVC
+type VolumeChangeAction string
+
+const (
+	// VolumeChangeResize means the PVC is resized in place
+	VolumeChangeResize VolumeChangeAction = "Resize"
+	// VolumeChangeReplace means the PVC is replaced by a new one, e.g. the storage class is changed
+	// or the storage is shrunk
+	VolumeChangeReplace VolumeChangeAction = "Replace"
+)
+
+// VolumeChangePlan is a planned change of a PVC
+type VolumeChangePlan struct {
+	// PVCName is the name of the PVC
+	PVCName string `json:"pvcName"`
+	// Action is the action to change the PVC
+	Action VolumeChangeAction `json:"action"`
+	// From is the current storage class and size of the PVC
+	From string `json:"from"`
+	// To is the desired storage class and size of the PVC
+	To string `json:"to"`
+}
+
+// OperationType is the type of a lifecycle operation of a component
+type OperationType string
+
+const (
+	// OperationUpgrade means the pods of the component are restarted to apply a new pod template
+	OperationUpgrade OperationType = "Upgrade"
+	// OperationScaleOut means pods are added to the component
+	OperationScaleOut OperationType = "ScaleOut"
+	// OperationScaleIn means pods are removed from the component
+	OperationScaleIn OperationType = "ScaleIn"
+	// OperationFailover means a failed member is marked to be replaced
+	OperationFailover OperationType = "Failover"
+	// OperationFailoverRecovery means the failure members are cleared after the failed members recover
+	OperationFailoverRecovery OperationType = "FailoverRecovery"
+	// OperationVolumeResize means the PVCs are resized in place
+	OperationVolumeResize OperationType = "VolumeResize"
+	// OperationVolumeReplace means the PVCs are replaced by new ones
+	OperationVolumeReplace OperationType = "VolumeReplace"
+)
+
+// OperationTrigger is what triggers a lifecycle operation
+type OperationTrigger string
+
+const (
+	// OperationTriggerSpecChange means the operation is triggered by a change of the spec
+	OperationTriggerSpecChange OperationTrigger = "SpecChange"
+	// OperationTriggerFailover means the operation is triggered by the failover of failed members
+	OperationTriggerFailover OperationTrigger = "Failover"
+	// OperationTriggerAutoRecovery means the operation is triggered by the recovery of failed members
+	OperationTriggerAutoRecovery OperationTrigger = "AutoRecovery"
+)
+
+// OperationOutcome is the outcome of a lifecycle operation
+type OperationOutcome string
+
+const (
+	// OperationInProgress means the operation is not finished yet
+	OperationInProgress OperationOutcome = "InProgress"
+	// OperationSucceeded means the operation is finished successfully
+	OperationSucceeded OperationOutcome = "Succeeded"
+	// OperationFailed means the operation is finished with an error
+	OperationFailed OperationOutcome = "Failed"
+	// OperationCancelled means the operation is abandoned before it is finished, e.g. the replicas are reverted
+	OperationCancelled OperationOutcome = "Cancelled"
+)
+
+// OperationRecord is a record of a lifecycle operation of a component
+type OperationRecord struct {
+	// Type is the type of the operation
+	Type OperationType `json:"type"`
+	// Component is the component the operation is applied to
+	Component MemberType `json:"component"`
+	// Group is the TiDB group, the TiKV group or the TiFlash role the operation is applied to,
+	// it is empty for the default one of the component
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Trigger is what triggers the operation
+	Trigger OperationTrigger `json:"trigger"`
+	// StartTime is when the operation starts
+	StartTime metav1.Time `json:"startTime"`
+	// EndTime is when the operation is finished
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// Ordinals are the ordinals of the pods affected by the operation
+	// +optional
+	Ordinals []int32 `json:"ordinals,omitempty"`
+	// Outcome is the outcome of the operation
+	Outcome OperationOutcome `json:"outcome"`
+	// Message is the detail of the outcome
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IssuedCertificate is a certificate issued by the operator
+type IssuedCertificate struct {
+	// SecretName is the name of the Secret that stores the certificate
+	SecretName string `json:"secretName"`
+	// SerialNumber is the serial number of the certificate in hex
+	SerialNumber string `json:"serialNumber"`
+	// NotAfter is the expiry time of the certificate
+	NotAfter metav1.Time `json:"notAfter"`
 }
 
 // +genclient
@@ -2962,6 +3538,13 @@ type DMDiscoverySpec struct {
 	// +optional
 	LivenessProbe *Probe `json:"livenessProbe,omitempty"`
 
+	// Replicas is the number of the discovery pods. The bootstrap states of dm-master are persisted
+	// in a ConfigMap, so that multiple replicas can serve behind the discovery service.
+	// Optional: Defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
 	// (Deprecated) Address indicates the existed TiDB discovery address
 	// +k8s:openapi-gen=false
 	Address string `json:"address,omitempty"`
@@ -3273,6 +3856,9 @@ const (
 	// - All Master members are healthy.
 	// - All Worker pods are up.
 	DMClusterReady DMClusterConditionType = "Ready"
+	// DMClusterCertificateExpiringSoon indicates that any certificate in the TLS secrets
+	// consumed by the dm cluster expires soon or is already expired.
+	DMClusterCertificateExpiringSoon DMClusterConditionType = "CertificateExpiringSoon"
 )
 
 // MasterStatus is dm-master status
@@ -3504,6 +4090,55 @@ type ScalePolicy struct {
 	// +kubebuilder:default=1
 	// +optional
 	ScaleOutParallelism *int32 `json:"scaleOutParallelism,omitempty"`
+
+	// Acceleration raises the scheduling limits of PD while TiKV is being scaled or failed over,
+	// and restores the previous limits after that. It only takes effect for TiKV.
+	// +optional
+	Acceleration *ScaleAcceleration `json:"acceleration,omitempty"`
+}
+
+// ScaleAcceleration is the scheduling limits of PD used while TiKV is being scaled or failed over.
+// The limits that are not set are left unchanged.
+type ScaleAcceleration struct {
+	// StoreLimit is the rate of both adding and removing peers per minute of every TiKV store.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	StoreLimit *int32 `json:"storeLimit,omitempty"`
+
+	// RegionScheduleLimit is the `schedule.region-schedule-limit` of PD.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RegionScheduleLimit *int32 `json:"regionScheduleLimit,omitempty"`
+
+	// ReplicaScheduleLimit is the `schedule.replica-schedule-limit` of PD.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ReplicaScheduleLimit *int32 `json:"replicaScheduleLimit,omitempty"`
+}
+
+// ScaleAccelerationStatus records the scheduling limits of PD before the acceleration, they are
+// restored after the scale or failover is completed.
+type ScaleAccelerationStatus struct {
+	// StartTime is the time when the acceleration starts
+	StartTime metav1.Time `json:"startTime"`
+
+	// PreviousStoreLimits is the store limits before the acceleration, keyed by store id
+	// +optional
+	PreviousStoreLimits map[string]StoreLimitStatus `json:"previousStoreLimits,omitempty"`
+
+	// PreviousRegionScheduleLimit is the `schedule.region-schedule-limit` of PD before the acceleration
+	// +optional
+	PreviousRegionScheduleLimit *int64 `json:"previousRegionScheduleLimit,omitempty"`
+
+	// PreviousReplicaScheduleLimit is the `schedule.replica-schedule-limit` of PD before the acceleration
+	// +optional
+	PreviousReplicaScheduleLimit *int64 `json:"previousReplicaScheduleLimit,omitempty"`
+}
+
+// StoreLimitStatus is the store limit of a store, the rates are the number of peers per minute
+type StoreLimitStatus struct {
+	AddPeer    float64 `json:"addPeer"`
+	RemovePeer float64 `json:"removePeer"`
 }
 
 // +genclient
@@ -21,14 +21,17 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
 	"github.com/prometheus/common/model"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -36,6 +39,9 @@ import (
 	utilnet "k8s.io/utils/net"
 )
 
+// keyspaceNameRegex is the valid pattern of keyspace names, which is the same as PD
+var keyspaceNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
 // ValidateTidbCluster validates a TidbCluster, it performs basic validation for all TidbClusters despite it is legacy
 // or not
 func ValidateTidbCluster(tc *v1alpha1.TidbCluster) field.ErrorList {
@@ -88,6 +94,105 @@ func ValidateTiDBDashboard(td *v1alpha1.TidbDashboard) field.ErrorList {
 	return allErrs
 }
 
+// ValidateTiDBTenant validates a TidbTenant.
+func ValidateTiDBTenant(tt *v1alpha1.TidbTenant) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// the name of the tenant is used as the name of its TiDB group
+	allErrs = append(allErrs, validateGroupName(tt.Name, reservedTiDBGroupNames, field.NewPath("metadata").Child("name"))...)
+
+	specPath := field.NewPath("spec")
+	if tt.Spec.Cluster.Name == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("cluster", "name"), "cluster name must be set"))
+	}
+	if ns := tt.Spec.Cluster.Namespace; ns != "" && ns != tt.Namespace {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("cluster", "namespace"), ns, "must be the namespace of the TidbTenant"))
+	}
+	if name := tt.Spec.KeyspaceName; name != "" && !keyspaceNameRegex.MatchString(name) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("keyspaceName"), name, "must consist of at most 64 alphanumeric characters, '-' or '_'"))
+	}
+
+	tidbPath := specPath.Child("tidb")
+	allErrs = append(allErrs, validateTiDBSpec(&tt.Spec.TiDB, tidbPath)...)
+	if tt.Spec.TiDB.Config != nil && tt.Spec.TiDB.Config.Get("keyspace-name") != nil {
+		allErrs = append(allErrs, field.Forbidden(tidbPath.Child("config", "keyspace-name"), "keyspace-name is set by the TidbTenant"))
+	}
+	if tt.Spec.TiDB.Idle != nil {
+		allErrs = append(allErrs, field.Forbidden(tidbPath.Child("idle"), "idle policy is not supported by TidbTenant"))
+	}
+
+	return allErrs
+}
+
+// ValidateTidbClusterScalingSchedule validates a TidbClusterScalingSchedule, the cron strings of the windows
+// are validated when they are evaluated.
+func ValidateTidbClusterScalingSchedule(tcss *v1alpha1.TidbClusterScalingSchedule) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	specPath := field.NewPath("spec")
+	if tcss.Spec.Cluster.Name == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("cluster", "name"), "cluster name must be set"))
+	}
+	if ns := tcss.Spec.Cluster.Namespace; ns != "" && ns != tcss.Namespace {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("cluster", "namespace"), ns, "must be the namespace of the TidbClusterScalingSchedule"))
+	}
+	if tz := tcss.Spec.Timezone; tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("timezone"), tz, err.Error()))
+		}
+	}
+	if limit := tcss.Spec.HistoryLimit; limit != nil && *limit < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("historyLimit"), *limit, "must be greater than or equal to 0"))
+	}
+	if tcss.Spec.Default != nil {
+		allErrs = append(allErrs, validateScalingTargets(tcss.Spec.Default, specPath.Child("default"))...)
+	}
+
+	windowsPath := specPath.Child("windows")
+	if len(tcss.Spec.Windows) == 0 {
+		allErrs = append(allErrs, field.Required(windowsPath, "at least one window must be set"))
+	}
+	names := map[string]struct{}{}
+	for i := range tcss.Spec.Windows {
+		window := &tcss.Spec.Windows[i]
+		windowPath := windowsPath.Index(i)
+		if window.Name == "" {
+			allErrs = append(allErrs, field.Required(windowPath.Child("name"), "name must be set"))
+		} else if _, ok := names[window.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(windowPath.Child("name"), window.Name))
+		}
+		names[window.Name] = struct{}{}
+		if window.Schedule == "" {
+			allErrs = append(allErrs, field.Required(windowPath.Child("schedule"), "schedule must be set"))
+		}
+		if window.Duration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("duration"), window.Duration.Duration.String(), "must be greater than 0"))
+		}
+		allErrs = append(allErrs, validateScalingTargets(&window.Replicas, windowPath.Child("replicas"))...)
+	}
+
+	return allErrs
+}
+
+func validateScalingTargets(targets *v1alpha1.ScalingTargets, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, target := range []struct {
+		name     string
+		replicas *int32
+	}{
+		{"tidb", targets.TiDB},
+		{"tikv", targets.TiKV},
+		{"tiflash", targets.TiFlash},
+		{"ticdc", targets.TiCDC},
+		{"tiproxy", targets.TiProxy},
+	} {
+		if target.replicas != nil && *target.replicas < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(target.name), *target.replicas, "must be greater than or equal to 0"))
+		}
+	}
+	return allErrs
+}
+
 func ValidateTidbMonitor(monitor *v1alpha1.TidbMonitor) field.ErrorList {
 	allErrs := field.ErrorList{}
 	// validate monitor service
@@ -111,6 +216,11 @@ func validateAnnotations(anns map[string]string, fldPath *field.Path) field.Erro
 	for _, key := range []string{label.AnnPDDeleteSlots, label.AnnTiDBDeleteSlots, label.AnnTiKVDeleteSlots, label.AnnTiFlashDeleteSlots, label.AnnTiProxyDeleteSlots, label.AnnTiCDCDeleteSlots} {
 		allErrs = append(allErrs, validateDeleteSlots(anns, key, fldPath.Child(key))...)
 	}
+	for _, key := range []string{label.AnnTiDBGroupKey, label.AnnTiKVGroupKey} {
+		if _, ok := anns[key]; ok {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(key), "annotation is reserved for internal use"))
+		}
+	}
 	return allErrs
 }
 
@@ -138,14 +248,24 @@ func validateTiDBClusterSpec(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path
 	if spec.TiKV != nil {
 		allErrs = append(allErrs, validateTiKVSpec(spec.TiKV, fldPath.Child("tikv"))...)
 	}
+	if spec.TiKVGroups != nil {
+		allErrs = append(allErrs, validateTiKVGroups(spec.TiKVGroups, fldPath.Child("tikvGroups"))...)
+	}
 	if spec.TiDB != nil {
 		allErrs = append(allErrs, validateTiDBSpec(spec.TiDB, fldPath.Child("tidb"))...)
 	}
+	if spec.TiDBGroups != nil {
+		allErrs = append(allErrs, validateTiDBGroups(spec.TiDBGroups, fldPath.Child("tidbGroups"))...)
+	}
 	if spec.Pump != nil {
 		allErrs = append(allErrs, validatePumpSpec(spec.Pump, fldPath.Child("pump"))...)
 	}
 	if spec.TiFlash != nil {
 		allErrs = append(allErrs, validateTiFlashSpec(spec.TiFlash, fldPath.Child("tiflash"))...)
+		if spec.TiFlash.Disaggregated != nil && spec.StartScriptVersion != v1alpha1.StartScriptV2 && spec.StartScriptVersion != v1alpha1.StartScriptV3 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("tiflash", "disaggregated"), spec.StartScriptVersion,
+				"disaggregated TiFlash requires startScriptVersion v2 or v3"))
+		}
 	}
 	if spec.TiCDC != nil {
 		allErrs = append(allErrs, validateTiCDCSpec(spec.TiCDC, fldPath.Child("ticdc"))...)
@@ -156,6 +276,20 @@ func validateTiDBClusterSpec(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path
 	if spec.StartScriptV2FeatureFlags != nil {
 		allErrs = append(allErrs, validateStartScriptFeatureFlags(spec.StartScriptV2FeatureFlags, fldPath.Child("startScriptV2FeatureFlags"))...)
 	}
+	if spec.TLSCluster != nil && spec.TLSCluster.Issuer != nil {
+		allErrs = append(allErrs, validateTLSClusterIssuer(spec.TLSCluster.Issuer, fldPath.Child("tlsCluster", "issuer"))...)
+	}
+	return allErrs
+}
+
+func validateTLSClusterIssuer(issuer *v1alpha1.TLSClusterIssuer, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if issuer.GetDuration() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("duration"), issuer.GetDuration().String(), "duration must be positive"))
+	}
+	if issuer.GetRenewBefore() <= 0 || issuer.GetRenewBefore() >= issuer.GetDuration() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("renewBefore"), issuer.GetRenewBefore().String(), "renewBefore must be positive and less than duration"))
+	}
 	return allErrs
 }
 
@@ -164,6 +298,16 @@ func validateDiscoverySpec(spec v1alpha1.DiscoverySpec, fldPath *field.Path) fie
 	if spec.ComponentSpec != nil {
 		allErrs = append(allErrs, validateComponentSpec(spec.ComponentSpec, fldPath)...)
 	}
+	allErrs = append(allErrs, validateDiscoveryReplicas(spec.Replicas, fldPath.Child("replicas"))...)
+	return allErrs
+}
+
+// validateDiscoveryReplicas validates the replicas of the discovery service, which must serve at least one pod
+func validateDiscoveryReplicas(replicas *int32, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if replicas != nil && *replicas < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, *replicas, "must be greater than or equal to 1"))
+	}
 	return allErrs
 }
 
@@ -237,6 +381,25 @@ func validateTiKVSpec(spec *v1alpha1.TiKVSpec, fldPath *field.Path) field.ErrorL
 	return allErrs
 }
 
+func validateTiKVGroups(groups []*v1alpha1.TiKVGroupSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := map[string]struct{}{}
+	for i, group := range groups {
+		idxPath := fldPath.Index(i)
+		if group == nil {
+			allErrs = append(allErrs, field.Required(idxPath, "TiKV group must not be empty"))
+			continue
+		}
+		allErrs = append(allErrs, validateGroupName(group.Name, nil, idxPath.Child("name"))...)
+		if _, ok := names[group.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), group.Name))
+		}
+		names[group.Name] = struct{}{}
+		allErrs = append(allErrs, validateTiKVSpec(&group.TiKVSpec, idxPath)...)
+	}
+	return allErrs
+}
+
 func validateTiFlashSpec(spec *v1alpha1.TiFlashSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
@@ -246,6 +409,9 @@ func validateTiFlashSpec(spec *v1alpha1.TiFlashSpec, fldPath *field.Path) field.
 			spec.StorageClaims, "storageClaims should be configured at least one item."))
 	}
 	allErrs = append(allErrs, validateScalePolicy(&spec.ScalePolicy, fldPath.Child("scalePolicy"))...)
+	if spec.Disaggregated != nil {
+		allErrs = append(allErrs, validateTiFlashDisaggregatedSpec(spec.Disaggregated, fldPath.Child("disaggregated"))...)
+	}
 
 	// fix storageClaim
 	for _, storageClaim := range spec.StorageClaims {
@@ -257,6 +423,22 @@ func validateTiFlashSpec(spec *v1alpha1.TiFlashSpec, fldPath *field.Path) field.
 	return allErrs
 }
 
+func validateTiFlashDisaggregatedSpec(spec *v1alpha1.TiFlashDisaggregatedSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.S3.Endpoint == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("s3", "endpoint"), "endpoint of S3 must be specified"))
+	}
+	if spec.S3.Bucket == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("s3", "bucket"), "bucket of S3 must be specified"))
+	}
+	if spec.Compute != nil {
+		computePath := fldPath.Child("compute")
+		allErrs = append(allErrs, validateComponentSpec(&spec.Compute.ComponentSpec, computePath)...)
+		allErrs = append(allErrs, validateTiFlashConfig(spec.Compute.Config, computePath)...)
+	}
+	return allErrs
+}
+
 func validateTiCDCSpec(spec *v1alpha1.TiCDCSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
@@ -330,6 +512,55 @@ func validateTiDBSpec(spec *v1alpha1.TiDBSpec, fldPath *field.Path) field.ErrorL
 	if spec.ShouldSeparateSlowLog() && spec.SlowLogVolumeName != "" {
 		allErrs = append(allErrs, validateVolumeName(spec.SlowLogVolumeName, spec.StorageVolumes, spec.AdditionalVolumes, spec.AdditionalVolumeMounts, fldPath)...)
 	}
+	if spec.Idle != nil && spec.Idle.Timeout != nil && spec.Idle.Timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("idle", "timeout"), spec.Idle.Timeout.Duration.String(), "must be greater than 0"))
+	}
+	return allErrs
+}
+
+// reservedTiDBGroupNames are the group names whose derived names are used by
+// other components, e.g. ${cluster}-tidb-initializer by the TidbInitializer.
+var reservedTiDBGroupNames = []string{"initializer"}
+
+// validateGroupName validates the name of a TiKV or TiDB group, from which the
+// names of its StatefulSet and Services are derived. A group named "peer" or
+// "xxx-peer" would take the peer Service of the default component or of the
+// group "xxx", so these names are rejected as well as the reserved ones.
+func validateGroupName(name string, reserved []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsDNS1123Label(name) {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, msg))
+	}
+	if name == "peer" || strings.HasSuffix(name, "-peer") {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, "must not be \"peer\" or end with \"-peer\""))
+	}
+	for _, r := range reserved {
+		if name == r {
+			allErrs = append(allErrs, field.Invalid(fldPath, name, "is reserved"))
+		}
+	}
+	return allErrs
+}
+
+func validateTiDBGroups(groups []*v1alpha1.TiDBGroupSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := map[string]struct{}{}
+	for i, group := range groups {
+		idxPath := fldPath.Index(i)
+		if group == nil {
+			allErrs = append(allErrs, field.Required(idxPath, "TiDB group must not be empty"))
+			continue
+		}
+		allErrs = append(allErrs, validateGroupName(group.Name, reservedTiDBGroupNames, idxPath.Child("name"))...)
+		if _, ok := names[group.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), group.Name))
+		}
+		names[group.Name] = struct{}{}
+		allErrs = append(allErrs, validateTiDBSpec(&group.TiDBSpec, idxPath)...)
+		if group.Idle != nil {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("idle"), "idle policy is only supported by the default TiDB"))
+		}
+	}
 	return allErrs
 }
 
@@ -357,6 +588,9 @@ func validateDMClusterSpec(spec *v1alpha1.DMClusterSpec, fldPath *field.Path) fi
 	if spec.Worker != nil {
 		allErrs = append(allErrs, validateWorkerSpec(spec.Worker, fldPath.Child("worker"))...)
 	}
+	if spec.TLSCluster != nil && spec.TLSCluster.Issuer != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("tlsCluster", "issuer"), "issuing certificates by the operator is not supported by DMCluster"))
+	}
 	return allErrs
 }
 
@@ -365,6 +599,7 @@ func validateDMDiscoverySpec(spec v1alpha1.DMDiscoverySpec, fldPath *field.Path)
 	if spec.ComponentSpec != nil {
 		allErrs = append(allErrs, validateComponentSpec(spec.ComponentSpec, fldPath)...)
 	}
+	allErrs = append(allErrs, validateDiscoveryReplicas(spec.Replicas, fldPath.Child("replicas"))...)
 	return allErrs
 }
 
@@ -577,10 +812,199 @@ func ValidateUpdateTidbCluster(old, tc *v1alpha1.TidbCluster) field.ErrorList {
 	allErrs = append(allErrs, validateUpdatePDConfig(old.Spec.PD, tc.Spec.PD, field.NewPath("spec.pd.config"))...)
 	allErrs = append(allErrs, disallowMutateBootstrapSQLConfigMapName(old.Spec.TiDB, tc.Spec.TiDB, field.NewPath("spec.tidb.bootstrapSQLConfigMapName"))...)
 	allErrs = append(allErrs, disallowUsingLegacyAPIInNewCluster(old, tc)...)
+	allErrs = append(allErrs, validateUpdatePDReplicas(old, tc, field.NewPath("spec.pd.replicas"))...)
+	allErrs = append(allErrs, validateUpdateTiKVReplicas(old, tc, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateUpdateStorage(old, tc, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateUpdateTLSCluster(old, tc, field.NewPath("spec.tlsCluster"))...)
 
 	return allErrs
 }
 
+// IsUpdateOverridden returns whether the update safety rule is overridden by the annotation of the cluster.
+func IsUpdateOverridden(tc *v1alpha1.TidbCluster, annotation string) bool {
+	return tc.Annotations[annotation] == "true"
+}
+
+// validateUpdatePDReplicas refuses to remove a majority of PD replicas in one step, in which case the PD
+// cluster loses its quorum if the removed members are not healthy.
+func validateUpdatePDReplicas(old, tc *v1alpha1.TidbCluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if old.Spec.PD == nil || tc.Spec.PD == nil || IsUpdateOverridden(tc, label.AnnAllowPDQuorumUnsafeScaleIn) {
+		return allErrs
+	}
+	oldReplicas, replicas := old.Spec.PD.Replicas, tc.Spec.PD.Replicas
+	if replicas >= oldReplicas {
+		return allErrs
+	}
+	if quorum := oldReplicas/2 + 1; replicas < quorum {
+		allErrs = append(allErrs, field.Invalid(fldPath, replicas,
+			fmt.Sprintf("scaling in PD from %d to %d replicas in one step may lose the quorum (%d), scale in at most %d replicas at a time or set annotation %s to \"true\"",
+				oldReplicas, replicas, quorum, oldReplicas-quorum, label.AnnAllowPDQuorumUnsafeScaleIn)))
+	}
+	return allErrs
+}
+
+// validateUpdateTiKVReplicas refuses to scale in TiKV to fewer replicas than the max-replicas of PD, in
+// which case the regions can not be fully replicated. The stores of the TiKV groups hold the replicas of
+// the regions too, so the replicas of the default TiKV and the TiKV groups are counted together.
+func validateUpdateTiKVReplicas(old, tc *v1alpha1.TidbCluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if tc.Spec.PD == nil || IsUpdateOverridden(tc, label.AnnAllowTiKVBelowMaxReplicas) {
+		return allErrs
+	}
+	replicas := totalTiKVReplicas(tc)
+	if replicas >= totalTiKVReplicas(old) {
+		return allErrs
+	}
+	maxReplicas := int64(3)
+	if tc.Spec.PD.Config != nil {
+		if v := tc.Spec.PD.Config.Get("replication.max-replicas"); v != nil {
+			if n, err := v.AsInt(); err == nil {
+				maxReplicas = n
+			}
+		}
+	}
+	if int64(replicas) >= maxReplicas {
+		return allErrs
+	}
+
+	// the errors are reported on the scaled in replicas
+	msg := fmt.Sprintf("TiKV replicas (%d in total) must not be less than the max-replicas (%d) of PD, or set annotation %s to \"true\"",
+		replicas, maxReplicas, label.AnnAllowTiKVBelowMaxReplicas)
+	if old.Spec.TiKV != nil && tc.Spec.TiKV != nil && tc.Spec.TiKV.Replicas < old.Spec.TiKV.Replicas {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("tikv", "replicas"), tc.Spec.TiKV.Replicas, msg))
+	}
+	for i, group := range tc.Spec.TiKVGroups {
+		if group == nil {
+			continue
+		}
+		if oldGroup := old.TiKVGroup(group.Name); oldGroup != nil && group.Replicas < oldGroup.Replicas {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("tikvGroups").Index(i).Child("replicas"), group.Replicas, msg))
+		}
+	}
+	if len(allErrs) == 0 {
+		// the TiKV or the TiKV groups are removed
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("tikvGroups"), replicas, msg))
+	}
+	return allErrs
+}
+
+// totalTiKVReplicas returns the replicas of the default TiKV and the TiKV groups
+func totalTiKVReplicas(tc *v1alpha1.TidbCluster) int32 {
+	var replicas int32
+	if tc.Spec.TiKV != nil {
+		replicas += tc.Spec.TiKV.Replicas
+	}
+	for _, group := range tc.Spec.TiKVGroups {
+		if group != nil {
+			replicas += group.Replicas
+		}
+	}
+	return replicas
+}
+
+// validateUpdateStorage refuses to shrink the storage requests, which can not be applied to the existing
+// PVCs and leads to the failure of new PVCs if the data does not fit.
+func validateUpdateStorage(old, tc *v1alpha1.TidbCluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if IsUpdateOverridden(tc, label.AnnAllowStorageShrink) {
+		return allErrs
+	}
+	if old.Spec.PD != nil && tc.Spec.PD != nil {
+		allErrs = append(allErrs, validateStorageNotShrunk(old.Spec.PD.Requests, tc.Spec.PD.Requests, fldPath.Child("pd", "requests", "storage"))...)
+		allErrs = append(allErrs, validateStorageVolumesNotShrunk(old.Spec.PD.StorageVolumes, tc.Spec.PD.StorageVolumes, fldPath.Child("pd", "storageVolumes"))...)
+	}
+	if old.Spec.TiDB != nil && tc.Spec.TiDB != nil {
+		allErrs = append(allErrs, validateStorageVolumesNotShrunk(old.Spec.TiDB.StorageVolumes, tc.Spec.TiDB.StorageVolumes, fldPath.Child("tidb", "storageVolumes"))...)
+	}
+	for i, group := range tc.Spec.TiDBGroups {
+		if group == nil {
+			continue
+		}
+		if oldGroup := old.TiDBGroup(group.Name); oldGroup != nil {
+			allErrs = append(allErrs, validateStorageVolumesNotShrunk(oldGroup.StorageVolumes, group.StorageVolumes, fldPath.Child("tidbGroups").Index(i).Child("storageVolumes"))...)
+		}
+	}
+	if old.Spec.TiKV != nil && tc.Spec.TiKV != nil {
+		allErrs = append(allErrs, validateStorageNotShrunk(old.Spec.TiKV.Requests, tc.Spec.TiKV.Requests, fldPath.Child("tikv", "requests", "storage"))...)
+		allErrs = append(allErrs, validateStorageVolumesNotShrunk(old.Spec.TiKV.StorageVolumes, tc.Spec.TiKV.StorageVolumes, fldPath.Child("tikv", "storageVolumes"))...)
+	}
+	if old.Spec.TiFlash != nil && tc.Spec.TiFlash != nil {
+		for i := range tc.Spec.TiFlash.StorageClaims {
+			if i >= len(old.Spec.TiFlash.StorageClaims) {
+				break
+			}
+			allErrs = append(allErrs, validateStorageNotShrunk(old.Spec.TiFlash.StorageClaims[i].Resources.Requests, tc.Spec.TiFlash.StorageClaims[i].Resources.Requests,
+				fldPath.Child("tiflash", "storageClaims").Index(i).Child("resources", "requests", "storage"))...)
+		}
+	}
+	if old.Spec.TiCDC != nil && tc.Spec.TiCDC != nil {
+		allErrs = append(allErrs, validateStorageVolumesNotShrunk(old.Spec.TiCDC.StorageVolumes, tc.Spec.TiCDC.StorageVolumes, fldPath.Child("ticdc", "storageVolumes"))...)
+	}
+	if old.Spec.Pump != nil && tc.Spec.Pump != nil {
+		allErrs = append(allErrs, validateStorageNotShrunk(old.Spec.Pump.Requests, tc.Spec.Pump.Requests, fldPath.Child("pump", "requests", "storage"))...)
+	}
+	return allErrs
+}
+
+func validateStorageNotShrunk(old, requests corev1.ResourceList, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	oldSize, ok := old[corev1.ResourceStorage]
+	if !ok {
+		return allErrs
+	}
+	size, ok := requests[corev1.ResourceStorage]
+	if ok && size.Cmp(oldSize) < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, size.String(),
+			fmt.Sprintf("storage must not be shrunk from %s, or set annotation %s to \"true\"", oldSize.String(), label.AnnAllowStorageShrink)))
+	}
+	return allErrs
+}
+
+func validateStorageVolumesNotShrunk(old, volumes []v1alpha1.StorageVolume, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	oldSizes := map[string]string{}
+	for _, v := range old {
+		oldSizes[v.Name] = v.StorageSize
+	}
+	for i, v := range volumes {
+		oldSize, ok := oldSizes[v.Name]
+		if !ok {
+			continue
+		}
+		oldQuantity, err := resource.ParseQuantity(oldSize)
+		if err != nil {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(v.StorageSize)
+		if err != nil {
+			// the invalid size is reported by validateStorageVolumes
+			continue
+		}
+		if quantity.Cmp(oldQuantity) < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("storageSize"), v.StorageSize,
+				fmt.Sprintf("storage must not be shrunk from %s, or set annotation %s to \"true\"", oldSize, label.AnnAllowStorageShrink)))
+		}
+	}
+	return allErrs
+}
+
+// validateUpdateTLSCluster refuses to enable or disable TLSCluster on a running cluster, the components
+// can not talk to each other while they are rolling updated with different TLS settings.
+func validateUpdateTLSCluster(old, tc *v1alpha1.TidbCluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if old.IsTLSClusterEnabled() == tc.IsTLSClusterEnabled() || IsUpdateOverridden(tc, label.AnnAllowTLSClusterChange) {
+		return allErrs
+	}
+	// the cluster is not running yet
+	if old.Status.PD.StatefulSet == nil && old.Status.TiKV.StatefulSet == nil {
+		return allErrs
+	}
+	allErrs = append(allErrs, field.Invalid(fldPath.Child("enabled"), tc.IsTLSClusterEnabled(),
+		fmt.Sprintf("TLSCluster must not be enabled or disabled on a running cluster, or set annotation %s to \"true\"", label.AnnAllowTLSClusterChange)))
+	return allErrs
+}
+
 // For now we limit some validations only in Create phase to keep backward compatibility
 // TODO(aylei): call this in ValidateTidbCluster after we deprecated the old versions of helm chart officially
 func validateNewTidbClusterSpec(spec *v1alpha1.TidbClusterSpec, path *field.Path) field.ErrorList {
@@ -832,5 +1256,400 @@ func validateScalePolicy(scalePolicy *v1alpha1.ScalePolicy, fldPath *field.Path)
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("ScaleOutParallelism"),
 			*scalePolicy.ScaleOutParallelism, "ScaleOutParallelism should be positive"))
 	}
+	if acceleration := scalePolicy.Acceleration; acceleration != nil {
+		accelerationPath := fldPath.Child("acceleration")
+		limits := []struct {
+			name  string
+			value *int32
+		}{
+			{"storeLimit", acceleration.StoreLimit},
+			{"regionScheduleLimit", acceleration.RegionScheduleLimit},
+			{"replicaScheduleLimit", acceleration.ReplicaScheduleLimit},
+		}
+		for _, limit := range limits {
+			if limit.value != nil && *limit.value <= 0 {
+				allErrs = append(allErrs, field.Invalid(accelerationPath.Child(limit.name), *limit.value, "should be positive"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// ValidateBackup validates a Backup, the checks depending on the cluster, such as the version of TiKV,
+// are performed by the backup controller.
+func ValidateBackup(backup *v1alpha1.Backup) field.ErrorList {
+	return validateBackupSpec(&backup.Spec, field.NewPath("spec"))
+}
+
+// ValidateUpdateBackup validates an update of Backup, the storage location and the kind of the backup
+// are immutable after the backup starts.
+func ValidateUpdateBackup(old, backup *v1alpha1.Backup) field.ErrorList {
+	// Backup has no status subresource, the updates of the status by the controller must not be rejected
+	// by the validation of the spec
+	if apiequality.Semantic.DeepEqual(old.Spec, backup.Spec) {
+		return nil
+	}
+	allErrs := ValidateBackup(backup)
+	if old.Status.Phase == "" {
+		return allErrs
+	}
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateImmutable(old.Spec.StorageProvider, backup.Spec.StorageProvider, specPath.Child("storageProvider"))...)
+	allErrs = append(allErrs, validateImmutable(old.Spec.Mode, backup.Spec.Mode, specPath.Child("backupMode"))...)
+	allErrs = append(allErrs, validateImmutable(old.Spec.Type, backup.Spec.Type, specPath.Child("backupType"))...)
+	allErrs = append(allErrs, validateImmutable(old.Spec.CommitTs, backup.Spec.CommitTs, specPath.Child("commitTs"))...)
+	allErrs = append(allErrs, validateImmutableBRCluster(old.Spec.BR, backup.Spec.BR, specPath.Child("br"))...)
+	return allErrs
+}
+
+func validateBackupSpec(spec *v1alpha1.BackupSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch spec.Mode {
+	case "", v1alpha1.BackupModeSnapshot, v1alpha1.BackupModeLog, v1alpha1.BackupModeVolumeSnapshot:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("backupMode"), spec.Mode,
+			[]string{string(v1alpha1.BackupModeSnapshot), string(v1alpha1.BackupModeLog), string(v1alpha1.BackupModeVolumeSnapshot)}))
+	}
+	allErrs = append(allErrs, validateBackupType(spec.Type, fldPath.Child("backupType"))...)
+	allErrs = append(allErrs, validateStorageProvider(&spec.StorageProvider, spec.BR != nil, spec.BR != nil, fldPath)...)
+
+	if spec.BR == nil {
+		if spec.Mode == v1alpha1.BackupModeLog || spec.Mode == v1alpha1.BackupModeVolumeSnapshot {
+			allErrs = append(allErrs, field.Required(fldPath.Child("br"), fmt.Sprintf("br must be set in %s mode", spec.Mode)))
+		}
+	} else {
+		allErrs = append(allErrs, validateBRConfig(spec.BR, spec.Type, fldPath.Child("br"))...)
+	}
+
+	if spec.Mode == v1alpha1.BackupModeLog {
+		switch spec.LogSubcommand {
+		case "", v1alpha1.LogStartCommand, v1alpha1.LogStopCommand, v1alpha1.LogPauseCommand:
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("logSubcommand"), spec.LogSubcommand,
+				[]string{string(v1alpha1.LogStartCommand), string(v1alpha1.LogStopCommand), string(v1alpha1.LogPauseCommand)}))
+		}
+		if spec.LogStop && spec.LogSubcommand != "" && spec.LogSubcommand != v1alpha1.LogStopCommand {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("logStop"), spec.LogStop,
+				fmt.Sprintf("logStop conflicts with logSubcommand %s", spec.LogSubcommand)))
+		}
+		allErrs = append(allErrs, validateTSString(spec.CommitTs, fldPath.Child("commitTs"))...)
+		allErrs = append(allErrs, validateTSString(spec.LogTruncateUntil, fldPath.Child("logTruncateUntil"))...)
+	} else {
+		if spec.LogSubcommand != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("logSubcommand"), "logSubcommand is only supported in log mode"))
+		}
+		if spec.LogTruncateUntil != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("logTruncateUntil"), "logTruncateUntil is only supported in log mode"))
+		}
+		if spec.LogStop {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("logStop"), "logStop is only supported in log mode"))
+		}
+	}
+
+	switch spec.CleanPolicy {
+	case "", v1alpha1.CleanPolicyTypeRetain, v1alpha1.CleanPolicyTypeOnFailure, v1alpha1.CleanPolicyTypeDelete:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("cleanPolicy"), spec.CleanPolicy,
+			[]string{string(v1alpha1.CleanPolicyTypeRetain), string(v1alpha1.CleanPolicyTypeOnFailure), string(v1alpha1.CleanPolicyTypeDelete)}))
+	}
+	retryPath := fldPath.Child("backoffRetryPolicy")
+	if d := spec.BackoffRetryPolicy.MinRetryDuration; d != "" {
+		allErrs = append(allErrs, validateTimeDurationStr(&d, retryPath.Child("minRetryDuration"))...)
+	}
+	if d := spec.BackoffRetryPolicy.RetryTimeout; d != "" {
+		allErrs = append(allErrs, validateTimeDurationStr(&d, retryPath.Child("retryTimeout"))...)
+	}
+	if spec.BackoffRetryPolicy.MaxRetryTimes < 0 {
+		allErrs = append(allErrs, field.Invalid(retryPath.Child("maxRetryTimes"), spec.BackoffRetryPolicy.MaxRetryTimes, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+// ValidateRestore validates a Restore, the checks depending on the cluster are performed by the restore
+// controller.
+func ValidateRestore(restore *v1alpha1.Restore) field.ErrorList {
+	allErrs := field.ErrorList{}
+	spec := &restore.Spec
+	specPath := field.NewPath("spec")
+
+	switch spec.Mode {
+	case "", v1alpha1.RestoreModeSnapshot, v1alpha1.RestoreModePiTR, v1alpha1.RestoreModeVolumeSnapshot:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("restoreMode"), spec.Mode,
+			[]string{string(v1alpha1.RestoreModeSnapshot), string(v1alpha1.RestoreModePiTR), string(v1alpha1.RestoreModeVolumeSnapshot)}))
+	}
+	allErrs = append(allErrs, validateBackupType(spec.Type, specPath.Child("backupType"))...)
+	allErrs = append(allErrs, validateStorageProvider(&spec.StorageProvider, spec.BR != nil, spec.BR != nil, specPath)...)
+
+	if spec.BR == nil {
+		if spec.Mode == v1alpha1.RestoreModePiTR || spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+			allErrs = append(allErrs, field.Required(specPath.Child("br"), fmt.Sprintf("br must be set in %s mode", spec.Mode)))
+		}
+	} else {
+		allErrs = append(allErrs, validateBRConfig(spec.BR, spec.Type, specPath.Child("br"))...)
+	}
+
+	if spec.Mode == v1alpha1.RestoreModePiTR {
+		allErrs = append(allErrs, validateTSString(spec.PitrRestoredTs, specPath.Child("pitrRestoredTs"))...)
+		allErrs = append(allErrs, validateTSString(spec.LogRestoreStartTs, specPath.Child("logRestoreStartTs"))...)
+		fullBackupPath := specPath.Child("pitrFullBackupStorageProvider")
+		allErrs = append(allErrs, validateStorageProvider(&spec.PitrFullBackupStorageProvider, false, true, fullBackupPath)...)
+		hasFullBackup := countStorageProviders(&spec.PitrFullBackupStorageProvider) > 0
+		if hasFullBackup && spec.LogRestoreStartTs != "" {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("logRestoreStartTs"), spec.LogRestoreStartTs,
+				"pitrFullBackupStorageProvider and logRestoreStartTs can not be set at the same time"))
+		}
+		if !hasFullBackup && spec.LogRestoreStartTs == "" {
+			allErrs = append(allErrs, field.Required(fullBackupPath, "either pitrFullBackupStorageProvider or logRestoreStartTs must be set in pitr mode"))
+		}
+	} else {
+		if spec.PitrRestoredTs != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("pitrRestoredTs"), "pitrRestoredTs is only supported in pitr mode"))
+		}
+		if spec.LogRestoreStartTs != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("logRestoreStartTs"), "logRestoreStartTs is only supported in pitr mode"))
+		}
+	}
+	if spec.BackoffLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("backoffLimit"), spec.BackoffLimit, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+// ValidateUpdateRestore validates an update of Restore, the source and the target of the restore are
+// immutable after the restore starts.
+func ValidateUpdateRestore(old, restore *v1alpha1.Restore) field.ErrorList {
+	// Restore has no status subresource, the updates of the status by the controller must not be rejected
+	// by the validation of the spec
+	if apiequality.Semantic.DeepEqual(old.Spec, restore.Spec) {
+		return nil
+	}
+	allErrs := ValidateRestore(restore)
+	if old.Status.Phase == "" {
+		return allErrs
+	}
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateImmutable(old.Spec.StorageProvider, restore.Spec.StorageProvider, specPath.Child("storageProvider"))...)
+	allErrs = append(allErrs, validateImmutable(old.Spec.PitrFullBackupStorageProvider, restore.Spec.PitrFullBackupStorageProvider, specPath.Child("pitrFullBackupStorageProvider"))...)
+	allErrs = append(allErrs, validateImmutable(old.Spec.Mode, restore.Spec.Mode, specPath.Child("restoreMode"))...)
+	allErrs = append(allErrs, validateImmutable(old.Spec.Type, restore.Spec.Type, specPath.Child("backupType"))...)
+	allErrs = append(allErrs, validateImmutable(old.Spec.PitrRestoredTs, restore.Spec.PitrRestoredTs, specPath.Child("pitrRestoredTs"))...)
+	allErrs = append(allErrs, validateImmutable(old.Spec.LogRestoreStartTs, restore.Spec.LogRestoreStartTs, specPath.Child("logRestoreStartTs"))...)
+	allErrs = append(allErrs, validateImmutableBRCluster(old.Spec.BR, restore.Spec.BR, specPath.Child("br"))...)
+	return allErrs
+}
+
+// ValidateBackupSchedule validates a BackupSchedule, the cron string of the schedule is validated by the
+// webhook strategy.
+func ValidateBackupSchedule(bs *v1alpha1.BackupSchedule) field.ErrorList {
+	allErrs := field.ErrorList{}
+	spec := &bs.Spec
+	specPath := field.NewPath("spec")
+
+	if spec.Schedule == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("schedule"), "schedule must be set"))
+	}
+	if spec.MaxBackups != nil && *spec.MaxBackups < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("maxBackups"), *spec.MaxBackups, "must be greater than or equal to 0"))
+	}
+	allErrs = append(allErrs, validateTimeDurationStr(spec.MaxReservedTime, specPath.Child("maxReservedTime"))...)
+	allErrs = append(allErrs, validateTimeDurationStr(spec.CompactInterval, specPath.Child("compactInterval"))...)
+
+	templatePath := specPath.Child("backupTemplate")
+	allErrs = append(allErrs, validateBackupSpec(&spec.BackupTemplate, templatePath)...)
+	if spec.BackupTemplate.Mode == v1alpha1.BackupModeLog {
+		allErrs = append(allErrs, field.Invalid(templatePath.Child("backupMode"), spec.BackupTemplate.Mode, "use logBackupTemplate for log backup"))
+	}
+	if spec.LogBackupTemplate != nil {
+		logPath := specPath.Child("logBackupTemplate")
+		allErrs = append(allErrs, validateBackupSpec(spec.LogBackupTemplate, logPath)...)
+		if mode := spec.LogBackupTemplate.Mode; mode != "" && mode != v1alpha1.BackupModeLog {
+			allErrs = append(allErrs, field.Invalid(logPath.Child("backupMode"), mode, "must be log"))
+		}
+	}
+	if spec.CompactBackupTemplate != nil {
+		allErrs = append(allErrs, validateCompactSpec(spec.CompactBackupTemplate, specPath.Child("compactBackupTemplate"))...)
+		if spec.LogBackupTemplate == nil {
+			allErrs = append(allErrs, field.Required(specPath.Child("logBackupTemplate"), "logBackupTemplate must be set to compact the log backups"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateUpdateBackupSchedule validates an update of BackupSchedule, the storage location of the log
+// backup is immutable after the log backup is created.
+func ValidateUpdateBackupSchedule(old, bs *v1alpha1.BackupSchedule) field.ErrorList {
+	// BackupSchedule has no status subresource, the updates of the status by the controller must not be
+	// rejected by the validation of the spec
+	if apiequality.Semantic.DeepEqual(old.Spec, bs.Spec) {
+		return nil
+	}
+	allErrs := ValidateBackupSchedule(bs)
+	if old.Status.LogBackup == nil || old.Spec.LogBackupTemplate == nil {
+		return allErrs
+	}
+	logPath := field.NewPath("spec", "logBackupTemplate")
+	if bs.Spec.LogBackupTemplate == nil {
+		return append(allErrs, field.Forbidden(logPath, "logBackupTemplate can not be removed after the log backup is created"))
+	}
+	allErrs = append(allErrs, validateImmutable(old.Spec.LogBackupTemplate.StorageProvider, bs.Spec.LogBackupTemplate.StorageProvider, logPath.Child("storageProvider"))...)
+	return allErrs
+}
+
+// ValidateCompactBackup validates a CompactBackup.
+func ValidateCompactBackup(compact *v1alpha1.CompactBackup) field.ErrorList {
+	return validateCompactSpec(&compact.Spec, field.NewPath("spec"))
+}
+
+// ValidateUpdateCompactBackup validates an update of CompactBackup, the storage location and the range of
+// the compaction are immutable after the compaction starts.
+func ValidateUpdateCompactBackup(old, compact *v1alpha1.CompactBackup) field.ErrorList {
+	// CompactBackup has no status subresource, the updates of the status by the controller must not be
+	// rejected by the validation of the spec
+	if apiequality.Semantic.DeepEqual(old.Spec, compact.Spec) {
+		return nil
+	}
+	allErrs := ValidateCompactBackup(compact)
+	if old.Status.State == "" {
+		return allErrs
+	}
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateImmutable(old.Spec.StorageProvider, compact.Spec.StorageProvider, specPath.Child("storageProvider"))...)
+	allErrs = append(allErrs, validateImmutable(old.Spec.StartTs, compact.Spec.StartTs, specPath.Child("startTs"))...)
+	allErrs = append(allErrs, validateImmutable(old.Spec.EndTs, compact.Spec.EndTs, specPath.Child("endTs"))...)
+	return allErrs
+}
+
+func validateCompactSpec(spec *v1alpha1.CompactSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateStorageProvider(&spec.StorageProvider, true, true, fldPath)...)
+	allErrs = append(allErrs, validateTSString(spec.StartTs, fldPath.Child("startTs"))...)
+	allErrs = append(allErrs, validateTSString(spec.EndTs, fldPath.Child("endTs"))...)
+	if spec.StartTs != "" && spec.EndTs != "" {
+		start, startErr := config.ParseTSString(spec.StartTs)
+		end, endErr := config.ParseTSString(spec.EndTs)
+		if startErr == nil && endErr == nil && start >= end {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("endTs"), spec.EndTs, "must be later than startTs"))
+		}
+	}
+	if spec.Concurrency < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("concurrency"), spec.Concurrency, "must be greater than or equal to 0"))
+	}
+	if spec.MaxRetryTimes < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxRetryTimes"), spec.MaxRetryTimes, "must be greater than or equal to 0"))
+	}
+	if spec.BR != nil && spec.BR.Cluster == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("br", "cluster"), "cluster must be set"))
+	}
+	return allErrs
+}
+
+func validateBackupType(backupType v1alpha1.BackupType, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch backupType {
+	case "", v1alpha1.BackupTypeFull, v1alpha1.BackupTypeDB, v1alpha1.BackupTypeTable:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath, backupType,
+			[]string{string(v1alpha1.BackupTypeFull), string(v1alpha1.BackupTypeDB), string(v1alpha1.BackupTypeTable)}))
+	}
+	return allErrs
+}
+
+func validateBRConfig(br *v1alpha1.BRConfig, backupType v1alpha1.BackupType, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if br.Cluster == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("cluster"), "cluster must be set"))
+	}
+	if (backupType == v1alpha1.BackupTypeDB || backupType == v1alpha1.BackupTypeTable) && br.DB == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("db"), fmt.Sprintf("db must be set for backup type %s", backupType)))
+	}
+	if backupType == v1alpha1.BackupTypeTable && br.Table == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("table"), "table must be set for backup type table"))
+	}
+	return allErrs
+}
+
+func countStorageProviders(provider *v1alpha1.StorageProvider) int {
+	count := 0
+	for _, set := range []bool{provider.S3 != nil, provider.Gcs != nil, provider.Azblob != nil, provider.Local != nil} {
+		if set {
+			count++
+		}
+	}
+	return count
+}
+
+// validateStorageProvider validates the storage provider, which is inlined in the spec at fldPath. The bucket
+// is only required if the storage is accessed by BR, as Dumpling only uses the path of the storage.
+func validateStorageProvider(provider *v1alpha1.StorageProvider, required, br bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch count := countStorageProviders(provider); {
+	case count > 1:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of s3, gcs, azblob and local can be set"))
+	case count == 0 && required:
+		allErrs = append(allErrs, field.Required(fldPath, "one of s3, gcs, azblob and local must be set"))
+	}
+
+	if s3 := provider.S3; s3 != nil {
+		s3Path := fldPath.Child("s3")
+		if br && s3.Bucket == "" {
+			allErrs = append(allErrs, field.Required(s3Path.Child("bucket"), "bucket must be set for BR"))
+		}
+		if s3.Endpoint != "" {
+			u, err := url.Parse(s3.Endpoint)
+			if err != nil || u.Scheme == "" || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(s3Path.Child("endpoint"), s3.Endpoint, "must be a URL with scheme and host"))
+			}
+		}
+	}
+	if gcs := provider.Gcs; gcs != nil && br {
+		gcsPath := fldPath.Child("gcs")
+		if gcs.ProjectId == "" {
+			allErrs = append(allErrs, field.Required(gcsPath.Child("projectId"), "projectId must be set for BR"))
+		}
+		if gcs.Bucket == "" {
+			allErrs = append(allErrs, field.Required(gcsPath.Child("bucket"), "bucket must be set for BR"))
+		}
+	}
+	if local := provider.Local; local != nil {
+		localPath := fldPath.Child("local")
+		if local.VolumeMount.Name != local.Volume.Name {
+			allErrs = append(allErrs, field.Invalid(localPath.Child("volumeMount", "name"), local.VolumeMount.Name, "must be the name of the volume"))
+		}
+		if local.VolumeMount.MountPath == "" {
+			allErrs = append(allErrs, field.Required(localPath.Child("volumeMount", "mountPath"), "mountPath must be set"))
+		} else if strings.Contains(local.VolumeMount.MountPath, ":") {
+			allErrs = append(allErrs, field.Invalid(localPath.Child("volumeMount", "mountPath"), local.VolumeMount.MountPath, "must not contain ':'"))
+		}
+	}
+	return allErrs
+}
+
+func validateTSString(ts string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ts == "" {
+		return allErrs
+	}
+	if _, err := config.ParseTSString(ts); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, ts, err.Error()))
+	}
+	return allErrs
+}
+
+func validateImmutable(old, value interface{}, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !apiequality.Semantic.DeepEqual(old, value) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "field is immutable after it starts"))
+	}
+	return allErrs
+}
+
+func validateImmutableBRCluster(old, br *v1alpha1.BRConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if old == nil || br == nil {
+		return validateImmutable(old == nil, br == nil, fldPath)
+	}
+	allErrs = append(allErrs, validateImmutable(old.Cluster, br.Cluster, fldPath.Child("cluster"))...)
+	allErrs = append(allErrs, validateImmutable(old.ClusterNamespace, br.ClusterNamespace, fldPath.Child("clusterNamespace"))...)
 	return allErrs
 }
@@ -16,10 +16,12 @@ package validation
 import (
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -749,6 +751,32 @@ func TestValidateStartScriptFeatureFlags(t *testing.T) {
 	}
 }
 
+func TestValidateTLSClusterIssuer(t *testing.T) {
+	successCases := []*v1alpha1.TLSClusterIssuer{
+		{},
+		{Duration: &metav1.Duration{Duration: 24 * time.Hour}, RenewBefore: &metav1.Duration{Duration: time.Hour}},
+	}
+
+	for _, c := range successCases {
+		errs := validateTLSClusterIssuer(c, field.NewPath("issuer"))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := []*v1alpha1.TLSClusterIssuer{
+		{Duration: &metav1.Duration{Duration: 24 * time.Hour}},
+		{RenewBefore: &metav1.Duration{Duration: -time.Hour}},
+	}
+
+	for _, c := range errorCases {
+		errs := validateTLSClusterIssuer(c, field.NewPath("issuer"))
+		if len(errs) != 1 {
+			t.Errorf("expected 1 failure for %v but there was %d", c, len(errs))
+		}
+	}
+}
+
 func TestValidatePDSpec(t *testing.T) {
 	g := NewGomegaWithT(t)
 	tests := []struct {
@@ -796,6 +824,270 @@ func TestValidatePDSpec(t *testing.T) {
 	}
 }
 
+func TestValidateTiDBGroups(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tests := []struct {
+		name           string
+		groups         []*v1alpha1.TiDBGroupSpec
+		expectedErrors int
+	}{
+		{
+			name: "valid groups",
+			groups: []*v1alpha1.TiDBGroupSpec{
+				{Name: "oltp"},
+				{Name: "olap"},
+			},
+			expectedErrors: 0,
+		},
+		{
+			name:           "nil group",
+			groups:         []*v1alpha1.TiDBGroupSpec{nil},
+			expectedErrors: 1,
+		},
+		{
+			name: "invalid name",
+			groups: []*v1alpha1.TiDBGroupSpec{
+				{Name: "OLAP"},
+				{Name: ""},
+			},
+			expectedErrors: 2,
+		},
+		{
+			name: "duplicated name",
+			groups: []*v1alpha1.TiDBGroupSpec{
+				{Name: "olap"},
+				{Name: "olap"},
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "name collides with other services",
+			groups: []*v1alpha1.TiDBGroupSpec{
+				{Name: "peer"},
+				{Name: "olap-peer"},
+				{Name: "initializer"},
+			},
+			expectedErrors: 3,
+		},
+		{
+			name: "idle policy",
+			groups: []*v1alpha1.TiDBGroupSpec{
+				{Name: "olap", TiDBSpec: v1alpha1.TiDBSpec{Idle: &v1alpha1.TiDBIdlePolicy{}}},
+			},
+			expectedErrors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTiDBGroups(tt.groups, field.NewPath("spec", "tidbGroups"))
+			g.Expect(len(err)).Should(Equal(tt.expectedErrors))
+		})
+	}
+}
+
+func TestValidateTiDBTenant(t *testing.T) {
+	g := NewGomegaWithT(t)
+	newTenant := func() *v1alpha1.TidbTenant {
+		return &v1alpha1.TidbTenant{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "ns"},
+			Spec: v1alpha1.TidbTenantSpec{
+				Cluster: v1alpha1.TidbClusterRef{Name: "tc", Namespace: "ns"},
+			},
+		}
+	}
+	tests := []struct {
+		name           string
+		update         func(tt *v1alpha1.TidbTenant)
+		expectedErrors int
+	}{
+		{
+			name:           "valid tenant",
+			update:         func(tt *v1alpha1.TidbTenant) {},
+			expectedErrors: 0,
+		},
+		{
+			name: "invalid tenant name",
+			update: func(tt *v1alpha1.TidbTenant) {
+				tt.Name = "a.b"
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "tenant name collides with other services",
+			update: func(tt *v1alpha1.TidbTenant) {
+				tt.Name = "tenant-peer"
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "invalid cluster",
+			update: func(tt *v1alpha1.TidbTenant) {
+				tt.Spec.Cluster = v1alpha1.TidbClusterRef{Namespace: "other"}
+			},
+			expectedErrors: 2,
+		},
+		{
+			name: "invalid keyspace name",
+			update: func(tt *v1alpha1.TidbTenant) {
+				tt.Spec.KeyspaceName = "key/space"
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "keyspace-name is set in config",
+			update: func(tt *v1alpha1.TidbTenant) {
+				tt.Spec.TiDB.Config = v1alpha1.NewTiDBConfig()
+				tt.Spec.TiDB.Config.Set("keyspace-name", "other")
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "idle policy",
+			update: func(tt *v1alpha1.TidbTenant) {
+				tt.Spec.TiDB.Idle = &v1alpha1.TiDBIdlePolicy{}
+			},
+			expectedErrors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenant := newTenant()
+			tt.update(tenant)
+			err := ValidateTiDBTenant(tenant)
+			g.Expect(len(err)).Should(Equal(tt.expectedErrors))
+		})
+	}
+}
+
+func TestValidateTidbClusterScalingSchedule(t *testing.T) {
+	g := NewGomegaWithT(t)
+	newSchedule := func() *v1alpha1.TidbClusterScalingSchedule {
+		return &v1alpha1.TidbClusterScalingSchedule{
+			ObjectMeta: metav1.ObjectMeta{Name: "schedule", Namespace: "ns"},
+			Spec: v1alpha1.TidbClusterScalingScheduleSpec{
+				Cluster:  v1alpha1.TidbClusterRef{Name: "tc", Namespace: "ns"},
+				Timezone: "Asia/Shanghai",
+				Windows: []v1alpha1.ScalingWindow{
+					{
+						Name:     "day",
+						Schedule: "0 8 * * *",
+						Duration: metav1.Duration{Duration: 12 * time.Hour},
+						Replicas: v1alpha1.ScalingTargets{TiDB: pointer.Int32Ptr(5)},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name           string
+		update         func(tcss *v1alpha1.TidbClusterScalingSchedule)
+		expectedErrors int
+	}{
+		{
+			name:           "valid schedule",
+			update:         func(tcss *v1alpha1.TidbClusterScalingSchedule) {},
+			expectedErrors: 0,
+		},
+		{
+			name: "invalid cluster",
+			update: func(tcss *v1alpha1.TidbClusterScalingSchedule) {
+				tcss.Spec.Cluster = v1alpha1.TidbClusterRef{Namespace: "other"}
+			},
+			expectedErrors: 2,
+		},
+		{
+			name: "invalid timezone",
+			update: func(tcss *v1alpha1.TidbClusterScalingSchedule) {
+				tcss.Spec.Timezone = "Mars/Olympus"
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "no window",
+			update: func(tcss *v1alpha1.TidbClusterScalingSchedule) {
+				tcss.Spec.Windows = nil
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "invalid windows",
+			update: func(tcss *v1alpha1.TidbClusterScalingSchedule) {
+				tcss.Spec.Windows = append(tcss.Spec.Windows, v1alpha1.ScalingWindow{
+					Name:     "day",
+					Replicas: v1alpha1.ScalingTargets{TiKV: pointer.Int32Ptr(-1)},
+				})
+			},
+			expectedErrors: 4,
+		},
+		{
+			name: "invalid default",
+			update: func(tcss *v1alpha1.TidbClusterScalingSchedule) {
+				tcss.Spec.Default = &v1alpha1.ScalingTargets{TiDB: pointer.Int32Ptr(-1)}
+			},
+			expectedErrors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcss := newSchedule()
+			tt.update(tcss)
+			err := ValidateTidbClusterScalingSchedule(tcss)
+			g.Expect(len(err)).Should(Equal(tt.expectedErrors))
+		})
+	}
+}
+
+func TestValidateTiKVGroups(t *testing.T) {
+	g := NewGomegaWithT(t)
+	newGroup := func(name string) *v1alpha1.TiKVGroupSpec {
+		group := &v1alpha1.TiKVGroupSpec{Name: name}
+		group.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10G")}
+		return group
+	}
+	tests := []struct {
+		name           string
+		groups         []*v1alpha1.TiKVGroupSpec
+		expectedErrors int
+	}{
+		{
+			name:           "valid groups",
+			groups:         []*v1alpha1.TiKVGroupSpec{newGroup("nvme"), newGroup("hdd")},
+			expectedErrors: 0,
+		},
+		{
+			name:           "nil group",
+			groups:         []*v1alpha1.TiKVGroupSpec{nil},
+			expectedErrors: 1,
+		},
+		{
+			name:           "invalid name",
+			groups:         []*v1alpha1.TiKVGroupSpec{newGroup("NVMe"), newGroup("")},
+			expectedErrors: 2,
+		},
+		{
+			name:           "duplicated name",
+			groups:         []*v1alpha1.TiKVGroupSpec{newGroup("nvme"), newGroup("nvme")},
+			expectedErrors: 1,
+		},
+		{
+			name:           "name collides with other services",
+			groups:         []*v1alpha1.TiKVGroupSpec{newGroup("peer"), newGroup("nvme-peer")},
+			expectedErrors: 2,
+		},
+		{
+			name:           "no storage request",
+			groups:         []*v1alpha1.TiKVGroupSpec{{Name: "nvme"}},
+			expectedErrors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTiKVGroups(tt.groups, field.NewPath("spec", "tikvGroups"))
+			g.Expect(len(err)).Should(Equal(tt.expectedErrors))
+		})
+	}
+}
+
 func TestValidateTiFlashSpec(t *testing.T) {
 	g := NewGomegaWithT(t)
 	tests := []struct {
@@ -852,6 +1144,58 @@ func TestValidateTiFlashSpec(t *testing.T) {
 	}
 }
 
+func TestValidateTiFlashDisaggregatedSpec(t *testing.T) {
+	g := NewGomegaWithT(t)
+	storageClaims := []v1alpha1.StorageClaim{
+		{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("2G"),
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name           string
+		spec           *v1alpha1.TiFlashDisaggregatedSpec
+		expectedErrors int
+	}{
+		{
+			name: "valid",
+			spec: &v1alpha1.TiFlashDisaggregatedSpec{
+				S3:      v1alpha1.TiFlashS3Spec{Endpoint: "http://minio:9000", Bucket: "tiflash"},
+				Compute: &v1alpha1.TiFlashComputeSpec{Replicas: 2},
+			},
+			expectedErrors: 0,
+		},
+		{
+			name:           "no endpoint and bucket",
+			spec:           &v1alpha1.TiFlashDisaggregatedSpec{},
+			expectedErrors: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterWithTiflash()
+			tc.Spec.TiFlash.StorageClaims = storageClaims
+			tc.Spec.TiFlash.Disaggregated = tt.spec
+			err := validateTiFlashSpec(tc.Spec.TiFlash, field.NewPath("spec", "tiflash"))
+			g.Expect(len(err)).Should(Equal(tt.expectedErrors))
+		})
+	}
+
+	// disaggregated TiFlash requires the start script v2 or v3
+	tc := newTidbClusterWithTiflash()
+	tc.Spec.TiFlash.Disaggregated = tests[0].spec
+	err := validateTiDBClusterSpec(&tc.Spec, field.NewPath("spec"))
+	g.Expect(err).To(ContainElement(HaveField("Field", "spec.tiflash.disaggregated")))
+	for _, ver := range []v1alpha1.StartScriptVersion{v1alpha1.StartScriptV2, v1alpha1.StartScriptV3} {
+		tc.Spec.StartScriptVersion = ver
+		err = validateTiDBClusterSpec(&tc.Spec, field.NewPath("spec"))
+		g.Expect(err).NotTo(ContainElement(HaveField("Field", "spec.tiflash.disaggregated")))
+	}
+}
+
 func Test_disallowMutateBootstrapSQLConfigMapName(t *testing.T) {
 	g := NewGomegaWithT(t)
 	tests := []struct {
@@ -922,3 +1266,564 @@ func Test_disallowMutateBootstrapSQLConfigMapName(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTiDBIdlePolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tests := []struct {
+		name           string
+		idle           *v1alpha1.TiDBIdlePolicy
+		expectedErrors int
+	}{
+		{
+			name:           "default timeout",
+			idle:           &v1alpha1.TiDBIdlePolicy{},
+			expectedErrors: 0,
+		},
+		{
+			name:           "valid timeout",
+			idle:           &v1alpha1.TiDBIdlePolicy{Timeout: &metav1.Duration{Duration: time.Minute}},
+			expectedErrors: 0,
+		},
+		{
+			name:           "zero timeout",
+			idle:           &v1alpha1.TiDBIdlePolicy{Timeout: &metav1.Duration{}},
+			expectedErrors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &v1alpha1.TiDBSpec{Idle: tt.idle}
+			err := validateTiDBSpec(spec, field.NewPath("spec", "tidb"))
+			g.Expect(len(err)).Should(Equal(tt.expectedErrors))
+		})
+	}
+}
+
+func TestValidateDiscoveryReplicas(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tests := []struct {
+		name           string
+		replicas       *int32
+		expectedErrors int
+	}{
+		{
+			name:           "default replicas",
+			expectedErrors: 0,
+		},
+		{
+			name:           "multiple replicas",
+			replicas:       pointer.Int32Ptr(3),
+			expectedErrors: 0,
+		},
+		{
+			name:           "zero replicas",
+			replicas:       pointer.Int32Ptr(0),
+			expectedErrors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDiscoverySpec(v1alpha1.DiscoverySpec{Replicas: tt.replicas}, field.NewPath("spec", "discovery"))
+			g.Expect(len(err)).Should(Equal(tt.expectedErrors))
+			err = validateDMDiscoverySpec(v1alpha1.DMDiscoverySpec{Replicas: tt.replicas}, field.NewPath("spec", "discovery"))
+			g.Expect(len(err)).Should(Equal(tt.expectedErrors))
+		})
+	}
+}
+
+func TestValidateUpdateTidbClusterSafety(t *testing.T) {
+	newTC := func() *v1alpha1.TidbCluster {
+		tc := &v1alpha1.TidbCluster{
+			Spec: v1alpha1.TidbClusterSpec{
+				PD: &v1alpha1.PDSpec{
+					Replicas: 5,
+					Config:   v1alpha1.NewPDConfig(),
+					ResourceRequirements: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+					},
+				},
+				TiKV: &v1alpha1.TiKVSpec{
+					Replicas: 4,
+					ResourceRequirements: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Gi")},
+					},
+					StorageVolumes: []v1alpha1.StorageVolume{{Name: "raft", StorageSize: "10Gi"}},
+				},
+				TiDBGroups: []*v1alpha1.TiDBGroupSpec{
+					{
+						Name: "olap",
+						TiDBSpec: v1alpha1.TiDBSpec{
+							StorageVolumes: []v1alpha1.StorageVolume{{Name: "log", StorageSize: "10Gi"}},
+						},
+					},
+				},
+			},
+		}
+		tc.Status.PD.StatefulSet = &apps.StatefulSetStatus{}
+		return tc
+	}
+
+	tests := []struct {
+		name       string
+		update     func(tc *v1alpha1.TidbCluster)
+		annotation string
+		errs       []string
+	}{
+		{
+			name:   "no change",
+			update: func(tc *v1alpha1.TidbCluster) {},
+		},
+		{
+			name:   "scale in PD by a minority",
+			update: func(tc *v1alpha1.TidbCluster) { tc.Spec.PD.Replicas = 3 },
+		},
+		{
+			name:       "scale in PD by a majority",
+			update:     func(tc *v1alpha1.TidbCluster) { tc.Spec.PD.Replicas = 2 },
+			annotation: label.AnnAllowPDQuorumUnsafeScaleIn,
+			errs:       []string{"spec.pd.replicas"},
+		},
+		{
+			name:   "scale in TiKV to the max-replicas",
+			update: func(tc *v1alpha1.TidbCluster) { tc.Spec.TiKV.Replicas = 3 },
+		},
+		{
+			name:       "scale in TiKV below the default max-replicas",
+			update:     func(tc *v1alpha1.TidbCluster) { tc.Spec.TiKV.Replicas = 2 },
+			annotation: label.AnnAllowTiKVBelowMaxReplicas,
+			errs:       []string{"spec.tikv.replicas"},
+		},
+		{
+			name: "scale in TiKV below the configured max-replicas",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.Config.Set("replication.max-replicas", 5)
+				tc.Spec.TiKV.Replicas = 3
+			},
+			annotation: label.AnnAllowTiKVBelowMaxReplicas,
+			errs:       []string{"spec.tikv.replicas"},
+		},
+		{
+			name: "expand storage",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Requests[corev1.ResourceStorage] = resource.MustParse("200Gi")
+				tc.Spec.TiKV.StorageVolumes[0].StorageSize = "20Gi"
+			},
+		},
+		{
+			name: "shrink storage",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.Requests[corev1.ResourceStorage] = resource.MustParse("5Gi")
+				tc.Spec.TiKV.StorageVolumes[0].StorageSize = "5Gi"
+			},
+			annotation: label.AnnAllowStorageShrink,
+			errs:       []string{"spec.pd.requests.storage", "spec.tikv.storageVolumes[0].storageSize"},
+		},
+		{
+			name: "shrink storage of a TiDB group",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiDBGroups[0].StorageVolumes[0].StorageSize = "5Gi"
+			},
+			annotation: label.AnnAllowStorageShrink,
+			errs:       []string{"spec.tidbGroups[0].storageVolumes[0].storageSize"},
+		},
+		{
+			name: "enable TLSCluster",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+			},
+			annotation: label.AnnAllowTLSClusterChange,
+			errs:       []string{"spec.tlsCluster.enabled"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			old := newTC()
+			tc := newTC()
+			tt.update(tc)
+			errs := ValidateUpdateTidbCluster(old, tc)
+			fields := []string{}
+			for _, err := range errs {
+				if strings.HasPrefix(err.Field, "spec.pd.replicas") || strings.HasPrefix(err.Field, "spec.tikv.replicas") ||
+					strings.Contains(err.Field, "storage") || strings.HasPrefix(err.Field, "spec.tlsCluster") {
+					fields = append(fields, err.Field)
+				}
+			}
+			g.Expect(fields).To(ConsistOf(tt.errs))
+
+			if tt.annotation != "" {
+				tc.Annotations = map[string]string{tt.annotation: "true"}
+				errs = ValidateUpdateTidbCluster(old, tc)
+				for _, err := range errs {
+					g.Expect(tt.errs).NotTo(ContainElement(err.Field))
+				}
+			}
+		})
+	}
+}
+
+func TestValidateUpdateTiKVReplicasWithGroups(t *testing.T) {
+	newTC := func(replicas, groupReplicas int32) *v1alpha1.TidbCluster {
+		return &v1alpha1.TidbCluster{
+			Spec: v1alpha1.TidbClusterSpec{
+				PD:   &v1alpha1.PDSpec{Replicas: 3},
+				TiKV: &v1alpha1.TiKVSpec{Replicas: replicas},
+				TiKVGroups: []*v1alpha1.TiKVGroupSpec{
+					{Name: "hot", TiKVSpec: v1alpha1.TiKVSpec{Replicas: groupReplicas}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		old  *v1alpha1.TidbCluster
+		tc   *v1alpha1.TidbCluster
+		errs []string
+	}{
+		{
+			name: "scale in TiKV with the replicas of the TiKV groups",
+			old:  newTC(3, 1),
+			tc:   newTC(2, 1),
+		},
+		{
+			name: "scale in a TiKV group below the max-replicas",
+			old:  newTC(1, 3),
+			tc:   newTC(1, 1),
+			errs: []string{"spec.tikvGroups[0].replicas"},
+		},
+		{
+			name: "scale in TiKV and a TiKV group below the max-replicas",
+			old:  newTC(2, 2),
+			tc:   newTC(1, 1),
+			errs: []string{"spec.tikv.replicas", "spec.tikvGroups[0].replicas"},
+		},
+		{
+			name: "remove a TiKV group below the max-replicas",
+			old:  newTC(2, 2),
+			tc: func() *v1alpha1.TidbCluster {
+				tc := newTC(2, 0)
+				tc.Spec.TiKVGroups = nil
+				return tc
+			}(),
+			errs: []string{"spec.tikvGroups"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			fields := []string{}
+			for _, err := range validateUpdateTiKVReplicas(tt.old, tt.tc, field.NewPath("spec")) {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(ConsistOf(tt.errs))
+
+			tt.tc.Annotations = map[string]string{label.AnnAllowTiKVBelowMaxReplicas: "true"}
+			g.Expect(validateUpdateTiKVReplicas(tt.old, tt.tc, field.NewPath("spec"))).To(BeEmpty())
+		})
+	}
+}
+
+func TestValidateUpdateTLSClusterNotRunning(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	old := &v1alpha1.TidbCluster{}
+	tc := &v1alpha1.TidbCluster{Spec: v1alpha1.TidbClusterSpec{TLSCluster: &v1alpha1.TLSCluster{Enabled: true}}}
+	g.Expect(validateUpdateTLSCluster(old, tc, field.NewPath("spec.tlsCluster"))).To(BeEmpty())
+}
+
+func TestValidateBackup(t *testing.T) {
+	newBackup := func() *v1alpha1.Backup {
+		return &v1alpha1.Backup{
+			Spec: v1alpha1.BackupSpec{
+				Mode: v1alpha1.BackupModeSnapshot,
+				BR:   &v1alpha1.BRConfig{Cluster: "basic"},
+				StorageProvider: v1alpha1.StorageProvider{
+					S3: &v1alpha1.S3StorageProvider{Bucket: "backup", Endpoint: "http://minio:9000"},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		update func(b *v1alpha1.Backup)
+		errs   []string
+	}{
+		{
+			name:   "valid snapshot backup",
+			update: func(b *v1alpha1.Backup) {},
+		},
+		{
+			name: "valid log backup",
+			update: func(b *v1alpha1.Backup) {
+				b.Spec.Mode = v1alpha1.BackupModeLog
+				b.Spec.LogSubcommand = v1alpha1.LogStartCommand
+				b.Spec.CommitTs = "2024-01-01 00:00:00"
+			},
+		},
+		{
+			name: "invalid storage provider",
+			update: func(b *v1alpha1.Backup) {
+				b.Spec.S3.Bucket = ""
+				b.Spec.S3.Endpoint = "minio:9000"
+				b.Spec.Gcs = &v1alpha1.GcsStorageProvider{ProjectId: "p", Bucket: "b"}
+			},
+			errs: []string{"spec", "spec.s3.bucket", "spec.s3.endpoint"},
+		},
+		{
+			name: "missing storage provider",
+			update: func(b *v1alpha1.Backup) {
+				b.Spec.S3 = nil
+			},
+			errs: []string{"spec"},
+		},
+		{
+			name: "dumpling backup without bucket",
+			update: func(b *v1alpha1.Backup) {
+				b.Spec.BR = nil
+				b.Spec.S3 = &v1alpha1.S3StorageProvider{Path: "s3://backup/dumpling"}
+			},
+		},
+		{
+			name: "dumpling backup without project id",
+			update: func(b *v1alpha1.Backup) {
+				b.Spec.BR = nil
+				b.Spec.S3 = nil
+				b.Spec.Gcs = &v1alpha1.GcsStorageProvider{Path: "gcs://backup/dumpling"}
+			},
+		},
+		{
+			name: "malformed commitTs",
+			update: func(b *v1alpha1.Backup) {
+				b.Spec.Mode = v1alpha1.BackupModeLog
+				b.Spec.CommitTs = "yesterday"
+			},
+			errs: []string{"spec.commitTs"},
+		},
+		{
+			name: "log subcommand in snapshot mode",
+			update: func(b *v1alpha1.Backup) {
+				b.Spec.LogSubcommand = v1alpha1.LogStopCommand
+			},
+			errs: []string{"spec.logSubcommand"},
+		},
+		{
+			name: "conflicting logStop and logSubcommand",
+			update: func(b *v1alpha1.Backup) {
+				b.Spec.Mode = v1alpha1.BackupModeLog
+				b.Spec.LogSubcommand = v1alpha1.LogStartCommand
+				b.Spec.LogStop = true
+			},
+			errs: []string{"spec.logStop"},
+		},
+		{
+			name: "unknown log subcommand",
+			update: func(b *v1alpha1.Backup) {
+				b.Spec.Mode = v1alpha1.BackupModeLog
+				b.Spec.LogSubcommand = "log-restart"
+			},
+			errs: []string{"spec.logSubcommand"},
+		},
+		{
+			name: "table backup without table",
+			update: func(b *v1alpha1.Backup) {
+				b.Spec.Type = v1alpha1.BackupTypeTable
+				b.Spec.BR.DB = "test"
+			},
+			errs: []string{"spec.br.table"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			backup := newBackup()
+			tt.update(backup)
+			fields := []string{}
+			for _, err := range ValidateBackup(backup) {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(ConsistOf(tt.errs))
+		})
+	}
+}
+
+func TestValidateUpdateBackup(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	old := &v1alpha1.Backup{
+		Spec: v1alpha1.BackupSpec{
+			Mode: v1alpha1.BackupModeLog,
+			BR:   &v1alpha1.BRConfig{Cluster: "basic"},
+			StorageProvider: v1alpha1.StorageProvider{
+				S3: &v1alpha1.S3StorageProvider{Bucket: "backup", Prefix: "log"},
+			},
+		},
+	}
+	backup := old.DeepCopy()
+	backup.Spec.S3.Prefix = "another"
+	backup.Spec.BR.Cluster = "another"
+	g.Expect(ValidateUpdateBackup(old, backup)).To(BeEmpty())
+
+	// the storage location is immutable after the backup starts
+	old.Status.Phase = v1alpha1.BackupScheduled
+	errs := ValidateUpdateBackup(old, backup)
+	g.Expect(errs).To(HaveLen(2))
+	g.Expect(errs[0].Field).To(Equal("spec.storageProvider"))
+	g.Expect(errs[1].Field).To(Equal("spec.br.cluster"))
+
+	// the log backup is controlled by the log subcommand
+	backup = old.DeepCopy()
+	backup.Spec.LogSubcommand = v1alpha1.LogStopCommand
+	g.Expect(ValidateUpdateBackup(old, backup)).To(BeEmpty())
+
+	// the status of an existing backup whose spec is invalid can still be updated
+	old.Spec.S3.Bucket = ""
+	backup = old.DeepCopy()
+	backup.Status.Phase = v1alpha1.BackupComplete
+	g.Expect(ValidateUpdateBackup(old, backup)).To(BeEmpty())
+}
+
+func TestValidateRestore(t *testing.T) {
+	newRestore := func() *v1alpha1.Restore {
+		return &v1alpha1.Restore{
+			Spec: v1alpha1.RestoreSpec{
+				Mode: v1alpha1.RestoreModePiTR,
+				BR:   &v1alpha1.BRConfig{Cluster: "basic"},
+				StorageProvider: v1alpha1.StorageProvider{
+					S3: &v1alpha1.S3StorageProvider{Bucket: "log"},
+				},
+				PitrFullBackupStorageProvider: v1alpha1.StorageProvider{
+					S3: &v1alpha1.S3StorageProvider{Bucket: "full"},
+				},
+				PitrRestoredTs: "2024-01-01 00:00:00",
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		update func(r *v1alpha1.Restore)
+		errs   []string
+	}{
+		{
+			name:   "valid pitr restore",
+			update: func(r *v1alpha1.Restore) {},
+		},
+		{
+			name: "malformed pitrRestoredTs",
+			update: func(r *v1alpha1.Restore) {
+				r.Spec.PitrRestoredTs = "now"
+			},
+			errs: []string{"spec.pitrRestoredTs"},
+		},
+		{
+			name: "both full backup and log restore start ts",
+			update: func(r *v1alpha1.Restore) {
+				r.Spec.LogRestoreStartTs = "2023-12-01 00:00:00"
+			},
+			errs: []string{"spec.logRestoreStartTs"},
+		},
+		{
+			name: "neither full backup nor log restore start ts",
+			update: func(r *v1alpha1.Restore) {
+				r.Spec.PitrFullBackupStorageProvider = v1alpha1.StorageProvider{}
+			},
+			errs: []string{"spec.pitrFullBackupStorageProvider"},
+		},
+		{
+			name: "pitrRestoredTs in snapshot mode",
+			update: func(r *v1alpha1.Restore) {
+				r.Spec.Mode = v1alpha1.RestoreModeSnapshot
+				r.Spec.PitrFullBackupStorageProvider = v1alpha1.StorageProvider{}
+			},
+			errs: []string{"spec.pitrRestoredTs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			restore := newRestore()
+			tt.update(restore)
+			fields := []string{}
+			for _, err := range ValidateRestore(restore) {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(ConsistOf(tt.errs))
+		})
+	}
+}
+
+func TestValidateCompactBackup(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	compact := &v1alpha1.CompactBackup{
+		Spec: v1alpha1.CompactSpec{
+			StorageProvider: v1alpha1.StorageProvider{
+				S3: &v1alpha1.S3StorageProvider{Bucket: "log"},
+			},
+			StartTs: "2024-01-01 00:00:00",
+			EndTs:   "2024-01-02 00:00:00",
+		},
+	}
+	g.Expect(ValidateCompactBackup(compact)).To(BeEmpty())
+
+	compact.Spec.EndTs = "2023-12-31 00:00:00"
+	errs := ValidateCompactBackup(compact)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("spec.endTs"))
+
+	old := compact.DeepCopy()
+	old.Status.State = "RUNNING"
+	compact.Spec.EndTs = "2024-01-03 00:00:00"
+	errs = ValidateUpdateCompactBackup(old, compact)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+}
+
+func TestValidateBackupSchedule(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bs := &v1alpha1.BackupSchedule{
+		Spec: v1alpha1.BackupScheduleSpec{
+			Schedule:        "0 0 * * *",
+			MaxReservedTime: pointer.String("72h"),
+			BackupTemplate: v1alpha1.BackupSpec{
+				BR:              &v1alpha1.BRConfig{Cluster: "basic"},
+				StorageProvider: v1alpha1.StorageProvider{S3: &v1alpha1.S3StorageProvider{Bucket: "full"}},
+			},
+			LogBackupTemplate: &v1alpha1.BackupSpec{
+				Mode:            v1alpha1.BackupModeLog,
+				BR:              &v1alpha1.BRConfig{Cluster: "basic"},
+				StorageProvider: v1alpha1.StorageProvider{S3: &v1alpha1.S3StorageProvider{Bucket: "log"}},
+			},
+		},
+	}
+	g.Expect(ValidateBackupSchedule(bs)).To(BeEmpty())
+
+	bs.Spec.MaxReservedTime = pointer.String("3 days")
+	bs.Spec.LogBackupTemplate.Mode = v1alpha1.BackupModeSnapshot
+	fields := []string{}
+	for _, err := range ValidateBackupSchedule(bs) {
+		fields = append(fields, err.Field)
+	}
+	g.Expect(fields).To(ConsistOf("spec.maxReservedTime", "spec.logBackupTemplate.backupMode"))
+
+	// the storage of the log backup is immutable after it is created
+	bs.Spec.MaxReservedTime = pointer.String("72h")
+	bs.Spec.LogBackupTemplate.Mode = v1alpha1.BackupModeLog
+	old := bs.DeepCopy()
+	old.Status.LogBackup = pointer.String("log-backup")
+	bs.Spec.LogBackupTemplate.S3.Bucket = "another"
+	errs := ValidateUpdateBackupSchedule(old, bs)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("spec.logBackupTemplate.storageProvider"))
+}
@@ -619,6 +619,30 @@ func (in *CDCConfigWraper) DeepCopy() *CDCConfigWraper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChangePlanStatus) DeepCopyInto(out *ChangePlanStatus) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentChangePlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangePlanStatus.
+func (in *ChangePlanStatus) DeepCopy() *ChangePlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChangePlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CleanOption) DeepCopyInto(out *CleanOption) {
 	*out = *in
@@ -945,6 +969,47 @@ func (in *CompactStatus) DeepCopy() *CompactStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentChangePlan) DeepCopyInto(out *ComponentChangePlan) {
+	*out = *in
+	if in.TemplateChanges != nil {
+		in, out := &in.TemplateChanges, &out.TemplateChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UpgradeOrdinals != nil {
+		in, out := &in.UpgradeOrdinals, &out.UpgradeOrdinals
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScaleOutOrdinals != nil {
+		in, out := &in.ScaleOutOrdinals, &out.ScaleOutOrdinals
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScaleInOrdinals != nil {
+		in, out := &in.ScaleInOrdinals, &out.ScaleInOrdinals
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeChanges != nil {
+		in, out := &in.VolumeChanges, &out.VolumeChanges
+		*out = make([]VolumeChangePlan, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentChangePlan.
+func (in *ComponentChangePlan) DeepCopy() *ComponentChangePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentChangePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 	*out = *in
@@ -1103,6 +1168,65 @@ func (in *ComponentSpec) DeepCopy() *ComponentSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigDriftDetection) DeepCopyInto(out *ConfigDriftDetection) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigDriftDetection.
+func (in *ConfigDriftDetection) DeepCopy() *ConfigDriftDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigDriftDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigDriftItem) DeepCopyInto(out *ConfigDriftItem) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigDriftItem.
+func (in *ConfigDriftItem) DeepCopy() *ConfigDriftItem {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigDriftItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigDriftStatus) DeepCopyInto(out *ConfigDriftStatus) {
+	*out = *in
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConfigDriftItem, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigDriftStatus.
+func (in *ConfigDriftStatus) DeepCopy() *ConfigDriftStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigDriftStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapRef) DeepCopyInto(out *ConfigMapRef) {
 	*out = *in
@@ -1363,7 +1487,7 @@ func (in *DMClusterSpec) DeepCopyInto(out *DMClusterSpec) {
 	if in.TLSCluster != nil {
 		in, out := &in.TLSCluster, &out.TLSCluster
 		*out = new(TLSCluster)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.TLSClientSecretNames != nil {
 		in, out := &in.TLSClientSecretNames, &out.TLSClientSecretNames
@@ -1487,6 +1611,11 @@ func (in *DMDiscoverySpec) DeepCopyInto(out *DMDiscoverySpec) {
 		*out = new(Probe)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -1724,6 +1853,11 @@ func (in *DiscoverySpec) DeepCopyInto(out *DiscoverySpec) {
 		*out = new(Probe)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -2352,6 +2486,33 @@ func (in *HelperSpec) DeepCopy() *HelperSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HotConfigStatus) DeepCopyInto(out *HotConfigStatus) {
+	*out = *in
+	if in.AppliedKeys != nil {
+		in, out := &in.AppliedKeys, &out.AppliedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingKeys != nil {
+		in, out := &in.PendingKeys, &out.PendingKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HotConfigStatus.
+func (in *HotConfigStatus) DeepCopy() *HotConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HotConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
 	*out = *in
@@ -2495,6 +2656,23 @@ func (in *IsolationRead) DeepCopy() *IsolationRead {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuedCertificate) DeepCopyInto(out *IssuedCertificate) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuedCertificate.
+func (in *IssuedCertificate) DeepCopy() *IssuedCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuedCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalStorageProvider) DeepCopyInto(out *LocalStorageProvider) {
 	*out = *in
@@ -3180,6 +3358,32 @@ func (in *OpenTracingSampler) DeepCopy() *OpenTracingSampler {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationRecord) DeepCopyInto(out *OperationRecord) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Ordinals != nil {
+		in, out := &in.Ordinals, &out.Ordinals
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationRecord.
+func (in *OperationRecord) DeepCopy() *OperationRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PDConfig) DeepCopyInto(out *PDConfig) {
 	*out = *in
@@ -4094,6 +4298,11 @@ func (in *PDStatus) DeepCopyInto(out *PDStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HotConfig != nil {
+		in, out := &in.HotConfig, &out.HotConfig
+		*out = new(HotConfigStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5165,147 +5374,293 @@ func (in *SafeTLSConfig) DeepCopy() *SafeTLSConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScalePolicy) DeepCopyInto(out *ScalePolicy) {
+func (in *ScaleAcceleration) DeepCopyInto(out *ScaleAcceleration) {
 	*out = *in
-	if in.ScaleInParallelism != nil {
-		in, out := &in.ScaleInParallelism, &out.ScaleInParallelism
+	if in.StoreLimit != nil {
+		in, out := &in.StoreLimit, &out.StoreLimit
 		*out = new(int32)
 		**out = **in
 	}
-	if in.ScaleOutParallelism != nil {
-		in, out := &in.ScaleOutParallelism, &out.ScaleOutParallelism
+	if in.RegionScheduleLimit != nil {
+		in, out := &in.RegionScheduleLimit, &out.RegionScheduleLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReplicaScheduleLimit != nil {
+		in, out := &in.ReplicaScheduleLimit, &out.ReplicaScheduleLimit
 		*out = new(int32)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalePolicy.
-func (in *ScalePolicy) DeepCopy() *ScalePolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleAcceleration.
+func (in *ScaleAcceleration) DeepCopy() *ScaleAcceleration {
 	if in == nil {
 		return nil
 	}
-	out := new(ScalePolicy)
+	out := new(ScaleAcceleration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretOrConfigMap) DeepCopyInto(out *SecretOrConfigMap) {
+func (in *ScaleAccelerationStatus) DeepCopyInto(out *ScaleAccelerationStatus) {
 	*out = *in
-	if in.Secret != nil {
-		in, out := &in.Secret, &out.Secret
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.PreviousStoreLimits != nil {
+		in, out := &in.PreviousStoreLimits, &out.PreviousStoreLimits
+		*out = make(map[string]StoreLimitStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.ConfigMap != nil {
-		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(v1.ConfigMapKeySelector)
-		(*in).DeepCopyInto(*out)
+	if in.PreviousRegionScheduleLimit != nil {
+		in, out := &in.PreviousRegionScheduleLimit, &out.PreviousRegionScheduleLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PreviousReplicaScheduleLimit != nil {
+		in, out := &in.PreviousReplicaScheduleLimit, &out.PreviousReplicaScheduleLimit
+		*out = new(int64)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretOrConfigMap.
-func (in *SecretOrConfigMap) DeepCopy() *SecretOrConfigMap {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleAccelerationStatus.
+func (in *ScaleAccelerationStatus) DeepCopy() *ScaleAccelerationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretOrConfigMap)
+	out := new(ScaleAccelerationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Security) DeepCopyInto(out *Security) {
+func (in *ScalePolicy) DeepCopyInto(out *ScalePolicy) {
 	*out = *in
-	if in.SkipGrantTable != nil {
-		in, out := &in.SkipGrantTable, &out.SkipGrantTable
-		*out = new(bool)
-		**out = **in
-	}
-	if in.SSLCA != nil {
-		in, out := &in.SSLCA, &out.SSLCA
-		*out = new(string)
-		**out = **in
-	}
-	if in.SSLCert != nil {
-		in, out := &in.SSLCert, &out.SSLCert
-		*out = new(string)
-		**out = **in
-	}
-	if in.SSLKey != nil {
-		in, out := &in.SSLKey, &out.SSLKey
-		*out = new(string)
-		**out = **in
-	}
-	if in.ClusterSSLCA != nil {
-		in, out := &in.ClusterSSLCA, &out.ClusterSSLCA
-		*out = new(string)
-		**out = **in
-	}
-	if in.ClusterSSLCert != nil {
-		in, out := &in.ClusterSSLCert, &out.ClusterSSLCert
-		*out = new(string)
+	if in.ScaleInParallelism != nil {
+		in, out := &in.ScaleInParallelism, &out.ScaleInParallelism
+		*out = new(int32)
 		**out = **in
 	}
-	if in.ClusterSSLKey != nil {
-		in, out := &in.ClusterSSLKey, &out.ClusterSSLKey
-		*out = new(string)
+	if in.ScaleOutParallelism != nil {
+		in, out := &in.ScaleOutParallelism, &out.ScaleOutParallelism
+		*out = new(int32)
 		**out = **in
 	}
-	if in.ClusterVerifyCN != nil {
-		in, out := &in.ClusterVerifyCN, &out.ClusterVerifyCN
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Acceleration != nil {
+		in, out := &in.Acceleration, &out.Acceleration
+		*out = new(ScaleAcceleration)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Security.
-func (in *Security) DeepCopy() *Security {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalePolicy.
+func (in *ScalePolicy) DeepCopy() *ScalePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(Security)
+	out := new(ScalePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Service) DeepCopyInto(out *Service) {
+func (in *ScalingRecord) DeepCopyInto(out *ScalingRecord) {
 	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Service.
-func (in *Service) DeepCopy() *Service {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingRecord.
+func (in *ScalingRecord) DeepCopy() *ScalingRecord {
 	if in == nil {
 		return nil
 	}
-	out := new(Service)
+	out := new(ScalingRecord)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
+func (in *ScalingTargets) DeepCopyInto(out *ScalingTargets) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.TiDB != nil {
+		in, out := &in.TiDB, &out.TiDB
+		*out = new(int32)
+		**out = **in
 	}
-	if in.LoadBalancerIP != nil {
+	if in.TiKV != nil {
+		in, out := &in.TiKV, &out.TiKV
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TiFlash != nil {
+		in, out := &in.TiFlash, &out.TiFlash
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TiCDC != nil {
+		in, out := &in.TiCDC, &out.TiCDC
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TiProxy != nil {
+		in, out := &in.TiProxy, &out.TiProxy
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingTargets.
+func (in *ScalingTargets) DeepCopy() *ScalingTargets {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingTargets)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingWindow) DeepCopyInto(out *ScalingWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	in.Replicas.DeepCopyInto(&out.Replicas)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingWindow.
+func (in *ScalingWindow) DeepCopy() *ScalingWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretOrConfigMap) DeepCopyInto(out *SecretOrConfigMap) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretOrConfigMap.
+func (in *SecretOrConfigMap) DeepCopy() *SecretOrConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretOrConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Security) DeepCopyInto(out *Security) {
+	*out = *in
+	if in.SkipGrantTable != nil {
+		in, out := &in.SkipGrantTable, &out.SkipGrantTable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SSLCA != nil {
+		in, out := &in.SSLCA, &out.SSLCA
+		*out = new(string)
+		**out = **in
+	}
+	if in.SSLCert != nil {
+		in, out := &in.SSLCert, &out.SSLCert
+		*out = new(string)
+		**out = **in
+	}
+	if in.SSLKey != nil {
+		in, out := &in.SSLKey, &out.SSLKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClusterSSLCA != nil {
+		in, out := &in.ClusterSSLCA, &out.ClusterSSLCA
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClusterSSLCert != nil {
+		in, out := &in.ClusterSSLCert, &out.ClusterSSLCert
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClusterSSLKey != nil {
+		in, out := &in.ClusterSSLKey, &out.ClusterSSLKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClusterVerifyCN != nil {
+		in, out := &in.ClusterVerifyCN, &out.ClusterVerifyCN
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Security.
+func (in *Security) DeepCopy() *Security {
+	if in == nil {
+		return nil
+	}
+	out := new(Security)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Service) DeepCopyInto(out *Service) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Service.
+func (in *Service) DeepCopy() *Service {
+	if in == nil {
+		return nil
+	}
+	out := new(Service)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LoadBalancerIP != nil {
 		in, out := &in.LoadBalancerIP, &out.LoadBalancerIP
 		*out = new(string)
 		**out = **in
@@ -5526,6 +5881,22 @@ func (in *StorageVolumeStatus) DeepCopy() *StorageVolumeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StoreLimitStatus) DeepCopyInto(out *StoreLimitStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoreLimitStatus.
+func (in *StoreLimitStatus) DeepCopy() *StoreLimitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StoreLimitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SuspendAction) DeepCopyInto(out *SuspendAction) {
 	*out = *in
@@ -5545,6 +5916,11 @@ func (in *SuspendAction) DeepCopy() *SuspendAction {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSCluster) DeepCopyInto(out *TLSCluster) {
 	*out = *in
+	if in.Issuer != nil {
+		in, out := &in.Issuer, &out.Issuer
+		*out = new(TLSClusterIssuer)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5558,6 +5934,55 @@ func (in *TLSCluster) DeepCopy() *TLSCluster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSClusterIssuer) DeepCopyInto(out *TLSClusterIssuer) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSClusterIssuer.
+func (in *TLSClusterIssuer) DeepCopy() *TLSClusterIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSClusterIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSClusterStatus) DeepCopyInto(out *TLSClusterStatus) {
+	*out = *in
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make(map[string]IssuedCertificate, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSClusterStatus.
+func (in *TLSClusterStatus) DeepCopy() *TLSClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	*out = *in
@@ -5627,6 +6052,7 @@ func (in *ThanosSpec) DeepCopy() *ThanosSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiCDCCapture) DeepCopyInto(out *TiCDCCapture) {
 	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
@@ -5676,6 +6102,23 @@ func (in *TiCDCConfig) DeepCopy() *TiCDCConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiCDCFailureMember) DeepCopyInto(out *TiCDCFailureMember) {
+	*out = *in
+	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiCDCFailureMember.
+func (in *TiCDCFailureMember) DeepCopy() *TiCDCFailureMember {
+	if in == nil {
+		return nil
+	}
+	out := new(TiCDCFailureMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiCDCSpec) DeepCopyInto(out *TiCDCSpec) {
 	*out = *in
@@ -5708,6 +6151,11 @@ func (in *TiCDCSpec) DeepCopyInto(out *TiCDCSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.MaxFailoverCount != nil {
+		in, out := &in.MaxFailoverCount, &out.MaxFailoverCount
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -5733,7 +6181,14 @@ func (in *TiCDCStatus) DeepCopyInto(out *TiCDCStatus) {
 		in, out := &in.Captures, &out.Captures
 		*out = make(map[string]TiCDCCapture, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.FailureMembers != nil {
+		in, out := &in.FailureMembers, &out.FailureMembers
+		*out = make(map[string]TiCDCFailureMember, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	if in.Volumes != nil {
@@ -6072,6 +6527,84 @@ func (in *TiDBFailureMember) DeepCopy() *TiDBFailureMember {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBGroupSpec) DeepCopyInto(out *TiDBGroupSpec) {
+	*out = *in
+	in.TiDBSpec.DeepCopyInto(&out.TiDBSpec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBGroupSpec.
+func (in *TiDBGroupSpec) DeepCopy() *TiDBGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBGroupStatus) DeepCopyInto(out *TiDBGroupStatus) {
+	*out = *in
+	in.TiDBStatus.DeepCopyInto(&out.TiDBStatus)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBGroupStatus.
+func (in *TiDBGroupStatus) DeepCopy() *TiDBGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBIdlePolicy) DeepCopyInto(out *TiDBIdlePolicy) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WakeOnConnect != nil {
+		in, out := &in.WakeOnConnect, &out.WakeOnConnect
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBIdlePolicy.
+func (in *TiDBIdlePolicy) DeepCopy() *TiDBIdlePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBIdlePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBIdleStatus) DeepCopyInto(out *TiDBIdleStatus) {
+	*out = *in
+	in.LastActiveTime.DeepCopyInto(&out.LastActiveTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBIdleStatus.
+func (in *TiDBIdleStatus) DeepCopy() *TiDBIdleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBIdleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiDBInitializer) DeepCopyInto(out *TiDBInitializer) {
 	*out = *in
@@ -6271,6 +6804,16 @@ func (in *TiDBSpec) DeepCopyInto(out *TiDBSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Idle != nil {
+		in, out := &in.Idle, &out.Idle
+		*out = new(TiDBIdlePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HotConfigSecretName != nil {
+		in, out := &in.HotConfigSecretName, &out.HotConfigSecretName
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -6333,6 +6876,16 @@ func (in *TiDBStatus) DeepCopyInto(out *TiDBStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HotConfig != nil {
+		in, out := &in.HotConfig, &out.HotConfig
+		*out = new(HotConfigStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Idle != nil {
+		in, out := &in.Idle, &out.Idle
+		*out = new(TiDBIdleStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -6382,6 +6935,29 @@ func (in *TiFlashCommonConfigWraper) DeepCopy() *TiFlashCommonConfigWraper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiFlashComputeSpec) DeepCopyInto(out *TiFlashComputeSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(TiFlashConfigWraper)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiFlashComputeSpec.
+func (in *TiFlashComputeSpec) DeepCopy() *TiFlashComputeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TiFlashComputeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiFlashConfig) DeepCopyInto(out *TiFlashConfig) {
 	*out = *in
@@ -6434,6 +7010,28 @@ func (in *TiFlashConfigWraper) DeepCopy() *TiFlashConfigWraper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiFlashDisaggregatedSpec) DeepCopyInto(out *TiFlashDisaggregatedSpec) {
+	*out = *in
+	out.S3 = in.S3
+	if in.Compute != nil {
+		in, out := &in.Compute, &out.Compute
+		*out = new(TiFlashComputeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiFlashDisaggregatedSpec.
+func (in *TiFlashDisaggregatedSpec) DeepCopy() *TiFlashDisaggregatedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TiFlashDisaggregatedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiFlashProxyConfigWraper) DeepCopyInto(out *TiFlashProxyConfigWraper) {
 	*out = *in
@@ -6454,6 +7052,22 @@ func (in *TiFlashProxyConfigWraper) DeepCopy() *TiFlashProxyConfigWraper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiFlashS3Spec) DeepCopyInto(out *TiFlashS3Spec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiFlashS3Spec.
+func (in *TiFlashS3Spec) DeepCopy() *TiFlashS3Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(TiFlashS3Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiFlashSpec) DeepCopyInto(out *TiFlashSpec) {
 	*out = *in
@@ -6497,6 +7111,11 @@ func (in *TiFlashSpec) DeepCopyInto(out *TiFlashSpec) {
 		**out = **in
 	}
 	in.ScalePolicy.DeepCopyInto(&out.ScalePolicy)
+	if in.Disaggregated != nil {
+		in, out := &in.Disaggregated, &out.Disaggregated
+		*out = new(TiFlashDisaggregatedSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -7420,6 +8039,40 @@ func (in *TiKVGCConfig) DeepCopy() *TiKVGCConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVGroupSpec) DeepCopyInto(out *TiKVGroupSpec) {
+	*out = *in
+	in.TiKVSpec.DeepCopyInto(&out.TiKVSpec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVGroupSpec.
+func (in *TiKVGroupSpec) DeepCopy() *TiKVGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVGroupStatus) DeepCopyInto(out *TiKVGroupStatus) {
+	*out = *in
+	in.TiKVStatus.DeepCopyInto(&out.TiKVStatus)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVGroupStatus.
+func (in *TiKVGroupStatus) DeepCopy() *TiKVGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiKVImportConfig) DeepCopyInto(out *TiKVImportConfig) {
 	*out = *in
@@ -8479,6 +9132,16 @@ func (in *TiKVStatus) DeepCopyInto(out *TiKVStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HotConfig != nil {
+		in, out := &in.HotConfig, &out.HotConfig
+		*out = new(HotConfigStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleAcceleration != nil {
+		in, out := &in.ScaleAcceleration, &out.ScaleAcceleration
+		*out = new(ScaleAccelerationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -8608,6 +9271,11 @@ func (in *TiKVStore) DeepCopyInto(out *TiKVStore) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(TiKVStoreMigration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -8621,6 +9289,38 @@ func (in *TiKVStore) DeepCopy() *TiKVStore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVStoreMigration) DeepCopyInto(out *TiKVStoreMigration) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.LastSampleTime.DeepCopyInto(&out.LastSampleTime)
+	if in.RegionRate != nil {
+		in, out := &in.RegionRate, &out.RegionRate
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ByteRate != nil {
+		in, out := &in.ByteRate, &out.ByteRate
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVStoreMigration.
+func (in *TiKVStoreMigration) DeepCopy() *TiKVStoreMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVStoreMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiKVTitanCfConfig) DeepCopyInto(out *TiKVTitanCfConfig) {
 	*out = *in
@@ -8893,70 +9593,147 @@ func (in *TiProxyStatus) DeepCopyInto(out *TiProxyStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiProxyStatus.
-func (in *TiProxyStatus) DeepCopy() *TiProxyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiProxyStatus.
+func (in *TiProxyStatus) DeepCopy() *TiProxyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TiProxyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbCluster) DeepCopyInto(out *TidbCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbCluster.
+func (in *TidbCluster) DeepCopy() *TidbCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterCondition) DeepCopyInto(out *TidbClusterCondition) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterCondition.
+func (in *TidbClusterCondition) DeepCopy() *TidbClusterCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterList) DeepCopyInto(out *TidbClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TidbCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterList.
+func (in *TidbClusterList) DeepCopy() *TidbClusterList {
 	if in == nil {
 		return nil
 	}
-	out := new(TiProxyStatus)
+	out := new(TidbClusterList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TidbCluster) DeepCopyInto(out *TidbCluster) {
+func (in *TidbClusterRef) DeepCopyInto(out *TidbClusterRef) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbCluster.
-func (in *TidbCluster) DeepCopy() *TidbCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterRef.
+func (in *TidbClusterRef) DeepCopy() *TidbClusterRef {
 	if in == nil {
 		return nil
 	}
-	out := new(TidbCluster)
+	out := new(TidbClusterRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TidbCluster) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TidbClusterCondition) DeepCopyInto(out *TidbClusterCondition) {
+func (in *TidbClusterScalingSchedule) DeepCopyInto(out *TidbClusterScalingSchedule) {
 	*out = *in
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterCondition.
-func (in *TidbClusterCondition) DeepCopy() *TidbClusterCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterScalingSchedule.
+func (in *TidbClusterScalingSchedule) DeepCopy() *TidbClusterScalingSchedule {
 	if in == nil {
 		return nil
 	}
-	out := new(TidbClusterCondition)
+	out := new(TidbClusterScalingSchedule)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbClusterScalingSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TidbClusterList) DeepCopyInto(out *TidbClusterList) {
+func (in *TidbClusterScalingScheduleList) DeepCopyInto(out *TidbClusterScalingScheduleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]TidbCluster, len(*in))
+		*out = make([]TidbClusterScalingSchedule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -8964,18 +9741,18 @@ func (in *TidbClusterList) DeepCopyInto(out *TidbClusterList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterList.
-func (in *TidbClusterList) DeepCopy() *TidbClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterScalingScheduleList.
+func (in *TidbClusterScalingScheduleList) DeepCopy() *TidbClusterScalingScheduleList {
 	if in == nil {
 		return nil
 	}
-	out := new(TidbClusterList)
+	out := new(TidbClusterScalingScheduleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TidbClusterList) DeepCopyObject() runtime.Object {
+func (in *TidbClusterScalingScheduleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -8983,17 +9760,75 @@ func (in *TidbClusterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TidbClusterRef) DeepCopyInto(out *TidbClusterRef) {
+func (in *TidbClusterScalingScheduleSpec) DeepCopyInto(out *TidbClusterScalingScheduleSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]ScalingWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(ScalingTargets)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterScalingScheduleSpec.
+func (in *TidbClusterScalingScheduleSpec) DeepCopy() *TidbClusterScalingScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterScalingScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterScalingScheduleStatus) DeepCopyInto(out *TidbClusterScalingScheduleStatus) {
 	*out = *in
+	if in.ActiveWindows != nil {
+		in, out := &in.ActiveWindows, &out.ActiveWindows
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastEvaluateTime != nil {
+		in, out := &in.LastEvaluateTime, &out.LastEvaluateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextEvaluateTime != nil {
+		in, out := &in.NextEvaluateTime, &out.NextEvaluateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ScalingRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterRef.
-func (in *TidbClusterRef) DeepCopy() *TidbClusterRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterScalingScheduleStatus.
+func (in *TidbClusterScalingScheduleStatus) DeepCopy() *TidbClusterScalingScheduleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TidbClusterRef)
+	out := new(TidbClusterScalingScheduleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -9023,11 +9858,33 @@ func (in *TidbClusterSpec) DeepCopyInto(out *TidbClusterSpec) {
 		*out = new(TiDBSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TiDBGroups != nil {
+		in, out := &in.TiDBGroups, &out.TiDBGroups
+		*out = make([]*TiDBGroupSpec, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(TiDBGroupSpec)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.TiKV != nil {
 		in, out := &in.TiKV, &out.TiKV
 		*out = new(TiKVSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TiKVGroups != nil {
+		in, out := &in.TiKVGroups, &out.TiKVGroups
+		*out = make([]*TiKVGroupSpec, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(TiKVGroupSpec)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.TiFlash != nil {
 		in, out := &in.TiFlash, &out.TiFlash
 		*out = new(TiFlashSpec)
@@ -9076,7 +9933,7 @@ func (in *TidbClusterSpec) DeepCopyInto(out *TidbClusterSpec) {
 	if in.TLSCluster != nil {
 		in, out := &in.TLSCluster, &out.TLSCluster
 		*out = new(TLSCluster)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.HostNetwork != nil {
 		in, out := &in.HostNetwork, &out.HostNetwork
@@ -9168,6 +10025,11 @@ func (in *TidbClusterSpec) DeepCopyInto(out *TidbClusterSpec) {
 		*out = make([]StartScriptV2FeatureFlag, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConfigDriftDetection != nil {
+		in, out := &in.ConfigDriftDetection, &out.ConfigDriftDetection
+		*out = new(ConfigDriftDetection)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -9213,6 +10075,63 @@ func (in *TidbClusterStatus) DeepCopyInto(out *TidbClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TiDBGroups != nil {
+		in, out := &in.TiDBGroups, &out.TiDBGroups
+		*out = make(map[string]*TiDBGroupStatus, len(*in))
+		for key, val := range *in {
+			var outVal *TiDBGroupStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(TiDBGroupStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.TiKVGroups != nil {
+		in, out := &in.TiKVGroups, &out.TiKVGroups
+		*out = make(map[string]*TiKVGroupStatus, len(*in))
+		for key, val := range *in {
+			var outVal *TiKVGroupStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(TiKVGroupStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.TiFlashCompute != nil {
+		in, out := &in.TiFlashCompute, &out.TiFlashCompute
+		*out = new(TiFlashStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSCluster != nil {
+		in, out := &in.TLSCluster, &out.TLSCluster
+		*out = new(TLSClusterStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigDrift != nil {
+		in, out := &in.ConfigDrift, &out.ConfigDrift
+		*out = new(ConfigDriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ChangePlan != nil {
+		in, out := &in.ChangePlan, &out.ChangePlan
+		*out = new(ChangePlanStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]OperationRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -9361,6 +10280,13 @@ func (in *TidbDashboardStatus) DeepCopyInto(out *TidbDashboardStatus) {
 		*out = new(appsv1.StatefulSetStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -9745,6 +10671,13 @@ func (in *TidbMonitorStatus) DeepCopyInto(out *TidbMonitorStatus) {
 		*out = new(appsv1.StatefulSetStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -9864,6 +10797,109 @@ func (in *TidbNGMonitoringStatus) DeepCopy() *TidbNGMonitoringStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbTenant) DeepCopyInto(out *TidbTenant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbTenant.
+func (in *TidbTenant) DeepCopy() *TidbTenant {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbTenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbTenant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbTenantList) DeepCopyInto(out *TidbTenantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TidbTenant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbTenantList.
+func (in *TidbTenantList) DeepCopy() *TidbTenantList {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbTenantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbTenantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbTenantSpec) DeepCopyInto(out *TidbTenantSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	if in.KeyspaceConfig != nil {
+		in, out := &in.KeyspaceConfig, &out.KeyspaceConfig
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.TiDB.DeepCopyInto(&out.TiDB)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbTenantSpec.
+func (in *TidbTenantSpec) DeepCopy() *TidbTenantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbTenantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbTenantStatus) DeepCopyInto(out *TidbTenantStatus) {
+	*out = *in
+	in.TiDB.DeepCopyInto(&out.TiDB)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbTenantStatus.
+func (in *TidbTenantStatus) DeepCopy() *TidbTenantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbTenantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopologySpreadConstraint) DeepCopyInto(out *TopologySpreadConstraint) {
 	*out = *in
@@ -9978,6 +11014,22 @@ func (in *User) DeepCopy() *User {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeChangePlan) DeepCopyInto(out *VolumeChangePlan) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeChangePlan.
+func (in *VolumeChangePlan) DeepCopy() *VolumeChangePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeChangePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerConfig) DeepCopyInto(out *WorkerConfig) {
 	*out = *in
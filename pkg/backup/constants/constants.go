@@ -26,6 +26,9 @@ const (
 	// DefaultBackoffLimit specifies the number of retries before marking this job failed.
 	DefaultBackoffLimit = 6
 
+	// TidbUserKey represents the user key in tidb secret
+	TidbUserKey = "user"
+
 	// TidbPasswordKey represents the password key in tidb secret
 	TidbPasswordKey = "password"
 
@@ -53,6 +53,10 @@ func (c *FakePingcapV1alpha1) TidbClusters(namespace string) v1alpha1.TidbCluste
 	return &FakeTidbClusters{c, namespace}
 }
 
+func (c *FakePingcapV1alpha1) TidbClusterScalingSchedules(namespace string) v1alpha1.TidbClusterScalingScheduleInterface {
+	return &FakeTidbClusterScalingSchedules{c, namespace}
+}
+
 func (c *FakePingcapV1alpha1) TidbDashboards(namespace string) v1alpha1.TidbDashboardInterface {
 	return &FakeTidbDashboards{c, namespace}
 }
@@ -69,6 +73,10 @@ func (c *FakePingcapV1alpha1) TidbNGMonitorings(namespace string) v1alpha1.TidbN
 	return &FakeTidbNGMonitorings{c, namespace}
 }
 
+func (c *FakePingcapV1alpha1) TidbTenants(namespace string) v1alpha1.TidbTenantInterface {
+	return &FakeTidbTenants{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakePingcapV1alpha1) RESTClient() rest.Interface {
@@ -29,6 +29,8 @@ type RestoreExpansion interface{}
 
 type TidbClusterExpansion interface{}
 
+type TidbClusterScalingScheduleExpansion interface{}
+
 type TidbDashboardExpansion interface{}
 
 type TidbInitializerExpansion interface{}
@@ -36,3 +38,5 @@ type TidbInitializerExpansion interface{}
 type TidbMonitorExpansion interface{}
 
 type TidbNGMonitoringExpansion interface{}
+
+type TidbTenantExpansion interface{}
@@ -32,10 +32,12 @@ type PingcapV1alpha1Interface interface {
 	DataResourcesGetter
 	RestoresGetter
 	TidbClustersGetter
+	TidbClusterScalingSchedulesGetter
 	TidbDashboardsGetter
 	TidbInitializersGetter
 	TidbMonitorsGetter
 	TidbNGMonitoringsGetter
+	TidbTenantsGetter
 }
 
 // PingcapV1alpha1Client is used to interact with features provided by the pingcap.com group.
@@ -71,6 +73,10 @@ func (c *PingcapV1alpha1Client) TidbClusters(namespace string) TidbClusterInterf
 	return newTidbClusters(c, namespace)
 }
 
+func (c *PingcapV1alpha1Client) TidbClusterScalingSchedules(namespace string) TidbClusterScalingScheduleInterface {
+	return newTidbClusterScalingSchedules(c, namespace)
+}
+
 func (c *PingcapV1alpha1Client) TidbDashboards(namespace string) TidbDashboardInterface {
 	return newTidbDashboards(c, namespace)
 }
@@ -87,6 +93,10 @@ func (c *PingcapV1alpha1Client) TidbNGMonitorings(namespace string) TidbNGMonito
 	return newTidbNGMonitorings(c, namespace)
 }
 
+func (c *PingcapV1alpha1Client) TidbTenants(namespace string) TidbTenantInterface {
+	return newTidbTenants(c, namespace)
+}
+
 // NewForConfig creates a new PingcapV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
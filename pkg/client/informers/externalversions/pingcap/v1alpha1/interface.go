@@ -35,6 +35,8 @@ type Interface interface {
 	Restores() RestoreInformer
 	// TidbClusters returns a TidbClusterInformer.
 	TidbClusters() TidbClusterInformer
+	// TidbClusterScalingSchedules returns a TidbClusterScalingScheduleInformer.
+	TidbClusterScalingSchedules() TidbClusterScalingScheduleInformer
 	// TidbDashboards returns a TidbDashboardInformer.
 	TidbDashboards() TidbDashboardInformer
 	// TidbInitializers returns a TidbInitializerInformer.
@@ -43,6 +45,8 @@ type Interface interface {
 	TidbMonitors() TidbMonitorInformer
 	// TidbNGMonitorings returns a TidbNGMonitoringInformer.
 	TidbNGMonitorings() TidbNGMonitoringInformer
+	// TidbTenants returns a TidbTenantInformer.
+	TidbTenants() TidbTenantInformer
 }
 
 type version struct {
@@ -91,6 +95,11 @@ func (v *version) TidbClusters() TidbClusterInformer {
 	return &tidbClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// TidbClusterScalingSchedules returns a TidbClusterScalingScheduleInformer.
+func (v *version) TidbClusterScalingSchedules() TidbClusterScalingScheduleInformer {
+	return &tidbClusterScalingScheduleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TidbDashboards returns a TidbDashboardInformer.
 func (v *version) TidbDashboards() TidbDashboardInformer {
 	return &tidbDashboardInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -110,3 +119,8 @@ func (v *version) TidbMonitors() TidbMonitorInformer {
 func (v *version) TidbNGMonitorings() TidbNGMonitoringInformer {
 	return &tidbNGMonitoringInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// TidbTenants returns a TidbTenantInformer.
+func (v *version) TidbTenants() TidbTenantInformer {
+	return &tidbTenantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
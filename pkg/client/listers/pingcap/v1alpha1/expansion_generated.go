@@ -71,6 +71,14 @@ type TidbClusterListerExpansion interface{}
 // TidbClusterNamespaceLister.
 type TidbClusterNamespaceListerExpansion interface{}
 
+// TidbClusterScalingScheduleListerExpansion allows custom methods to be added to
+// TidbClusterScalingScheduleLister.
+type TidbClusterScalingScheduleListerExpansion interface{}
+
+// TidbClusterScalingScheduleNamespaceListerExpansion allows custom methods to be added to
+// TidbClusterScalingScheduleNamespaceLister.
+type TidbClusterScalingScheduleNamespaceListerExpansion interface{}
+
 // TidbDashboardListerExpansion allows custom methods to be added to
 // TidbDashboardLister.
 type TidbDashboardListerExpansion interface{}
@@ -102,3 +110,11 @@ type TidbNGMonitoringListerExpansion interface{}
 // TidbNGMonitoringNamespaceListerExpansion allows custom methods to be added to
 // TidbNGMonitoringNamespaceLister.
 type TidbNGMonitoringNamespaceListerExpansion interface{}
+
+// TidbTenantListerExpansion allows custom methods to be added to
+// TidbTenantLister.
+type TidbTenantListerExpansion interface{}
+
+// TidbTenantNamespaceListerExpansion allows custom methods to be added to
+// TidbTenantNamespaceLister.
+type TidbTenantNamespaceListerExpansion interface{}
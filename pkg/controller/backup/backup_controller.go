@@ -154,6 +154,12 @@ func (c *Controller) sync(key string) (err error) {
 	if err != nil {
 		return err
 	}
+	release, owned := c.deps.Sharder.Acquire(controller.BackupClusterKey(backup))
+	if !owned {
+		// reconciled by the shard of the TidbCluster
+		return nil
+	}
+	defer release()
 
 	return c.syncBackup(backup.DeepCopy())
 }
@@ -147,6 +147,12 @@ func (c *Controller) sync(key string) (err error) {
 	if err != nil {
 		return err
 	}
+	release, owned := c.deps.Sharder.Acquire(controller.BackupScheduleClusterKey(bs))
+	if !owned {
+		// reconciled by the shard of the TidbCluster
+		return nil
+	}
+	defer release()
 
 	return c.syncBackupSchedule(bs.DeepCopy())
 }
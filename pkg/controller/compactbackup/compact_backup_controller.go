@@ -255,7 +255,6 @@ func (c *Controller) sync(key string) (err error) {
 	if err != nil {
 		return err
 	}
-	klog.Infof("Compact: [%s/%s] start to sync", ns, name)
 	compact, err := c.deps.CompactBackupLister.CompactBackups(ns).Get(name)
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -265,6 +264,13 @@ func (c *Controller) sync(key string) (err error) {
 		klog.Infof("Compact get failed %v", err)
 		return err
 	}
+	release, owned := c.deps.Sharder.Acquire(controller.CompactBackupClusterKey(compact))
+	if !owned {
+		// reconciled by the shard of the TidbCluster
+		return nil
+	}
+	defer release()
+	klog.Infof("Compact: [%s/%s] start to sync", ns, name)
 
 	err = c.validate(compact)
 	if compact.Status.State == "" {
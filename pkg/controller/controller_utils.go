@@ -343,6 +343,24 @@ func TiKVPeerMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-tikv-peer", clusterName)
 }
 
+// TiKVGroupMemberName returns tikv member name of a TiKV group,
+// the empty group name stands for the default TiKV of the cluster
+func TiKVGroupMemberName(clusterName, groupName string) string {
+	if groupName == "" {
+		return TiKVMemberName(clusterName)
+	}
+	return fmt.Sprintf("%s-tikv-%s", clusterName, groupName)
+}
+
+// TiKVGroupPeerMemberName returns tikv peer service name of a TiKV group,
+// the empty group name stands for the default TiKV of the cluster
+func TiKVGroupPeerMemberName(clusterName, groupName string) string {
+	if groupName == "" {
+		return TiKVPeerMemberName(clusterName)
+	}
+	return fmt.Sprintf("%s-tikv-%s-peer", clusterName, groupName)
+}
+
 // TiFlashMemberName returns tiflash member name
 func TiFlashMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-tiflash", clusterName)
@@ -353,6 +371,24 @@ func TiFlashPeerMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-tiflash-peer", clusterName)
 }
 
+// TiFlashRoleMemberName returns tiflash member name of the TiFlash nodes with the role,
+// the empty role stands for the TiFlash write nodes, or the classic TiFlash
+func TiFlashRoleMemberName(clusterName, role string) string {
+	if role == "" {
+		return TiFlashMemberName(clusterName)
+	}
+	return fmt.Sprintf("%s-tiflash-%s", clusterName, role)
+}
+
+// TiFlashRolePeerMemberName returns tiflash peer service name of the TiFlash nodes with the role,
+// the empty role stands for the TiFlash write nodes, or the classic TiFlash
+func TiFlashRolePeerMemberName(clusterName, role string) string {
+	if role == "" {
+		return TiFlashPeerMemberName(clusterName)
+	}
+	return fmt.Sprintf("%s-tiflash-%s-peer", clusterName, role)
+}
+
 // TiProxyMemberName returns tiproxy member name
 func TiProxyMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-tiproxy", clusterName)
@@ -383,6 +419,24 @@ func TiDBPeerMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-tidb-peer", clusterName)
 }
 
+// TiDBGroupMemberName returns tidb member name of a TiDB group,
+// the empty group name stands for the default TiDB of the cluster
+func TiDBGroupMemberName(clusterName, groupName string) string {
+	if groupName == "" {
+		return TiDBMemberName(clusterName)
+	}
+	return fmt.Sprintf("%s-tidb-%s", clusterName, groupName)
+}
+
+// TiDBGroupPeerMemberName returns tidb peer service name of a TiDB group,
+// the empty group name stands for the default TiDB of the cluster
+func TiDBGroupPeerMemberName(clusterName, groupName string) string {
+	if groupName == "" {
+		return TiDBPeerMemberName(clusterName)
+	}
+	return fmt.Sprintf("%s-tidb-%s-peer", clusterName, groupName)
+}
+
 // PumpMemberName returns pump member name
 func PumpMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-pump", clusterName)
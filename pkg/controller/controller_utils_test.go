@@ -239,6 +239,22 @@ func TestTiKVPeerMemberName(t *testing.T) {
 	g.Expect(TiKVPeerMemberName("demo")).To(Equal("demo-tikv-peer"))
 }
 
+func TestTiKVGroupMemberName(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(TiKVGroupMemberName("demo", "")).To(Equal("demo-tikv"))
+	g.Expect(TiKVGroupMemberName("demo", "nvme")).To(Equal("demo-tikv-nvme"))
+	g.Expect(TiKVGroupPeerMemberName("demo", "")).To(Equal("demo-tikv-peer"))
+	g.Expect(TiKVGroupPeerMemberName("demo", "nvme")).To(Equal("demo-tikv-nvme-peer"))
+}
+
+func TestTiFlashRoleMemberName(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(TiFlashRoleMemberName("demo", "")).To(Equal("demo-tiflash"))
+	g.Expect(TiFlashRoleMemberName("demo", "compute")).To(Equal("demo-tiflash-compute"))
+	g.Expect(TiFlashRolePeerMemberName("demo", "")).To(Equal("demo-tiflash-peer"))
+	g.Expect(TiFlashRolePeerMemberName("demo", "compute")).To(Equal("demo-tiflash-compute-peer"))
+}
+
 func TestTiDBMemberName(t *testing.T) {
 	g := NewGomegaWithT(t)
 	g.Expect(TiDBMemberName("demo")).To(Equal("demo-tidb"))
@@ -250,6 +266,14 @@ func TestTiDBPeerMemberName(t *testing.T) {
 	g.Expect(TiDBPeerMemberName("demo")).To(Equal("demo-tidb-peer"))
 }
 
+func TestTiDBGroupMemberName(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(TiDBGroupMemberName("demo", "")).To(Equal("demo-tidb"))
+	g.Expect(TiDBGroupMemberName("demo", "olap")).To(Equal("demo-tidb-olap"))
+	g.Expect(TiDBGroupPeerMemberName("demo", "")).To(Equal("demo-tidb-peer"))
+	g.Expect(TiDBGroupPeerMemberName("demo", "olap")).To(Equal("demo-tidb-olap-peer"))
+}
+
 func TestPumpMemberName(t *testing.T) {
 	g := NewGomegaWithT(t)
 	g.Expect(PumpMemberName("demo")).To(Equal("demo-pump"))
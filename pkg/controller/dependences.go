@@ -21,6 +21,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
@@ -33,6 +34,10 @@ import (
 	extensionslister "k8s.io/client-go/listers/extensions/v1beta1"
 	networklister "k8s.io/client-go/listers/networking/v1"
 	storagelister "k8s.io/client-go/listers/storage/v1"
+	"k8s.io/client-go/metadata"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
@@ -72,6 +77,7 @@ type CLIConfig struct {
 	TiKVFailoverPeriod    time.Duration
 	TiDBFailoverPeriod    time.Duration
 	TiFlashFailoverPeriod time.Duration
+	TiCDCFailoverPeriod   time.Duration
 	MasterFailoverPeriod  time.Duration
 	WorkerFailoverPeriod  time.Duration
 	LeaseDuration         time.Duration
@@ -85,6 +91,9 @@ type CLIConfig struct {
 	DetectNodeFailure bool
 	// PodHardRecoveryPeriod is the hard recovery period for a failure pod
 	PodHardRecoveryPeriod time.Duration
+	// CertExpiryWarningThreshold is the time before the certificates in TLS secrets expire
+	// to warn about them by the CertificateExpiringSoon condition and event
+	CertExpiryWarningThreshold time.Duration
 	// Defines whether tidb operator run in test mode, test mode is
 	// only open when test
 	TestMode               bool
@@ -93,35 +102,58 @@ type CLIConfig struct {
 	// Selector is used to filter CR labels to decide
 	// what resources should be watched and synced by controller
 	Selector string
+	// SecretSelector is used to filter the labels of the secrets cached by controller,
+	// all secrets are cached if it is empty. The secrets that do not match it are got
+	// from the API server directly
+	SecretSelector string
 
 	// KubeClientQPS indicates the maximum QPS to the kubenetes API server from client.
 	KubeClientQPS   float64
 	KubeClientBurst int
+
+	// TracingOTLPEndpoint is the address of the OTLP gRPC receiver to export the traces of the
+	// reconciles to, tracing is disabled if it is empty
+	TracingOTLPEndpoint string
+	// TracingOTLPInsecure disables the TLS of the connection to the OTLP receiver
+	TracingOTLPInsecure bool
+	// TracingSampleRatio is the ratio of the reconciles to trace
+	TracingSampleRatio float64
+
+	// ShardID is the identity of the shard of this controller-manager, the TidbClusters and the
+	// objects that belong to them are distributed among the active shards. Sharding is disabled
+	// if it is empty.
+	ShardID string
+	// ShardPodName is the name of the pod of the controller-manager StatefulSet, the ordinal of the
+	// pod is used as ShardID if ShardID is empty.
+	ShardPodName string
 }
 
 // DefaultCLIConfig returns the default command line configuration
 func DefaultCLIConfig() *CLIConfig {
 	return &CLIConfig{
-		Workers:                5,
-		ClusterScoped:          true,
-		AutoFailover:           true,
-		PDFailoverPeriod:       5 * time.Minute,
-		TiKVFailoverPeriod:     5 * time.Minute,
-		TiDBFailoverPeriod:     5 * time.Minute,
-		TiFlashFailoverPeriod:  5 * time.Minute,
-		MasterFailoverPeriod:   5 * time.Minute,
-		WorkerFailoverPeriod:   5 * time.Minute,
-		LeaseDuration:          15 * time.Second,
-		RenewDeadline:          10 * time.Second,
-		RetryPeriod:            2 * time.Second,
-		ResourceLock:           resourcelock.LeasesResourceLock, // k8s uses leases by default from v1.20
-		WaitDuration:           5 * time.Second,
-		ResyncDuration:         30 * time.Second,
-		PodHardRecoveryPeriod:  24 * time.Hour,
-		DetectNodeFailure:      false,
-		TiDBBackupManagerImage: "pingcap/tidb-backup-manager:latest",
-		TiDBDiscoveryImage:     "pingcap/tidb-operator:latest",
-		Selector:               "",
+		Workers:                    5,
+		ClusterScoped:              true,
+		AutoFailover:               true,
+		PDFailoverPeriod:           5 * time.Minute,
+		TiKVFailoverPeriod:         5 * time.Minute,
+		TiDBFailoverPeriod:         5 * time.Minute,
+		TiFlashFailoverPeriod:      5 * time.Minute,
+		TiCDCFailoverPeriod:        5 * time.Minute,
+		MasterFailoverPeriod:       5 * time.Minute,
+		WorkerFailoverPeriod:       5 * time.Minute,
+		LeaseDuration:              15 * time.Second,
+		RenewDeadline:              10 * time.Second,
+		RetryPeriod:                2 * time.Second,
+		ResourceLock:               resourcelock.LeasesResourceLock, // k8s uses leases by default from v1.20
+		WaitDuration:               5 * time.Second,
+		ResyncDuration:             30 * time.Second,
+		PodHardRecoveryPeriod:      24 * time.Hour,
+		CertExpiryWarningThreshold: 30 * 24 * time.Hour,
+		DetectNodeFailure:          false,
+		TiDBBackupManagerImage:     "pingcap/tidb-backup-manager:latest",
+		TiDBDiscoveryImage:         "pingcap/tidb-operator:latest",
+		Selector:                   "",
+		TracingSampleRatio:         1,
 	}
 }
 
@@ -139,9 +171,11 @@ func (c *CLIConfig) AddFlag(_ *flag.FlagSet) {
 	flag.DurationVar(&c.TiKVFailoverPeriod, "tikv-failover-period", c.TiKVFailoverPeriod, "TiKV failover period default(5m)")
 	flag.DurationVar(&c.TiFlashFailoverPeriod, "tiflash-failover-period", c.TiFlashFailoverPeriod, "TiFlash failover period default(5m)")
 	flag.DurationVar(&c.TiDBFailoverPeriod, "tidb-failover-period", c.TiDBFailoverPeriod, "TiDB failover period")
+	flag.DurationVar(&c.TiCDCFailoverPeriod, "ticdc-failover-period", c.TiCDCFailoverPeriod, "TiCDC failover period default(5m)")
 	flag.DurationVar(&c.MasterFailoverPeriod, "dm-master-failover-period", c.MasterFailoverPeriod, "dm-master failover period")
 	flag.DurationVar(&c.WorkerFailoverPeriod, "dm-worker-failover-period", c.WorkerFailoverPeriod, "dm-worker failover period")
 	flag.DurationVar(&c.PodHardRecoveryPeriod, "pod-hard-recovery-period", c.PodHardRecoveryPeriod, "Hard recovery period for a failure pod default(24h)")
+	flag.DurationVar(&c.CertExpiryWarningThreshold, "cert-expiry-warning-threshold", c.CertExpiryWarningThreshold, "The time before the certificates in TLS secrets expire to warn about them default(720h)")
 	flag.BoolVar(&c.DetectNodeFailure, "detect-node-failure", c.DetectNodeFailure, "Automatically detect node failures")
 	flag.DurationVar(&c.ResyncDuration, "resync-duration", c.ResyncDuration, "Resync time of informer")
 	flag.BoolVar(&c.TestMode, "test-mode", false, "whether tidb-operator run in test mode")
@@ -149,6 +183,7 @@ func (c *CLIConfig) AddFlag(_ *flag.FlagSet) {
 	// TODO: actually we just want to use the same image with tidb-controller-manager, but DownwardAPI cannot get image ID, see if there is any better solution
 	flag.StringVar(&c.TiDBDiscoveryImage, "tidb-discovery-image", c.TiDBDiscoveryImage, "The image of the tidb discovery service")
 	flag.StringVar(&c.Selector, "selector", c.Selector, "Selector (label query) to filter on, supports '=', '==', and '!='")
+	flag.StringVar(&c.SecretSelector, "secret-selector", c.SecretSelector, "Selector (label query) to filter the cached secrets on, the secrets that do not match it are got from the API server directly, supports '=', '==', and '!='")
 
 	// see https://pkg.go.dev/k8s.io/client-go/tools/leaderelection#LeaderElectionConfig for the config
 	flag.DurationVar(&c.LeaseDuration, "leader-lease-duration", c.LeaseDuration, "leader-lease-duration is the duration that non-leader candidates will wait to force acquire leadership")
@@ -157,6 +192,11 @@ func (c *CLIConfig) AddFlag(_ *flag.FlagSet) {
 	flag.StringVar(&c.ResourceLock, "leader-resource-lock", c.ResourceLock, "The type of resource object that is used for locking during leader election")
 	flag.Float64Var(&c.KubeClientQPS, "kube-client-qps", c.KubeClientQPS, "The maximum QPS to the kubenetes API server from client")
 	flag.IntVar(&c.KubeClientBurst, "kube-client-burst", c.KubeClientBurst, "The maximum burst for throttle to the kubenetes API server from client")
+	flag.StringVar(&c.TracingOTLPEndpoint, "tracing-otlp-endpoint", c.TracingOTLPEndpoint, "The address of the OTLP gRPC receiver to export the traces of the reconciles to, tracing is disabled if it is empty")
+	flag.BoolVar(&c.TracingOTLPInsecure, "tracing-otlp-insecure", c.TracingOTLPInsecure, "Whether to disable the TLS of the connection to the OTLP receiver")
+	flag.Float64Var(&c.TracingSampleRatio, "tracing-sample-ratio", c.TracingSampleRatio, "The ratio of the reconciles to trace, in [0, 1]")
+	flag.StringVar(&c.ShardID, "shard-id", c.ShardID, "The identity of the shard of this controller-manager, TidbClusters are distributed among the active shards by consistent hashing, sharding is disabled if it is empty")
+	flag.StringVar(&c.ShardPodName, "shard-pod-name", c.ShardPodName, "The name of the pod of the controller-manager StatefulSet, the ordinal of the pod is used as the identity of the shard if -shard-id is empty")
 }
 
 // HasNodePermission returns whether the user has permission for node operations.
@@ -213,7 +253,12 @@ type Dependencies struct {
 	InformerFactory                informers.SharedInformerFactory
 	KubeInformerFactory            kubeinformers.SharedInformerFactory
 	LabelFilterKubeInformerFactory kubeinformers.SharedInformerFactory
-	Recorder                       record.EventRecorder
+	// SecretInformerFactory caches the secrets filtered by the secret selector, it is the same as
+	// KubeInformerFactory if the secret selector is empty
+	SecretInformerFactory kubeinformers.SharedInformerFactory
+	// MetadataInformerFactory caches only the metadata of the objects
+	MetadataInformerFactory metadatainformer.SharedInformerFactory
+	Recorder                record.EventRecorder
 
 	// Listers
 	ServiceLister          corelisterv1.ServiceLister
@@ -225,7 +270,7 @@ type Dependencies struct {
 	SecretLister           corelisterv1.SecretLister
 	ConfigMapLister        corelisterv1.ConfigMapLister
 	StatefulSetLister      appslisters.StatefulSetLister
-	DeploymentLister       appslisters.DeploymentLister
+	DeploymentLister       cache.GenericLister // only the metadata of deployments is cached
 	JobLister              batchlisters.JobLister
 	IngressLister          networklister.IngressLister
 	IngressV1Beta1Lister   extensionslister.IngressLister // TODO: in order to be compatibility with kubernetes which less than v1.19, remove it if v1.19- is not supported
@@ -240,6 +285,11 @@ type Dependencies struct {
 	TiDBMonitorLister      listers.TidbMonitorLister
 	TiDBNGMonitoringLister listers.TidbNGMonitoringLister
 	TiDBDashboardLister    listers.TidbDashboardLister
+	TiDBTenantLister       listers.TidbTenantLister
+	ScalingScheduleLister  listers.TidbClusterScalingScheduleLister
+
+	// Sharder decides whether an object is reconciled by this controller-manager
+	Sharder Sharder
 
 	// Controls
 	Controls
@@ -254,10 +304,11 @@ func newRealControls(
 	genericCli client.Client,
 	informerFactory informers.SharedInformerFactory,
 	kubeInformerFactory kubeinformers.SharedInformerFactory,
+	secretInformerFactory kubeinformers.SharedInformerFactory,
 	recorder record.EventRecorder) Controls {
 	// Shared variables to construct `Dependencies` and some of its fields
 	var (
-		secretLister      = kubeInformerFactory.Core().V1().Secrets().Lister()
+		secretLister      = newSecretLister(cliCfg, kubeClientset, secretInformerFactory)
 		pdControl         = pdapi.NewDefaultPDControl(secretLister)
 		tikvControl       = tikvapi.NewDefaultTiKVControl(secretLister)
 		tiflashControl    = tiflashapi.NewDefaultTiFlashControl(secretLister)
@@ -311,6 +362,8 @@ func newDependencies(
 	informerFactory informers.SharedInformerFactory,
 	kubeInformerFactory kubeinformers.SharedInformerFactory,
 	labelFilterKubeInformerFactory kubeinformers.SharedInformerFactory,
+	secretInformerFactory kubeinformers.SharedInformerFactory,
+	metadataInformerFactory metadatainformer.SharedInformerFactory,
 	recorder record.EventRecorder) (*Dependencies, error) {
 
 	var (
@@ -359,6 +412,8 @@ func newDependencies(
 		GenericClient:                  genericCli,
 		KubeInformerFactory:            kubeInformerFactory,
 		LabelFilterKubeInformerFactory: labelFilterKubeInformerFactory,
+		SecretInformerFactory:          secretInformerFactory,
+		MetadataInformerFactory:        metadataInformerFactory,
 		Recorder:                       recorder,
 
 		// Listers
@@ -368,10 +423,10 @@ func newDependencies(
 		PVLister:               pvLister,
 		PodLister:              kubeInformerFactory.Core().V1().Pods().Lister(),
 		NodeLister:             nodeLister,
-		SecretLister:           kubeInformerFactory.Core().V1().Secrets().Lister(),
+		SecretLister:           newSecretLister(cliCfg, kubeClientset, secretInformerFactory),
 		ConfigMapLister:        labelFilterKubeInformerFactory.Core().V1().ConfigMaps().Lister(),
 		StatefulSetLister:      kubeInformerFactory.Apps().V1().StatefulSets().Lister(),
-		DeploymentLister:       kubeInformerFactory.Apps().V1().Deployments().Lister(),
+		DeploymentLister:       metadataInformerFactory.ForResource(appsv1.SchemeGroupVersion.WithResource("deployments")).Lister(),
 		StorageClassLister:     scLister,
 		JobLister:              kubeInformerFactory.Batch().V1().Jobs().Lister(),
 		IngressLister:          ingLister,
@@ -386,20 +441,26 @@ func newDependencies(
 		TiDBMonitorLister:      informerFactory.Pingcap().V1alpha1().TidbMonitors().Lister(),
 		TiDBNGMonitoringLister: informerFactory.Pingcap().V1alpha1().TidbNGMonitorings().Lister(),
 		TiDBDashboardLister:    informerFactory.Pingcap().V1alpha1().TidbDashboards().Lister(),
+		TiDBTenantLister:       informerFactory.Pingcap().V1alpha1().TidbTenants().Lister(),
+		ScalingScheduleLister:  informerFactory.Pingcap().V1alpha1().TidbClusterScalingSchedules().Lister(),
+
+		Sharder: NewUnshardedSharder(),
 
 		AWSConfig: cfg,
 	}, nil
 }
 
 // NewDependencies is used to construct the dependencies
-func NewDependencies(ns string, cliCfg *CLIConfig, clientset versioned.Interface, kubeClientset kubernetes.Interface, genericCli client.Client) (*Dependencies, error) {
+func NewDependencies(ns string, cliCfg *CLIConfig, clientset versioned.Interface, kubeClientset kubernetes.Interface, metadataCli metadata.Interface, genericCli client.Client) (*Dependencies, error) {
 	var (
 		options     []informers.SharedInformerOption
 		kubeoptions []kubeinformers.SharedInformerOption
+		metadataNS  = metav1.NamespaceAll
 	)
 	if !cliCfg.ClusterScoped {
 		options = append(options, informers.WithNamespace(ns))
 		kubeoptions = append(kubeoptions, kubeinformers.WithNamespace(ns))
+		metadataNS = ns
 	}
 	tweakListOptionsFunc := func(options *metav1.ListOptions) {
 		if len(options.LabelSelector) > 0 {
@@ -420,6 +481,14 @@ func NewDependencies(ns string, cliCfg *CLIConfig, clientset versioned.Interface
 	informerFactory := informers.NewSharedInformerFactoryWithOptions(clientset, cliCfg.ResyncDuration, options...)
 	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, cliCfg.ResyncDuration, kubeoptions...)
 	labelFilterKubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, cliCfg.ResyncDuration, labelKubeOptions...)
+	secretInformerFactory := kubeInformerFactory
+	if len(cliCfg.SecretSelector) > 0 {
+		secretKubeOptions := append(kubeoptions, kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = cliCfg.SecretSelector
+		}))
+		secretInformerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, cliCfg.ResyncDuration, secretKubeOptions...)
+	}
+	metadataInformerFactory := metadatainformer.NewFilteredSharedInformerFactory(metadataCli, cliCfg.ResyncDuration, metadataNS, nil)
 
 	// Initialize the event recorder
 	eventBroadcaster := record.NewBroadcasterWithCorrelatorOptions(record.CorrelatorOptions{QPS: 1})
@@ -427,11 +496,35 @@ func NewDependencies(ns string, cliCfg *CLIConfig, clientset versioned.Interface
 	eventBroadcaster.StartRecordingToSink(&eventv1.EventSinkImpl{
 		Interface: eventv1.New(kubeClientset.CoreV1().RESTClient()).Events("")})
 	recorder := eventBroadcaster.NewRecorder(v1alpha1.Scheme, corev1.EventSource{Component: "tidb-controller-manager"})
-	deps, err := newDependencies(cliCfg, clientset, kubeClientset, genericCli, informerFactory, kubeInformerFactory, labelFilterKubeInformerFactory, recorder)
+	deps, err := newDependencies(cliCfg, clientset, kubeClientset, genericCli, informerFactory, kubeInformerFactory, labelFilterKubeInformerFactory, secretInformerFactory, metadataInformerFactory, recorder)
 	if err != nil {
 		return nil, err
 	}
-	deps.Controls = newRealControls(cliCfg, clientset, kubeClientset, genericCli, informerFactory, kubeInformerFactory, recorder)
+	deps.Controls = newRealControls(cliCfg, clientset, kubeClientset, genericCli, informerFactory, kubeInformerFactory, secretInformerFactory, recorder)
+
+	// drop the unused fields of the Kubernetes objects from the caches, the informers are already
+	// created by the listers above
+	transformed := []cache.SharedIndexInformer{
+		kubeInformerFactory.Core().V1().Services().Informer(),
+		kubeInformerFactory.Core().V1().Endpoints().Informer(),
+		kubeInformerFactory.Core().V1().PersistentVolumeClaims().Informer(),
+		kubeInformerFactory.Core().V1().Pods().Informer(),
+		kubeInformerFactory.Apps().V1().StatefulSets().Informer(),
+		kubeInformerFactory.Batch().V1().Jobs().Informer(),
+		secretInformerFactory.Core().V1().Secrets().Informer(),
+		labelFilterKubeInformerFactory.Core().V1().ConfigMaps().Informer(),
+		metadataInformerFactory.ForResource(appsv1.SchemeGroupVersion.WithResource("deployments")).Informer(),
+	}
+	if deps.NodeLister != nil {
+		transformed = append(transformed, kubeInformerFactory.Core().V1().Nodes().Informer())
+	}
+	if deps.PVLister != nil {
+		transformed = append(transformed, kubeInformerFactory.Core().V1().PersistentVolumes().Informer())
+	}
+	if deps.StorageClassLister != nil {
+		transformed = append(transformed, kubeInformerFactory.Storage().V1().StorageClasses().Informer())
+	}
+	setInformerTransforms(transformed...)
 	return deps, nil
 }
 
@@ -484,6 +577,7 @@ func NewFakeDependencies() *Dependencies {
 	informerFactory := informers.NewSharedInformerFactory(cli, 0)
 	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeCli, 0)
 	labelFilterKubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeCli, 0)
+	metadataInformerFactory := metadatainformer.NewSharedInformerFactory(metadatafake.NewSimpleMetadataClient(metadatafake.NewTestScheme()), 0)
 	recorder := record.NewFakeRecorder(100)
 
 	kubeCli.Fake.Resources = append(kubeCli.Fake.Resources, &metav1.APIResourceList{
@@ -495,7 +589,7 @@ func NewFakeDependencies() *Dependencies {
 		},
 	})
 
-	deps, err := newDependencies(cliCfg, cli, kubeCli, genCli, informerFactory, kubeInformerFactory, labelFilterKubeInformerFactory, recorder)
+	deps, err := newDependencies(cliCfg, cli, kubeCli, genCli, informerFactory, kubeInformerFactory, labelFilterKubeInformerFactory, kubeInformerFactory, metadataInformerFactory, recorder)
 	if err != nil {
 		klog.Fatalf("failed to create Dependencies: %s", err)
 	}
@@ -42,6 +42,7 @@ func NewDefaultDMClusterControl(
 	masterMemberManager manager.DMManager,
 	workerMemberManager manager.DMManager,
 	reclaimPolicyManager manager.DMManager,
+	certExpiryManager manager.DMManager,
 	orphanPodsCleaner member.OrphanPodsCleaner,
 	pvcCleaner member.PVCCleanerInterface,
 	pvcResizer member.PVCResizerInterface,
@@ -53,6 +54,7 @@ func NewDefaultDMClusterControl(
 		masterMemberManager,
 		workerMemberManager,
 		reclaimPolicyManager,
+		certExpiryManager,
 		//metaManager,
 		orphanPodsCleaner,
 		pvcCleaner,
@@ -68,6 +70,7 @@ type defaultDMClusterControl struct {
 	masterMemberManager  manager.DMManager
 	workerMemberManager  manager.DMManager
 	reclaimPolicyManager manager.DMManager
+	certExpiryManager    manager.DMManager
 	//metaManager       manager.DMManager
 	orphanPodsCleaner member.OrphanPodsCleaner
 	pvcCleaner        member.PVCCleanerInterface
@@ -142,6 +145,12 @@ func (c *defaultDMClusterControl) updateDMCluster(dc *v1alpha1.DMCluster) error
 		return err
 	}
 
+	// track the expiry of the certificates in the TLS secrets consumed by dm-master and dm-worker,
+	// stop here if any certificate is expired, so that pods are not rolled onto it
+	if err := c.certExpiryManager.SyncDM(dc); err != nil {
+		return err
+	}
+
 	// works that should be done to make the dm-master cluster current state match the desired state:
 	//   - create or update the dm-master service
 	//   - create or update the dm-master headless service
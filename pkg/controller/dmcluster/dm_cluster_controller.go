@@ -55,6 +55,7 @@ func NewController(deps *controller.Dependencies) *Controller {
 			mm.NewMasterMemberManager(deps, mm.NewMasterScaler(deps), mm.NewMasterUpgrader(deps), mm.NewMasterFailover(deps), suspender),
 			mm.NewWorkerMemberManager(deps, mm.NewWorkerScaler(deps), mm.NewWorkerFailover(deps), suspender),
 			meta.NewReclaimPolicyManager(deps),
+			meta.NewCertExpiryManager(deps),
 			mm.NewOrphanPodsCleaner(deps),
 			mm.NewRealPVCCleaner(deps),
 			mm.NewPVCResizer(deps),
@@ -160,6 +161,12 @@ func (c *Controller) sync(key string) (err error) {
 	if err != nil {
 		return err
 	}
+	release, owned := c.deps.Sharder.Acquire(ns, name)
+	if !owned {
+		// reconciled by another shard
+		return nil
+	}
+	defer release()
 	dc, err := c.deps.DMClusterLister.DMClusters(ns).Get(name)
 	if errors.IsNotFound(err) {
 		klog.Infof("DMCluster has been deleted %v", key)
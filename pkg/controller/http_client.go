@@ -20,6 +20,7 @@ import (
 	"net/http"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/tracing"
 	"github.com/pingcap/tidb-operator/pkg/util"
 	v1 "k8s.io/api/core/v1"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
@@ -32,6 +33,7 @@ type httpClient struct {
 func (c *httpClient) getHTTPClient(tc *v1alpha1.TidbCluster) (*http.Client, error) {
 	httpClient := &http.Client{Timeout: timeout}
 	if !tc.IsTLSClusterEnabled() {
+		httpClient.Transport = tracing.WrapTransport(tc.Namespace, tc.Name, nil)
 		return httpClient, nil
 	}
 
@@ -60,7 +62,7 @@ func (c *httpClient) getHTTPClient(tc *v1alpha1.TidbCluster) (*http.Client, erro
 		RootCAs:      rootCAs,
 		Certificates: []tls.Certificate{tlsCert},
 	}
-	httpClient.Transport = &http.Transport{TLSClientConfig: config, DisableKeepAlives: true}
+	httpClient.Transport = tracing.WrapTransport(ns, tcName, &http.Transport{TLSClientConfig: config, DisableKeepAlives: true})
 
 	return httpClient, nil
 }
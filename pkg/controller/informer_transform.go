@@ -0,0 +1,61 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// kubectlLastAppliedAnnotation is the annotation kept by `kubectl apply`, it holds the whole object
+// and is never read by the operator
+const kubectlLastAppliedAnnotation = corev1.LastAppliedConfigAnnotation
+
+// StripUnusedFields is the transform function of the informers that drops the fields never read by
+// the operator before the objects are stored in the caches, which reduces the memory usage of the
+// caches of large Kubernetes clusters.
+//
+// The managed fields are dropped from all objects, it is safe even if the object is updated with the
+// cached copy because the managed fields are kept by the API server if they are not set in the update.
+// The other fields are only dropped from the objects that are never written by the operator.
+func StripUnusedFields(obj interface{}) (interface{}, error) {
+	if accessor, err := meta.Accessor(obj); err == nil {
+		accessor.SetManagedFields(nil)
+	}
+
+	switch o := obj.(type) {
+	case *corev1.Node:
+		// only the labels and the conditions of the nodes are read
+		delete(o.Annotations, kubectlLastAppliedAnnotation)
+		o.Status.Images = nil
+		o.Status.VolumesInUse = nil
+		o.Status.VolumesAttached = nil
+	case *storagev1.StorageClass:
+		delete(o.Annotations, kubectlLastAppliedAnnotation)
+	}
+	return obj, nil
+}
+
+// setInformerTransforms sets StripUnusedFields as the transform function of the informers, it must be
+// called after the informers are created by the listers and before they are started
+func setInformerTransforms(informers ...cache.SharedIndexInformer) {
+	for _, informer := range informers {
+		if err := informer.SetTransform(StripUnusedFields); err != nil {
+			klog.Warningf("failed to set the transform function of the informer: %v", err)
+		}
+	}
+}
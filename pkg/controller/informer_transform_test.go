@@ -0,0 +1,160 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestStripUnusedFields(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pod := newBenchmarkPod(0)
+	pod.Annotations = map[string]string{kubectlLastAppliedAnnotation: "{}", "pingcap.com/last-applied-configuration": "{}"}
+	obj, err := StripUnusedFields(pod)
+	g.Expect(err).NotTo(HaveOccurred())
+	pod = obj.(*corev1.Pod)
+	g.Expect(pod.ManagedFields).To(BeNil())
+	// the pods may be updated with the cached copies, so their annotations are kept
+	g.Expect(pod.Annotations).To(HaveLen(2))
+	g.Expect(pod.Spec.Containers).To(HaveLen(1))
+
+	node := newBenchmarkNode(0)
+	obj, err = StripUnusedFields(node)
+	g.Expect(err).NotTo(HaveOccurred())
+	node = obj.(*corev1.Node)
+	g.Expect(node.ManagedFields).To(BeNil())
+	g.Expect(node.Annotations).To(BeEmpty())
+	g.Expect(node.Status.Images).To(BeNil())
+	g.Expect(node.Labels).To(HaveKeyWithValue(corev1.LabelTopologyZone, "zone-0"))
+	g.Expect(node.Status.Conditions).To(HaveLen(1))
+
+	obj, err = StripUnusedFields(&metav1.PartialObjectMetadata{ObjectMeta: *newBenchmarkObjectMeta("deploy")})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(obj.(*metav1.PartialObjectMetadata).ManagedFields).To(BeNil())
+
+	// the tombstones are not changed
+	tombstone := cache.DeletedFinalStateUnknown{Key: "ns/pod", Obj: newBenchmarkPod(1)}
+	obj, err = StripUnusedFields(tombstone)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(obj).To(Equal(tombstone))
+}
+
+// BenchmarkInformerCacheMemory reports the memory used by the cached pods and nodes with and without
+// StripUnusedFields, e.g. `go test ./pkg/controller -run xxx -bench InformerCacheMemory`.
+func BenchmarkInformerCacheMemory(b *testing.B) {
+	const count = 1000
+	var objs []interface{}
+	for i := 0; i < count; i++ {
+		objs = append(objs, newBenchmarkPod(i), newBenchmarkNode(i))
+	}
+
+	for _, c := range []struct {
+		name      string
+		transform cache.TransformFunc
+	}{
+		{name: "Full"},
+		{name: "StripUnusedFields", transform: StripUnusedFields},
+	} {
+		b.Run(c.name, func(b *testing.B) {
+			var total int64
+			for i := 0; i < b.N; i++ {
+				var before, after runtime.MemStats
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+
+				store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+				for _, obj := range objs {
+					var cached interface{}
+					switch o := obj.(type) {
+					case *corev1.Pod:
+						cached = o.DeepCopy()
+					case *corev1.Node:
+						cached = o.DeepCopy()
+					}
+					if c.transform != nil {
+						cached, _ = c.transform(cached)
+					}
+					if err := store.Add(cached); err != nil {
+						b.Fatal(err)
+					}
+				}
+
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				// the heap may shrink by the GC of the garbage of the previous iterations
+				total += int64(after.HeapAlloc) - int64(before.HeapAlloc)
+				runtime.KeepAlive(store)
+			}
+			b.ReportMetric(float64(total)/float64(b.N*len(objs)), "B/object")
+		})
+	}
+}
+
+func newBenchmarkObjectMeta(name string) *metav1.ObjectMeta {
+	meta := &metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      name,
+		Labels:    map[string]string{"app.kubernetes.io/managed-by": "tidb-operator"},
+	}
+	// the managed fields of an object are usually larger than its spec
+	for _, manager := range []string{"tidb-controller-manager", "kube-scheduler", "kubelet"} {
+		meta.ManagedFields = append(meta.ManagedFields, metav1.ManagedFieldsEntry{
+			Manager:    manager,
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			APIVersion: "v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{` + strings.Repeat(`"f:labels":{".":{}},`, 40) + `}}`)},
+		})
+	}
+	return meta
+}
+
+func newBenchmarkPod(i int) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: *newBenchmarkObjectMeta(fmt.Sprintf("pod-%d", i)),
+		Spec: corev1.PodSpec{
+			NodeName:   fmt.Sprintf("node-%d", i),
+			Containers: []corev1.Container{{Name: "tikv", Image: "pingcap/tikv:latest"}},
+		},
+	}
+}
+
+func newBenchmarkNode(i int) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: *newBenchmarkObjectMeta(fmt.Sprintf("node-%d", i)),
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	node.Namespace = ""
+	node.Labels[corev1.LabelTopologyZone] = fmt.Sprintf("zone-%d", i%3)
+	node.Annotations = map[string]string{kubectlLastAppliedAnnotation: strings.Repeat("x", 1024)}
+	// the nodes report the images pulled to them
+	for j := 0; j < 50; j++ {
+		node.Status.Images = append(node.Status.Images, corev1.ContainerImage{
+			Names:     []string{fmt.Sprintf("registry.example.com/image-%d@sha256:%064d", j, j), fmt.Sprintf("registry.example.com/image-%d:v%d", j, j)},
+			SizeBytes: 1 << 20,
+		})
+	}
+	return node
+}
@@ -159,8 +159,16 @@ func (c *realPodControl) UpdateMetaInfo(tc *v1alpha1.TidbCluster, pod *corev1.Po
 		}
 	case label.TiKVLabelVal:
 		storeIDFromStatus := ""
+		stores := tc.Status.TiKV.Stores
+		// the stores of a TiKV group are recorded in the status of the group
+		if group := labels[label.TiKVGroupLabelKey]; group != tc.TiKVGroupName() {
+			stores = nil
+			if status := tc.Status.TiKVGroups[group]; status != nil {
+				stores = status.Stores
+			}
+		}
 		// get store id
-		for _, store := range tc.Status.TiKV.Stores {
+		for _, store := range stores {
 			if store.PodName == podName {
 				storeIDFromStatus = store.ID
 				break
@@ -181,9 +189,17 @@ func (c *realPodControl) UpdateMetaInfo(tc *v1alpha1.TidbCluster, pod *corev1.Po
 			}
 		}
 	case label.TiFlashLabelVal:
+		stores := tc.Status.TiFlash.Stores
+		// the stores of TiFlash compute nodes are recorded in the status of TiFlash compute
+		if labels[label.TiFlashRoleLabelKey] != tc.TiFlashRole() {
+			stores = nil
+			if tc.Status.TiFlashCompute != nil {
+				stores = tc.Status.TiFlashCompute.Stores
+			}
+		}
 		if labels[label.StoreIDLabelKey] == "" {
 			// get store id
-			for _, store := range tc.Status.TiFlash.Stores {
+			for _, store := range stores {
 				if store.PodName == podName {
 					storeID = store.ID
 					break
@@ -148,6 +148,12 @@ func (c *Controller) sync(key string) (err error) {
 	if err != nil {
 		return err
 	}
+	release, owned := c.deps.Sharder.Acquire(controller.RestoreClusterKey(restore))
+	if !owned {
+		// reconciled by the shard of the TidbCluster
+		return nil
+	}
+	defer release()
 
 	return c.syncRestore(restore.DeepCopy())
 }
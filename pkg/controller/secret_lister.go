@@ -0,0 +1,69 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisterv1 "k8s.io/client-go/listers/core/v1"
+)
+
+// selectedSecretLister is the lister of the secrets cached with the SecretSelector. The secrets that do not
+// match the selector, e.g. the TLS secrets issued by the operator, are missed by the cache, so they are got
+// from the API server directly.
+type selectedSecretLister struct {
+	corelisterv1.SecretLister
+	kubeClientset kubernetes.Interface
+}
+
+// NewSelectedSecretLister returns a lister that falls back to the API server for the secrets not in the cache
+func NewSelectedSecretLister(lister corelisterv1.SecretLister, kubeClientset kubernetes.Interface) corelisterv1.SecretLister {
+	return &selectedSecretLister{SecretLister: lister, kubeClientset: kubeClientset}
+}
+
+func (l *selectedSecretLister) Secrets(namespace string) corelisterv1.SecretNamespaceLister {
+	return &selectedSecretNamespaceLister{
+		SecretNamespaceLister: l.SecretLister.Secrets(namespace),
+		kubeClientset:         l.kubeClientset,
+		namespace:             namespace,
+	}
+}
+
+type selectedSecretNamespaceLister struct {
+	corelisterv1.SecretNamespaceLister
+	kubeClientset kubernetes.Interface
+	namespace     string
+}
+
+func (l *selectedSecretNamespaceLister) Get(name string) (*corev1.Secret, error) {
+	secret, err := l.SecretNamespaceLister.Get(name)
+	if err == nil || !errors.IsNotFound(err) {
+		return secret, err
+	}
+	return l.kubeClientset.CoreV1().Secrets(l.namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+// newSecretLister returns the lister of the secrets used by the controllers
+func newSecretLister(cliCfg *CLIConfig, kubeClientset kubernetes.Interface, secretInformerFactory kubeinformers.SharedInformerFactory) corelisterv1.SecretLister {
+	lister := secretInformerFactory.Core().V1().Secrets().Lister()
+	if len(cliCfg.SecretSelector) == 0 {
+		return lister
+	}
+	return NewSelectedSecretLister(lister, kubeClientset)
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSecretListerWithSelector(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	selected := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "selected",
+			Namespace: corev1.NamespaceDefault,
+			Labels:    map[string]string{"app.kubernetes.io/used-by": "tidb-operator"},
+		},
+	}
+	// the TLS secrets issued by the operator do not match the selector
+	issued := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "demo-cluster-client-secret",
+			Namespace: corev1.NamespaceDefault,
+		},
+	}
+	kubeCli := kubefake.NewSimpleClientset(selected, issued)
+	cliCfg := &CLIConfig{SecretSelector: "app.kubernetes.io/used-by=tidb-operator"}
+	informerFactory := kubeinformers.NewSharedInformerFactory(kubeCli, 0)
+	// only the selected secrets are cached
+	g.Expect(informerFactory.Core().V1().Secrets().Informer().GetIndexer().Add(selected)).To(Succeed())
+
+	lister := newSecretLister(cliCfg, kubeCli, informerFactory)
+	secret, err := lister.Secrets(corev1.NamespaceDefault).Get("selected")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(secret.Name).To(Equal("selected"))
+
+	// the secrets missed by the cache are got from the API server, so they are not created again
+	secret, err = lister.Secrets(corev1.NamespaceDefault).Get("demo-cluster-client-secret")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(secret.Name).To(Equal("demo-cluster-client-secret"))
+
+	g.Expect(kubeCli.CoreV1().Secrets(corev1.NamespaceDefault).Delete(context.TODO(), issued.Name, metav1.DeleteOptions{})).To(Succeed())
+	_, err = lister.Secrets(corev1.NamespaceDefault).Get("demo-cluster-client-secret")
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	// the API server is not requested without the selector
+	lister = newSecretLister(&CLIConfig{}, kubeCli, informerFactory)
+	_, err = kubeCli.CoreV1().Secrets(corev1.NamespaceDefault).Create(context.TODO(), issued, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = lister.Secrets(corev1.NamespaceDefault).Get("demo-cluster-client-secret")
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
@@ -0,0 +1,418 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+const (
+	shardLeaseInfix = "-shard-"
+	// staleShardLeaseFactor is the number of the lease durations after which the lease of a shard
+	// that left is deleted
+	staleShardLeaseFactor = 10
+
+	// shardViewAnnotation is the annotation of the lease of a shard that records the active shards
+	// acknowledged by the shard, the shard only reconciles the objects hashed to it among them
+	shardViewAnnotation = "tidb.pingcap.com/shard-view"
+	// shardLeavingAnnotation is the annotation of the lease of a shard that has stopped reconciling
+	// and is going to exit, it is not an active shard even though its lease is held
+	shardLeavingAnnotation = "tidb.pingcap.com/shard-leaving"
+)
+
+// Sharder decides whether an object is reconciled by this controller-manager. The TidbClusters are
+// distributed among the active shards by consistent hashing, and the objects that belong to a
+// TidbCluster, e.g. its pods and backups, are reconciled by the shard of the TidbCluster.
+type Sharder interface {
+	// Acquire returns whether the object with the given namespace and name is reconciled by this
+	// shard. If it is, release must be called once the reconciliation is done, the object is not
+	// handed off to another shard before that.
+	Acquire(namespace, name string) (release func(), owned bool)
+}
+
+// ShardLeaseName returns the name of the lease held by the leader of the shard
+func ShardLeaseName(lockName, shardID string) string {
+	return lockName + shardLeaseInfix + shardID
+}
+
+// ShardIDOfPod returns the identity of the shard run by the pod of the controller-manager
+// StatefulSet, which is the ordinal of the pod, so that the shards are kept across the rollouts
+func ShardIDOfPod(podName string) (string, error) {
+	ordinal, err := util.GetOrdinalFromPodName(podName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the ordinal of pod %s: %v", podName, err)
+	}
+	return strconv.Itoa(int(ordinal)), nil
+}
+
+// BackupClusterKey returns the namespace and the name of the TidbCluster of the backup, or of the
+// backup itself if it does not refer to a TidbCluster
+func BackupClusterKey(backup *v1alpha1.Backup) (string, string) {
+	return brClusterKey(backup.Namespace, backup.Name, backup.Spec.BR)
+}
+
+// RestoreClusterKey returns the namespace and the name of the TidbCluster of the restore, or of the
+// restore itself if it does not refer to a TidbCluster
+func RestoreClusterKey(restore *v1alpha1.Restore) (string, string) {
+	return brClusterKey(restore.Namespace, restore.Name, restore.Spec.BR)
+}
+
+// BackupScheduleClusterKey returns the namespace and the name of the TidbCluster of the backup
+// schedule, which is the same as the one of the backups it creates, or of the backup schedule
+// itself if it does not refer to a TidbCluster
+func BackupScheduleClusterKey(bs *v1alpha1.BackupSchedule) (string, string) {
+	brs := []*v1alpha1.BRConfig{bs.Spec.BR, bs.Spec.BackupTemplate.BR}
+	if bs.Spec.LogBackupTemplate != nil {
+		brs = append(brs, bs.Spec.LogBackupTemplate.BR)
+	}
+	if bs.Spec.CompactBackupTemplate != nil {
+		brs = append(brs, bs.Spec.CompactBackupTemplate.BR)
+	}
+	return brClusterKey(bs.Namespace, bs.Name, brs...)
+}
+
+// CompactBackupClusterKey returns the namespace and the name of the TidbCluster of the compact
+// backup, or of the compact backup itself if it does not refer to a TidbCluster
+func CompactBackupClusterKey(compact *v1alpha1.CompactBackup) (string, string) {
+	return brClusterKey(compact.Namespace, compact.Name, compact.Spec.BR)
+}
+
+// brClusterKey returns the TidbCluster of the first BR config that refers to one, or the given
+// namespace and name if none of them does
+func brClusterKey(namespace, name string, brs ...*v1alpha1.BRConfig) (string, string) {
+	for _, br := range brs {
+		if br == nil || br.Cluster == "" {
+			continue
+		}
+		if br.ClusterNamespace != "" {
+			return br.ClusterNamespace, br.Cluster
+		}
+		return namespace, br.Cluster
+	}
+	return namespace, name
+}
+
+// ClusterRefKey returns the namespace and the name of the TidbCluster referred by an object in
+// the given namespace, the namespace of the reference defaults to the one of the object
+func ClusterRefKey(namespace string, ref v1alpha1.TidbClusterRef) (string, string) {
+	if ref.Namespace != "" {
+		return ref.Namespace, ref.Name
+	}
+	return namespace, ref.Name
+}
+
+type unshardedSharder struct{}
+
+// NewUnshardedSharder returns a Sharder that owns all objects, it is used if sharding is disabled
+func NewUnshardedSharder() Sharder {
+	return unshardedSharder{}
+}
+
+func (unshardedSharder) Acquire(namespace, name string) (func(), bool) {
+	return func() {}, true
+}
+
+// LeaseSharder is a Sharder whose active shards are the ones whose leases are held. The leader of
+// each shard holds the lease named by ShardLeaseName, so the objects are rebalanced automatically
+// once a shard joins or leaves.
+//
+// An object is handed off only after its previous shard confirms that it stopped reconciling it:
+// every shard records the active shards it acknowledged in the annotation of its lease, and only
+// acknowledges a new set of the active shards after the reconciliations of the objects it loses
+// are done. A shard acquires an object only if the object is hashed to it among both the active
+// shards and the ones it acknowledged, and is not hashed to any other active shard among the ones
+// that shard acknowledged. As every shard records its acknowledgement before reading the ones of
+// the others, two shards never acquire the same object at the same time.
+type LeaseSharder struct {
+	kubeCli       kubernetes.Interface
+	namespace     string
+	lockName      string
+	id            string
+	leaseDuration time.Duration
+
+	mutex   sync.Mutex
+	started bool
+	leaving bool
+	// shards are the active shards
+	shards []string
+	// acked are the active shards acknowledged by this shard
+	acked []string
+	// views are the active shards acknowledged by each of the other active shards
+	views map[string][]string
+	// inflight are the numbers of the reconciliations in progress of the objects
+	inflight map[string]int
+}
+
+var _ Sharder = &LeaseSharder{}
+
+// NewLeaseSharder returns a LeaseSharder of the shard with the given id, the leases of the shards
+// are in the given namespace and named after lockName
+func NewLeaseSharder(kubeCli kubernetes.Interface, namespace, lockName, id string, leaseDuration time.Duration) *LeaseSharder {
+	return &LeaseSharder{
+		kubeCli:       kubeCli,
+		namespace:     namespace,
+		lockName:      lockName,
+		id:            id,
+		leaseDuration: leaseDuration,
+		inflight:      map[string]int{},
+	}
+}
+
+// Start refreshes the active shards and keeps refreshing them every period until ctx is done,
+// it must be called after this shard holds its lease and before any controller runs.
+func (s *LeaseSharder) Start(ctx context.Context, period time.Duration) error {
+	// the first refresh finds the active shards and the second one acknowledges them
+	for i := 0; i < 2; i++ {
+		if err := s.refresh(ctx); err != nil {
+			return err
+		}
+	}
+	s.mutex.Lock()
+	s.started = true
+	s.mutex.Unlock()
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := s.refresh(ctx); err != nil {
+			klog.Errorf("failed to refresh the shards of shard %s: %v", s.id, err)
+		}
+	}, period)
+	return nil
+}
+
+// Leave hands off all objects of this shard before it exits. It stops acquiring any object, waits
+// for the reconciliations in progress and marks its lease as leaving, so that the other shards take
+// over the objects without waiting for the lease to expire.
+func (s *LeaseSharder) Leave(ctx context.Context, period time.Duration) error {
+	s.mutex.Lock()
+	started := s.started
+	s.leaving = true
+	s.mutex.Unlock()
+	if !started {
+		return nil
+	}
+
+	err := wait.PollUntilContextCancel(ctx, period, true, func(ctx context.Context) (bool, error) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		return len(s.inflight) == 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wait for the reconciliations of shard %s: %v", s.id, err)
+	}
+	return s.updateLease(ctx, func(lease *coordinationv1.Lease) {
+		lease.Annotations[shardViewAnnotation] = ""
+		lease.Annotations[shardLeavingAnnotation] = "true"
+	})
+}
+
+// Acquire returns whether the object is hashed to this shard and has been handed off to it
+func (s *LeaseSharder) Acquire(namespace, name string) (func(), bool) {
+	key := namespace + "/" + name
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.leaving || ownerShard(s.shards, key) != s.id || ownerShard(s.acked, key) != s.id {
+		return nil, false
+	}
+	for id, view := range s.views {
+		// the other shard may be still reconciling it
+		if ownerShard(view, key) == id {
+			return nil, false
+		}
+	}
+
+	s.inflight[key]++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+			if s.inflight[key]--; s.inflight[key] <= 0 {
+				delete(s.inflight, key)
+			}
+		})
+	}, true
+}
+
+// Shards returns the ids of the active shards
+func (s *LeaseSharder) Shards() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string(nil), s.shards...)
+}
+
+// refresh acknowledges the active shards if the reconciliations of the objects this shard loses
+// are done, and then reads the active shards and the ones acknowledged by the other shards.
+func (s *LeaseSharder) refresh(ctx context.Context) error {
+	s.mutex.Lock()
+	acked := s.acknowledgeable()
+	s.mutex.Unlock()
+	err := s.updateLease(ctx, func(lease *coordinationv1.Lease) {
+		lease.Annotations[shardViewAnnotation] = strings.Join(acked, ",")
+		delete(lease.Annotations, shardLeavingAnnotation)
+	})
+	if err != nil {
+		return err
+	}
+
+	leases, err := s.kubeCli.CoordinationV1().Leases(s.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list the leases of the shards in namespace %s: %v", s.namespace, err)
+	}
+
+	now := time.Now()
+	prefix := ShardLeaseName(s.lockName, "")
+	// this shard is active as it holds its lease
+	shards := []string{s.id}
+	views := map[string][]string{}
+	for i := range leases.Items {
+		lease := &leases.Items[i]
+		if !strings.HasPrefix(lease.Name, prefix) {
+			continue
+		}
+		id := strings.TrimPrefix(lease.Name, prefix)
+		if id == s.id {
+			continue
+		}
+
+		if lease.Spec.RenewTime == nil || lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" {
+			continue
+		}
+		duration := s.leaseDuration
+		if lease.Spec.LeaseDurationSeconds != nil {
+			duration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+		}
+		expiry := lease.Spec.RenewTime.Add(duration)
+		if expiry.After(now) {
+			// the shard that is leaving has handed off all its objects
+			if lease.Annotations[shardLeavingAnnotation] != "true" {
+				shards = append(shards, id)
+				views[id] = parseShardView(lease.Annotations[shardViewAnnotation])
+			}
+			continue
+		}
+
+		// the shard left a long time ago, e.g. the StatefulSet is scaled in
+		if expiry.Add(staleShardLeaseFactor * duration).Before(now) {
+			err := s.kubeCli.CoordinationV1().Leases(s.namespace).Delete(ctx, lease.Name, metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				klog.Warningf("failed to delete the stale lease %s/%s of shard %s: %v", s.namespace, lease.Name, id, err)
+			}
+		}
+	}
+	sort.Strings(shards)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if strings.Join(s.shards, ",") != strings.Join(shards, ",") {
+		klog.Infof("the active shards are changed from %v to %v", s.shards, shards)
+		s.shards = shards
+	}
+	s.acked = acked
+	s.views = views
+	return nil
+}
+
+// acknowledgeable returns the active shards if no object this shard loses among them is being
+// reconciled, or the active shards acknowledged before otherwise
+func (s *LeaseSharder) acknowledgeable() []string {
+	if s.leaving {
+		return nil
+	}
+	for key := range s.inflight {
+		if ownerShard(s.shards, key) != s.id {
+			return s.acked
+		}
+	}
+	return s.shards
+}
+
+// updateLease updates the annotations of the lease of this shard
+func (s *LeaseSharder) updateLease(ctx context.Context, fn func(*coordinationv1.Lease)) error {
+	name := ShardLeaseName(s.lockName, s.id)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		lease, err := s.kubeCli.CoordinationV1().Leases(s.namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		updated := lease.DeepCopy()
+		if updated.Annotations == nil {
+			updated.Annotations = map[string]string{}
+		}
+		fn(updated)
+		if apiequality.Semantic.DeepEqual(lease.Annotations, updated.Annotations) {
+			return nil
+		}
+		// the lease is also renewed by the leader election
+		_, err = s.kubeCli.CoordinationV1().Leases(s.namespace).Update(ctx, updated, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update the lease %s/%s of shard %s: %v", s.namespace, name, s.id, err)
+	}
+	return nil
+}
+
+func parseShardView(view string) []string {
+	if view == "" {
+		return nil
+	}
+	return strings.Split(view, ",")
+}
+
+// ownerShard returns the shard of the key by rendezvous hashing, which only moves the keys of a shard
+// that leaves, or the keys that are moved to a shard that joins
+func ownerShard(shards []string, key string) string {
+	var owner string
+	var max uint64
+	keyHash := hashString(key)
+	for _, shard := range shards {
+		if score := mix64(keyHash ^ hashString(shard)); owner == "" || score > max {
+			owner, max = shard, score
+		}
+	}
+	return owner
+}
+
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// mix64 is the finalizer of splitmix64, it spreads the similar hashes of the keys and the shards
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
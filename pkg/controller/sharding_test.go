@@ -0,0 +1,231 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func TestLeaseSharder(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	kubeCli := kubefake.NewSimpleClientset(
+		newShardLease("shard-a", "pod-a", now),
+		newShardLease("shard-b", "pod-b", now),
+		// the lease of a shard that left recently is kept
+		newShardLease("shard-c", "pod-c", now.Add(-time.Minute)),
+		// the lease of a shard that left a long time ago is deleted
+		newShardLease("shard-d", "pod-d", now.Add(-time.Hour)),
+		// the lease that is released
+		newShardLease("shard-e", "", now),
+		&coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Namespace: "pingcap", Name: "tidb-controller-manager"}},
+	)
+
+	sharders := map[string]*LeaseSharder{}
+	for _, id := range []string{"shard-a", "shard-b"} {
+		sharders[id] = NewLeaseSharder(kubeCli, "pingcap", "tidb-controller-manager", id, 15*time.Second)
+	}
+	// the shards find the active shards and then acknowledge them
+	refreshShards(g, sharders)
+	refreshShards(g, sharders)
+	for _, sharder := range sharders {
+		g.Expect(sharder.Shards()).To(Equal([]string{"shard-a", "shard-b"}))
+	}
+	_, err := kubeCli.CoordinationV1().Leases("pingcap").Get(ctx, ShardLeaseName("tidb-controller-manager", "shard-c"), metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = kubeCli.CoordinationV1().Leases("pingcap").Get(ctx, ShardLeaseName("tidb-controller-manager", "shard-d"), metav1.GetOptions{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	// every object is owned by exactly one shard
+	owners := map[string]int{}
+	for i := 0; i < 100; i++ {
+		name := fmt.Sprintf("tc-%d", i)
+		owned := 0
+		for id, sharder := range sharders {
+			if owns(sharder, "default", name) {
+				owned++
+				owners[id]++
+			}
+		}
+		g.Expect(owned).To(Equal(1))
+	}
+	g.Expect(owners).To(HaveLen(2))
+
+	// a shard joins, only the objects moved to it change their owners
+	_, err = kubeCli.CoordinationV1().Leases("pingcap").Create(ctx, newShardLease("shard-f", "pod-f", time.Now()), metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	sharders["shard-f"] = NewLeaseSharder(kubeCli, "pingcap", "tidb-controller-manager", "shard-f", 15*time.Second)
+	// the shards find the new shard, acknowledge it and read the acknowledgements of the others
+	refreshShards(g, sharders)
+	refreshShards(g, sharders)
+	refreshShards(g, sharders)
+	g.Expect(sharders["shard-a"].Shards()).To(Equal([]string{"shard-a", "shard-b", "shard-f"}))
+	for i := 0; i < 100; i++ {
+		name := fmt.Sprintf("tc-%d", i)
+		before := ownerShard([]string{"shard-a", "shard-b"}, "default/"+name)
+		after := ownerShard([]string{"shard-a", "shard-b", "shard-f"}, "default/"+name)
+		if before != after {
+			g.Expect(after).To(Equal("shard-f"))
+		}
+		for id, sharder := range sharders {
+			g.Expect(owns(sharder, "default", name)).To(Equal(after == id))
+		}
+	}
+}
+
+func TestLeaseSharderHandoff(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	kubeCli := kubefake.NewSimpleClientset(
+		newShardLease("shard-a", "pod-a", now),
+		newShardLease("shard-b", "pod-b", now),
+	)
+	sharders := map[string]*LeaseSharder{}
+	for _, id := range []string{"shard-a", "shard-b"} {
+		sharders[id] = NewLeaseSharder(kubeCli, "pingcap", "tidb-controller-manager", id, 15*time.Second)
+	}
+	refreshShards(g, sharders)
+	refreshShards(g, sharders)
+
+	// find an object of shard-a that is moved to shard-f once shard-f joins
+	var name string
+	for i := 0; name == ""; i++ {
+		if ownerShard([]string{"shard-a", "shard-b", "shard-f"}, fmt.Sprintf("default/tc-%d", i)) == "shard-f" &&
+			ownerShard([]string{"shard-a", "shard-b"}, fmt.Sprintf("default/tc-%d", i)) == "shard-a" {
+			name = fmt.Sprintf("tc-%d", i)
+		}
+	}
+	release, owned := sharders["shard-a"].Acquire("default", name)
+	g.Expect(owned).To(BeTrue())
+
+	_, err := kubeCli.CoordinationV1().Leases("pingcap").Create(ctx, newShardLease("shard-f", "pod-f", time.Now()), metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	sharders["shard-f"] = NewLeaseSharder(kubeCli, "pingcap", "tidb-controller-manager", "shard-f", 15*time.Second)
+	// the shards find the new shard, acknowledge it and read the acknowledgements of the others
+	refreshShards(g, sharders)
+	refreshShards(g, sharders)
+	refreshShards(g, sharders)
+	// shard-a stops acquiring the object, but shard-f waits for the reconciliation in progress
+	g.Expect(owns(sharders["shard-a"], "default", name)).To(BeFalse())
+	g.Expect(owns(sharders["shard-f"], "default", name)).To(BeFalse())
+	lease, err := kubeCli.CoordinationV1().Leases("pingcap").Get(ctx, ShardLeaseName("tidb-controller-manager", "shard-a"), metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(lease.Annotations[shardViewAnnotation]).To(Equal("shard-a,shard-b"))
+
+	release()
+	refreshShards(g, sharders)
+	refreshShards(g, sharders)
+	g.Expect(owns(sharders["shard-a"], "default", name)).To(BeFalse())
+	g.Expect(owns(sharders["shard-f"], "default", name)).To(BeTrue())
+
+	// shard-f leaves, its objects are handed off without waiting for its lease to expire
+	release, owned = sharders["shard-f"].Acquire("default", name)
+	g.Expect(owned).To(BeTrue())
+	sharders["shard-f"].started = true
+	leaveCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	g.Expect(sharders["shard-f"].Leave(leaveCtx, 10*time.Millisecond)).NotTo(Succeed())
+	release()
+	g.Expect(sharders["shard-f"].Leave(ctx, 10*time.Millisecond)).To(Succeed())
+	delete(sharders, "shard-f")
+	refreshShards(g, sharders)
+	refreshShards(g, sharders)
+	g.Expect(sharders["shard-a"].Shards()).To(Equal([]string{"shard-a", "shard-b"}))
+	g.Expect(owns(sharders["shard-a"], "default", name)).To(BeTrue())
+}
+
+func TestShardIDOfPod(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	id, err := ShardIDOfPod("tidb-controller-manager-2")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(id).To(Equal("2"))
+	_, err = ShardIDOfPod("tidb-controller-manager-5d8f9c7b6-x2v4q")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestBackupClusterKey(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	backup := &v1alpha1.Backup{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "backup"}}
+	ns, name := BackupClusterKey(backup)
+	g.Expect([]string{ns, name}).To(Equal([]string{"ns", "backup"}))
+
+	backup.Spec.BR = &v1alpha1.BRConfig{Cluster: "basic"}
+	ns, name = BackupClusterKey(backup)
+	g.Expect([]string{ns, name}).To(Equal([]string{"ns", "basic"}))
+
+	backup.Spec.BR.ClusterNamespace = "tidb"
+	ns, name = BackupClusterKey(backup)
+	g.Expect([]string{ns, name}).To(Equal([]string{"tidb", "basic"}))
+}
+
+func TestBackupScheduleClusterKey(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bs := &v1alpha1.BackupSchedule{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "schedule"}}
+	ns, name := BackupScheduleClusterKey(bs)
+	g.Expect([]string{ns, name}).To(Equal([]string{"ns", "schedule"}))
+
+	bs.Spec.LogBackupTemplate = &v1alpha1.BackupSpec{BR: &v1alpha1.BRConfig{Cluster: "basic", ClusterNamespace: "tidb"}}
+	ns, name = BackupScheduleClusterKey(bs)
+	g.Expect([]string{ns, name}).To(Equal([]string{"tidb", "basic"}))
+
+	bs.Spec.BackupTemplate.BR = &v1alpha1.BRConfig{Cluster: "basic"}
+	ns, name = BackupScheduleClusterKey(bs)
+	g.Expect([]string{ns, name}).To(Equal([]string{"ns", "basic"}))
+}
+
+func refreshShards(g *GomegaWithT, sharders map[string]*LeaseSharder) {
+	for _, sharder := range sharders {
+		g.Expect(sharder.refresh(context.Background())).To(Succeed())
+	}
+}
+
+func owns(sharder Sharder, namespace, name string) bool {
+	release, owned := sharder.Acquire(namespace, name)
+	if owned {
+		release()
+	}
+	return owned
+}
+
+func newShardLease(id, holder string, renewTime time.Time) *coordinationv1.Lease {
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "pingcap",
+			Name:      ShardLeaseName("tidb-controller-manager", id),
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       pointer.String(holder),
+			LeaseDurationSeconds: pointer.Int32(15),
+			RenewTime:            &metav1.MicroTime{Time: renewTime},
+		},
+	}
+}
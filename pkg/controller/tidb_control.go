@@ -15,14 +15,24 @@ package controller
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"sort"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/backup/constants"
+	"github.com/pingcap/tidb-operator/pkg/util"
 	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
+	corev1 "k8s.io/api/core/v1"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 )
 
@@ -33,18 +43,35 @@ const (
 	timeout          = 5 * time.Second
 )
 
+// variableNamePattern is the pattern of the names of the system variables set by SetVariables
+var variableNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
 type DBInfo struct {
 	IsOwner bool `json:"is_owner"`
 }
 
+// DBStatus is the status returned by the status API of TiDB
+type DBStatus struct {
+	// Connections is the number of the client connections
+	Connections int    `json:"connections"`
+	Version     string `json:"version"`
+	GitHash     string `json:"git_hash"`
+}
+
 // TiDBControlInterface is the interface that knows how to manage tidb peers
 type TiDBControlInterface interface {
 	// GetHealth returns tidb's health info
 	GetHealth(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error)
 	// Get TIDB info return tidb's DBInfo
 	GetInfo(tc *v1alpha1.TidbCluster, ordinal int32) (*DBInfo, error)
+	// GetStatus returns tidb's DBStatus, e.g. the number of the client connections
+	GetStatus(tc *v1alpha1.TidbCluster, ordinal int32) (*DBStatus, error)
 	// SetServerLabels update TiDB's labels config
 	SetServerLabels(tc *v1alpha1.TidbCluster, ordinal int32, labels map[string]string) error
+	// SetVariables changes the instance-scoped system variables of the TiDB server online, e.g. `tidb_general_log`
+	SetVariables(tc *v1alpha1.TidbCluster, ordinal int32, variables map[string]string) error
+	// GetConfig returns the running config of the TiDB server
+	GetConfig(tc *v1alpha1.TidbCluster, ordinal int32) (map[string]interface{}, error)
 }
 
 // defaultTiDBControl is default implementation of TiDBControlInterface.
@@ -104,6 +131,24 @@ func (c *defaultTiDBControl) GetInfo(tc *v1alpha1.TidbCluster, ordinal int32) (*
 	return &info, nil
 }
 
+func (c *defaultTiDBControl) GetStatus(tc *v1alpha1.TidbCluster, ordinal int32) (*DBStatus, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/status", c.getBaseURL(tc, ordinal))
+	body, err := getBodyOK(httpClient, url)
+	if err != nil {
+		return nil, err
+	}
+	status := DBStatus{}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
 // SetServerLabels update TiDB's labels config
 func (c *defaultTiDBControl) SetServerLabels(tc *v1alpha1.TidbCluster, ordinal int32, labels map[string]string) error {
 	httpClient, err := c.getHTTPClient(tc)
@@ -121,6 +166,61 @@ func (c *defaultTiDBControl) SetServerLabels(tc *v1alpha1.TidbCluster, ordinal i
 	return err
 }
 
+// SetVariables changes the instance-scoped system variables of the TiDB server online by `SET GLOBAL`,
+// the config of TiDB can not be changed by `SET CONFIG` but by these variables. The statements are
+// executed by the account in the secret `.spec.tidb.hotConfigSecretName`, and the connection is
+// encrypted by the TiDB client certificate if the TLS for the MySQL client is enabled.
+func (c *defaultTiDBControl) SetVariables(tc *v1alpha1.TidbCluster, ordinal int32, variables map[string]string) error {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		if !variableNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid variable name %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cfg, err := c.getDBConfig(tc, ordinal)
+	if err != nil {
+		return err
+	}
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return err
+	}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	for _, name := range names {
+		// the name is validated, and the value is quoted by the driver
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("SET GLOBAL %s = ?", name), variables[name]); err != nil {
+			return fmt.Errorf("set variable %s failed, error: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// GetConfig returns the running config of the TiDB server
+func (c *defaultTiDBControl) GetConfig(tc *v1alpha1.TidbCluster, ordinal int32) (map[string]interface{}, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/config", c.getBaseURL(tc, ordinal))
+	body, err := getBodyOK(httpClient, url)
+	if err != nil {
+		return nil, err
+	}
+	config := map[string]interface{}{}
+	if err := json.Unmarshal(body, &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 func getBodyOK(httpClient *http.Client, apiURL string) ([]byte, error) {
 	res, err := httpClient.Get(apiURL)
 	if err != nil {
@@ -147,21 +247,97 @@ func (c *defaultTiDBControl) getBaseURL(tc *v1alpha1.TidbCluster, ordinal int32)
 	tcName := tc.GetName()
 	ns := tc.GetNamespace()
 	scheme := tc.Scheme()
-	hostName := fmt.Sprintf("%s-%d", TiDBMemberName(tcName), ordinal)
+	group := tc.TiDBGroupName()
+	hostName := fmt.Sprintf("%s-%d", TiDBGroupMemberName(tcName, group), ordinal)
 
-	baseURL := fmt.Sprintf("%s://%s.%s.%s:%d", scheme, hostName, TiDBPeerMemberName(tcName), ns, v1alpha1.DefaultTiDBStatusPort)
+	baseURL := fmt.Sprintf("%s://%s.%s.%s:%d", scheme, hostName, TiDBGroupPeerMemberName(tcName, group), ns, v1alpha1.DefaultTiDBStatusPort)
 	if tc.Spec.ClusterDomain != "" {
-		baseURL = fmt.Sprintf("%s://%s.%s.%s.svc.%s:%d", scheme, hostName, TiDBPeerMemberName(tcName), ns, tc.Spec.ClusterDomain, v1alpha1.DefaultTiDBStatusPort)
+		baseURL = fmt.Sprintf("%s://%s.%s.%s.svc.%s:%d", scheme, hostName, TiDBGroupPeerMemberName(tcName, group), ns, tc.Spec.ClusterDomain, v1alpha1.DefaultTiDBStatusPort)
 	}
 	return baseURL
 }
 
+// getDBConfig returns the config to connect the TiDB server directly by the peer service
+func (c *defaultTiDBControl) getDBConfig(tc *v1alpha1.TidbCluster, ordinal int32) (*mysql.Config, error) {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	if tc.Spec.TiDB.HotConfigSecretName == nil {
+		return nil, fmt.Errorf("the secret of the credentials to connect tidb is not set for cluster %s/%s", ns, tcName)
+	}
+	secretName := *tc.Spec.TiDB.HotConfigSecretName
+	secret, err := c.secretLister.Secrets(ns).Get(secretName)
+	if err != nil {
+		return nil, err
+	}
+	user := string(secret.Data[constants.TidbUserKey])
+	if user == "" {
+		return nil, fmt.Errorf("%s does not exist in secret %s/%s", constants.TidbUserKey, ns, secretName)
+	}
+
+	group := tc.TiDBGroupName()
+	host := fmt.Sprintf("%s-%d.%s.%s", TiDBGroupMemberName(tcName, group), ordinal, TiDBGroupPeerMemberName(tcName, group), ns)
+	if tc.Spec.ClusterDomain != "" {
+		host = fmt.Sprintf("%s.svc.%s", host, tc.Spec.ClusterDomain)
+	}
+	if c.testURL != "" {
+		host = c.testURL
+	}
+
+	cfg := mysql.NewConfig()
+	cfg.User = user
+	cfg.Passwd = string(secret.Data[constants.TidbPasswordKey])
+	cfg.Net = "tcp"
+	cfg.Addr = fmt.Sprintf("%s:%d", host, tc.Spec.TiDB.GetServicePort())
+	cfg.Timeout = timeout
+	cfg.InterpolateParams = true
+	if tc.Spec.TiDB.IsTLSClientEnabled() {
+		cfg.TLS, err = c.getTiDBClientTLSConfig(tc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// getTiDBClientTLSConfig returns the TLS config built from the TiDB client certificate used by the operator
+func (c *defaultTiDBControl) getTiDBClientTLSConfig(tc *v1alpha1.TidbCluster) (*tls.Config, error) {
+	ns := tc.GetNamespace()
+	secretName := util.TiDBClientTLSSecretName(tc.GetName(), nil)
+	secret, err := c.secretLister.Secrets(ns).Get(secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{}
+	if tc.Spec.TiDB.TLSClient.SkipInternalClientCA {
+		config.InsecureSkipVerify = true
+	} else {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(secret.Data[corev1.ServiceAccountRootCAKey]) {
+			return nil, fmt.Errorf("ca does not exist in secret %s/%s", ns, secretName)
+		}
+		config.RootCAs = rootCAs
+	}
+	if !tc.Spec.TiDB.TLSClient.DisableClientAuthn {
+		cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("unable to load certificates from secret %s/%s: %v", ns, secretName, err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
 // FakeTiDBControl is a fake implementation of TiDBControlInterface.
 type FakeTiDBControl struct {
-	healthInfo     map[string]bool
-	tiDBInfo       *DBInfo
-	getInfoError   error
-	setLabelsError error
+	healthInfo        map[string]bool
+	tiDBInfo          *DBInfo
+	getInfoError      error
+	setLabelsError    error
+	variables         map[int32]map[string]string
+	setVariablesError error
+	configs           map[int32]map[string]interface{}
+	statuses          map[int32]*DBStatus
 }
 
 // NewFakeTiDBControl returns a FakeTiDBControl instance
@@ -178,8 +354,33 @@ func (c *FakeTiDBControl) SetLabelsErr(err error) {
 	c.setLabelsError = err
 }
 
+func (c *FakeTiDBControl) SetVariablesErr(err error) {
+	c.setVariablesError = err
+}
+
+// SetConfig sets the running config of the TiDB server
+func (c *FakeTiDBControl) SetConfig(ordinal int32, config map[string]interface{}) {
+	if c.configs == nil {
+		c.configs = map[int32]map[string]interface{}{}
+	}
+	c.configs[ordinal] = config
+}
+
+// SetStatus sets the status of the TiDB server
+func (c *FakeTiDBControl) SetStatus(ordinal int32, status *DBStatus) {
+	if c.statuses == nil {
+		c.statuses = map[int32]*DBStatus{}
+	}
+	c.statuses[ordinal] = status
+}
+
+// GetVariables returns the variables that are changed for the TiDB server
+func (c *FakeTiDBControl) GetVariables(ordinal int32) map[string]string {
+	return c.variables[ordinal]
+}
+
 func (c *FakeTiDBControl) GetHealth(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
-	podName := fmt.Sprintf("%s-%d", TiDBMemberName(tc.GetName()), ordinal)
+	podName := fmt.Sprintf("%s-%d", TiDBGroupMemberName(tc.GetName(), tc.TiDBGroupName()), ordinal)
 	if c.healthInfo == nil {
 		return false, nil
 	}
@@ -193,6 +394,38 @@ func (c *FakeTiDBControl) GetInfo(tc *v1alpha1.TidbCluster, ordinal int32) (*DBI
 	return c.tiDBInfo, c.getInfoError
 }
 
+func (c *FakeTiDBControl) GetStatus(tc *v1alpha1.TidbCluster, ordinal int32) (*DBStatus, error) {
+	status, ok := c.statuses[ordinal]
+	if !ok {
+		return nil, fmt.Errorf("status of tidb %d not found", ordinal)
+	}
+	return status, nil
+}
+
 func (c *FakeTiDBControl) SetServerLabels(tc *v1alpha1.TidbCluster, ordinal int32, labels map[string]string) error {
 	return c.setLabelsError
 }
+
+func (c *FakeTiDBControl) SetVariables(tc *v1alpha1.TidbCluster, ordinal int32, variables map[string]string) error {
+	if c.setVariablesError != nil {
+		return c.setVariablesError
+	}
+	if c.variables == nil {
+		c.variables = map[int32]map[string]string{}
+	}
+	if c.variables[ordinal] == nil {
+		c.variables[ordinal] = map[string]string{}
+	}
+	for k, v := range variables {
+		c.variables[ordinal][k] = v
+	}
+	return nil
+}
+
+func (c *FakeTiDBControl) GetConfig(tc *v1alpha1.TidbCluster, ordinal int32) (map[string]interface{}, error) {
+	config, ok := c.configs[ordinal]
+	if !ok {
+		return nil, fmt.Errorf("config of tidb %d not found", ordinal)
+	}
+	return config, nil
+}
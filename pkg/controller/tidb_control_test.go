@@ -30,6 +30,7 @@ import (
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
+	"k8s.io/utils/pointer"
 )
 
 const (
@@ -212,6 +213,55 @@ func TestInfo(t *testing.T) {
 	}
 }
 
+func TestStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		caseName string
+		resp     string
+		failed   bool
+		expected *DBStatus
+	}{
+		{
+			caseName: "GetStatus",
+			resp:     `{"connections":3,"version":"8.0.11-TiDB-v7.5.0","git_hash":"abc","status":{"init_stats_percentage":100}}`,
+			expected: &DBStatus{Connections: 3, Version: "8.0.11-TiDB-v7.5.0", GitHash: "abc"},
+		},
+		{
+			caseName: "GetStatus failed",
+			failed:   true,
+		},
+	}
+
+	for _, c := range cases {
+		svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+			g.Expect(request.Method).To(Equal("GET"), "check method")
+			g.Expect(request.URL.Path).To(Equal("/status"), "check url")
+
+			if c.failed {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", ContentTypeJSON)
+			w.Write([]byte(c.resp))
+		})
+		defer svc.Close()
+
+		fakeClient := &fake.Clientset{}
+		informer := kubeinformers.NewSharedInformerFactory(fakeClient, 0)
+		control := NewDefaultTiDBControl(informer.Core().V1().Secrets().Lister())
+		control.testURL = svc.URL
+		tc := getTidbCluster()
+		result, err := control.GetStatus(tc, 0)
+		if c.failed {
+			g.Expect(err).To(HaveOccurred(), c.caseName)
+		} else {
+			g.Expect(err).NotTo(HaveOccurred(), c.caseName)
+			g.Expect(result).To(Equal(c.expected), c.caseName)
+		}
+	}
+}
+
 func TestGetHTTPClient(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -370,3 +420,52 @@ func fakeSecret(fakeClient *fake.Clientset) {
 		}, nil
 	})
 }
+
+func TestGetDBConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	informer := kubeinformers.NewSharedInformerFactory(&fake.Clientset{}, 0)
+	indexer := informer.Core().V1().Secrets().Informer().GetIndexer()
+	g.Expect(indexer.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "hot-config", Namespace: corev1.NamespaceDefault},
+		Data: map[string][]byte{
+			"user":     []byte("operator"),
+			"password": []byte("pass'word"),
+		},
+	})).To(Succeed())
+	g.Expect(indexer.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo-tidb-client-secret", Namespace: corev1.NamespaceDefault},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:              []byte(certData),
+			corev1.TLSPrivateKeyKey:        []byte(keyData),
+			corev1.ServiceAccountRootCAKey: []byte(caData),
+		},
+	})).To(Succeed())
+	control := NewDefaultTiDBControl(informer.Core().V1().Secrets().Lister())
+
+	// the secret is required
+	tc := getTidbCluster()
+	_, err := control.getDBConfig(tc, 0)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(control.SetVariables(tc, 0, map[string]string{"tidb_general_log": "ON"})).NotTo(Succeed())
+
+	tc.Spec.TiDB.HotConfigSecretName = pointer.StringPtr("hot-config")
+	cfg, err := control.getDBConfig(tc, 0)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cfg.User).To(Equal("operator"))
+	g.Expect(cfg.Passwd).To(Equal("pass'word"))
+	g.Expect(cfg.Addr).To(Equal("demo-tidb-0.demo-tidb-peer.default:4000"))
+	g.Expect(cfg.TLS).To(BeNil())
+
+	// the connection is encrypted by the client certificate if the TLS for the MySQL client is enabled
+	tc.Spec.TiDB.TLSClient = &v1alpha1.TiDBTLSClient{Enabled: true}
+	cfg, err = control.getDBConfig(tc, 0)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cfg.TLS).NotTo(BeNil())
+	g.Expect(cfg.TLS.RootCAs).NotTo(BeNil())
+	g.Expect(cfg.TLS.Certificates).To(HaveLen(1))
+
+	// the names of the variables are validated before connecting
+	err = control.SetVariables(tc, 0, map[string]string{"tidb_general_log = 1; DROP DATABASE test; --": "ON"})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid variable name")))
+}
@@ -198,6 +198,12 @@ func (c *PodController) sync(key string) (result reconcile.Result, err error) {
 	if tcName == "" {
 		return reconcile.Result{}, nil
 	}
+	release, owned := c.deps.Sharder.Acquire(ns, tcName)
+	if !owned {
+		// reconciled by the shard of the TidbCluster
+		return reconcile.Result{}, nil
+	}
+	defer release()
 
 	tc, err := c.deps.TiDBClusterLister.TidbClusters(ns).Get(tcName)
 	if err != nil {
@@ -448,8 +454,7 @@ func (c *PodController) syncTiKVPodForEviction(ctx context.Context, pod *corev1.
 
 		// delete pod after eviction finished if needed
 		if value == v1alpha1.EvictLeaderValueDeletePod {
-			tlsEnabled := tc.IsTLSClusterEnabled()
-			kvClient := c.deps.TiKVControl.GetTiKVPodClient(tc.Namespace, tc.Name, pod.Name, tc.Spec.ClusterDomain, tlsEnabled)
+			kvClient := controller.GetTiKVPodClient(c.deps.TiKVControl, tc, pod.Labels[label.TiKVGroupLabelKey], pod.Name)
 			leaderCount, err := kvClient.GetLeaderCount()
 			if err != nil {
 				return reconcile.Result{}, perrors.Annotatef(err, "failed to get leader count for pod %s/%s", pod.Namespace, pod.Name)
@@ -53,6 +53,16 @@ func (c *kvClient) FlushLogBackupTasks(ctx context.Context) error {
 	return nil
 }
 
+// UpdateConfig implements tikvapi.TiKVClient.
+func (c *kvClient) UpdateConfig(config map[string]string) error {
+	return nil
+}
+
+// GetConfig implements tikvapi.TiKVClient.
+func (c *kvClient) GetConfig() (map[string]interface{}, error) {
+	return nil, nil
+}
+
 func TestTiKVPodSyncForEviction(t *testing.T) {
 	interval := time.Millisecond * 100
 	timeout := time.Minute * 1
@@ -44,6 +44,19 @@ func allStatefulSetsAreUpToDate(tc *v1alpha1.TidbCluster) bool {
 		}
 		return status.CurrentRevision == status.UpdateRevision
 	}
+	for _, group := range tc.Status.TiKVGroups {
+		if !isUpToDate(group.StatefulSet, false) {
+			return false
+		}
+	}
+	for _, group := range tc.Status.TiDBGroups {
+		if !isUpToDate(group.StatefulSet, false) {
+			return false
+		}
+	}
+	if tc.Status.TiFlashCompute != nil && !isUpToDate(tc.Status.TiFlashCompute.StatefulSet, false) {
+		return false
+	}
 	return (isUpToDate(tc.Status.PD.StatefulSet, false)) &&
 		(isUpToDate(tc.Status.TiKV.StatefulSet, false)) &&
 		(isUpToDate(tc.Status.TiDB.StatefulSet, false)) &&
@@ -65,12 +78,21 @@ func (u *tidbClusterConditionUpdater) updateReadyCondition(tc *v1alpha1.TidbClus
 	case tc.Spec.TiKV != nil && !tc.TiKVAllStoresReady():
 		reason = utiltidbcluster.TiKVStoreNotUp
 		message = "TiKV store(s) are not up"
+	case !tc.TiKVGroupsAllStoresReady():
+		reason = utiltidbcluster.TiKVStoreNotUp
+		message = "TiKV store(s) of TiKV groups are not up"
 	case tc.Spec.TiDB != nil && !tc.TiDBAllMembersReady():
 		reason = utiltidbcluster.TiDBUnhealthy
 		message = "TiDB(s) are not healthy"
+	case !tc.TiDBGroupsAllMembersReady():
+		reason = utiltidbcluster.TiDBUnhealthy
+		message = "TiDB(s) of TiDB groups are not healthy"
 	case tc.Spec.TiFlash != nil && !tc.TiFlashAllStoresReady():
 		reason = utiltidbcluster.TiFlashStoreNotUp
 		message = "TiFlash store(s) are not up"
+	case tc.TiFlashComputeSpec() != nil && !tc.TiFlashComputeView().TiFlashAllStoresReady():
+		reason = utiltidbcluster.TiFlashStoreNotUp
+		message = "TiFlash store(s) of TiFlash compute nodes are not up"
 	case tc.Spec.TiCDC != nil && !tc.TiCDCAllCapturesReady():
 		reason = utiltidbcluster.TiCDCCaptureNotReady
 		message = "TiCDC capture(s) are not up"
@@ -14,6 +14,8 @@
 package tidbcluster
 
 import (
+	"time"
+
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
 	v1alpha1validation "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
@@ -44,8 +46,15 @@ func NewDefaultTidbClusterControl(
 	tcControl controller.TidbClusterControlInterface,
 	pdMemberManager manager.Manager,
 	pdMSMemberManager manager.Manager,
+	pdMSMigrator manager.Manager,
+	tlsCertManager manager.Manager,
+	certExpiryManager manager.Manager,
+	tikvScaleAccelerationManager manager.Manager,
 	tikvMemberManager manager.Manager,
+	tikvGroupMemberManager manager.Manager,
+	tidbIdleManager manager.Manager,
 	tidbMemberManager manager.Manager,
+	tidbGroupMemberManager manager.Manager,
 	tiproxyMemberManager manager.Manager,
 	reclaimPolicyManager manager.Manager,
 	metaManager manager.Manager,
@@ -56,54 +65,74 @@ func NewDefaultTidbClusterControl(
 	pvcReplacer volumes.PVCReplacerInterface,
 	pumpMemberManager manager.Manager,
 	tiflashMemberManager manager.Manager,
+	tiflashComputeMemberManager manager.Manager,
 	ticdcMemberManager manager.Manager,
+	configDriftManager manager.Manager,
 	discoveryManager member.TidbDiscoveryManager,
 	tidbClusterStatusManager manager.Manager,
 	conditionUpdater TidbClusterConditionUpdater,
 	recorder record.EventRecorder) ControlInterface {
 	return &defaultTidbClusterControl{
-		tcControl:                tcControl,
-		pdMemberManager:          pdMemberManager,
-		pdMSMemberManager:        pdMSMemberManager,
-		tikvMemberManager:        tikvMemberManager,
-		tidbMemberManager:        tidbMemberManager,
-		tiproxyMemberManager:     tiproxyMemberManager,
-		reclaimPolicyManager:     reclaimPolicyManager,
-		metaManager:              metaManager,
-		orphanPodsCleaner:        orphanPodsCleaner,
-		pvcCleaner:               pvcCleaner,
-		pvcModifier:              pvcModifier,
-		pvcReplacer:              pvcReplacer,
-		pumpMemberManager:        pumpMemberManager,
-		tiflashMemberManager:     tiflashMemberManager,
-		ticdcMemberManager:       ticdcMemberManager,
-		discoveryManager:         discoveryManager,
-		tidbClusterStatusManager: tidbClusterStatusManager,
-		conditionUpdater:         conditionUpdater,
-		recorder:                 recorder,
+		tcControl:                    tcControl,
+		pdMemberManager:              member.TraceManager("PDMemberManager", pdMemberManager),
+		pdMSMemberManager:            member.TraceManager("PDMSMemberManager", pdMSMemberManager),
+		pdMSMigrator:                 member.TraceManager("PDMSMigrator", pdMSMigrator),
+		tlsCertManager:               member.TraceManager("TLSCertManager", tlsCertManager),
+		certExpiryManager:            member.TraceManager("CertExpiryManager", certExpiryManager),
+		tikvScaleAccelerationManager: member.TraceManager("TiKVScaleAccelerationManager", tikvScaleAccelerationManager),
+		tikvMemberManager:            member.TraceManager("TiKVMemberManager", tikvMemberManager),
+		tikvGroupMemberManager:       member.TraceManager("TiKVGroupMemberManager", tikvGroupMemberManager),
+		tidbIdleManager:              member.TraceManager("TiDBIdleManager", tidbIdleManager),
+		tidbMemberManager:            member.TraceManager("TiDBMemberManager", tidbMemberManager),
+		tidbGroupMemberManager:       member.TraceManager("TiDBGroupMemberManager", tidbGroupMemberManager),
+		tiproxyMemberManager:         member.TraceManager("TiProxyMemberManager", tiproxyMemberManager),
+		reclaimPolicyManager:         member.TraceManager("ReclaimPolicyManager", reclaimPolicyManager),
+		metaManager:                  member.TraceManager("MetaManager", metaManager),
+		orphanPodsCleaner:            orphanPodsCleaner,
+		pvcCleaner:                   pvcCleaner,
+		pvcModifier:                  pvcModifier,
+		pvcReplacer:                  pvcReplacer,
+		pumpMemberManager:            member.TraceManager("PumpMemberManager", pumpMemberManager),
+		tiflashMemberManager:         member.TraceManager("TiFlashMemberManager", tiflashMemberManager),
+		tiflashComputeMemberManager:  member.TraceManager("TiFlashComputeMemberManager", tiflashComputeMemberManager),
+		ticdcMemberManager:           member.TraceManager("TiCDCMemberManager", ticdcMemberManager),
+		configDriftManager:           member.TraceManager("ConfigDriftManager", configDriftManager),
+		discoveryManager:             discoveryManager,
+		tidbClusterStatusManager:     member.TraceManager("TidbClusterStatusManager", tidbClusterStatusManager),
+		conditionUpdater:             conditionUpdater,
+		recorder:                     recorder,
 	}
 }
 
 type defaultTidbClusterControl struct {
-	tcControl                controller.TidbClusterControlInterface
-	pdMemberManager          manager.Manager
-	pdMSMemberManager        manager.Manager
-	tikvMemberManager        manager.Manager
-	tidbMemberManager        manager.Manager
-	tiproxyMemberManager     manager.Manager
-	reclaimPolicyManager     manager.Manager
-	metaManager              manager.Manager
-	orphanPodsCleaner        member.OrphanPodsCleaner
-	pvcCleaner               member.PVCCleanerInterface
-	pvcModifier              volumes.PVCModifierInterface
-	pvcReplacer              volumes.PVCReplacerInterface
-	pumpMemberManager        manager.Manager
-	tiflashMemberManager     manager.Manager
-	ticdcMemberManager       manager.Manager
-	discoveryManager         member.TidbDiscoveryManager
-	tidbClusterStatusManager manager.Manager
-	conditionUpdater         TidbClusterConditionUpdater
-	recorder                 record.EventRecorder
+	tcControl                    controller.TidbClusterControlInterface
+	pdMemberManager              manager.Manager
+	pdMSMemberManager            manager.Manager
+	pdMSMigrator                 manager.Manager
+	tlsCertManager               manager.Manager
+	certExpiryManager            manager.Manager
+	tikvScaleAccelerationManager manager.Manager
+	tikvMemberManager            manager.Manager
+	tikvGroupMemberManager       manager.Manager
+	tidbIdleManager              manager.Manager
+	tidbMemberManager            manager.Manager
+	tidbGroupMemberManager       manager.Manager
+	tiproxyMemberManager         manager.Manager
+	reclaimPolicyManager         manager.Manager
+	metaManager                  manager.Manager
+	orphanPodsCleaner            member.OrphanPodsCleaner
+	pvcCleaner                   member.PVCCleanerInterface
+	pvcModifier                  volumes.PVCModifierInterface
+	pvcReplacer                  volumes.PVCReplacerInterface
+	pumpMemberManager            manager.Manager
+	tiflashMemberManager         manager.Manager
+	tiflashComputeMemberManager  manager.Manager
+	ticdcMemberManager           manager.Manager
+	configDriftManager           manager.Manager
+	discoveryManager             member.TidbDiscoveryManager
+	tidbClusterStatusManager     manager.Manager
+	conditionUpdater             TidbClusterConditionUpdater
+	recorder                     record.EventRecorder
 }
 
 // UpdateTidbCluster executes the core logic loop for a tidbcluster.
@@ -123,6 +152,7 @@ func (c *defaultTidbClusterControl) UpdateTidbCluster(tc *v1alpha1.TidbCluster)
 	if err := c.conditionUpdater.Update(tc); err != nil {
 		errs = append(errs, err)
 	}
+	c.recordStatusMetrics(tc)
 
 	if apiequality.Semantic.DeepEqual(&tc.Status, oldStatus) {
 		return errorutils.NewAggregate(errs)
@@ -187,6 +217,34 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		}
 	}
 
+	// works that should be done to issue the certificates of TLSCluster by the operator:
+	//   - generate the CA of the cluster
+	//   - issue the certificates of all components and the client
+	//   - renew the certificates before they expire
+	if err := c.tlsCertManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "tls_cert").Inc()
+		return err
+	}
+
+	// works that should be done to track the expiry of the certificates in the TLS secrets:
+	//   - export the time to expiry of the certificates
+	//   - set the CertificateExpiringSoon condition and record an event
+	//   - stop here if any certificate is expired, so that pods are not rolled onto it
+	if err := c.certExpiryManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "cert_expiry").Inc()
+		return err
+	}
+
+	// works that should be done to migrate the pd cluster between pd and pd microservices:
+	//   - start the pdms cluster before pd is switched to the mode of pdms
+	//   - switch the mode of pd by rolling restart
+	//   - stop the pdms cluster after pd is switched back from the mode of pdms
+	//   - track the progress in the PDMSMigrating condition of pd
+	if err := c.pdMSMigrator.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pdms_migrator").Inc()
+		return err
+	}
+
 	// works that should be done to make the pd microservice current state match the desired state:
 	//   - create or update the pdms service
 	//   - create or update the pdms headless service
@@ -241,6 +299,27 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		return err
 	}
 
+	// works that should be done to make the TiFlash compute nodes current state match the desired state,
+	// the same as the tiflash above, and:
+	//   - upgrade the TiFlash compute nodes after the TiFlash write nodes
+	//   - scale in the TiFlash compute nodes without waiting for the stores to become tombstone
+	//   - scale in the TiFlash compute nodes that have been removed from the spec
+	if err := c.tiflashComputeMemberManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "tiflash-compute").Inc()
+		return err
+	}
+
+	// works that should be done to accelerate the scheduling of PD while TiKV is being scaled or failed over:
+	//   - raise the store limits and the schedule limits of PD to the acceleration profile in the scale policy
+	//   - restore the previous limits after none of the default TiKV and the TiKV groups is in progress
+	// It runs before the TiKV member managers as they requeue while the scaling is in progress. The
+	// acceleration is best-effort, a failure must not block the scaling and upgrading of TiKV.
+	if err := c.tikvScaleAccelerationManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "tikv_scale_acceleration").Inc()
+		klog.Warningf("TidbCluster: [%s/%s], sync scale acceleration of TiKV failed, err: %v", ns, tcName, err)
+		c.recorder.Event(tc, v1.EventTypeWarning, "FailedAccelerateTiKVScale", err.Error())
+	}
+
 	// works that should be done to make the tikv cluster current state match the desired state:
 	//   - waiting for the pd cluster available(pd cluster is in quorum)
 	//   - create or update tikv headless service
@@ -255,12 +334,30 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		return err
 	}
 
+	// works that should be done to make every TiKV group current state match the desired state,
+	// the same as the default TiKV above, and:
+	//   - upgrade the TiKV groups and the default TiKV one at a time
+	//   - scale in the TiKV groups that have been removed from the spec
+	if err := c.tikvGroupMemberManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "tikv-group").Inc()
+		return err
+	}
+
 	// syncing the pump cluster
 	if err := c.pumpMemberManager.Sync(tc); err != nil {
 		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pump").Inc()
 		return err
 	}
 
+	// works that should be done to scale the idle TiDB to zero and wake it up:
+	//   - count the client connections of TiDB and mark it as idle after the idle timeout
+	//   - mark TiDB as waking when it is requested to wake up by the activator
+	//   - mark TiDB as active when all the members are ready after waking up
+	if err := c.tidbIdleManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "tidb_idle").Inc()
+		return err
+	}
+
 	// works that should be done to make the tidb cluster current state match the desired state:
 	//   - waiting for the tikv cluster available(at least one peer works)
 	//   - create or update tidb headless service
@@ -274,6 +371,14 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		return err
 	}
 
+	// works that should be done to make every TiDB group current state match the desired state,
+	// the same as the default TiDB above, and:
+	//   - scale in the TiDB groups that have been removed from the spec
+	if err := c.tidbGroupMemberManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "tidb-group").Inc()
+		return err
+	}
+
 	// works that should be done to make the ticdc cluster current state match the desired state:
 	//   - waiting for the pd cluster available(pd cluster is in quorum)
 	//   - waiting for the tikv cluster available(at least one peer works)
@@ -284,6 +389,17 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		return err
 	}
 
+	// works that should be done to detect the drift between the running config and the spec:
+	//   - compare the running config of the components with the config in the ConfigMaps in use
+	//   - reapply the drifted config items that can be changed online in the enforce mode
+	//   - set the ConfigDrift condition
+	// The detection is best-effort, a failure must not block the syncing of the other parts of the cluster.
+	if err := c.configDriftManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "config_drift").Inc()
+		klog.Warningf("TidbCluster: [%s/%s], detect config drift failed, err: %v", ns, tcName, err)
+		c.recorder.Event(tc, v1.EventTypeWarning, "FailedDetectConfigDrift", err.Error())
+	}
+
 	// syncing the labels from Pod to PVC and PV, these labels include:
 	//   - label.StoreIDLabelKey
 	//   - label.MemberIDLabelKey
@@ -305,18 +421,21 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		}
 	}
 
-	// Replace volumes if necessary. Note: if enabled, takes precedence over pvcModifier.
-	if features.DefaultFeatureGate.Enabled(features.VolumeReplacing) || tc.IsPVCReplaceEnabled() {
-		if err := c.pvcReplacer.Sync(tc); err != nil {
-			metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pvc_replacer_sync").Inc()
-			return err
+	// the volumes are not changed until the change plan is approved in the plan mode
+	if !tc.IsChangePlanPending() {
+		// Replace volumes if necessary. Note: if enabled, takes precedence over pvcModifier.
+		if features.DefaultFeatureGate.Enabled(features.VolumeReplacing) || tc.IsPVCReplaceEnabled() {
+			if err := c.pvcReplacer.Sync(tc); err != nil {
+				metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pvc_replacer_sync").Inc()
+				return err
+			}
 		}
-	}
 
-	// modify volumes if necessary
-	if err := c.pvcModifier.Sync(tc); err != nil {
-		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pvc_modifier").Inc()
-		return err
+		// modify volumes if necessary
+		if err := c.pvcModifier.Sync(tc); err != nil {
+			metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pvc_modifier").Inc()
+			return err
+		}
 	}
 
 	// syncing the some tidbcluster status attributes
@@ -340,15 +459,24 @@ func (c *defaultTidbClusterControl) recordMetrics(tc *v1alpha1.TidbCluster) {
 	if tc.Spec.TiKV != nil {
 		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "tikv").Set(float64(tc.Spec.TiKV.Replicas))
 	}
+	for _, group := range tc.Spec.TiKVGroups {
+		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "tikv-"+group.Name).Set(float64(group.Replicas))
+	}
 	if tc.Spec.TiDB != nil {
 		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "tidb").Set(float64(tc.Spec.TiDB.Replicas))
 	}
+	for _, group := range tc.Spec.TiDBGroups {
+		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "tidb-"+group.Name).Set(float64(group.Replicas))
+	}
 	if tc.Spec.TiProxy != nil {
 		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "tiproxy").Set(float64(tc.Spec.TiProxy.Replicas))
 	}
 	if tc.Spec.TiFlash != nil {
 		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "tiflash").Set(float64(tc.Spec.TiFlash.Replicas))
 	}
+	if compute := tc.TiFlashComputeSpec(); compute != nil {
+		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "tiflash-compute").Set(float64(compute.Replicas))
+	}
 	if tc.Spec.TiCDC != nil {
 		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "ticdc").Set(float64(tc.Spec.TiCDC.Replicas))
 	}
@@ -357,6 +485,103 @@ func (c *defaultTidbClusterControl) recordMetrics(tc *v1alpha1.TidbCluster) {
 	}
 }
 
+// recordStatusMetrics records the lifecycle metrics of the components from the status of the TidbCluster
+func (c *defaultTidbClusterControl) recordStatusMetrics(tc *v1alpha1.TidbCluster) {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	for _, status := range tc.AllComponentStatus() {
+		memberType := status.MemberType()
+		component := memberType.String()
+
+		upgrading := status.GetPhase() == v1alpha1.UpgradePhase
+		metrics.ClusterComponentUpgrading.WithLabelValues(ns, tcName, component).Set(boolToFloat64(upgrading))
+		var duration float64
+		if op := tc.InProgressOperation(v1alpha1.OperationUpgrade, memberType); upgrading && op != nil {
+			duration = time.Since(op.StartTime.Time).Seconds()
+		}
+		metrics.ClusterComponentUpgradeDurationSeconds.WithLabelValues(ns, tcName, component).Set(duration)
+		var remaining float64
+		if sts := status.GetStatefulSet(); sts != nil && sts.UpdateRevision != sts.CurrentRevision && sts.Replicas > sts.UpdatedReplicas {
+			remaining = float64(sts.Replicas - sts.UpdatedReplicas)
+		}
+		metrics.ClusterComponentUpgradeRemainingPods.WithLabelValues(ns, tcName, component).Set(remaining)
+
+		metrics.ClusterComponentSuspended.WithLabelValues(ns, tcName, component).Set(boolToFloat64(status.GetPhase() == v1alpha1.SuspendPhase))
+		metrics.ClusterComponentVolumeReplacing.WithLabelValues(ns, tcName, component).Set(boolToFloat64(status.GetVolReplaceInProgress()))
+	}
+
+	failureMembers := map[v1alpha1.MemberType]int{}
+	if tc.Spec.PD != nil {
+		failureMembers[v1alpha1.PDMemberType] = len(tc.Status.PD.FailureMembers)
+	}
+	if tc.Spec.TiKV != nil || len(tc.Spec.TiKVGroups) > 0 {
+		// the stores of the TiKV groups are counted in TiKV
+		failures := 0
+		stores := map[string]v1alpha1.TiKVStore{}
+		tombstoneStores := map[string]v1alpha1.TiKVStore{}
+		for _, status := range append([]*v1alpha1.TiKVStatus{&tc.Status.TiKV}, tikvGroupStatuses(tc)...) {
+			failures += len(status.FailureStores)
+			for id, store := range status.Stores {
+				stores[id] = store
+			}
+			for id, store := range status.TombstoneStores {
+				tombstoneStores[id] = store
+			}
+		}
+		failureMembers[v1alpha1.TiKVMemberType] = failures
+		recordStoreMetrics(ns, tcName, v1alpha1.TiKVMemberType, stores, tombstoneStores)
+	}
+	if tc.Spec.TiFlash != nil {
+		failureMembers[v1alpha1.TiFlashMemberType] = len(tc.Status.TiFlash.FailureStores)
+		recordStoreMetrics(ns, tcName, v1alpha1.TiFlashMemberType, tc.Status.TiFlash.Stores, tc.Status.TiFlash.TombstoneStores)
+	}
+	if tc.Spec.TiDB != nil {
+		failureMembers[v1alpha1.TiDBMemberType] = len(tc.Status.TiDB.FailureMembers)
+	}
+	if tc.Spec.TiCDC != nil {
+		failureMembers[v1alpha1.TiCDCMemberType] = len(tc.Status.TiCDC.FailureMembers)
+	}
+	for memberType, count := range failureMembers {
+		metrics.ClusterComponentFailureMembers.WithLabelValues(ns, tcName, memberType.String()).Set(float64(count))
+	}
+}
+
+// tikvGroupStatuses returns the status of the TiKV groups of the TidbCluster
+func tikvGroupStatuses(tc *v1alpha1.TidbCluster) []*v1alpha1.TiKVStatus {
+	var statuses []*v1alpha1.TiKVStatus
+	for _, status := range tc.Status.TiKVGroups {
+		if status != nil {
+			statuses = append(statuses, &status.TiKVStatus)
+		}
+	}
+	return statuses
+}
+
+// recordStoreMetrics records the number of the stores in each state, the states that no store is in are
+// recorded as 0 so that the series do not disappear when the stores change their states
+func recordStoreMetrics(ns, tcName string, memberType v1alpha1.MemberType, stores, tombstoneStores map[string]v1alpha1.TiKVStore) {
+	counts := map[string]int{
+		v1alpha1.TiKVStateUp:           0,
+		v1alpha1.TiKVStateDown:         0,
+		v1alpha1.TiKVStateOffline:      0,
+		v1alpha1.TiKVStateDisconnected: 0,
+		v1alpha1.TiKVStateTombstone:    len(tombstoneStores),
+	}
+	for _, store := range stores {
+		counts[store.State]++
+	}
+	for state, count := range counts {
+		metrics.ClusterStores.WithLabelValues(ns, tcName, memberType.String(), state).Set(float64(count))
+	}
+}
+
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 var _ ControlInterface = &defaultTidbClusterControl{}
 
 type FakeTidbClusterControlInterface struct {
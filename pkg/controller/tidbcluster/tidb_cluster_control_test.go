@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -26,6 +27,9 @@ import (
 	mm "github.com/pingcap/tidb-operator/pkg/manager/member"
 	"github.com/pingcap/tidb-operator/pkg/manager/meta"
 	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
@@ -33,6 +37,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 )
 
 func TestTidbClusterControlUpdateTidbCluster(t *testing.T) {
@@ -282,6 +287,38 @@ func TestTidbClusterControlUpdateTidbCluster(t *testing.T) {
 	}
 }
 
+func TestTidbClusterControlTiKVScaleAcceleration(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTidbClusterControl()
+	tc.Spec.TiKV.ScalePolicy.Acceleration = &v1alpha1.ScaleAcceleration{StoreLimit: pointer.Int32(60)}
+	tc.Status.TiKV.Phase = v1alpha1.ScalePhase
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{"1": {ID: "1", State: v1alpha1.TiKVStateUp}}
+
+	deps := controller.NewFakeDependencies()
+	var storeLimits []float64
+	pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), tc)
+	pdClient.AddReaction(pdapi.GetStoreLimitsActionType, func(action *pdapi.Action) (interface{}, error) {
+		return map[uint64]pdapi.StoreLimit{1: {AddPeer: 15, RemovePeer: 15}}, nil
+	})
+	pdClient.AddReaction(pdapi.SetStoreLimitActionType, func(action *pdapi.Action) (interface{}, error) {
+		storeLimits = append(storeLimits, action.Rate)
+		return nil, nil
+	})
+
+	control, _, _, _, tikvMemberManager, _, _, _, _ := newFakeTidbClusterControl()
+	control.(*defaultTidbClusterControl).tikvScaleAccelerationManager = mm.NewTiKVScaleAccelerationManager(deps)
+	// the scaler requeues while the store of the scaled in pod is still in the cluster
+	tikvMemberManager.SetSyncError(controller.RequeueErrorf("TiKV %s/%s store 1 is still in cluster", tc.Namespace, "test-pd-tikv-2"))
+
+	// the previous limits are recorded first, then the store limits are raised in the next round
+	g.Expect(control.UpdateTidbCluster(tc)).To(MatchError(ContainSubstring("is still in cluster")))
+	g.Expect(tc.Status.TiKV.ScaleAcceleration).NotTo(BeNil())
+	g.Expect(storeLimits).To(BeEmpty())
+	g.Expect(control.UpdateTidbCluster(tc)).To(MatchError(ContainSubstring("is still in cluster")))
+	g.Expect(storeLimits).To(Equal([]float64{60, 60}))
+}
+
 func TestTidbClusterStatusEquality(t *testing.T) {
 	g := NewGomegaWithT(t)
 	tcStatus := v1alpha1.TidbClusterStatus{}
@@ -295,6 +332,72 @@ func TestTidbClusterStatusEquality(t *testing.T) {
 	g.Expect(apiequality.Semantic.DeepEqual(&tcStatus, tcStatusCopy)).To(Equal(false))
 }
 
+func TestTidbClusterControlRecordStatusMetrics(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTidbClusterControl()
+	ns, name := tc.GetNamespace(), tc.GetName()
+	defer metrics.DeleteClusterMetrics(ns, name)
+
+	tc.Status.TiKV.Phase = v1alpha1.UpgradePhase
+	tc.Status.TiKV.StatefulSet = &apps.StatefulSetStatus{
+		Replicas:        3,
+		UpdatedReplicas: 1,
+		CurrentRevision: "1",
+		UpdateRevision:  "2",
+	}
+	tc.StartOperation(v1alpha1.OperationUpgrade, v1alpha1.TiKVMemberType, v1alpha1.OperationTriggerSpecChange, 2, 1, 0)
+	tc.Status.Operations[0].StartTime = metav1.NewTime(time.Now().Add(-time.Minute))
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"1": {State: v1alpha1.TiKVStateUp},
+		"2": {State: v1alpha1.TiKVStateUp},
+		"3": {State: v1alpha1.TiKVStateDisconnected},
+	}
+	tc.Status.TiKV.TombstoneStores = map[string]v1alpha1.TiKVStore{"4": {State: v1alpha1.TiKVStateTombstone}}
+	tc.Status.TiKV.FailureStores = map[string]v1alpha1.TiKVFailureStore{"3": {PodName: "test-pd-tikv-2"}}
+	tc.Status.TiDB.Phase = v1alpha1.SuspendPhase
+	// the stores of the TiKV groups are counted in TiKV
+	tc.Spec.TiKVGroups = []*v1alpha1.TiKVGroupSpec{{Name: "hot"}}
+	tc.Status.TiKVGroups = map[string]*v1alpha1.TiKVGroupStatus{
+		"hot": {Name: "hot", TiKVStatus: v1alpha1.TiKVStatus{
+			Stores: map[string]v1alpha1.TiKVStore{
+				"5": {State: v1alpha1.TiKVStateUp},
+				"6": {State: v1alpha1.TiKVStateDown},
+			},
+			FailureStores: map[string]v1alpha1.TiKVFailureStore{"6": {PodName: "test-pd-tikv-hot-1"}},
+		}},
+	}
+	metrics.TiKVStoreMigrationRemainingRegions.WithLabelValues(ns, name, "5", string(v1alpha1.TiKVStoreMigrationOut)).Set(10)
+
+	control := &defaultTidbClusterControl{}
+	control.recordStatusMetrics(tc)
+
+	g.Expect(testutil.ToFloat64(metrics.ClusterComponentUpgrading.WithLabelValues(ns, name, "tikv"))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(metrics.ClusterComponentUpgrading.WithLabelValues(ns, name, "pd"))).To(Equal(0.0))
+	g.Expect(testutil.ToFloat64(metrics.ClusterComponentUpgradeDurationSeconds.WithLabelValues(ns, name, "tikv"))).To(BeNumerically(">=", 60))
+	g.Expect(testutil.ToFloat64(metrics.ClusterComponentUpgradeRemainingPods.WithLabelValues(ns, name, "tikv"))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(metrics.ClusterComponentFailureMembers.WithLabelValues(ns, name, "tikv"))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(metrics.ClusterComponentSuspended.WithLabelValues(ns, name, "tidb"))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(metrics.ClusterStores.WithLabelValues(ns, name, "tikv", v1alpha1.TiKVStateUp))).To(Equal(3.0))
+	g.Expect(testutil.ToFloat64(metrics.ClusterStores.WithLabelValues(ns, name, "tikv", v1alpha1.TiKVStateDown))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(metrics.ClusterStores.WithLabelValues(ns, name, "tikv", v1alpha1.TiKVStateDisconnected))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(metrics.ClusterStores.WithLabelValues(ns, name, "tikv", v1alpha1.TiKVStateTombstone))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(metrics.ClusterStores.WithLabelValues(ns, name, "tikv", v1alpha1.TiKVStateOffline))).To(Equal(0.0))
+
+	// the upgrade is finished
+	tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+	tc.Status.TiKV.StatefulSet.UpdatedReplicas = 3
+	tc.Status.TiKV.StatefulSet.CurrentRevision = "2"
+	control.recordStatusMetrics(tc)
+	g.Expect(testutil.ToFloat64(metrics.ClusterComponentUpgrading.WithLabelValues(ns, name, "tikv"))).To(Equal(0.0))
+	g.Expect(testutil.ToFloat64(metrics.ClusterComponentUpgradeDurationSeconds.WithLabelValues(ns, name, "tikv"))).To(Equal(0.0))
+	g.Expect(testutil.ToFloat64(metrics.ClusterComponentUpgradeRemainingPods.WithLabelValues(ns, name, "tikv"))).To(Equal(0.0))
+
+	metrics.DeleteClusterMetrics(ns, name)
+	g.Expect(metrics.ClusterStores.DeleteLabelValues(ns, name, "tikv", v1alpha1.TiKVStateUp)).To(BeFalse())
+	g.Expect(metrics.TiKVStoreMigrationRemainingRegions.DeleteLabelValues(ns, name, "5", string(v1alpha1.TiKVStoreMigrationOut))).To(BeFalse())
+}
+
 func newFakeTidbClusterControl() (
 	ControlInterface,
 	*meta.FakeReclaimPolicyManager,
@@ -312,16 +415,25 @@ func newFakeTidbClusterControl() (
 	tcUpdater := controller.NewFakeTidbClusterControl(tcInformer)
 	pdMemberManager := mm.NewFakePDMemberManager()
 	pdMSMemberManager := mm.NewFakePDMSMemberManager()
+	pdMSMigrator := mm.NewFakePDMSMigrator()
+	tlsCertManager := mm.NewFakeTLSCertManager()
+	certExpiryManager := meta.NewFakeCertExpiryManager()
+	tikvScaleAccelerationManager := mm.NewFakeTiKVScaleAccelerationManager()
 	tikvMemberManager := mm.NewFakeTiKVMemberManager()
+	tikvGroupMemberManager := mm.NewFakeTiKVGroupMemberManager()
+	tidbIdleManager := mm.NewFakeTiDBIdleManager()
 	tidbMemberManager := mm.NewFakeTiDBMemberManager()
+	tidbGroupMemberManager := mm.NewFakeTiDBGroupMemberManager()
 	reclaimPolicyManager := meta.NewFakeReclaimPolicyManager()
 	metaManager := meta.NewFakeMetaManager()
 	orphanPodCleaner := mm.NewFakeOrphanPodsCleaner()
 	pvcCleaner := mm.NewFakePVCCleaner()
 	pumpMemberManager := mm.NewFakePumpMemberManager()
 	tiflashMemberManager := mm.NewFakeTiFlashMemberManager()
+	tiflashComputeMemberManager := mm.NewFakeTiFlashComputeMemberManager()
 	tiproxyMemberManager := mm.NewFakeTiProxyMemberManager()
 	ticdcMemberManager := mm.NewFakeTiCDCMemberManager()
+	configDriftManager := mm.NewFakeConfigDriftManager()
 	discoveryManager := mm.NewFakeDiscoveryManger()
 	statusManager := mm.NewFakeTidbClusterStatusManager()
 	pvcResizer := mm.NewFakePVCResizer()
@@ -330,8 +442,15 @@ func newFakeTidbClusterControl() (
 		tcUpdater,
 		pdMemberManager,
 		pdMSMemberManager,
+		pdMSMigrator,
+		tlsCertManager,
+		certExpiryManager,
+		tikvScaleAccelerationManager,
 		tikvMemberManager,
+		tikvGroupMemberManager,
+		tidbIdleManager,
 		tidbMemberManager,
+		tidbGroupMemberManager,
 		tiproxyMemberManager,
 		reclaimPolicyManager,
 		metaManager,
@@ -341,7 +460,9 @@ func newFakeTidbClusterControl() (
 		pvcReplacer,
 		pumpMemberManager,
 		tiflashMemberManager,
+		tiflashComputeMemberManager,
 		ticdcMemberManager,
+		configDriftManager,
 		discoveryManager,
 		statusManager,
 		&tidbClusterConditionUpdater{},
@@ -34,6 +34,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/manager/suspender"
 	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
 	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/pingcap/tidb-operator/pkg/tracing"
 )
 
 // Controller controls tidbclusters.
@@ -55,20 +56,29 @@ func NewController(deps *controller.Dependencies) *Controller {
 		deps: deps,
 		control: NewDefaultTidbClusterControl(
 			deps.TiDBClusterControl,
-			mm.NewPDMemberManager(deps, mm.NewPDScaler(deps), mm.NewPDUpgrader(deps), mm.NewPDFailover(deps), suspender, podVolumeModifier),
+			mm.NewPDMemberManager(deps, mm.TraceScaler(v1alpha1.PDMemberType, mm.NewPDScaler(deps)), mm.TraceUpgrader(v1alpha1.PDMemberType, mm.NewPDUpgrader(deps)), mm.TraceFailover(v1alpha1.PDMemberType, mm.NewPDFailover(deps)), suspender, podVolumeModifier),
 			mm.NewPDMSMemberManager(deps, mm.NewPDMSScaler(deps), mm.NewPDMSUpgrader(deps), suspender, podVolumeModifier),
-			mm.NewTiKVMemberManager(deps, mm.NewTiKVFailover(deps), mm.NewTiKVScaler(deps), mm.NewTiKVUpgrader(deps, podVolumeModifier), suspender, podVolumeModifier),
-			mm.NewTiDBMemberManager(deps, mm.NewTiDBScaler(deps), mm.NewTiDBUpgrader(deps), mm.NewTiDBFailover(deps), suspender, podVolumeModifier),
-			mm.NewTiProxyMemberManager(deps, mm.NewTiProxyScaler(deps), mm.NewTiProxyUpgrader(deps), suspender),
+			mm.NewPDMSMigrator(deps),
+			mm.NewTLSCertManager(deps),
+			meta.NewCertExpiryManager(deps),
+			mm.NewTiKVScaleAccelerationManager(deps),
+			mm.NewTiKVMemberManager(deps, mm.TraceFailover(v1alpha1.TiKVMemberType, mm.NewTiKVFailover(deps)), mm.TraceScaler(v1alpha1.TiKVMemberType, mm.NewTiKVScaler(deps)), mm.TraceTiKVUpgrader(v1alpha1.TiKVMemberType, mm.NewTiKVUpgrader(deps, podVolumeModifier)), suspender, podVolumeModifier),
+			mm.NewTiKVGroupMemberManager(deps, mm.TraceFailover(v1alpha1.TiKVMemberType, mm.NewTiKVFailover(deps)), mm.TraceScaler(v1alpha1.TiKVMemberType, mm.NewTiKVScaler(deps)), mm.TraceTiKVUpgrader(v1alpha1.TiKVMemberType, mm.NewTiKVUpgrader(deps, podVolumeModifier)), suspender, podVolumeModifier),
+			mm.NewTiDBIdleManager(deps),
+			mm.NewTiDBMemberManager(deps, mm.TraceScaler(v1alpha1.TiDBMemberType, mm.NewTiDBScaler(deps)), mm.TraceUpgrader(v1alpha1.TiDBMemberType, mm.NewTiDBUpgrader(deps)), mm.TraceFailover(v1alpha1.TiDBMemberType, mm.NewTiDBFailover(deps)), suspender, podVolumeModifier),
+			mm.NewTiDBGroupMemberManager(deps, mm.TraceScaler(v1alpha1.TiDBMemberType, mm.NewTiDBScaler(deps)), mm.TraceUpgrader(v1alpha1.TiDBMemberType, mm.NewTiDBUpgrader(deps)), mm.TraceFailover(v1alpha1.TiDBMemberType, mm.NewTiDBFailover(deps)), suspender, podVolumeModifier),
+			mm.NewTiProxyMemberManager(deps, mm.TraceScaler(v1alpha1.TiProxyMemberType, mm.NewTiProxyScaler(deps)), mm.TraceUpgrader(v1alpha1.TiProxyMemberType, mm.NewTiProxyUpgrader(deps)), suspender),
 			meta.NewReclaimPolicyManager(deps),
 			meta.NewMetaManager(deps),
 			mm.NewOrphanPodsCleaner(deps),
 			mm.NewRealPVCCleaner(deps),
 			volumes.NewPVCModifier(deps),
 			volumes.NewPVCReplacer(deps),
-			mm.NewPumpMemberManager(deps, mm.NewPumpScaler(deps), suspender, podVolumeModifier),
-			mm.NewTiFlashMemberManager(deps, mm.NewTiFlashFailover(deps), mm.NewTiFlashScaler(deps), mm.NewTiFlashUpgrader(deps), suspender, podVolumeModifier),
-			mm.NewTiCDCMemberManager(deps, mm.NewTiCDCScaler(deps), mm.NewTiCDCUpgrader(deps), suspender, podVolumeModifier),
+			mm.NewPumpMemberManager(deps, mm.TraceScaler(v1alpha1.PumpMemberType, mm.NewPumpScaler(deps)), suspender, podVolumeModifier),
+			mm.NewTiFlashMemberManager(deps, mm.TraceFailover(v1alpha1.TiFlashMemberType, mm.NewTiFlashFailover(deps)), mm.TraceScaler(v1alpha1.TiFlashMemberType, mm.NewTiFlashScaler(deps)), mm.TraceUpgrader(v1alpha1.TiFlashMemberType, mm.NewTiFlashUpgrader(deps)), suspender, podVolumeModifier),
+			mm.NewTiFlashComputeMemberManager(deps, mm.TraceFailover(v1alpha1.TiFlashMemberType, mm.NewTiFlashFailover(deps)), mm.TraceScaler(v1alpha1.TiFlashMemberType, mm.NewTiFlashScaler(deps)), mm.TraceUpgrader(v1alpha1.TiFlashMemberType, mm.NewTiFlashUpgrader(deps)), suspender, podVolumeModifier),
+			mm.NewTiCDCMemberManager(deps, mm.TraceFailover(v1alpha1.TiCDCMemberType, mm.NewTiCDCFailover(deps)), mm.TraceScaler(v1alpha1.TiCDCMemberType, mm.NewTiCDCScaler(deps)), mm.TraceUpgrader(v1alpha1.TiCDCMemberType, mm.NewTiCDCUpgrader(deps)), suspender, podVolumeModifier),
+			mm.NewConfigDriftManager(deps),
 			mm.NewTidbDiscoveryManager(deps),
 			mm.NewTidbClusterStatusManager(deps),
 			&tidbClusterConditionUpdater{},
@@ -173,15 +183,33 @@ func (c *Controller) sync(key string) (err error) {
 	if err != nil {
 		return err
 	}
+	release, owned := c.deps.Sharder.Acquire(ns, name)
+	if !owned {
+		// reconciled by another shard, which exports the metrics of the TidbCluster
+		metrics.DeleteClusterMetrics(ns, name)
+		return nil
+	}
+	defer release()
 	tc, err := c.deps.TiDBClusterLister.TidbClusters(ns).Get(name)
 	if errors.IsNotFound(err) {
 		klog.Infof("TidbCluster has been deleted %v", key)
+		metrics.DeleteClusterMetrics(ns, name)
 		return nil
 	}
 	if err != nil {
 		return err
 	}
 
+	endReconcile := tracing.StartReconcile(v1alpha1.TiDBClusterKind, tc)
+	defer func() {
+		if perrors.Find(err, controller.IsRequeueError) != nil {
+			// requeue is a normal result of a reconcile
+			endReconcile(nil)
+			return
+		}
+		endReconcile(err)
+	}()
+
 	return c.syncTidbCluster(tc.DeepCopy())
 }
 
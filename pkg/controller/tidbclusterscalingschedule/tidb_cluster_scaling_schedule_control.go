@@ -0,0 +1,148 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterscalingschedule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
+	v1alpha1validation "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+
+	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// ControlInterface abstracts the business logic for TidbClusterScalingSchedule reconciliation.
+type ControlInterface interface {
+	Reconcile(*v1alpha1.TidbClusterScalingSchedule) error
+}
+
+func NewTidbClusterScalingScheduleControl(
+	deps *controller.Dependencies,
+	scheduleManager manager.TidbClusterScalingScheduleManager,
+	recorder record.EventRecorder,
+) ControlInterface {
+	return &defaultTidbClusterScalingScheduleControl{
+		deps:            deps,
+		recorder:        recorder,
+		scheduleManager: scheduleManager,
+	}
+}
+
+type defaultTidbClusterScalingScheduleControl struct {
+	deps     *controller.Dependencies
+	recorder record.EventRecorder
+
+	scheduleManager manager.TidbClusterScalingScheduleManager
+}
+
+func (c *defaultTidbClusterScalingScheduleControl) Reconcile(tcss *v1alpha1.TidbClusterScalingSchedule) error {
+	c.defaulting(tcss)
+	if !c.validate(tcss) {
+		return nil
+	}
+
+	oldStatus := tcss.Status.DeepCopy()
+
+	tcRef := tcss.Spec.Cluster
+	tc, err := c.deps.TiDBClusterLister.TidbClusters(tcRef.Namespace).Get(tcRef.Name)
+	if err != nil {
+		return fmt.Errorf("get tc %s/%s failed: %s", tcRef.Namespace, tcRef.Name, err)
+	}
+
+	err = c.scheduleManager.Sync(tcss, tc)
+
+	if !apiequality.Semantic.DeepEqual(&tcss.Status, oldStatus) {
+		if _, updateErr := c.updateStatus(tcss.DeepCopy()); updateErr != nil {
+			return updateErr
+		}
+	}
+
+	return err
+}
+
+func (c *defaultTidbClusterScalingScheduleControl) updateStatus(tcss *v1alpha1.TidbClusterScalingSchedule) (*v1alpha1.TidbClusterScalingSchedule, error) {
+	var (
+		ns     = tcss.GetNamespace()
+		name   = tcss.GetName()
+		status = tcss.Status.DeepCopy()
+		update *v1alpha1.TidbClusterScalingSchedule
+	)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var updateErr error
+		update, updateErr = c.deps.Clientset.PingcapV1alpha1().TidbClusterScalingSchedules(ns).UpdateStatus(context.TODO(), tcss, metav1.UpdateOptions{})
+		if updateErr == nil {
+			klog.Infof("TidbClusterScalingSchedule: [%s/%s], update status successfully", ns, name)
+			return nil
+		}
+
+		klog.V(4).Infof("TidbClusterScalingSchedule: [%s/%s], update status failed, error: %v", ns, name, updateErr)
+
+		// If failed to update status, then:
+		// get the latest TidbClusterScalingSchedule, override the status to local newest, prepare for next update.
+		if updated, err := c.deps.ScalingScheduleLister.TidbClusterScalingSchedules(ns).Get(name); err == nil {
+			tcss = updated.DeepCopy()
+			tcss.Status = *status
+		} else {
+			utilruntime.HandleError(fmt.Errorf("error getting updated TidbClusterScalingSchedule %s/%s from lister: %v", ns, name, err))
+		}
+
+		return updateErr
+	})
+	if err != nil {
+		klog.Errorf("TidbClusterScalingSchedule: [%s/%s], failed to updateStatus, error: %v", ns, name, err)
+	}
+
+	return update, err
+}
+
+func (c *defaultTidbClusterScalingScheduleControl) defaulting(tcss *v1alpha1.TidbClusterScalingSchedule) {
+	defaulting.SetTidbClusterScalingScheduleDefault(tcss)
+}
+
+func (c *defaultTidbClusterScalingScheduleControl) validate(tcss *v1alpha1.TidbClusterScalingSchedule) bool {
+	errs := v1alpha1validation.ValidateTidbClusterScalingSchedule(tcss)
+	if len(errs) > 0 {
+		aggregatedErr := errs.ToAggregate()
+		klog.Errorf("tidb cluster scaling schedule %s/%s is not valid and must be fixed first, aggregated error: %v", tcss.GetNamespace(), tcss.GetName(), aggregatedErr)
+		c.recorder.Event(tcss, v1.EventTypeWarning, "FailedValidation", aggregatedErr.Error())
+		return false
+	}
+	return true
+}
+
+type FakeTidbClusterScalingScheduleControl struct {
+	reconcile func(*v1alpha1.TidbClusterScalingSchedule) error
+}
+
+func (c *FakeTidbClusterScalingScheduleControl) MockReconcile(reconcile func(*v1alpha1.TidbClusterScalingSchedule) error) {
+	c.reconcile = reconcile
+}
+
+func (c *FakeTidbClusterScalingScheduleControl) Reconcile(tcss *v1alpha1.TidbClusterScalingSchedule) error {
+	if c.reconcile != nil {
+		return c.reconcile(tcss)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterscalingschedule
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager/scalingschedule"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+)
+
+func newScalingSchedule() *v1alpha1.TidbClusterScalingSchedule {
+	return &v1alpha1.TidbClusterScalingSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "tcss", Namespace: "default"},
+		Spec: v1alpha1.TidbClusterScalingScheduleSpec{
+			Cluster: v1alpha1.TidbClusterRef{Name: "tc"},
+			Windows: []v1alpha1.ScalingWindow{
+				{
+					Name:     "business-hours",
+					Schedule: "0 9 * * 1-5",
+					Duration: metav1.Duration{Duration: 9 * time.Hour},
+					Replicas: v1alpha1.ScalingTargets{TiDB: pointer.Int32(6)},
+				},
+			},
+		},
+	}
+}
+
+func newFakeTidbClusterScalingScheduleControl(objs ...interface{}) (*defaultTidbClusterScalingScheduleControl, *scalingschedule.FakeManager, *record.FakeRecorder) {
+	deps := controller.NewFakeDependencies()
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *v1alpha1.TidbClusterScalingSchedule:
+			deps.Clientset.PingcapV1alpha1().TidbClusterScalingSchedules(o.Namespace).Create(context.TODO(), o, metav1.CreateOptions{})
+			deps.InformerFactory.Pingcap().V1alpha1().TidbClusterScalingSchedules().Informer().GetIndexer().Add(o)
+		case *v1alpha1.TidbCluster:
+			deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer().Add(o)
+		}
+	}
+	scheduleManager := scalingschedule.NewFakeManager()
+	recorder := record.NewFakeRecorder(10)
+	control := NewTidbClusterScalingScheduleControl(deps, scheduleManager, recorder).(*defaultTidbClusterScalingScheduleControl)
+	return control, scheduleManager, recorder
+}
+
+func TestTidbClusterScalingScheduleControlReconcile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{}
+	tc.Name = "tc"
+	tc.Namespace = "default"
+
+	// the error of the TidbCluster not found is returned
+	tcss := newScalingSchedule()
+	control, _, _ := newFakeTidbClusterScalingScheduleControl(tcss.DeepCopy())
+	g.Expect(control.Reconcile(tcss.DeepCopy())).NotTo(Succeed())
+
+	// the sync error is returned
+	control, scheduleManager, _ := newFakeTidbClusterScalingScheduleControl(tcss.DeepCopy(), tc)
+	scheduleManager.SetSyncError(fmt.Errorf("sync error"))
+	g.Expect(control.Reconcile(tcss.DeepCopy())).To(MatchError("sync error"))
+
+	// the status is updated after the windows are evaluated
+	control.scheduleManager = scalingschedule.NewManager(control.deps)
+	g.Expect(control.Reconcile(tcss.DeepCopy())).To(Succeed())
+	updated, err := control.deps.Clientset.PingcapV1alpha1().TidbClusterScalingSchedules(tcss.Namespace).Get(context.TODO(), tcss.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(updated.Status.LastEvaluateTime).NotTo(BeNil())
+	g.Expect(updated.Status.NextEvaluateTime).NotTo(BeNil())
+
+	// the invalid schedule is not synced
+	tcss = newScalingSchedule()
+	tcss.Spec.Windows[0].Duration = metav1.Duration{}
+	control, scheduleManager, recorder := newFakeTidbClusterScalingScheduleControl(tcss.DeepCopy(), tc)
+	scheduleManager.SetSyncError(fmt.Errorf("sync error"))
+	g.Expect(control.Reconcile(tcss.DeepCopy())).To(Succeed())
+	g.Expect(recorder.Events).To(HaveLen(1))
+	g.Expect(<-recorder.Events).To(ContainSubstring("FailedValidation"))
+}
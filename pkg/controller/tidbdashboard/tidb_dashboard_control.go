@@ -37,6 +37,11 @@ type ReclaimPolicyManager interface {
 	SyncTiDBDashboard(dashboard *v1alpha1.TidbDashboard) error
 }
 
+// CertExpiryManager abstracts the logic of tracking the expiry of the certificates consumed by TiDBDashboard.
+type CertExpiryManager interface {
+	SyncTiDBDashboard(dashboard *v1alpha1.TidbDashboard, tc *v1alpha1.TidbCluster) error
+}
+
 // ControlInterface abstracts the business logic for TiDBDashboard reconciliation.
 type ControlInterface interface {
 	Reconcile(*v1alpha1.TidbDashboard) error
@@ -47,6 +52,7 @@ func NewTiDBDashboardControl(
 	dashboardManager manager.TiDBDashboardManager,
 	tlsCertManager manager.TiDBDashboardManager,
 	reclaimPolicyManager ReclaimPolicyManager,
+	certExpiryManager CertExpiryManager,
 	recorder record.EventRecorder,
 ) ControlInterface {
 
@@ -56,6 +62,7 @@ func NewTiDBDashboardControl(
 		dashboardManager:     dashboardManager,
 		tlsCertManager:       tlsCertManager,
 		reclaimPolicyManager: reclaimPolicyManager,
+		certExpiryManager:    certExpiryManager,
 	}
 }
 
@@ -66,6 +73,7 @@ type defaultTiDBDashboardControl struct {
 	dashboardManager     manager.TiDBDashboardManager
 	tlsCertManager       manager.TiDBDashboardManager
 	reclaimPolicyManager ReclaimPolicyManager
+	certExpiryManager    CertExpiryManager
 }
 
 func (c *defaultTiDBDashboardControl) Reconcile(td *v1alpha1.TidbDashboard) error {
@@ -97,6 +105,18 @@ func (c *defaultTiDBDashboardControl) Reconcile(td *v1alpha1.TidbDashboard) erro
 		return err
 	}
 
+	// the client certificates of the tidb cluster are copied to tidb dashboard,
+	// stop here if any of them is expired, so that tidb dashboard is not rolled onto it
+	err = c.certExpiryManager.SyncTiDBDashboard(td, tc)
+	if err != nil {
+		if !apiequality.Semantic.DeepEqual(&td.Status, oldStatus) {
+			if _, uerr := c.updateStatus(td.DeepCopy()); uerr != nil {
+				klog.Errorf("TidbDashboard: [%s/%s], failed to update status, error: %v", td.Namespace, td.Name, uerr)
+			}
+		}
+		return err
+	}
+
 	err = c.tlsCertManager.Sync(td, tc)
 	if err != nil {
 		return err
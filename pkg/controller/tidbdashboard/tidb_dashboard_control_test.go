@@ -292,6 +292,7 @@ func newTidbDashboardControlForTest() (*defaultTiDBDashboardControl, *controller
 	tdManager := tidbdashboard.NewFakeManager()
 	tlsManager := tidbdashboard.NewFakeManager()
 	reclaimPolicyManager := meta.NewFakeReclaimPolicyManager()
+	certExpiryManager := meta.NewFakeCertExpiryManager()
 
 	control := &defaultTiDBDashboardControl{
 		deps:                 deps,
@@ -299,6 +300,7 @@ func newTidbDashboardControlForTest() (*defaultTiDBDashboardControl, *controller
 		dashboardManager:     tdManager,
 		tlsCertManager:       tlsManager,
 		reclaimPolicyManager: reclaimPolicyManager,
+		certExpiryManager:    certExpiryManager,
 	}
 
 	return control, deps
@@ -46,6 +46,7 @@ func NewController(deps *controller.Dependencies) *Controller {
 		tidbdashboard.NewManager(deps),
 		tidbdashboard.NewTcTlsManager(deps),
 		meta.NewReclaimPolicyManager(deps),
+		meta.NewCertExpiryManager(deps),
 		deps.Recorder,
 	)
 
@@ -145,6 +146,12 @@ func (c *Controller) sync(key string) (err error) {
 	if err != nil {
 		return err
 	}
+	release, owned := c.deps.Sharder.Acquire(ns, name)
+	if !owned {
+		// reconciled by another shard
+		return nil
+	}
+	defer release()
 
 	td, err := c.deps.TiDBDashboardLister.TidbDashboards(ns).Get(name)
 	if errors.IsNotFound(err) {
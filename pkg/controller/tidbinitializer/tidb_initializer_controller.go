@@ -134,6 +134,12 @@ func (c *Controller) sync(key string) (err error) {
 	if err != nil {
 		return err
 	}
+	release, owned := c.deps.Sharder.Acquire(ns, name)
+	if !owned {
+		// reconciled by another shard
+		return nil
+	}
+	defer release()
 	ti, err := c.deps.TiDBInitializerLister.TidbInitializers(ns).Get(name)
 	if errors.IsNotFound(err) {
 		klog.Infof("TiDBInitializer %v has been deleted", key)
@@ -144,6 +144,12 @@ func (c *Controller) sync(key string) (err error) {
 	if err != nil {
 		return err
 	}
+	release, owned := c.deps.Sharder.Acquire(ns, name)
+	if !owned {
+		// reconciled by another shard
+		return nil
+	}
+	defer release()
 
 	tngm, err := c.deps.TiDBNGMonitoringLister.TidbNGMonitorings(ns).Get(name)
 	if errors.IsNotFound(err) {
@@ -0,0 +1,200 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbtenant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
+	v1alpha1validation "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/third_party/k8s"
+
+	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// ControlInterface abstracts the business logic for TidbTenant reconciliation.
+type ControlInterface interface {
+	Reconcile(*v1alpha1.TidbTenant) error
+}
+
+func NewTiDBTenantControl(
+	deps *controller.Dependencies,
+	tenantManager manager.TiDBTenantManager,
+	recorder record.EventRecorder,
+) ControlInterface {
+	return &defaultTiDBTenantControl{
+		deps:          deps,
+		recorder:      recorder,
+		tenantManager: tenantManager,
+	}
+}
+
+type defaultTiDBTenantControl struct {
+	deps     *controller.Dependencies
+	recorder record.EventRecorder
+
+	tenantManager manager.TiDBTenantManager
+}
+
+func (c *defaultTiDBTenantControl) Reconcile(tt *v1alpha1.TidbTenant) error {
+	// add the finalizer before anything is created for the tenant, so that the
+	// TiDB servers and the keyspace are always reclaimed when the tenant is deleted
+	if tt.DeletionTimestamp == nil && !k8s.ContainsString(tt.Finalizers, label.TiDBTenantProtectionFinalizer, nil) {
+		tt.Finalizers = append(tt.Finalizers, label.TiDBTenantProtectionFinalizer)
+		updated, err := c.deps.Clientset.PingcapV1alpha1().TidbTenants(tt.Namespace).Update(context.TODO(), tt, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("add TidbTenant %s/%s protection finalizer failed, err: %v", tt.Namespace, tt.Name, err)
+		}
+		tt = updated
+	}
+
+	c.defaulting(tt)
+	if !c.validate(tt) {
+		return nil
+	}
+
+	if tt.DeletionTimestamp != nil {
+		return c.reclaim(tt)
+	}
+
+	oldStatus := tt.Status.DeepCopy()
+
+	tcRef := tt.Spec.Cluster
+	tc, err := c.deps.TiDBClusterLister.TidbClusters(tcRef.Namespace).Get(tcRef.Name)
+	if err != nil {
+		return fmt.Errorf("get tc %s/%s failed: %s", tcRef.Namespace, tcRef.Name, err)
+	}
+
+	err = c.tenantManager.Sync(tt, tc)
+
+	if !apiequality.Semantic.DeepEqual(&tt.Status, oldStatus) {
+		if _, updateErr := c.updateStatus(tt.DeepCopy()); updateErr != nil {
+			return updateErr
+		}
+	}
+
+	return err
+}
+
+// reclaim reclaims the TiDB servers and the keyspace of a deleted TidbTenant
+// and removes the protection finalizer when all of them are reclaimed.
+func (c *defaultTiDBTenantControl) reclaim(tt *v1alpha1.TidbTenant) error {
+	if !k8s.ContainsString(tt.Finalizers, label.TiDBTenantProtectionFinalizer, nil) {
+		return nil
+	}
+
+	tcRef := tt.Spec.Cluster
+	tc, err := c.deps.TiDBClusterLister.TidbClusters(tcRef.Namespace).Get(tcRef.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("get tc %s/%s failed: %s", tcRef.Namespace, tcRef.Name, err)
+	}
+
+	if errors.IsNotFound(err) {
+		// the TiDB servers are garbage collected with the TidbCluster, and the keyspace can not be reclaimed without PD
+		c.recorder.Eventf(tt, v1.EventTypeWarning, "SkipReclaimKeyspace", "TidbCluster %s/%s is not found, skip reclaiming keyspace %s", tcRef.Namespace, tcRef.Name, tt.GetKeyspaceName())
+	} else if err := c.tenantManager.Reclaim(tt, tc); err != nil {
+		return err
+	}
+
+	// remove the finalizer from the object in cache, which is not defaulted
+	latest, err := c.deps.TiDBTenantLister.TidbTenants(tt.Namespace).Get(tt.Name)
+	if err != nil {
+		return err
+	}
+	latest = latest.DeepCopy()
+	latest.Finalizers = k8s.RemoveString(latest.Finalizers, label.TiDBTenantProtectionFinalizer, nil)
+	if _, err := c.deps.Clientset.PingcapV1alpha1().TidbTenants(tt.Namespace).Update(context.TODO(), latest, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("remove TidbTenant %s/%s protection finalizer failed, err: %v", tt.Namespace, tt.Name, err)
+	}
+	klog.Infof("remove TidbTenant %s/%s protection finalizer success", tt.Namespace, tt.Name)
+	return nil
+}
+
+func (c *defaultTiDBTenantControl) updateStatus(tt *v1alpha1.TidbTenant) (*v1alpha1.TidbTenant, error) {
+	var (
+		ns     = tt.GetNamespace()
+		name   = tt.GetName()
+		status = tt.Status.DeepCopy()
+		update *v1alpha1.TidbTenant
+	)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var updateErr error
+		update, updateErr = c.deps.Clientset.PingcapV1alpha1().TidbTenants(ns).UpdateStatus(context.TODO(), tt, metav1.UpdateOptions{})
+		if updateErr == nil {
+			klog.Infof("TidbTenant: [%s/%s], update status successfully", ns, name)
+			return nil
+		}
+
+		klog.V(4).Infof("TidbTenant: [%s/%s], update status failed, error: %v", ns, name, updateErr)
+
+		// If failed to update status, then:
+		// get the latest TidbTenant, override the status to local newest, prepare for next update.
+		if updated, err := c.deps.TiDBTenantLister.TidbTenants(ns).Get(name); err == nil {
+			tt = updated.DeepCopy()
+			tt.Status = *status
+		} else {
+			utilruntime.HandleError(fmt.Errorf("error getting updated TidbTenant %s/%s from lister: %v", ns, name, err))
+		}
+
+		return updateErr
+	})
+	if err != nil {
+		klog.Errorf("TidbTenant: [%s/%s], failed to updateStatus, error: %v", ns, name, err)
+	}
+
+	return update, err
+}
+
+func (c *defaultTiDBTenantControl) defaulting(tt *v1alpha1.TidbTenant) {
+	defaulting.SetTidbTenantDefault(tt)
+}
+
+func (c *defaultTiDBTenantControl) validate(tt *v1alpha1.TidbTenant) bool {
+	errs := v1alpha1validation.ValidateTiDBTenant(tt)
+	if len(errs) > 0 {
+		aggregatedErr := errs.ToAggregate()
+		klog.Errorf("tidb tenant %s/%s is not valid and must be fixed first, aggregated error: %v", tt.GetNamespace(), tt.GetName(), aggregatedErr)
+		c.recorder.Event(tt, v1.EventTypeWarning, "FailedValidation", aggregatedErr.Error())
+		return false
+	}
+	return true
+}
+
+type FakeTiDBTenantControl struct {
+	reconcile func(tenant *v1alpha1.TidbTenant) error
+}
+
+func (c *FakeTiDBTenantControl) MockReconcile(reconcile func(*v1alpha1.TidbTenant) error) {
+	c.reconcile = reconcile
+}
+
+func (c *FakeTiDBTenantControl) Reconcile(tt *v1alpha1.TidbTenant) error {
+	if c.reconcile != nil {
+		return c.reconcile(tt)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbtenant
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager/member"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func newTidbTenant() *v1alpha1.TidbTenant {
+	return &v1alpha1.TidbTenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tenant-a",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.TidbTenantSpec{
+			Cluster: v1alpha1.TidbClusterRef{Name: "tc"},
+			TiDB:    v1alpha1.TiDBSpec{Replicas: 1},
+		},
+	}
+}
+
+func newFakeTiDBTenantControl(objs ...interface{}) (*defaultTiDBTenantControl, *member.FakeTiDBTenantMemberManager) {
+	deps := controller.NewFakeDependencies()
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *v1alpha1.TidbTenant:
+			deps.Clientset.PingcapV1alpha1().TidbTenants(o.Namespace).Create(context.TODO(), o, metav1.CreateOptions{})
+			deps.InformerFactory.Pingcap().V1alpha1().TidbTenants().Informer().GetIndexer().Add(o)
+		case *v1alpha1.TidbCluster:
+			deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer().Add(o)
+		}
+	}
+	tenantManager := member.NewFakeTiDBTenantMemberManager()
+	control := NewTiDBTenantControl(deps, tenantManager, record.NewFakeRecorder(10)).(*defaultTiDBTenantControl)
+	return control, tenantManager
+}
+
+func TestTiDBTenantControlReconcile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{}
+	tc.Name = "tc"
+	tc.Namespace = "default"
+
+	// the finalizer is added before the tenant is synced
+	tt := newTidbTenant()
+	control, tenantManager := newFakeTiDBTenantControl(tt.DeepCopy(), tc)
+	tenantManager.SetSyncError(fmt.Errorf("sync error"))
+	err := control.Reconcile(tt.DeepCopy())
+	g.Expect(err).To(MatchError("sync error"))
+	updated, err := control.deps.Clientset.PingcapV1alpha1().TidbTenants(tt.Namespace).Get(context.TODO(), tt.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(updated.Finalizers).To(ContainElement(label.TiDBTenantProtectionFinalizer))
+	// defaults are not persisted
+	g.Expect(updated.Spec.TiDB.Config).To(BeNil())
+
+	// the finalizer is removed after the tenant is reclaimed
+	tt = newTidbTenant()
+	tt.Finalizers = []string{label.TiDBTenantProtectionFinalizer}
+	tt.DeletionTimestamp = &metav1.Time{}
+	control, tenantManager = newFakeTiDBTenantControl(tt.DeepCopy(), tc)
+	tenantManager.SetSyncError(controller.RequeueErrorf("reclaiming"))
+	err = control.Reconcile(tt.DeepCopy())
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	updated, err = control.deps.Clientset.PingcapV1alpha1().TidbTenants(tt.Namespace).Get(context.TODO(), tt.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(updated.Finalizers).To(ContainElement(label.TiDBTenantProtectionFinalizer))
+
+	tenantManager.SetSyncError(nil)
+	err = control.Reconcile(tt.DeepCopy())
+	g.Expect(err).NotTo(HaveOccurred())
+	updated, err = control.deps.Clientset.PingcapV1alpha1().TidbTenants(tt.Namespace).Get(context.TODO(), tt.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(updated.Finalizers).NotTo(ContainElement(label.TiDBTenantProtectionFinalizer))
+
+	// the finalizer is removed if the TidbCluster is gone
+	control, _ = newFakeTiDBTenantControl(tt.DeepCopy())
+	err = control.Reconcile(tt.DeepCopy())
+	g.Expect(err).NotTo(HaveOccurred())
+	updated, err = control.deps.Clientset.PingcapV1alpha1().TidbTenants(tt.Namespace).Get(context.TODO(), tt.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(updated.Finalizers).NotTo(ContainElement(label.TiDBTenantProtectionFinalizer))
+}
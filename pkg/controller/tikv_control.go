@@ -18,6 +18,13 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/tikvapi"
 )
 
+// GetTiKVPodClient returns the TiKVClient of the TiKV pod of the given TiKV group of tc, the empty
+// group name stands for the default TiKV of the cluster
+func GetTiKVPodClient(tikvControl tikvapi.TiKVControlInterface, tc *v1alpha1.TidbCluster, groupName, podName string) tikvapi.TiKVClient {
+	return tikvControl.GetTiKVPodClient(tc.GetNamespace(), tc.GetName(), TiKVGroupPeerMemberName(tc.GetName(), groupName),
+		podName, tc.Spec.ClusterDomain, tc.IsTLSClusterEnabled())
+}
+
 // NewFakeTiKVClient creates a fake tikvclient that is set as the tikv client
 func NewFakeTiKVClient(tikvControl *tikvapi.FakeTiKVControl, tc *v1alpha1.TidbCluster, podName string) *tikvapi.FakeTiKVClient {
 	tikvClient := tikvapi.NewFakeTiKVClient()
@@ -36,6 +36,8 @@ type TiDBDiscovery interface {
 	Discover(string) (string, error)
 	DiscoverDM(string) (string, error)
 	VerifyPDEndpoint(string) (string, error)
+	// Ready returns an error if the discovery can not serve the requests
+	Ready() error
 }
 
 type tidbDiscovery struct {
@@ -45,6 +47,8 @@ type tidbDiscovery struct {
 	dmClusters    map[string]*clusterInfo
 	pdControl     pdapi.PDControlInterface
 	masterControl dmapi.MasterControlInterface
+	// store persists the states cached in clusters and dmClusters, the states are only kept in memory if it is nil
+	store stateStore
 }
 
 type clusterInfo struct {
@@ -61,13 +65,19 @@ type pdEndpointURL struct {
 
 // NewTiDBDiscovery returns a TiDBDiscovery
 func NewTiDBDiscovery(pdControl pdapi.PDControlInterface, masterControl dmapi.MasterControlInterface, cli versioned.Interface, kubeCli kubernetes.Interface) TiDBDiscovery {
-	return &tidbDiscovery{
+	td := &tidbDiscovery{
 		cli:           cli,
 		pdControl:     pdControl,
 		masterControl: masterControl,
 		clusters:      map[string]*clusterInfo{},
 		dmClusters:    map[string]*clusterInfo{},
 	}
+	// the states are persisted in the ConfigMap created by the operator, so that multiple replicas
+	// of the discovery service can serve the requests
+	if name := os.Getenv("DISCOVERY_STATE_CONFIGMAP"); name != "" {
+		td.store = newConfigMapStateStore(kubeCli, os.Getenv("MY_POD_NAMESPACE"), name)
+	}
+	return td
 }
 
 func (d *tidbDiscovery) Discover(advertisePeerUrl string) (string, error) {
@@ -96,21 +106,26 @@ func (d *tidbDiscovery) Discover(advertisePeerUrl string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	keyName := fmt.Sprintf("%s/%s", ns, tcName)
+	var initialize bool
+	_, err = d.updateCluster(d.clusters, "pd", ns, tcName, func(currentCluster *clusterInfo) {
+		initialize = false
+		if currentCluster.peers == nil || currentCluster.resourceVersion != tc.ResourceVersion {
+			currentCluster.resourceVersion = tc.ResourceVersion
+			currentCluster.peers = map[string]struct{}{}
+		}
+		currentCluster.peers[podName] = struct{}{}
 
-	currentCluster := d.clusters[keyName]
-	if currentCluster == nil || currentCluster.resourceVersion != tc.ResourceVersion {
-		d.clusters[keyName] = &clusterInfo{
-			resourceVersion: tc.ResourceVersion,
-			peers:           map[string]struct{}{},
+		// Should take failover replicas into consideration
+		if len(currentCluster.peers) == int(tc.PDStsDesiredReplicas()) && tc.Spec.Cluster == nil {
+			delete(currentCluster.peers, podName)
+			initialize = true
 		}
+	})
+	if err != nil {
+		return "", err
 	}
-	currentCluster = d.clusters[keyName]
-	currentCluster.peers[podName] = struct{}{}
 
-	// Should take failover replicas into consideration
-	if len(currentCluster.peers) == int(tc.PDStsDesiredReplicas()) && tc.Spec.Cluster == nil {
-		delete(currentCluster.peers, podName)
+	if initialize {
 		pdAddresses := tc.Spec.PDAddresses
 		// Join an existing PD cluster if tc.Spec.PDAddresses is set
 		if len(pdAddresses) != 0 {
@@ -179,7 +194,11 @@ func (d *tidbDiscovery) Discover(advertisePeerUrl string) (string, error) {
 		memberURL := strings.ReplaceAll(member.PeerUrls[0], fmt.Sprintf(":%d", v1alpha1.DefaultPDPeerPort), fmt.Sprintf(":%d", v1alpha1.DefaultPDClientPort))
 		membersArr = append(membersArr, memberURL)
 	}
-	delete(currentCluster.peers, podName)
+	if _, err = d.updateCluster(d.clusters, "pd", ns, tcName, func(currentCluster *clusterInfo) {
+		delete(currentCluster.peers, podName)
+	}); err != nil {
+		return "", err
+	}
 	return fmt.Sprintf("--join=%s", strings.Join(membersArr, ",")), nil
 }
 
@@ -209,20 +228,25 @@ func (d *tidbDiscovery) DiscoverDM(advertisePeerUrl string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	keyName := fmt.Sprintf("%s/%s", ns, dcName)
+	var initialize bool
+	_, err = d.updateCluster(d.dmClusters, "dm-master", ns, dcName, func(currentCluster *clusterInfo) {
+		initialize = false
+		if currentCluster.peers == nil || currentCluster.resourceVersion != dc.ResourceVersion {
+			currentCluster.resourceVersion = dc.ResourceVersion
+			currentCluster.peers = map[string]struct{}{}
+		}
+		currentCluster.peers[podName] = struct{}{}
 
-	currentCluster := d.dmClusters[keyName]
-	if currentCluster == nil || currentCluster.resourceVersion != dc.ResourceVersion {
-		d.dmClusters[keyName] = &clusterInfo{
-			resourceVersion: dc.ResourceVersion,
-			peers:           map[string]struct{}{},
+		if len(currentCluster.peers) == int(dc.MasterStsDesiredReplicas()) {
+			delete(currentCluster.peers, podName)
+			initialize = true
 		}
+	})
+	if err != nil {
+		return "", err
 	}
-	currentCluster = d.dmClusters[keyName]
-	currentCluster.peers[podName] = struct{}{}
 
-	if len(currentCluster.peers) == int(dc.MasterStsDesiredReplicas()) {
-		delete(currentCluster.peers, podName)
+	if initialize {
 		return fmt.Sprintf("--initial-cluster=%s=%s://%s", podName, dc.Scheme(), advertisePeerUrl), nil
 	}
 
@@ -243,7 +267,11 @@ func (d *tidbDiscovery) DiscoverDM(advertisePeerUrl string) (string, error) {
 		memberURL := strings.ReplaceAll(master.PeerURLs[0], ":8291", ":8261")
 		mastersArr = append(mastersArr, memberURL)
 	}
-	delete(currentCluster.peers, podName)
+	if _, err = d.updateCluster(d.dmClusters, "dm-master", ns, dcName, func(currentCluster *clusterInfo) {
+		delete(currentCluster.peers, podName)
+	}); err != nil {
+		return "", err
+	}
 	return fmt.Sprintf("--join=%s", strings.Join(mastersArr, ",")), nil
 }
 
@@ -286,6 +314,35 @@ func (d *tidbDiscovery) VerifyPDEndpoint(pdURL string) (string, error) {
 	return strings.Join(returnPDMembers, ","), nil
 }
 
+func (d *tidbDiscovery) Ready() error {
+	if d.store == nil {
+		return nil
+	}
+	return d.store.Ready()
+}
+
+// updateCluster calls fn to update the state of the cluster with the given component, namespace and name.
+// The state is read from and written back to the store if it is set, and is cached in clusters.
+func (d *tidbDiscovery) updateCluster(clusters map[string]*clusterInfo, component, ns, name string, fn func(*clusterInfo)) (*clusterInfo, error) {
+	keyName := fmt.Sprintf("%s/%s", ns, name)
+	if d.store == nil {
+		currentCluster := clusters[keyName]
+		if currentCluster == nil {
+			currentCluster = &clusterInfo{}
+			clusters[keyName] = currentCluster
+		}
+		fn(currentCluster)
+		return currentCluster, nil
+	}
+
+	currentCluster, err := d.store.Update(fmt.Sprintf("%s.%s", component, name), fn)
+	if err != nil {
+		return nil, err
+	}
+	clusters[keyName] = currentCluster
+	return currentCluster, nil
+}
+
 // parsePDURL parses pdURL to PDEndpoint related information
 func parsePDURL(pdURL string) pdEndpointURL {
 	// Deal with scheme
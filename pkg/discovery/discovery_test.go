@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/dmapi"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
@@ -1148,6 +1149,84 @@ func TestDiscoveryVerifyPDEndpoint(t *testing.T) {
 	}
 }
 
+func TestDiscoveryWithPersistedState(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	os.Setenv("MY_POD_NAMESPACE", metav1.NamespaceDefault)
+	os.Setenv("DISCOVERY_STATE_CONFIGMAP", "demo-discovery")
+	defer os.Unsetenv("DISCOVERY_STATE_CONFIGMAP")
+
+	tc := newTC()
+	dc := newDC()
+	cli := fake.NewSimpleClientset(tc, dc)
+	kubeCli := kubefake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo-discovery", Namespace: metav1.NamespaceDefault},
+	})
+	informer := kubeinformers.NewSharedInformerFactory(kubeCli, 0)
+	fakePDControl := pdapi.NewFakePDControl(informer.Core().V1().Secrets().Lister())
+	fakeMasterControl := dmapi.NewFakeMasterControl(informer.Core().V1().Secrets().Lister())
+	pdClient := pdapi.NewFakePDClient()
+	fakePDControl.SetPDClient(pdapi.Namespace(tc.GetNamespace()), tc.GetName(), pdClient)
+	masterClient := dmapi.NewFakeMasterClient()
+	fakeMasterControl.SetMasterClient(dc.GetNamespace(), dc.GetName(), masterClient)
+
+	var members *pdapi.MembersInfo
+	pdClient.AddReaction(pdapi.GetMembersActionType, func(action *pdapi.Action) (interface{}, error) {
+		if members == nil {
+			return nil, fmt.Errorf("no members yet")
+		}
+		return members, nil
+	})
+	masterClient.AddReaction(dmapi.GetMastersActionType, func(action *dmapi.Action) (interface{}, error) {
+		return nil, fmt.Errorf("no masters yet")
+	})
+
+	// two replicas of the discovery service share the states persisted in the ConfigMap
+	replicas := []TiDBDiscovery{
+		NewTiDBDiscovery(fakePDControl, fakeMasterControl, cli, kubeCli),
+		NewTiDBDiscovery(fakePDControl, fakeMasterControl, cli, kubeCli),
+	}
+	for _, td := range replicas {
+		g.Expect(td.Ready()).To(Succeed())
+	}
+
+	_, err := replicas[0].Discover("demo-pd-0.demo-pd-peer.default.svc:2380")
+	g.Expect(err).To(HaveOccurred())
+	_, err = replicas[1].Discover("demo-pd-1.demo-pd-peer.default.svc:2380")
+	g.Expect(err).To(HaveOccurred())
+	// the state survives the restart of the replica
+	replicas[0] = NewTiDBDiscovery(fakePDControl, fakeMasterControl, cli, kubeCli)
+	re, err := replicas[0].Discover("demo-pd-2.demo-pd-peer.default.svc:2380")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(re).To(Equal("--initial-cluster=demo-pd-2=http://demo-pd-2.demo-pd-peer.default.svc:2380"))
+
+	members = &pdapi.MembersInfo{
+		Members: []*pdpb.Member{
+			{Name: "demo-pd-2", PeerUrls: []string{"http://demo-pd-2.demo-pd-peer.default.svc:2380"}},
+		},
+	}
+	re, err = replicas[1].Discover("demo-pd-0.demo-pd-peer.default.svc:2380")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(re).To(Equal("--join=http://demo-pd-2.demo-pd-peer.default.svc:2379"))
+	g.Expect(len(replicas[1].(*tidbDiscovery).clusters["default/demo"].peers)).To(Equal(1))
+	g.Expect(replicas[1].(*tidbDiscovery).clusters["default/demo"].peers["demo-pd-1"]).To(Equal(struct{}{}))
+
+	_, err = replicas[0].DiscoverDM("demo-dm-master-0.demo-dm-master-peer:8291")
+	g.Expect(err).To(HaveOccurred())
+	_, err = replicas[1].DiscoverDM("demo-dm-master-1.demo-dm-master-peer:8291")
+	g.Expect(err).To(HaveOccurred())
+	re, err = replicas[0].DiscoverDM("demo-dm-master-2.demo-dm-master-peer:8291")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(re).To(Equal("--initial-cluster=demo-dm-master-2=http://demo-dm-master-2.demo-dm-master-peer:8291"))
+
+	cm, err := kubeCli.CoreV1().ConfigMaps(metav1.NamespaceDefault).Get(context.TODO(), "demo-discovery", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data).To(Equal(map[string]string{
+		"pd.demo":        `{"resourceVersion":"1","peers":["demo-pd-1"]}`,
+		"dm-master.demo": `{"resourceVersion":"1","peers":["demo-dm-master-0","demo-dm-master-1"]}`,
+	}))
+}
+
 func newTC() *v1alpha1.TidbCluster {
 	return &v1alpha1.TidbCluster{
 		TypeMeta: metav1.TypeMeta{Kind: "TidbCluster", APIVersion: "v1alpha1"},
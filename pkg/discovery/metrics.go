@@ -0,0 +1,77 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// RequestTypePD is the type of the requests from PD members
+	RequestTypePD = "pd"
+	// RequestTypeDM is the type of the requests from dm-master members
+	RequestTypeDM = "dm"
+	// RequestTypeVerify is the type of the requests to verify the PD endpoints
+	RequestTypeVerify = "verify"
+)
+
+var (
+	// requestCounter counts the requests served by the discovery service by the type and the result
+	requestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "discovery",
+			Name:      "requests_total",
+			Help:      "Total number of the requests served by the discovery service",
+		}, []string{"type", "result"})
+
+	// requestDuration observes the duration of the requests served by the discovery service
+	requestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "discovery",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the requests served by the discovery service",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"type"})
+
+	// stateConflictCounter counts the conflicts of the updates of the persisted states between the replicas
+	stateConflictCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "discovery",
+			Name:      "state_conflicts_total",
+			Help:      "Total number of the conflicts when updating the persisted bootstrap states",
+		})
+)
+
+func init() {
+	prometheus.MustRegister(
+		requestCounter,
+		requestDuration,
+		stateConflictCounter,
+	)
+}
+
+// ObserveRequest records the result and the duration of a request of the given type
+func ObserveRequest(requestType string, start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	requestCounter.WithLabelValues(requestType, result).Inc()
+	requestDuration.WithLabelValues(requestType).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// activatorWakeUpInterval is the min interval between two wake-up requests
+	activatorWakeUpInterval = 5 * time.Second
+	// activatorWaitTimeout is the max time to wait for TiDB to be ready for a client connection
+	activatorWaitTimeout = 5 * time.Minute
+	// activatorDialInterval is the interval between two attempts to connect to TiDB
+	activatorDialInterval = time.Second
+)
+
+// activatorServer accepts the client connections to the idle TiDB, requests the operator to wake up TiDB
+// by the annotation `tidb.pingcap.com/tidb-wake-up` of the TidbCluster, and forwards the connections to
+// TiDB after it is ready. The MySQL protocol is server-first, so the clients just see a slow handshake.
+type activatorServer struct {
+	cli         versioned.Interface
+	ns          string
+	tcName      string
+	target      string
+	waitTimeout time.Duration
+
+	lock       sync.Mutex
+	lastWakeUp time.Time
+}
+
+func NewActivatorServer(tcName, ns string, cli versioned.Interface) Server {
+	return &activatorServer{
+		cli:         cli,
+		ns:          ns,
+		tcName:      tcName,
+		target:      fmt.Sprintf("%s.%s:%d", controller.TiDBPeerMemberName(tcName), ns, v1alpha1.DefaultTiDBServerPort),
+		waitTimeout: activatorWaitTimeout,
+	}
+}
+
+func (a *activatorServer) ListenAndServe(addr string) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		klog.Fatal(err)
+	}
+	klog.Fatal(a.serve(ln))
+}
+
+func (a *activatorServer) serve(ln net.Listener) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		go a.handle(conn)
+	}
+}
+
+func (a *activatorServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	backend, err := a.dial()
+	if err != nil {
+		klog.Errorf("failed to forward the connection from %s to TiDB of %s/%s, err: %v", conn.RemoteAddr(), a.ns, a.tcName, err)
+		return
+	}
+	defer backend.Close()
+
+	done := make(chan struct{}, 2)
+	pipe := func(dst, src net.Conn) {
+		io.Copy(dst, src)
+		// unblock the other direction
+		dst.Close()
+		src.Close()
+		done <- struct{}{}
+	}
+	go pipe(backend, conn)
+	go pipe(conn, backend)
+	<-done
+	<-done
+}
+
+// dial connects to TiDB until it succeeds or times out, TiDB is requested to wake up on every attempt
+// in case the previous request is lost.
+func (a *activatorServer) dial() (net.Conn, error) {
+	deadline := time.Now().Add(a.waitTimeout)
+	for {
+		if err := a.wakeUp(); err != nil {
+			klog.Warningf("failed to wake up TiDB of %s/%s, err: %v", a.ns, a.tcName, err)
+		}
+		conn, err := net.DialTimeout("tcp", a.target, activatorDialInterval)
+		if err == nil {
+			return conn, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("TiDB is not ready in %s, last err: %v", a.waitTimeout, err)
+		}
+		time.Sleep(activatorDialInterval)
+	}
+}
+
+// wakeUp sets the annotation `tidb.pingcap.com/tidb-wake-up` of the TidbCluster to the current time,
+// it is skipped if TiDB has been requested to wake up in activatorWakeUpInterval.
+func (a *activatorServer) wakeUp() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	now := time.Now()
+	if now.Sub(a.lastWakeUp) < activatorWakeUpInterval {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				label.AnnTiDBWakeUp: now.UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := a.cli.PingcapV1alpha1().TidbClusters(a.ns).Patch(context.TODO(), a.tcName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	klog.Infof("requested to wake up TiDB of %s/%s", a.ns, a.tcName)
+	a.lastWakeUp = now
+	return nil
+}
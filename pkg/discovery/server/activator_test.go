@@ -0,0 +1,98 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestActivatorServer(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+	cli := fake.NewSimpleClientset(tc)
+
+	t.Log("create an echo server as TiDB")
+	tidb, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer tidb.Close()
+	go func() {
+		for {
+			conn, err := tidb.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	t.Log("create an activator server")
+	s := NewActivatorServer("foo", "default", cli).(*activatorServer)
+	s.target = tidb.Addr().String()
+	s.waitTimeout = 10 * time.Second
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer ln.Close()
+	go s.serve(ln)
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	g.Expect(err).NotTo(HaveOccurred())
+	defer conn.Close()
+	_, err = conn.Write([]byte("ping"))
+	g.Expect(err).NotTo(HaveOccurred())
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(buf)).To(Equal("ping"))
+
+	t.Log("TiDB is requested to wake up")
+	updated, err := cli.PingcapV1alpha1().TidbClusters("default").Get(context.TODO(), "foo", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	wakeUpTime, err := time.Parse(time.RFC3339, updated.Annotations[label.AnnTiDBWakeUp])
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(time.Since(wakeUpTime)).To(BeNumerically("<", time.Minute))
+
+	t.Log("the wake-up request is rate limited")
+	lastWakeUp := s.lastWakeUp
+	g.Expect(s.wakeUp()).To(Succeed())
+	g.Expect(s.lastWakeUp).To(Equal(lastWakeUp))
+}
+
+func TestActivatorServerTimeout(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// no TidbCluster, the wake-up request fails but the connection is still tried
+	s := NewActivatorServer("foo", "default", fake.NewSimpleClientset()).(*activatorServer)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).NotTo(HaveOccurred())
+	s.target = ln.Addr().String()
+	ln.Close()
+	s.waitTimeout = 0
+
+	_, err = s.dial()
+	g.Expect(err).To(MatchError(ContainSubstring("TiDB is not ready")))
+}
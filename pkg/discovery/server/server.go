@@ -19,6 +19,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/dmapi"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
 	"github.com/pingcap/tidb-operator/pkg/discovery"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 )
@@ -50,7 +52,10 @@ func (s *server) registerHandlers() {
 	ws.Route(ws.GET("/new/{advertise-peer-url}").To(s.newHandler))
 	ws.Route(ws.GET("/new/{advertise-peer-url}/{register-type}").To(s.newHandler))
 	ws.Route(ws.GET("/verify/{pd-url}").To(s.newVerifyHandler))
+	ws.Route(ws.GET("/healthz").To(s.healthzHandler))
+	ws.Route(ws.GET("/readyz").To(s.readyzHandler))
 	s.container.Add(ws)
+	s.container.Handle("/metrics", promhttp.Handler())
 }
 
 func (s *server) ListenAndServe(addr string) {
@@ -74,11 +79,14 @@ func (s *server) newHandler(req *restful.Request, resp *restful.Response) {
 	advertisePeerURL := string(data)
 
 	var result string
+	start := time.Now()
 	switch registerType {
-	case "pd":
+	case discovery.RequestTypePD:
 		result, err = s.discovery.Discover(advertisePeerURL)
-	case "dm":
+		discovery.ObserveRequest(registerType, start, err)
+	case discovery.RequestTypeDM:
 		result, err = s.discovery.DiscoverDM(advertisePeerURL)
+		discovery.ObserveRequest(registerType, start, err)
 	default:
 		err = fmt.Errorf("invalid register-type %s", registerType)
 		klog.Errorf("%v", err)
@@ -116,7 +124,9 @@ func (s *server) newVerifyHandler(req *restful.Request, resp *restful.Response)
 	pdPeerURL = strings.Trim(pdPeerURL, "\n")
 
 	var result string
+	start := time.Now()
 	result, err = s.discovery.VerifyPDEndpoint(pdPeerURL)
+	discovery.ObserveRequest(discovery.RequestTypeVerify, start, err)
 	if err != nil {
 		klog.Errorf("failed to verify pd-url: %s, %v", pdPeerURL, err)
 		if werr := resp.WriteError(http.StatusInternalServerError, err); werr != nil {
@@ -131,3 +141,24 @@ func (s *server) newVerifyHandler(req *restful.Request, resp *restful.Response)
 		klog.Errorf("failed to writeString: %s, %v", result, err)
 	}
 }
+
+func (s *server) healthzHandler(req *restful.Request, resp *restful.Response) {
+	if _, err := io.WriteString(resp, "ok"); err != nil {
+		klog.Errorf("failed to writeString: %v", err)
+	}
+}
+
+// readyzHandler reports the discovery is not ready if the persisted states can not be accessed,
+// so that the requests are only routed to the replicas that can serve them.
+func (s *server) readyzHandler(req *restful.Request, resp *restful.Response) {
+	if err := s.discovery.Ready(); err != nil {
+		klog.Errorf("discovery is not ready: %v", err)
+		if werr := resp.WriteError(http.StatusServiceUnavailable, err); werr != nil {
+			klog.Errorf("failed to writeError: %v", werr)
+		}
+		return
+	}
+	if _, err := io.WriteString(resp, "ok"); err != nil {
+		klog.Errorf("failed to writeString: %v", err)
+	}
+}
@@ -265,3 +265,55 @@ func TestVerifyServer(t *testing.T) {
 		t.Errorf("verify pdEndpoint failed: %v", err)
 	}
 }
+
+func TestServerHealthEndpoints(t *testing.T) {
+	os.Setenv("MY_POD_NAMESPACE", "default")
+	cli := fake.NewSimpleClientset()
+	kubeCli := kubefake.NewSimpleClientset()
+	informer := informers.NewSharedInformerFactory(kubeCli, 0)
+	fakePDControl := pdapi.NewFakePDControl(informer.Core().V1().Secrets().Lister())
+	fakeMasterControl := dmapi.NewFakeMasterControl(informer.Core().V1().Secrets().Lister())
+
+	get := func(httpServer *httptest.Server, path string) (int, string) {
+		resp, err := http.Get(httpServer.URL + path)
+		if err != nil {
+			t.Fatalf("get %s failed: %v", path, err)
+		}
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("read %s failed: %v", path, err)
+		}
+		return resp.StatusCode, string(data)
+	}
+
+	s := NewServer(fakePDControl, fakeMasterControl, cli, kubeCli)
+	httpServer := httptest.NewServer(s.(*server).container.ServeMux)
+	defer httpServer.Close()
+	for _, path := range []string{"/healthz", "/readyz"} {
+		if code, _ := get(httpServer, path); code != http.StatusOK {
+			t.Errorf("%s expects %d, got %d", path, http.StatusOK, code)
+		}
+	}
+	get(httpServer, fmt.Sprintf("/verify/%s", base64.StdEncoding.EncodeToString([]byte("demo-pd:2379"))))
+	code, metrics := get(httpServer, "/metrics")
+	if code != http.StatusOK || !strings.Contains(metrics, `tidb_operator_discovery_requests_total{result="error",type="verify"}`) {
+		t.Errorf("metrics of the requests are not exported, code: %d", code)
+	}
+
+	// the discovery is not ready until the ConfigMap of the states is created
+	os.Setenv("DISCOVERY_STATE_CONFIGMAP", "foo-discovery")
+	defer os.Unsetenv("DISCOVERY_STATE_CONFIGMAP")
+	s = NewServer(fakePDControl, fakeMasterControl, cli, kubeCli)
+	httpServer = httptest.NewServer(s.(*server).container.ServeMux)
+	defer httpServer.Close()
+	if code, _ := get(httpServer, "/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("readyz expects %d, got %d", http.StatusServiceUnavailable, code)
+	}
+	kubeCli.CoreV1().ConfigMaps("default").Create(context.TODO(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-discovery", Namespace: "default"},
+	}, metav1.CreateOptions{})
+	if code, _ := get(httpServer, "/readyz"); code != http.StatusOK {
+		t.Errorf("readyz expects %d, got %d", http.StatusOK, code)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// stateStore persists the bootstrap states of the clusters, so that the states survive the restarts of
+// the discovery pods and are shared by all the replicas of the discovery service.
+type stateStore interface {
+	// Update reads the state of the given key, calls fn to modify it and writes it back. fn may be called
+	// more than once if the state is modified by other replicas at the same time.
+	Update(key string, fn func(*clusterInfo)) (*clusterInfo, error)
+	// Ready returns an error if the store can not be accessed
+	Ready() error
+}
+
+// persistedClusterInfo is the format of clusterInfo in the store
+type persistedClusterInfo struct {
+	ResourceVersion string   `json:"resourceVersion"`
+	Peers           []string `json:"peers,omitempty"`
+}
+
+// configMapStateStore stores the states in the data of a ConfigMap created by the operator, and
+// uses the resource version of the ConfigMap for optimistic concurrency between the replicas.
+type configMapStateStore struct {
+	kubeCli   kubernetes.Interface
+	namespace string
+	name      string
+}
+
+func newConfigMapStateStore(kubeCli kubernetes.Interface, namespace, name string) stateStore {
+	return &configMapStateStore{
+		kubeCli:   kubeCli,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+func (s *configMapStateStore) Update(key string, fn func(*clusterInfo)) (*clusterInfo, error) {
+	var info *clusterInfo
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := s.kubeCli.CoreV1().ConfigMaps(s.namespace).Get(context.TODO(), s.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		info, err = decodeClusterInfo(cm.Data[key])
+		if err != nil {
+			return fmt.Errorf("failed to decode the state %s in configmap %s/%s: %v", key, s.namespace, s.name, err)
+		}
+		fn(info)
+		data, err := encodeClusterInfo(info)
+		if err != nil {
+			return err
+		}
+		if cm.Data[key] == data {
+			return nil
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[key] = data
+		_, err = s.kubeCli.CoreV1().ConfigMaps(s.namespace).Update(context.TODO(), cm, metav1.UpdateOptions{})
+		if apierrors.IsConflict(err) {
+			stateConflictCounter.Inc()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (s *configMapStateStore) Ready() error {
+	_, err := s.kubeCli.CoreV1().ConfigMaps(s.namespace).Get(context.TODO(), s.name, metav1.GetOptions{})
+	return err
+}
+
+func decodeClusterInfo(data string) (*clusterInfo, error) {
+	info := &clusterInfo{peers: map[string]struct{}{}}
+	if data == "" {
+		return info, nil
+	}
+	persisted := persistedClusterInfo{}
+	if err := json.Unmarshal([]byte(data), &persisted); err != nil {
+		return nil, err
+	}
+	info.resourceVersion = persisted.ResourceVersion
+	for _, peer := range persisted.Peers {
+		info.peers[peer] = struct{}{}
+	}
+	return info, nil
+}
+
+func encodeClusterInfo(info *clusterInfo) (string, error) {
+	persisted := persistedClusterInfo{ResourceVersion: info.resourceVersion}
+	for peer := range info.peers {
+		persisted.Peers = append(persisted.Peers, peer)
+	}
+	sort.Strings(persisted.Peers)
+	data, err := json.Marshal(persisted)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+)
+
+func TestConfigMapStateStoreUpdate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	kubeCli := kubefake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo-discovery", Namespace: metav1.NamespaceDefault},
+		Data: map[string]string{
+			"pd.demo": `{"resourceVersion":"1","peers":["demo-pd-0"]}`,
+		},
+	})
+	// another replica updates the ConfigMap between the read and the write of the first attempt
+	conflicts := 0
+	kubeCli.PrependReactor("update", "configmaps", func(action core.Action) (bool, runtime.Object, error) {
+		if conflicts > 0 {
+			return false, nil, nil
+		}
+		conflicts++
+		return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "demo-discovery", nil)
+	})
+	store := newConfigMapStateStore(kubeCli, metav1.NamespaceDefault, "demo-discovery")
+	g.Expect(store.Ready()).To(Succeed())
+
+	calls := 0
+	info, err := store.Update("pd.demo", func(info *clusterInfo) {
+		calls++
+		info.peers["demo-pd-1"] = struct{}{}
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(calls).To(Equal(2))
+	g.Expect(info.resourceVersion).To(Equal("1"))
+	g.Expect(info.peers).To(HaveLen(2))
+
+	cm, err := kubeCli.CoreV1().ConfigMaps(metav1.NamespaceDefault).Get(context.TODO(), "demo-discovery", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data["pd.demo"]).To(Equal(`{"resourceVersion":"1","peers":["demo-pd-0","demo-pd-1"]}`))
+
+	// the state of a new cluster is empty
+	info, err = store.Update("dm-master.demo", func(info *clusterInfo) {})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info.resourceVersion).To(BeEmpty())
+	g.Expect(info.peers).To(BeEmpty())
+}
+
+func TestConfigMapStateStoreNotFound(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	store := newConfigMapStateStore(kubefake.NewSimpleClientset(), metav1.NamespaceDefault, "demo-discovery")
+	g.Expect(apierrors.IsNotFound(store.Ready())).To(BeTrue())
+	_, err := store.Update("pd.demo", func(info *clusterInfo) {})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
@@ -32,6 +32,7 @@ var (
 		AdvancedStatefulSet: false,
 		VolumeModifying:     false,
 		VolumeReplacing:     false,
+		HotConfigApply:      false,
 	}
 	// DefaultFeatureGate is a shared global FeatureGate.
 	DefaultFeatureGate FeatureGate = NewDefaultFeatureGate()
@@ -51,6 +52,10 @@ const (
 	// VolumeReplacing controls whether to replace whole volumes by deleting and recreating on changes.
 	// tidb, tikv & pd supported. If enabled takes precedence over resizing/modifying.
 	VolumeReplacing string = "VolumeReplacing"
+
+	// HotConfigApply controls whether to apply the config changes that can be changed online
+	// without restarting the servers. tidb, tikv & pd supported.
+	HotConfigApply string = "HotConfigApply"
 )
 
 type FeatureGate interface {
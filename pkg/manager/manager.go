@@ -35,3 +35,16 @@ type TiDBNGMonitoringManager interface {
 type TiDBDashboardManager interface {
 	Sync(*v1alpha1.TidbDashboard, *v1alpha1.TidbCluster) error
 }
+
+type TiDBTenantManager interface {
+	// Sync syncs the keyspace and the TiDB servers of the tenant.
+	Sync(*v1alpha1.TidbTenant, *v1alpha1.TidbCluster) error
+	// Reclaim removes the TiDB servers of the deleted tenant and reclaims its keyspace
+	// according to the reclaim policy, nil is returned once everything is reclaimed.
+	Reclaim(*v1alpha1.TidbTenant, *v1alpha1.TidbCluster) error
+}
+
+type TidbClusterScalingScheduleManager interface {
+	// Sync evaluates the windows of the schedule and sets the replicas of the cluster to the targets.
+	Sync(*v1alpha1.TidbClusterScalingSchedule, *v1alpha1.TidbCluster) error
+}
@@ -0,0 +1,289 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const changePlanPendingReason = "ChangePlanPending"
+
+// syncChangePlan records the planned changes of the StatefulSet of the component in the status of the
+// TidbCluster in the plan mode. Only the changes driven by the spec are planned: the changes of the pod
+// template and of the replicas in the spec. Until the plan is approved, they are held in newSet, while the
+// replicas added or removed by the operator, e.g. for failover, and the scaling in progress still proceed.
+// specReplicas is the replicas of the component in the spec. oldSet is nil if the StatefulSet does not
+// exist, it returns false if the StatefulSet must not be created because the plan is not approved yet.
+//
+// tc may be a view of a TiDB group, a TiKV group or the TiFlash compute nodes, which shares the plan ID of
+// the TidbCluster and whose plan is copied back to the TidbCluster by the caller.
+func syncChangePlan(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, specReplicas int32, oldSet, newSet *apps.StatefulSet) (bool, error) {
+	if !tc.IsChangePlanEnabled() {
+		tc.Status.ChangePlan = nil
+		return true, nil
+	}
+
+	id := tc.ChangePlanID()
+	if tc.Status.ChangePlan == nil || tc.Status.ChangePlan.ID != id {
+		// the spec is changed, the plan is computed again and needs to be approved again
+		tc.Status.ChangePlan = &v1alpha1.ChangePlanStatus{ID: id}
+	}
+	plan := tc.Status.ChangePlan
+	plan.Approved = tc.IsChangePlanApproved()
+
+	// the replicas added or removed by the operator are applied without approval
+	operatorReplicas := *newSet.Spec.Replicas - specReplicas
+	appliedSpecReplicas := specReplicas
+	plannedSet := newSet
+	if oldSet != nil {
+		appliedSpecReplicas = appliedSpecReplicasOf(oldSet, operatorReplicas)
+		plannedSet = newSet.DeepCopy()
+		*plannedSet.Spec.Replicas = *oldSet.Spec.Replicas + specReplicas - appliedSpecReplicas
+	}
+	componentPlan, err := planStatefulSet(deps, tc, memberType, oldSet, plannedSet)
+	if err != nil {
+		return false, err
+	}
+	empty := isComponentChangePlanEmpty(componentPlan)
+
+	index := -1
+	for i := range plan.Components {
+		// the default component and its groups are planned separately by their StatefulSets
+		if plan.Components[i].StatefulSet == newSet.GetName() {
+			index = i
+			break
+		}
+	}
+	changed := false
+	switch {
+	case index < 0 && empty:
+	case index < 0:
+		plan.Components = append(plan.Components, *componentPlan)
+		changed = true
+	case empty:
+		plan.Components = append(plan.Components[:index], plan.Components[index+1:]...)
+		plan.LastUpdateTime = metav1.Now()
+	case apiequality.Semantic.DeepEqual(plan.Components[index], *componentPlan):
+	default:
+		plan.Components[index] = *componentPlan
+		changed = true
+	}
+	if changed {
+		plan.LastUpdateTime = metav1.Now()
+		if !plan.Approved {
+			msg := fmt.Sprintf("%s changes are planned and wait for approval, approve the plan by annotating the TidbCluster with %s=%s",
+				memberType, label.AnnChangePlanApproved, id)
+			klog.Infof("TidbCluster: [%s/%s] %s", tc.GetNamespace(), tc.GetName(), msg)
+			deps.Recorder.Event(tc, corev1.EventTypeNormal, changePlanPendingReason, msg)
+		}
+	}
+
+	approved := empty || plan.Approved
+	if approved {
+		setAppliedSpecReplicas(newSet, specReplicas)
+		return true, nil
+	}
+	if oldSet == nil {
+		return false, nil
+	}
+
+	// hold the changes driven by the spec until the plan is approved
+	_, podSpec, err := GetLastAppliedConfig(oldSet)
+	if err != nil {
+		return false, err
+	}
+	newSet.Spec.Template.Spec = *podSpec
+	*newSet.Spec.Replicas = max(appliedSpecReplicas+operatorReplicas, 0)
+	setAppliedSpecReplicas(newSet, appliedSpecReplicas)
+	return true, nil
+}
+
+// appliedSpecReplicasOf returns the replicas in the spec applied to the StatefulSet, if it is not recorded,
+// e.g. the plan mode is just enabled, it is computed with the replicas added or removed by the operator now.
+func appliedSpecReplicasOf(set *apps.StatefulSet, operatorReplicas int32) int32 {
+	if v, ok := set.Annotations[label.AnnStsSpecReplicas]; ok {
+		if replicas, err := strconv.ParseInt(v, 10, 32); err == nil {
+			return int32(replicas)
+		}
+	}
+	return *set.Spec.Replicas - operatorReplicas
+}
+
+func setAppliedSpecReplicas(set *apps.StatefulSet, replicas int32) {
+	if set.Annotations == nil {
+		set.Annotations = map[string]string{}
+	}
+	set.Annotations[label.AnnStsSpecReplicas] = strconv.FormatInt(int64(replicas), 10)
+}
+
+// planStatefulSet computes the changes to apply to the StatefulSet of the component and its volumes
+func planStatefulSet(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, oldSet, newSet *apps.StatefulSet) (*v1alpha1.ComponentChangePlan, error) {
+	plan := &v1alpha1.ComponentChangePlan{
+		Component:   memberType,
+		StatefulSet: newSet.GetName(),
+	}
+
+	newOrdinals := helper.GetPodOrdinals(*newSet.Spec.Replicas, newSet)
+	if oldSet == nil {
+		plan.ScaleOutOrdinals = newOrdinals.List()
+		return plan, nil
+	}
+	oldOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet)
+	plan.ScaleOutOrdinals = newOrdinals.Difference(oldOrdinals).List()
+	plan.ScaleInOrdinals = oldOrdinals.Difference(newOrdinals).List()
+	keptOrdinals := oldOrdinals.Intersection(newOrdinals).List()
+
+	if !templateEqual(newSet, oldSet) {
+		_, podSpec, err := GetLastAppliedConfig(oldSet)
+		if err != nil {
+			plan.TemplateChanges = []string{"spec"}
+		} else {
+			plan.TemplateChanges = podSpecChanges(podSpec, &newSet.Spec.Template.Spec)
+		}
+		// pods are restarted from the largest ordinal to the smallest one
+		for i := len(keptOrdinals) - 1; i >= 0; i-- {
+			plan.UpgradeOrdinals = append(plan.UpgradeOrdinals, keptOrdinals[i])
+		}
+	}
+
+	ns := tc.GetNamespace()
+	for _, vct := range newSet.Spec.VolumeClaimTemplates {
+		desiredSize := vct.Spec.Resources.Requests[corev1.ResourceStorage]
+		for _, ordinal := range keptOrdinals {
+			pvcName := fmt.Sprintf("%s-%s-%d", vct.Name, newSet.GetName(), ordinal)
+			pvc, err := deps.PVCLister.PersistentVolumeClaims(ns).Get(pvcName)
+			if errors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("planStatefulSet: failed to get pvc %s/%s, error: %v", ns, pvcName, err)
+			}
+
+			size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+			scChanged := vct.Spec.StorageClassName != nil && *vct.Spec.StorageClassName != stringValue(pvc.Spec.StorageClassName)
+			var action v1alpha1.VolumeChangeAction
+			switch cmp := desiredSize.Cmp(size); {
+			case scChanged || cmp < 0:
+				action = v1alpha1.VolumeChangeReplace
+			case cmp > 0:
+				action = v1alpha1.VolumeChangeResize
+			default:
+				continue
+			}
+			plan.VolumeChanges = append(plan.VolumeChanges, v1alpha1.VolumeChangePlan{
+				PVCName: pvcName,
+				Action:  action,
+				From:    volumeDescription(pvc.Spec.StorageClassName, size),
+				To:      volumeDescription(vct.Spec.StorageClassName, desiredSize),
+			})
+		}
+	}
+
+	return plan, nil
+}
+
+func isComponentChangePlanEmpty(plan *v1alpha1.ComponentChangePlan) bool {
+	return len(plan.TemplateChanges) == 0 && len(plan.UpgradeOrdinals) == 0 && len(plan.ScaleOutOrdinals) == 0 &&
+		len(plan.ScaleInOrdinals) == 0 && len(plan.VolumeChanges) == 0
+}
+
+// podSpecChanges returns the json paths of the changed fields of the pod spec, the containers are
+// compared field by field and identified by their names, e.g. containers[tikv].image
+func podSpecChanges(old, new *corev1.PodSpec) []string {
+	var changes []string
+	ov, nv := reflect.ValueOf(*old), reflect.ValueOf(*new)
+	t := ov.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonFieldName(t.Field(i))
+		switch name {
+		case "containers":
+			changes = append(changes, containerChanges(name, old.Containers, new.Containers)...)
+		case "initContainers":
+			changes = append(changes, containerChanges(name, old.InitContainers, new.InitContainers)...)
+		default:
+			if !apiequality.Semantic.DeepEqual(ov.Field(i).Interface(), nv.Field(i).Interface()) {
+				changes = append(changes, name)
+			}
+		}
+	}
+	return changes
+}
+
+func containerChanges(field string, old, new []corev1.Container) []string {
+	var changes []string
+	oldContainers := map[string]*corev1.Container{}
+	for i := range old {
+		oldContainers[old[i].Name] = &old[i]
+	}
+	for i := range new {
+		path := fmt.Sprintf("%s[%s]", field, new[i].Name)
+		oc, ok := oldContainers[new[i].Name]
+		if !ok {
+			changes = append(changes, path)
+			continue
+		}
+		delete(oldContainers, new[i].Name)
+		ov, nv := reflect.ValueOf(*oc), reflect.ValueOf(new[i])
+		t := ov.Type()
+		for j := 0; j < t.NumField(); j++ {
+			if !apiequality.Semantic.DeepEqual(ov.Field(j).Interface(), nv.Field(j).Interface()) {
+				changes = append(changes, path+"."+jsonFieldName(t.Field(j)))
+			}
+		}
+	}
+	// the removed containers
+	for i := range old {
+		if _, ok := oldContainers[old[i].Name]; ok {
+			changes = append(changes, fmt.Sprintf("%s[%s]", field, old[i].Name))
+		}
+	}
+	return changes
+}
+
+func jsonFieldName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+func volumeDescription(storageClassName *string, size resource.Quantity) string {
+	if sc := stringValue(storageClassName); sc != "" {
+		return fmt.Sprintf("%s/%s", sc, size.String())
+	}
+	return size.String()
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,289 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestSyncChangePlan(t *testing.T) {
+	g := NewGomegaWithT(t)
+	deps := controller.NewFakeDependencies()
+
+	tc := newTidbClusterForChangePlan()
+	tc.Annotations = nil
+	tc.Status.ChangePlan = &v1alpha1.ChangePlanStatus{ID: "stale"}
+	proceed, err := syncChangePlan(deps, tc, v1alpha1.PDMemberType, tc.Spec.PD.Replicas, nil, newStatefulSetForChangePlan(3, "pd:v1"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(proceed).To(BeTrue())
+	g.Expect(tc.Status.ChangePlan).To(BeNil())
+
+	// the StatefulSet to create is planned
+	tc = newTidbClusterForChangePlan()
+	proceed, err = syncChangePlan(deps, tc, v1alpha1.PDMemberType, tc.Spec.PD.Replicas, nil, newStatefulSetForChangePlan(3, "pd:v1"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(proceed).To(BeFalse())
+	g.Expect(tc.Status.ChangePlan.ID).To(Equal(tc.ChangePlanID()))
+	g.Expect(tc.Status.ChangePlan.Approved).To(BeFalse())
+	g.Expect(tc.Status.ChangePlan.Components).To(HaveLen(1))
+	g.Expect(tc.Status.ChangePlan.Components[0].ScaleOutOrdinals).To(Equal([]int32{0, 1, 2}))
+	g.Expect(tc.IsChangePlanPending()).To(BeTrue())
+
+	// the plan is approved
+	tc.Annotations[label.AnnChangePlanApproved] = tc.Status.ChangePlan.ID
+	proceed, err = syncChangePlan(deps, tc, v1alpha1.PDMemberType, tc.Spec.PD.Replicas, nil, newStatefulSetForChangePlan(3, "pd:v1"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(proceed).To(BeTrue())
+	g.Expect(tc.Status.ChangePlan.Approved).To(BeTrue())
+	g.Expect(tc.IsChangePlanPending()).To(BeFalse())
+
+	// the spec is changed, so the plan needs to be approved again and the changes are held until then
+	tc.Spec.PD.Replicas = 4
+	newSet := newStatefulSetForChangePlan(4, "pd:v2")
+	proceed, err = syncChangePlan(deps, tc, v1alpha1.PDMemberType, tc.Spec.PD.Replicas, newStatefulSetForChangePlan(3, "pd:v1"), newSet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(proceed).To(BeTrue())
+	g.Expect(tc.Status.ChangePlan.Approved).To(BeFalse())
+	g.Expect(tc.Status.ChangePlan.Components).To(HaveLen(1))
+	g.Expect(tc.Status.ChangePlan.Components[0].ScaleOutOrdinals).To(Equal([]int32{3}))
+	g.Expect(tc.Status.ChangePlan.Components[0].TemplateChanges).To(Equal([]string{"containers[pd].image"}))
+	g.Expect(*newSet.Spec.Replicas).To(Equal(int32(3)))
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("pd:v1"))
+	g.Expect(newSet.Annotations[label.AnnStsSpecReplicas]).To(Equal("3"))
+
+	// the changes are applied once the plan is approved
+	tc.Annotations[label.AnnChangePlanApproved] = tc.Status.ChangePlan.ID
+	newSet = newStatefulSetForChangePlan(4, "pd:v2")
+	proceed, err = syncChangePlan(deps, tc, v1alpha1.PDMemberType, tc.Spec.PD.Replicas, newStatefulSetForChangePlan(3, "pd:v1"), newSet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(proceed).To(BeTrue())
+	g.Expect(*newSet.Spec.Replicas).To(Equal(int32(4)))
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("pd:v2"))
+	g.Expect(newSet.Annotations[label.AnnStsSpecReplicas]).To(Equal("4"))
+
+	// nothing is planned once the changes are applied
+	proceed, err = syncChangePlan(deps, tc, v1alpha1.PDMemberType, tc.Spec.PD.Replicas, newSet, newStatefulSetForChangePlan(4, "pd:v2"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(proceed).To(BeTrue())
+	g.Expect(tc.Status.ChangePlan.Components).To(BeEmpty())
+	g.Expect(tc.IsChangePlanPending()).To(BeFalse())
+}
+
+func TestSyncChangePlanWithFailover(t *testing.T) {
+	g := NewGomegaWithT(t)
+	deps := controller.NewFakeDependencies()
+
+	tc := newTidbClusterForChangePlan()
+	tc.Spec.PD.Replicas = 4
+	oldSet := newStatefulSetForChangePlan(3, "pd:v1")
+	oldSet.Annotations[label.AnnStsSpecReplicas] = "3"
+
+	// a member is failed over while the scaling out in the spec is pending, the replica for the
+	// failover is added while the replica in the spec waits for the approval
+	newSet := newStatefulSetForChangePlan(5, "pd:v2")
+	proceed, err := syncChangePlan(deps, tc, v1alpha1.PDMemberType, tc.Spec.PD.Replicas, oldSet, newSet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(proceed).To(BeTrue())
+	g.Expect(tc.IsChangePlanPending()).To(BeTrue())
+	g.Expect(tc.Status.ChangePlan.Components[0].ScaleOutOrdinals).To(Equal([]int32{3}))
+	g.Expect(*newSet.Spec.Replicas).To(Equal(int32(4)))
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("pd:v1"))
+	g.Expect(newSet.Annotations[label.AnnStsSpecReplicas]).To(Equal("3"))
+
+	// the replica for the failover is not planned again once it is added
+	oldSet = newSet
+	newSet = newStatefulSetForChangePlan(5, "pd:v2")
+	proceed, err = syncChangePlan(deps, tc, v1alpha1.PDMemberType, tc.Spec.PD.Replicas, oldSet, newSet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(proceed).To(BeTrue())
+	g.Expect(tc.Status.ChangePlan.Components[0].ScaleOutOrdinals).To(Equal([]int32{4}))
+	g.Expect(*newSet.Spec.Replicas).To(Equal(int32(4)))
+}
+
+func TestSyncChangePlanWithTiKVGroup(t *testing.T) {
+	g := NewGomegaWithT(t)
+	deps := controller.NewFakeDependencies()
+
+	tc := newTidbClusterForChangePlan()
+	tc.Spec.TiKV = &v1alpha1.TiKVSpec{Replicas: 3}
+	group := &v1alpha1.TiKVGroupSpec{Name: "g1", TiKVSpec: v1alpha1.TiKVSpec{Replicas: 2}}
+	tc.Spec.TiKVGroups = []*v1alpha1.TiKVGroupSpec{group}
+
+	tikvSet := newStatefulSetForChangePlan(3, "tikv:v1")
+	tikvSet.Name = "test-tikv"
+	groupSet := newStatefulSetForChangePlan(2, "tikv:v1")
+	groupSet.Name = "test-tikv-g1"
+
+	// syncs the default TiKV and the TiKV group in the way of the member managers
+	sync := func() (bool, bool) {
+		proceed, err := syncChangePlan(deps, tc, v1alpha1.TiKVMemberType, 3, nil, tikvSet)
+		g.Expect(err).NotTo(HaveOccurred())
+		view := tc.TiKVGroupView(group)
+		groupProceed, err := syncChangePlan(deps, view, v1alpha1.TiKVMemberType, 2, nil, groupSet)
+		g.Expect(err).NotTo(HaveOccurred())
+		tc.Status.ChangePlan = view.Status.ChangePlan
+		return proceed, groupProceed
+	}
+
+	proceed, groupProceed := sync()
+	g.Expect(proceed).To(BeFalse())
+	g.Expect(groupProceed).To(BeFalse())
+	g.Expect(tc.Status.ChangePlan.ID).To(Equal(tc.ChangePlanID()))
+	g.Expect(tc.Status.ChangePlan.Components).To(HaveLen(2))
+	g.Expect(tc.Status.ChangePlan.Components[0].StatefulSet).To(Equal("test-tikv"))
+	g.Expect(tc.Status.ChangePlan.Components[0].ScaleOutOrdinals).To(Equal([]int32{0, 1, 2}))
+	g.Expect(tc.Status.ChangePlan.Components[1].StatefulSet).To(Equal("test-tikv-g1"))
+	g.Expect(tc.Status.ChangePlan.Components[1].ScaleOutOrdinals).To(Equal([]int32{0, 1}))
+
+	// the plan approved for the TidbCluster is also approved for the TiKV group
+	tc.Annotations[label.AnnChangePlanApproved] = tc.Status.ChangePlan.ID
+	proceed, groupProceed = sync()
+	g.Expect(proceed).To(BeTrue())
+	g.Expect(groupProceed).To(BeTrue())
+	g.Expect(tc.Status.ChangePlan.Approved).To(BeTrue())
+	g.Expect(tc.Status.ChangePlan.Components).To(HaveLen(2))
+	g.Expect(tc.IsChangePlanPending()).To(BeFalse())
+}
+
+func TestPlanStatefulSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldSet   *apps.StatefulSet
+		newSet   *apps.StatefulSet
+		pvcs     []*corev1.PersistentVolumeClaim
+		expectFn func(*GomegaWithT, *v1alpha1.ComponentChangePlan)
+	}{
+		{
+			name:   "no changes",
+			oldSet: newStatefulSetForChangePlan(3, "pd:v1"),
+			newSet: newStatefulSetForChangePlan(3, "pd:v1"),
+			expectFn: func(g *GomegaWithT, plan *v1alpha1.ComponentChangePlan) {
+				g.Expect(isComponentChangePlanEmpty(plan)).To(BeTrue())
+			},
+		},
+		{
+			name:   "upgrade",
+			oldSet: newStatefulSetForChangePlan(3, "pd:v1"),
+			newSet: newStatefulSetForChangePlan(3, "pd:v2"),
+			expectFn: func(g *GomegaWithT, plan *v1alpha1.ComponentChangePlan) {
+				g.Expect(plan.TemplateChanges).To(Equal([]string{"containers[pd].image"}))
+				g.Expect(plan.UpgradeOrdinals).To(Equal([]int32{2, 1, 0}))
+			},
+		},
+		{
+			name:   "scale in",
+			oldSet: newStatefulSetForChangePlan(3, "pd:v1"),
+			newSet: newStatefulSetForChangePlan(2, "pd:v1"),
+			expectFn: func(g *GomegaWithT, plan *v1alpha1.ComponentChangePlan) {
+				g.Expect(plan.ScaleInOrdinals).To(Equal([]int32{2}))
+				g.Expect(plan.ScaleOutOrdinals).To(BeEmpty())
+				g.Expect(plan.UpgradeOrdinals).To(BeEmpty())
+			},
+		},
+		{
+			name:   "volume changes",
+			oldSet: newStatefulSetForChangePlan(3, "pd:v1"),
+			newSet: newStatefulSetForChangePlan(3, "pd:v1"),
+			pvcs: []*corev1.PersistentVolumeClaim{
+				newPVCForChangePlan("pd-test-pd-0", "standard", "5Gi"),
+				newPVCForChangePlan("pd-test-pd-1", "standard", "10Gi"),
+				newPVCForChangePlan("pd-test-pd-2", "local", "10Gi"),
+			},
+			expectFn: func(g *GomegaWithT, plan *v1alpha1.ComponentChangePlan) {
+				g.Expect(plan.VolumeChanges).To(Equal([]v1alpha1.VolumeChangePlan{
+					{PVCName: "pd-test-pd-0", Action: v1alpha1.VolumeChangeResize, From: "standard/5Gi", To: "standard/10Gi"},
+					{PVCName: "pd-test-pd-2", Action: v1alpha1.VolumeChangeReplace, From: "local/10Gi", To: "standard/10Gi"},
+				}))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			deps := controller.NewFakeDependencies()
+			pvcIndexer := deps.KubeInformerFactory.Core().V1().PersistentVolumeClaims().Informer().GetIndexer()
+			for _, pvc := range test.pvcs {
+				g.Expect(pvcIndexer.Add(pvc)).To(Succeed())
+			}
+
+			plan, err := planStatefulSet(deps, newTidbClusterForChangePlan(), v1alpha1.PDMemberType, test.oldSet, test.newSet)
+			g.Expect(err).NotTo(HaveOccurred())
+			test.expectFn(g, plan)
+		})
+	}
+}
+
+func newTidbClusterForChangePlan() *v1alpha1.TidbCluster {
+	return &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   metav1.NamespaceDefault,
+			Annotations: map[string]string{label.AnnChangePlan: "true"},
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			PD: &v1alpha1.PDSpec{Replicas: 3},
+		},
+	}
+}
+
+func newStatefulSetForChangePlan(replicas int32, image string) *apps.StatefulSet {
+	set := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pd",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: apps.StatefulSetSpec{
+			Replicas: pointer.Int32(replicas),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "pd", Image: image}},
+				},
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				*newPVCForChangePlan("pd", "standard", "10Gi"),
+			},
+		},
+	}
+	if err := mngerutils.SetStatefulSetLastAppliedConfigAnnotation(set); err != nil {
+		panic(err)
+	}
+	return set
+}
+
+func newPVCForChangePlan(name, storageClass, size string) *corev1.PersistentVolumeClaim {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: pointer.String(storageClass),
+		},
+	}
+	pvc.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)}
+	return pvc
+}
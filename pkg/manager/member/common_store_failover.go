@@ -152,6 +152,7 @@ func (sf *commonStoreFailover) tryMarkAStoreAsFailure(tc *v1alpha1.TidbCluster)
 					})
 					msg := fmt.Sprintf("store[%s] is Down", store.ID)
 					sf.deps.Recorder.Event(tc, corev1.EventTypeWarning, unHealthEventReason, fmt.Sprintf(unHealthEventMsgPattern, sf.storeAccess.GetMemberType(), podName, msg))
+					recordFailoverOperation(tc, sf.storeAccess.GetMemberType(), podName, msg)
 				}
 			}
 		}
@@ -225,6 +226,11 @@ func (sf *commonStoreFailover) RemoveUndesiredFailures(tc *v1alpha1.TidbCluster)
 }
 
 func (sf *commonStoreFailover) Recover(tc *v1alpha1.TidbCluster) {
+	podNames := make([]string, 0, len(sf.storeAccess.GetFailureStores(tc)))
+	for _, failureStore := range sf.storeAccess.GetFailureStores(tc) {
+		podNames = append(podNames, failureStore.PodName)
+	}
+	recordFailoverRecoveryOperation(tc, sf.storeAccess.GetMemberType(), podNames...)
 	sf.storeAccess.ClearFailStatus(tc)
 	klog.Infof("%s recover: clear FailureStores, %s/%s", sf.storeAccess.GetMemberType(), tc.GetNamespace(), tc.GetName())
 }
@@ -0,0 +1,399 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+// Reasons of the ConfigDrift condition
+const (
+	// NoConfigDrift is the reason when the running config of all the components matches the spec
+	NoConfigDrift = "NoConfigDrift"
+	// ConfigDriftDetected is the reason when the running config of any component differs from the spec
+	ConfigDriftDetected = "ConfigDriftDetected"
+
+	configDriftEnforcedEvent = "ConfigDriftEnforced"
+
+	defaultConfigDriftCheckInterval = 10 * time.Minute
+)
+
+// configDriftManager periodically compares the running config of the components with the config rendered
+// in the ConfigMaps in use:
+//   - report the drifted config items in the status and the `ConfigDrift` condition
+//   - reapply the drifted config items that can be changed online if the enforce mode is enabled
+type configDriftManager struct {
+	deps *controller.Dependencies
+}
+
+// NewConfigDriftManager returns a *configDriftManager
+func NewConfigDriftManager(deps *controller.Dependencies) manager.Manager {
+	return &configDriftManager{
+		deps: deps,
+	}
+}
+
+// configDriftTarget is an instance whose running config is checked
+type configDriftTarget struct {
+	memberType v1alpha1.MemberType
+	// instance is the pod name, empty for the config shared by all the instances
+	instance  string
+	getConfig func() (map[string]interface{}, error)
+}
+
+func (m *configDriftManager) Sync(tc *v1alpha1.TidbCluster) error {
+	spec := tc.Spec.ConfigDriftDetection
+	if spec == nil {
+		tc.Status.ConfigDrift = nil
+		utiltidbcluster.RemoveTidbClusterCondition(&tc.Status, v1alpha1.TidbClusterConfigDrift)
+		return nil
+	}
+	interval := defaultConfigDriftCheckInterval
+	if spec.Interval != nil && spec.Interval.Duration > 0 {
+		interval = spec.Interval.Duration
+	}
+	if tc.Status.ConfigDrift != nil && time.Since(tc.Status.ConfigDrift.LastCheckTime.Time) < interval {
+		return nil
+	}
+
+	var (
+		items []v1alpha1.ConfigDriftItem
+		errs  []error
+	)
+	for _, memberType := range []v1alpha1.MemberType{
+		v1alpha1.PDMemberType,
+		v1alpha1.TiKVMemberType,
+		v1alpha1.TiDBMemberType,
+		v1alpha1.TiFlashMemberType,
+	} {
+		drifted, err := m.check(tc, memberType, spec.Enforce)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		items = append(items, drifted...)
+	}
+
+	tc.Status.ConfigDrift = &v1alpha1.ConfigDriftStatus{
+		LastCheckTime: metav1.Now(),
+		Items:         items,
+	}
+	old := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterConfigDrift)
+	var cond *v1alpha1.TidbClusterCondition
+	if len(items) == 0 {
+		cond = utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterConfigDrift, corev1.ConditionFalse, NoConfigDrift, "")
+	} else {
+		cond = utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterConfigDrift, corev1.ConditionTrue, ConfigDriftDetected, configDriftMessage(items))
+	}
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
+	if len(items) > 0 && (old == nil || old.Reason != cond.Reason) {
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, cond.Reason, cond.Message)
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// check returns the drifted config items of the component, the drifted items that are reapplied in the
+// enforce mode are not returned
+func (m *configDriftManager) check(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, enforce bool) ([]v1alpha1.ConfigDriftItem, error) {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	expected, ok, err := m.expectedConfig(tc, memberType)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var (
+		items []v1alpha1.ConfigDriftItem
+		errs  []error
+	)
+	for _, target := range m.targets(tc, memberType) {
+		actual, err := target.getConfig()
+		if err != nil {
+			// the unavailable instances are reported by the member managers
+			klog.Warningf("configDriftManager.check: failed to get config of %s %q of cluster %s/%s, error: %s", memberType, target.instance, ns, tcName, err)
+			continue
+		}
+		drifts, err := mngerutils.DetectConfigDrift(expected, actual)
+		if err != nil {
+			return nil, fmt.Errorf("configDriftManager.check: failed to compare config of %s of cluster %s/%s, error: %s", memberType, ns, tcName, err)
+		}
+		if len(drifts) == 0 {
+			continue
+		}
+		if enforce {
+			drifts, err = m.enforce(tc, target, drifts)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		for _, drift := range drifts {
+			items = append(items, v1alpha1.ConfigDriftItem{
+				Component: memberType,
+				Instance:  target.instance,
+				Key:       drift.Key,
+				Expected:  configDriftValue(drift.Expected),
+				Actual:    configDriftValue(drift.Actual),
+			})
+		}
+	}
+	return items, errorutils.NewAggregate(errs)
+}
+
+// expectedConfig returns the config in the ConfigMap used by the StatefulSet of the component
+func (m *configDriftManager) expectedConfig(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) ([]byte, bool, error) {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	var (
+		setName string
+		key     = hotConfigKey
+		phase   v1alpha1.MemberPhase
+	)
+	switch memberType {
+	case v1alpha1.PDMemberType:
+		if tc.Spec.PD == nil {
+			return nil, false, nil
+		}
+		setName, phase = controller.PDMemberName(tcName), tc.Status.PD.Phase
+	case v1alpha1.TiKVMemberType:
+		if tc.Spec.TiKV == nil {
+			return nil, false, nil
+		}
+		setName, phase = controller.TiKVGroupMemberName(tcName, tc.TiKVGroupName()), tc.Status.TiKV.Phase
+	case v1alpha1.TiDBMemberType:
+		if tc.Spec.TiDB == nil {
+			return nil, false, nil
+		}
+		setName, phase = controller.TiDBGroupMemberName(tcName, tc.TiDBGroupName()), tc.Status.TiDB.Phase
+	case v1alpha1.TiFlashMemberType:
+		if tc.Spec.TiFlash == nil {
+			return nil, false, nil
+		}
+		// only the config of the proxy can be fetched from the running tiflash
+		setName, key, phase = controller.TiFlashMemberName(tcName), "proxy_templ.toml", tc.Status.TiFlash.Phase
+	default:
+		return nil, false, nil
+	}
+	// the running config differs from the spec during the rolling update
+	if phase == v1alpha1.UpgradePhase {
+		return nil, false, nil
+	}
+
+	set, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(setName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("configDriftManager.expectedConfig: failed to get sts %s/%s, error: %s", ns, setName, err)
+	}
+	cmName := mngerutils.FindConfigMapVolume(&set.Spec.Template.Spec, func(name string) bool {
+		return strings.HasPrefix(name, setName)
+	})
+	if cmName == "" {
+		return nil, false, nil
+	}
+	cm, err := m.deps.ConfigMapLister.ConfigMaps(ns).Get(cmName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("configDriftManager.expectedConfig: failed to get configmap %s/%s, error: %s", ns, cmName, err)
+	}
+	config, ok := cm.Data[key]
+	if !ok || strings.TrimSpace(config) == "" {
+		return nil, false, nil
+	}
+	return []byte(config), true, nil
+}
+
+// targets returns the instances whose running config is checked
+func (m *configDriftManager) targets(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) []configDriftTarget {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	tlsEnabled := tc.IsTLSClusterEnabled()
+
+	var targets []configDriftTarget
+	switch memberType {
+	case v1alpha1.PDMemberType:
+		targets = append(targets, configDriftTarget{
+			memberType: memberType,
+			getConfig: func() (map[string]interface{}, error) {
+				config, err := controller.GetPDClient(m.deps.PDControl, tc).GetConfig()
+				if err != nil {
+					return nil, err
+				}
+				data, err := json.Marshal(config)
+				if err != nil {
+					return nil, err
+				}
+				result := map[string]interface{}{}
+				return result, json.Unmarshal(data, &result)
+			},
+		})
+	case v1alpha1.TiKVMemberType:
+		for _, store := range tc.Status.TiKV.Stores {
+			if store.State != v1alpha1.TiKVStateUp {
+				continue
+			}
+			client := controller.GetTiKVPodClient(m.deps.TiKVControl, tc, tc.TiKVGroupName(), store.PodName)
+			targets = append(targets, configDriftTarget{memberType: memberType, instance: store.PodName, getConfig: client.GetConfig})
+		}
+	case v1alpha1.TiDBMemberType:
+		for podName, member := range tc.Status.TiDB.Members {
+			if !member.Health {
+				continue
+			}
+			ordinal, err := util.GetOrdinalFromPodName(podName)
+			if err != nil {
+				klog.Warningf("configDriftManager.targets: failed to get ordinal of tidb %s/%s, error: %s", ns, podName, err)
+				continue
+			}
+			targets = append(targets, configDriftTarget{
+				memberType: memberType,
+				instance:   podName,
+				getConfig: func() (map[string]interface{}, error) {
+					return m.deps.TiDBControl.GetConfig(tc, ordinal)
+				},
+			})
+		}
+	case v1alpha1.TiFlashMemberType:
+		for _, store := range tc.Status.TiFlash.Stores {
+			if store.State != v1alpha1.TiKVStateUp {
+				continue
+			}
+			client := m.deps.TiFlashControl.GetTiFlashPodClient(ns, tcName, store.PodName, tlsEnabled)
+			targets = append(targets, configDriftTarget{memberType: memberType, instance: store.PodName, getConfig: client.GetConfig})
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].instance < targets[j].instance
+	})
+	return targets
+}
+
+// enforce reapplies the drifted config items that can be changed online, and returns the items that
+// are still drifted
+func (m *configDriftManager) enforce(tc *v1alpha1.TidbCluster, target configDriftTarget, drifts []mngerutils.ConfigDrift) ([]mngerutils.ConfigDrift, error) {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	_, _, version := hotConfigStatusOf(tc, target.memberType)
+
+	var (
+		remaining []mngerutils.ConfigDrift
+		keys      []string
+		config    = map[string]interface{}{}
+	)
+	for _, drift := range drifts {
+		item, ok := lookupHotConfigItem(target.memberType, version, drift.Key)
+		if !ok {
+			remaining = append(remaining, drift)
+			continue
+		}
+		value, ok := hotConfigValue(drift.Expected)
+		if !ok {
+			remaining = append(remaining, drift)
+			continue
+		}
+		name := item.name
+		if name == "" {
+			name = drift.Key
+		}
+		config[name] = value
+		keys = append(keys, drift.Key)
+	}
+	if len(config) == 0 {
+		return remaining, nil
+	}
+
+	var err error
+	switch target.memberType {
+	case v1alpha1.PDMemberType:
+		err = controller.GetPDClient(m.deps.PDControl, tc).UpdateConfig(config)
+	case v1alpha1.TiKVMemberType:
+		client := controller.GetTiKVPodClient(m.deps.TiKVControl, tc, tc.TiKVGroupName(), target.instance)
+		err = client.UpdateConfig(tikvHotConfig(config))
+	case v1alpha1.TiDBMemberType:
+		var ordinal int32
+		ordinal, err = util.GetOrdinalFromPodName(target.instance)
+		if err == nil {
+			err = m.deps.TiDBControl.SetVariables(tc, ordinal, tidbHotVariables(config))
+		}
+	default:
+		// the config of the other components is only reported
+		return drifts, nil
+	}
+	if err != nil {
+		return drifts, fmt.Errorf("configDriftManager.enforce: failed to reapply config items %v of %s %q of cluster %s/%s, error: %s",
+			keys, target.memberType, target.instance, ns, tcName, err)
+	}
+	klog.Infof("configDriftManager.enforce: drifted config items %v of %s %q of cluster %s/%s are reapplied", keys, target.memberType, target.instance, ns, tcName)
+	m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, configDriftEnforcedEvent, "drifted config items %v of %s %q are reapplied", keys, target.memberType, target.instance)
+	return remaining, nil
+}
+
+// configDriftValue returns the string representation of the config value
+func configDriftValue(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}, []map[string]interface{}:
+		data, err := json.Marshal(v)
+		if err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+func configDriftMessage(items []v1alpha1.ConfigDriftItem) string {
+	drifted := make([]string, 0, len(items))
+	for _, item := range items {
+		component := string(item.Component)
+		if item.Instance != "" {
+			component = fmt.Sprintf("%s/%s", component, item.Instance)
+		}
+		drifted = append(drifted, fmt.Sprintf("%s: %s", component, item.Key))
+	}
+	return fmt.Sprintf("running config differs from the spec: %s", strings.Join(drifted, ", "))
+}
+
+type FakeConfigDriftManager struct {
+	err error
+}
+
+func NewFakeConfigDriftManager() *FakeConfigDriftManager {
+	return &FakeConfigDriftManager{}
+}
+
+func (m *FakeConfigDriftManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeConfigDriftManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
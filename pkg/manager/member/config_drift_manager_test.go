@@ -0,0 +1,112 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/tikvapi"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConfigDriftManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	tc.Spec.Version = "v7.5.0"
+	tc.Spec.TiKV.BaseImage = "pingcap/tikv"
+	tc.Spec.ConfigDriftDetection = &v1alpha1.ConfigDriftDetection{}
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"1": {ID: "1", PodName: "test-tikv-0", State: v1alpha1.TiKVStateUp},
+	}
+
+	deps := controller.NewFakeDependencies()
+	m := NewConfigDriftManager(deps)
+	setName := controller.TiKVMemberName(tc.Name)
+	g.Expect(deps.KubeInformerFactory.Apps().V1().StatefulSets().Informer().GetIndexer().Add(&apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: setName, Namespace: tc.Namespace},
+		Spec: apps.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{{
+						Name: "config",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: setName + "-1"}},
+						},
+					}},
+				},
+			},
+		},
+	})).To(Succeed())
+	g.Expect(deps.LabelFilterKubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer().Add(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: setName + "-1", Namespace: tc.Namespace},
+		Data: map[string]string{
+			"config-file": "[raftstore]\nraft-log-gc-threshold = 50\n[storage]\nreserve-space = \"2GB\"\n",
+		},
+	})).To(Succeed())
+
+	tikvClient := tikvapi.NewFakeTiKVClient()
+	running := map[string]interface{}{
+		"raftstore": map[string]interface{}{"raft-log-gc-threshold": float64(100)},
+		"storage":   map[string]interface{}{"reserve-space": "1GiB"},
+	}
+	tikvClient.AddReaction(tikvapi.GetConfigActionType, func(action *tikvapi.Action) (interface{}, error) {
+		return running, nil
+	})
+	var updated map[string]string
+	tikvClient.AddReaction(tikvapi.UpdateConfigActionType, func(action *tikvapi.Action) (interface{}, error) {
+		updated = action.Config
+		return nil, nil
+	})
+	deps.TiKVControl.(*tikvapi.FakeTiKVControl).SetTiKVPodClient(tc.Namespace, tc.Name, "test-tikv-0", tikvClient)
+
+	// the drifted items are reported
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.ConfigDrift.Items).To(Equal([]v1alpha1.ConfigDriftItem{
+		{Component: v1alpha1.TiKVMemberType, Instance: "test-tikv-0", Key: "raftstore.raft-log-gc-threshold", Expected: "50", Actual: "100"},
+		{Component: v1alpha1.TiKVMemberType, Instance: "test-tikv-0", Key: "storage.reserve-space", Expected: "2GB", Actual: "1GiB"},
+	}))
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterConfigDrift)
+	g.Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(cond.Reason).To(Equal(ConfigDriftDetected))
+	g.Expect(cond.Message).To(ContainSubstring("tikv/test-tikv-0: raftstore.raft-log-gc-threshold"))
+	g.Expect(updated).To(BeNil())
+
+	// the config is not checked again within the interval
+	running["storage"] = map[string]interface{}{"reserve-space": "2GiB"}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.ConfigDrift.Items).To(HaveLen(2))
+
+	// the items that can be changed online are reapplied in the enforce mode
+	tc.Spec.ConfigDriftDetection.Enforce = true
+	tc.Status.ConfigDrift.LastCheckTime = metav1.Time{}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(updated).To(Equal(map[string]string{"raftstore.raft-log-gc-threshold": "50"}))
+	g.Expect(tc.Status.ConfigDrift.Items).To(BeEmpty())
+	cond = utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterConfigDrift)
+	g.Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+	g.Expect(cond.Reason).To(Equal(NoConfigDrift))
+
+	// the status is cleared when the detection is disabled
+	tc.Spec.ConfigDriftDetection = nil
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.ConfigDrift).To(BeNil())
+	g.Expect(utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterConfigDrift)).To(BeNil())
+}
@@ -246,6 +246,10 @@ func (m *masterMemberManager) syncMasterStatefulSetForDMCluster(dc *v1alpha1.DMC
 		}
 	}
 
+	if err := mngerutils.HoldPodSpecIfCertificateExpired(m.deps, dc, newMasterSet, oldMasterSet); err != nil {
+		return err
+	}
+
 	return mngerutils.UpdateStatefulSet(m.deps.StatefulSetControl, dc, newMasterSet, oldMasterSet)
 }
 
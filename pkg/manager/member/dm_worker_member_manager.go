@@ -235,6 +235,10 @@ func (m *workerMemberManager) syncWorkerStatefulSetForDMCluster(dc *v1alpha1.DMC
 		}
 	}
 
+	if err := mngerutils.HoldPodSpecIfCertificateExpired(m.deps, dc, newSts, oldSts); err != nil {
+		return err
+	}
+
 	return mngerutils.UpdateStatefulSet(m.deps.StatefulSetControl, dc, newSts, oldSts)
 }
 
@@ -0,0 +1,461 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/features"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"github.com/pingcap/tidb-operator/pkg/util/cmpver"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	hotConfigKey              = "config-file"
+	hotConfigAppliedEvent     = "HotConfigApplied"
+	hotConfigApplyFailedEvent = "FailedApplyHotConfig"
+)
+
+// hotConfigItem is a config item that can be changed online
+type hotConfigItem struct {
+	// key is the dotted path of the config item, a key ending with `.*` matches all the items in the table
+	key string
+	// since is the first version that supports changing the item online, empty means all versions
+	// that support changing config online
+	since string
+	// name is the name used to change the item online, empty means the same as the key
+	name string
+}
+
+// the allowlists of the config items that can be changed online, see
+// https://docs.pingcap.com/tidb/stable/dynamic-config
+var (
+	tikvHotConfigItems = append([]hotConfigItem{
+		{key: "raftstore.raft-max-inflight-msgs"},
+		{key: "raftstore.raft-log-gc-tick-interval"},
+		{key: "raftstore.raft-log-gc-threshold"},
+		{key: "raftstore.raft-log-gc-count-limit"},
+		{key: "raftstore.raft-log-gc-size-limit"},
+		{key: "raftstore.raft-max-size-per-msg"},
+		{key: "raftstore.raft-entry-max-size"},
+		{key: "raftstore.raft-entry-cache-life-time"},
+		{key: "raftstore.split-region-check-tick-interval"},
+		{key: "raftstore.region-split-check-diff"},
+		{key: "raftstore.region-compact-check-interval"},
+		{key: "raftstore.region-compact-check-step"},
+		{key: "raftstore.region-compact-min-tombstones"},
+		{key: "raftstore.region-compact-tombstones-percent"},
+		{key: "raftstore.pd-heartbeat-tick-interval"},
+		{key: "raftstore.pd-store-heartbeat-tick-interval"},
+		{key: "raftstore.snap-mgr-gc-tick-interval"},
+		{key: "raftstore.snap-gc-timeout"},
+		{key: "raftstore.lock-cf-compact-interval"},
+		{key: "raftstore.lock-cf-compact-bytes-threshold"},
+		{key: "raftstore.messages-per-tick"},
+		{key: "raftstore.max-peer-down-duration"},
+		{key: "raftstore.max-leader-missing-duration"},
+		{key: "raftstore.abnormal-leader-missing-duration"},
+		{key: "raftstore.peer-stale-state-check-interval"},
+		{key: "raftstore.consistency-check-interval"},
+		{key: "raftstore.raft-store-max-leader-lease"},
+		{key: "raftstore.merge-check-tick-interval"},
+		{key: "raftstore.cleanup-import-sst-interval"},
+		{key: "raftstore.local-read-batch-size"},
+		{key: "raftstore.apply-max-batch-size", since: "v5.0.0"},
+		{key: "raftstore.apply-pool-size", since: "v5.0.0"},
+		{key: "raftstore.store-max-batch-size", since: "v5.0.0"},
+		{key: "raftstore.store-pool-size", since: "v5.0.0"},
+		{key: "coprocessor.split-region-on-table"},
+		{key: "coprocessor.batch-split-limit"},
+		{key: "coprocessor.region-max-size"},
+		{key: "coprocessor.region-split-size"},
+		{key: "coprocessor.region-max-keys"},
+		{key: "coprocessor.region-split-keys"},
+		{key: "pessimistic-txn.wait-for-lock-timeout"},
+		{key: "pessimistic-txn.wake-up-delay-duration"},
+		{key: "pessimistic-txn.pipelined", since: "v5.0.0"},
+		{key: "pessimistic-txn.in-memory", since: "v6.0.0"},
+		{key: "gc.ratio-threshold"},
+		{key: "gc.batch-keys"},
+		{key: "gc.max-write-bytes-per-sec"},
+		{key: "gc.enable-compaction-filter", since: "v5.0.0"},
+		{key: "gc.compaction-filter-skip-version-check", since: "v5.0.0"},
+		{key: "rocksdb.max-total-wal-size"},
+		{key: "rocksdb.max-background-jobs"},
+		{key: "rocksdb.max-background-flushes", since: "v5.0.0"},
+		{key: "rocksdb.max-open-files"},
+		{key: "rocksdb.compaction-readahead-size"},
+		{key: "rocksdb.bytes-per-sync"},
+		{key: "rocksdb.wal-bytes-per-sync"},
+		{key: "rocksdb.writable-file-max-buffer-size"},
+		{key: "rocksdb.rate-bytes-per-sec"},
+		{key: "rocksdb.rate-limiter-auto-tuned", since: "v5.0.0"},
+		{key: "raftdb.max-background-jobs"},
+		{key: "raftdb.max-sub-compactions"},
+		{key: "raftdb.bytes-per-sync"},
+		{key: "raftdb.wal-bytes-per-sync"},
+		{key: "raftdb.writable-file-max-buffer-size"},
+		{key: "storage.block-cache.capacity"},
+		{key: "backup.num-threads"},
+		{key: "split.qps-threshold"},
+		{key: "split.byte-threshold"},
+		{key: "split.split-balance-score", since: "v5.0.0"},
+		{key: "split.split-contained-score", since: "v5.0.0"},
+		{key: "server.grpc-memory-pool-quota", since: "v5.0.0"},
+		{key: "server.max-grpc-send-msg-len", since: "v5.0.0"},
+		{key: "server.raft-msg-max-batch-size", since: "v5.0.0"},
+		{key: "readpool.unified.max-thread-count", since: "v5.0.0"},
+		{key: "resolved-ts.advance-ts-interval", since: "v5.1.0"},
+		{key: "cdc.min-ts-interval", since: "v5.1.0"},
+		{key: "cdc.old-value-cache-memory-quota", since: "v5.1.0"},
+		{key: "cdc.sink-memory-quota", since: "v5.1.0"},
+		{key: "cdc.incremental-scan-speed-limit", since: "v5.1.0"},
+		{key: "cdc.incremental-scan-concurrency", since: "v5.1.0"},
+	}, tikvCFHotConfigItems("rocksdb.defaultcf", "rocksdb.writecf", "rocksdb.lockcf", "raftdb.defaultcf")...)
+
+	pdHotConfigItems = []hotConfigItem{
+		{key: "schedule.*"},
+		{key: "replication.max-replicas"},
+		{key: "replication.location-labels"},
+		{key: "replication.strictly-match-label"},
+		{key: "replication.enable-placement-rules"},
+		{key: "replication.isolation-level"},
+		{key: "pd-server.use-region-storage"},
+		{key: "pd-server.max-gap-reset-ts"},
+		{key: "pd-server.key-type"},
+		{key: "pd-server.metric-storage"},
+		{key: "pd-server.dashboard-address"},
+		{key: "pd-server.flow-round-by-digit"},
+		{key: "pd-server.min-resolved-ts-persistence-interval", since: "v6.0.0"},
+		{key: "log.level"},
+	}
+
+	// the items of tidb are changed by `SET GLOBAL` of the instance-scoped system variables, which are
+	// introduced in v6.1.0, as the config of tidb can not be changed by `SET CONFIG`
+	tidbHotConfigItems = []hotConfigItem{
+		{key: "instance.tidb_general_log", name: "tidb_general_log"},
+		{key: "instance.tidb_enable_slow_log", name: "tidb_enable_slow_log"},
+		{key: "instance.tidb_slow_log_threshold", name: "tidb_slow_log_threshold"},
+		{key: "instance.tidb_record_plan_in_slow_log", name: "tidb_record_plan_in_slow_log"},
+		{key: "instance.tidb_expensive_query_time_threshold", name: "tidb_expensive_query_time_threshold"},
+		{key: "instance.tidb_check_mb4_value_in_utf8", name: "tidb_check_mb4_value_in_utf8"},
+		{key: "instance.tidb_force_priority", name: "tidb_force_priority"},
+	}
+
+	// the first versions that support changing config online
+	hotConfigSupportedVersions = map[v1alpha1.MemberType]string{
+		v1alpha1.PDMemberType:   "v4.0.0",
+		v1alpha1.TiKVMemberType: "v4.0.0",
+		v1alpha1.TiDBMemberType: "v6.1.0",
+	}
+)
+
+func tikvCFHotConfigItems(cfs ...string) []hotConfigItem {
+	var items []hotConfigItem
+	for _, cf := range cfs {
+		for _, key := range []string{
+			"block-cache-size",
+			"write-buffer-size",
+			"max-write-buffer-number",
+			"target-file-size-base",
+			"level0-file-num-compaction-trigger",
+			"level0-slowdown-writes-trigger",
+			"level0-stop-writes-trigger",
+			"max-compaction-bytes",
+			"max-bytes-for-level-base",
+			"disable-auto-compactions",
+			"soft-pending-compaction-bytes-limit",
+			"hard-pending-compaction-bytes-limit",
+			"titan.blob-run-mode",
+		} {
+			items = append(items, hotConfigItem{key: cf + "." + key})
+		}
+	}
+	return items
+}
+
+// lookupHotConfigItem returns the item that matches the key and can be changed online in the version
+func lookupHotConfigItem(memberType v1alpha1.MemberType, version, key string) (*hotConfigItem, bool) {
+	var items []hotConfigItem
+	switch memberType {
+	case v1alpha1.PDMemberType:
+		items = pdHotConfigItems
+	case v1alpha1.TiKVMemberType:
+		items = tikvHotConfigItems
+	case v1alpha1.TiDBMemberType:
+		items = tidbHotConfigItems
+	}
+	if !versionAtLeast(version, hotConfigSupportedVersions[memberType]) {
+		return nil, false
+	}
+	for i := range items {
+		item := &items[i]
+		matched := item.key == key
+		if prefix := strings.TrimSuffix(item.key, "*"); prefix != item.key {
+			matched = strings.HasPrefix(key, prefix)
+		}
+		if !matched {
+			continue
+		}
+		if item.since != "" && !versionAtLeast(version, item.since) {
+			return nil, false
+		}
+		return item, true
+	}
+	return nil, false
+}
+
+func versionAtLeast(version, since string) bool {
+	if since == "" {
+		return true
+	}
+	ok, err := cmpver.Compare(version, cmpver.GreaterOrEqual, since)
+	return err == nil && ok
+}
+
+// hotConfigValue returns the value used to change the item online, only scalars and arrays of strings are supported
+func hotConfigValue(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case string, bool, int64, float64:
+		return v, true
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, s)
+		}
+		return strings.Join(values, ","), true
+	}
+	return nil, false
+}
+
+// syncHotConfig applies the config changes of the desired ConfigMap that can be changed online. If all the
+// changed items can be changed online, the in-use ConfigMap is updated in place to avoid restarting the servers.
+// Otherwise, the servers are restarted by the rolling update as usual for the rest of the changed items, which
+// are reported as pending in the status. If the items fail to be changed online, all the changed items are
+// left to the rolling update and a warning event is emitted, the sync of the ConfigMap is not blocked.
+func syncHotConfig(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType,
+	updateStrategy v1alpha1.ConfigUpdateStrategy, inUseName string, desired *corev1.ConfigMap) error {
+	if !features.DefaultFeatureGate.Enabled(features.HotConfigApply) {
+		return nil
+	}
+	if updateStrategy != v1alpha1.ConfigUpdateStrategyRollingUpdate || inUseName == "" {
+		return nil
+	}
+	status, phase, version := hotConfigStatusOf(tc, memberType)
+	if phase == v1alpha1.UpgradePhase {
+		return nil
+	}
+	if desired.Name == inUseName {
+		// the pending items take effect after the rolling update
+		if *status != nil && len((*status).PendingKeys) > 0 {
+			setHotConfigStatus(status, (*status).AppliedKeys, nil)
+		}
+		return nil
+	}
+
+	existing, err := deps.ConfigMapLister.ConfigMaps(desired.Namespace).Get(inUseName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("syncHotConfig: failed to get configmap %s/%s for cluster %s/%s, error: %s",
+			desired.Namespace, inUseName, tc.Namespace, tc.Name, err)
+	}
+	for k := range existing.Data {
+		// the config is overlaid, e.g. by PiTR
+		if strings.HasSuffix(k, "-overlay") {
+			return nil
+		}
+	}
+	// only the config file can be changed online
+	oldData := copyDataWithout(existing.Data, hotConfigKey)
+	newData := copyDataWithout(desired.Data, hotConfigKey)
+	if !equality.Semantic.DeepEqual(oldData, newData) {
+		return nil
+	}
+
+	changes, err := mngerutils.DiffConfig([]byte(existing.Data[hotConfigKey]), []byte(desired.Data[hotConfigKey]))
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	// the variables of tidb are changed by the account in the secret, which is optional
+	online := memberType != v1alpha1.TiDBMemberType || (tc.Spec.TiDB != nil && tc.Spec.TiDB.HotConfigSecretName != nil)
+	var hotKeys, staticKeys []string
+	config := map[string]interface{}{}
+	for _, change := range changes {
+		item, ok := lookupHotConfigItem(memberType, version, change.Key)
+		if !online || !ok || change.Value == nil {
+			staticKeys = append(staticKeys, change.Key)
+			continue
+		}
+		value, ok := hotConfigValue(change.Value)
+		if !ok {
+			staticKeys = append(staticKeys, change.Key)
+			continue
+		}
+		name := item.name
+		if name == "" {
+			name = change.Key
+		}
+		config[name] = value
+		hotKeys = append(hotKeys, change.Key)
+	}
+
+	// the items are not retried after they are left to the rolling update because of a failure
+	allKeys := append(append([]string{}, hotKeys...), staticKeys...)
+	if len(hotKeys) > 0 && *status != nil && len((*status).AppliedKeys) == 0 &&
+		equality.Semantic.DeepEqual((*status).PendingKeys, allKeys) {
+		return nil
+	}
+
+	// the items are applied only once while waiting for the rolling update
+	applied := *status != nil && equality.Semantic.DeepEqual((*status).AppliedKeys, hotKeys) &&
+		equality.Semantic.DeepEqual((*status).PendingKeys, staticKeys)
+	if len(config) > 0 && !applied {
+		if err := applyHotConfig(deps, tc, memberType, config); err != nil {
+			klog.Warningf("syncHotConfig: failed to apply config items %v of %s of cluster %s/%s online, they are applied by the rolling update, error: %v",
+				hotKeys, memberType, tc.Namespace, tc.Name, err)
+			deps.Recorder.Eventf(tc, corev1.EventTypeWarning, hotConfigApplyFailedEvent,
+				"failed to apply config items %v of %s online, they are applied by the rolling update: %v", hotKeys, memberType, err)
+			setHotConfigStatus(status, nil, allKeys)
+			return nil
+		}
+		klog.Infof("syncHotConfig: config items %v of %s of cluster %s/%s are applied online", hotKeys, memberType, tc.Namespace, tc.Name)
+		deps.Recorder.Eventf(tc, corev1.EventTypeNormal, hotConfigAppliedEvent, "config items %v of %s are applied online", hotKeys, memberType)
+	}
+	if len(staticKeys) > 0 {
+		klog.Infof("syncHotConfig: %s of cluster %s/%s will be restarted, the config items %v can not be changed online",
+			memberType, tc.Namespace, tc.Name, staticKeys)
+		setHotConfigStatus(status, hotKeys, staticKeys)
+		return nil
+	}
+
+	desired.Name = inUseName
+	setHotConfigStatus(status, hotKeys, nil)
+	return nil
+}
+
+func applyHotConfig(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, config map[string]interface{}) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	switch memberType {
+	case v1alpha1.PDMemberType:
+		if err := controller.GetPDClient(deps.PDControl, tc).UpdateConfig(config); err != nil {
+			return fmt.Errorf("failed to update config of pd of cluster %s/%s, error: %s", ns, tcName, err)
+		}
+	case v1alpha1.TiKVMemberType:
+		tikvConfig := tikvHotConfig(config)
+		for _, store := range tc.Status.TiKV.Stores {
+			if store.State != v1alpha1.TiKVStateUp {
+				continue
+			}
+			client := controller.GetTiKVPodClient(deps.TiKVControl, tc, tc.TiKVGroupName(), store.PodName)
+			if err := client.UpdateConfig(tikvConfig); err != nil {
+				return fmt.Errorf("failed to update config of tikv %s/%s, error: %s", ns, store.PodName, err)
+			}
+		}
+	case v1alpha1.TiDBMemberType:
+		variables := tidbHotVariables(config)
+		for podName, member := range tc.Status.TiDB.Members {
+			if !member.Health {
+				continue
+			}
+			ordinal, err := util.GetOrdinalFromPodName(podName)
+			if err != nil {
+				return err
+			}
+			if err := deps.TiDBControl.SetVariables(tc, ordinal, variables); err != nil {
+				return fmt.Errorf("failed to update variables of tidb %s/%s, error: %s", ns, podName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// tikvHotConfig returns the config used by the `/config` API of tikv
+func tikvHotConfig(config map[string]interface{}) map[string]string {
+	tikvConfig := map[string]string{}
+	for k, v := range config {
+		tikvConfig[k] = fmt.Sprint(v)
+	}
+	return tikvConfig
+}
+
+// tidbHotVariables returns the system variables of tidb set by `SET GLOBAL`
+func tidbHotVariables(config map[string]interface{}) map[string]string {
+	variables := map[string]string{}
+	for k, v := range config {
+		if b, ok := v.(bool); ok {
+			// the switches of tidb are set by ON or OFF
+			v = "OFF"
+			if b {
+				v = "ON"
+			}
+		}
+		variables[k] = fmt.Sprint(v)
+	}
+	return variables
+}
+
+func hotConfigStatusOf(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) (**v1alpha1.HotConfigStatus, v1alpha1.MemberPhase, string) {
+	switch memberType {
+	case v1alpha1.PDMemberType:
+		return &tc.Status.PD.HotConfig, tc.Status.PD.Phase, tc.PDVersion()
+	case v1alpha1.TiKVMemberType:
+		return &tc.Status.TiKV.HotConfig, tc.Status.TiKV.Phase, tc.TiKVVersion()
+	case v1alpha1.TiDBMemberType:
+		return &tc.Status.TiDB.HotConfig, tc.Status.TiDB.Phase, tc.TiDBVersion()
+	}
+	return new(*v1alpha1.HotConfigStatus), v1alpha1.NormalPhase, ""
+}
+
+func setHotConfigStatus(status **v1alpha1.HotConfigStatus, applied, pending []string) {
+	if *status != nil && equality.Semantic.DeepEqual((*status).AppliedKeys, applied) &&
+		equality.Semantic.DeepEqual((*status).PendingKeys, pending) {
+		return
+	}
+	*status = &v1alpha1.HotConfigStatus{
+		AppliedKeys:        applied,
+		PendingKeys:        pending,
+		LastTransitionTime: metav1.Now(),
+	}
+}
+
+func copyDataWithout(data map[string]string, key string) map[string]string {
+	m := make(map[string]string, len(data))
+	for k, v := range data {
+		if k != key {
+			m[k] = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/features"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/tikvapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+)
+
+func TestLookupHotConfigItem(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		memberType v1alpha1.MemberType
+		version    string
+		key        string
+		dynamic    bool
+		name       string
+	}{
+		{v1alpha1.TiKVMemberType, "v7.5.0", "raftstore.raft-log-gc-threshold", true, ""},
+		{v1alpha1.TiKVMemberType, "v7.5.0", "rocksdb.writecf.block-cache-size", true, ""},
+		{v1alpha1.TiKVMemberType, "v7.5.0", "storage.data-dir", false, ""},
+		{v1alpha1.TiKVMemberType, "v5.4.0", "pessimistic-txn.in-memory", false, ""},
+		{v1alpha1.TiKVMemberType, "v3.1.0", "raftstore.raft-log-gc-threshold", false, ""},
+		{v1alpha1.TiKVMemberType, "nightly", "pessimistic-txn.in-memory", true, ""},
+		{v1alpha1.PDMemberType, "v7.5.0", "schedule.leader-schedule-limit", true, ""},
+		{v1alpha1.PDMemberType, "v7.5.0", "pd-server.tso-update-physical-interval", false, ""},
+		{v1alpha1.TiDBMemberType, "v7.5.0", "instance.tidb_general_log", true, "tidb_general_log"},
+		{v1alpha1.TiDBMemberType, "v6.0.0", "instance.tidb_general_log", false, ""},
+		{v1alpha1.TiDBMemberType, "v7.5.0", "log.level", false, ""},
+		{v1alpha1.TiDBMemberType, "v7.5.0", "log.file.max-size", false, ""},
+	}
+	for _, tt := range tests {
+		item, ok := lookupHotConfigItem(tt.memberType, tt.version, tt.key)
+		g.Expect(ok).To(Equal(tt.dynamic), "%s %s %s", tt.memberType, tt.version, tt.key)
+		if ok {
+			g.Expect(item.name).To(Equal(tt.name))
+		}
+	}
+}
+
+func TestSyncHotConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	saved := features.DefaultFeatureGate.String()
+	g.Expect(features.DefaultFeatureGate.Set(fmt.Sprintf("%s=true", features.HotConfigApply))).To(Succeed())
+	defer features.DefaultFeatureGate.Set(saved) // reset features on exit
+
+	tc := newTidbClusterForTiDB()
+	tc.Spec.Version = "v7.5.0"
+	tc.Spec.PD.BaseImage = "pingcap/pd"
+	tc.Spec.TiKV.BaseImage = "pingcap/tikv"
+	tc.Spec.TiDB.BaseImage = "pingcap/tidb"
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"1": {ID: "1", PodName: "test-tikv-0", State: v1alpha1.TiKVStateUp},
+		"2": {ID: "2", PodName: "test-tikv-1", State: v1alpha1.TiKVStateDown},
+	}
+	tc.Status.TiDB.Members = map[string]v1alpha1.TiDBMember{
+		"test-tidb-0": {Name: "test-tidb-0", Health: true},
+		"test-tidb-1": {Name: "test-tidb-1", Health: false},
+	}
+
+	deps := controller.NewFakeDependencies()
+	cmIndexer := deps.LabelFilterKubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer()
+	newCm := func(name, config string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: tc.Namespace},
+			Data: map[string]string{
+				"config-file":    config,
+				"startup-script": "script",
+			},
+		}
+	}
+	rollingUpdate := v1alpha1.ConfigUpdateStrategyRollingUpdate
+
+	// tikv: all the changed items can be changed online
+	g.Expect(cmIndexer.Add(newCm("test-tikv-1", "[raftstore]\nraft-log-gc-threshold = 50\n"))).To(Succeed())
+	tikvClient := tikvapi.NewFakeTiKVClient()
+	var tikvConfig map[string]string
+	tikvClient.AddReaction(tikvapi.UpdateConfigActionType, func(action *tikvapi.Action) (interface{}, error) {
+		tikvConfig = action.Config
+		return nil, nil
+	})
+	deps.TiKVControl.(*tikvapi.FakeTiKVControl).SetTiKVPodClient(tc.Namespace, tc.Name, "test-tikv-0", tikvClient)
+	desired := newCm("test-tikv-2", "[raftstore]\nraft-log-gc-threshold = 100\n")
+	g.Expect(syncHotConfig(deps, tc, v1alpha1.TiKVMemberType, rollingUpdate, "test-tikv-1", desired)).To(Succeed())
+	g.Expect(desired.Name).To(Equal("test-tikv-1"))
+	g.Expect(tikvConfig).To(Equal(map[string]string{"raftstore.raft-log-gc-threshold": "100"}))
+	g.Expect(tc.Status.TiKV.HotConfig.AppliedKeys).To(Equal([]string{"raftstore.raft-log-gc-threshold"}))
+	g.Expect(tc.Status.TiKV.HotConfig.PendingKeys).To(BeEmpty())
+
+	// tikv: an item that can not be changed online is changed, the others are still applied online
+	tikvConfig = nil
+	desired = newCm("test-tikv-2", "[raftstore]\nraft-log-gc-threshold = 100\n[storage]\ndata-dir = \"/data\"\n")
+	g.Expect(syncHotConfig(deps, tc, v1alpha1.TiKVMemberType, rollingUpdate, "test-tikv-1", desired)).To(Succeed())
+	g.Expect(desired.Name).To(Equal("test-tikv-2"))
+	g.Expect(tikvConfig).To(Equal(map[string]string{"raftstore.raft-log-gc-threshold": "100"}))
+	g.Expect(tc.Status.TiKV.HotConfig.AppliedKeys).To(Equal([]string{"raftstore.raft-log-gc-threshold"}))
+	g.Expect(tc.Status.TiKV.HotConfig.PendingKeys).To(Equal([]string{"storage.data-dir"}))
+
+	// tikv: the items are not applied again while waiting for the rolling update
+	tikvConfig = nil
+	g.Expect(syncHotConfig(deps, tc, v1alpha1.TiKVMemberType, rollingUpdate, "test-tikv-1", desired)).To(Succeed())
+	g.Expect(desired.Name).To(Equal("test-tikv-2"))
+	g.Expect(tikvConfig).To(BeNil())
+
+	// tikv: the pending items are cleared after the rolling update
+	desired = newCm("test-tikv-2", "")
+	g.Expect(syncHotConfig(deps, tc, v1alpha1.TiKVMemberType, rollingUpdate, "test-tikv-2", desired)).To(Succeed())
+	g.Expect(tc.Status.TiKV.HotConfig.AppliedKeys).To(Equal([]string{"raftstore.raft-log-gc-threshold"}))
+	g.Expect(tc.Status.TiKV.HotConfig.PendingKeys).To(BeEmpty())
+
+	// tikv: the servers are restarted if the startup script is changed
+	desired = newCm("test-tikv-2", "[raftstore]\nraft-log-gc-threshold = 100\n")
+	desired.Data["startup-script"] = "new script"
+	g.Expect(syncHotConfig(deps, tc, v1alpha1.TiKVMemberType, rollingUpdate, "test-tikv-1", desired)).To(Succeed())
+	g.Expect(desired.Name).To(Equal("test-tikv-2"))
+
+	// pd
+	g.Expect(cmIndexer.Add(newCm("test-pd-1", "[replication]\nlocation-labels = [\"zone\"]\n"))).To(Succeed())
+	pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), tc)
+	var pdConfig map[string]interface{}
+	pdClient.AddReaction(pdapi.UpdateConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+		pdConfig = action.Config
+		return nil, nil
+	})
+	desired = newCm("test-pd-2", "[replication]\nlocation-labels = [\"zone\", \"host\"]\n[schedule]\nleader-schedule-limit = 8\n")
+	g.Expect(syncHotConfig(deps, tc, v1alpha1.PDMemberType, rollingUpdate, "test-pd-1", desired)).To(Succeed())
+	g.Expect(desired.Name).To(Equal("test-pd-1"))
+	g.Expect(pdConfig).To(Equal(map[string]interface{}{
+		"replication.location-labels":    "zone,host",
+		"schedule.leader-schedule-limit": int64(8),
+	}))
+
+	// tidb: the items are applied by the rolling update without the secret of the account
+	g.Expect(cmIndexer.Add(newCm("test-tidb-1", "[instance]\ntidb_general_log = false\n"))).To(Succeed())
+	desired = newCm("test-tidb-2", "[instance]\ntidb_general_log = true\ntidb_slow_log_threshold = 500\n")
+	g.Expect(syncHotConfig(deps, tc, v1alpha1.TiDBMemberType, rollingUpdate, "test-tidb-1", desired)).To(Succeed())
+	g.Expect(desired.Name).To(Equal("test-tidb-2"))
+	tidbControl := deps.TiDBControl.(*controller.FakeTiDBControl)
+	g.Expect(tidbControl.GetVariables(0)).To(BeNil())
+	g.Expect(tc.Status.TiDB.HotConfig.PendingKeys).To(Equal([]string{"instance.tidb_general_log", "instance.tidb_slow_log_threshold"}))
+
+	// tidb
+	tc.Spec.TiDB.HotConfigSecretName = pointer.StringPtr("hot-config")
+	tc.Status.TiDB.HotConfig = nil
+	desired = newCm("test-tidb-2", "[instance]\ntidb_general_log = true\ntidb_slow_log_threshold = 500\n")
+	g.Expect(syncHotConfig(deps, tc, v1alpha1.TiDBMemberType, rollingUpdate, "test-tidb-1", desired)).To(Succeed())
+	g.Expect(desired.Name).To(Equal("test-tidb-1"))
+	g.Expect(tidbControl.GetVariables(0)).To(Equal(map[string]string{"tidb_general_log": "ON", "tidb_slow_log_threshold": "500"}))
+	g.Expect(tidbControl.GetVariables(1)).To(BeNil())
+
+	// tidb: the items are left to the rolling update with a warning event if they fail to be applied online
+	recorder := deps.Recorder.(*record.FakeRecorder)
+	for len(recorder.Events) > 0 {
+		<-recorder.Events
+	}
+	tidbControl.SetVariablesErr(fmt.Errorf("failed"))
+	desired = newCm("test-tidb-2", "[instance]\ntidb_general_log = true\n")
+	g.Expect(syncHotConfig(deps, tc, v1alpha1.TiDBMemberType, rollingUpdate, "test-tidb-1", desired)).To(Succeed())
+	g.Expect(desired.Name).To(Equal("test-tidb-2"))
+	g.Expect(tc.Status.TiDB.HotConfig.AppliedKeys).To(BeEmpty())
+	g.Expect(tc.Status.TiDB.HotConfig.PendingKeys).To(Equal([]string{"instance.tidb_general_log"}))
+	g.Expect(recorder.Events).To(HaveLen(1))
+	g.Expect(<-recorder.Events).To(ContainSubstring(hotConfigApplyFailedEvent))
+
+	// tidb: the failed items are not retried while waiting for the rolling update
+	g.Expect(syncHotConfig(deps, tc, v1alpha1.TiDBMemberType, rollingUpdate, "test-tidb-1", desired)).To(Succeed())
+	g.Expect(desired.Name).To(Equal("test-tidb-2"))
+	g.Expect(recorder.Events).To(BeEmpty())
+
+	// nothing is applied online with the InPlace strategy
+	desired = newCm("test-tidb-2", "[instance]\ntidb_general_log = true\n")
+	g.Expect(syncHotConfig(deps, tc, v1alpha1.TiDBMemberType, v1alpha1.ConfigUpdateStrategyInPlace, "test-tidb-1", desired)).To(Succeed())
+	g.Expect(desired.Name).To(Equal("test-tidb-2"))
+}
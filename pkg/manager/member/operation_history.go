@@ -0,0 +1,145 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+)
+
+// recordStatefulSetOperations records the scaling and the upgrade of the StatefulSet of the component in
+// the operation history of the TidbCluster. It is called before the StatefulSet is scaled and upgraded,
+// and the operations are finished once the StatefulSet reaches the desired state. The scaling is cancelled
+// if the replicas are reverted before the pods are added or removed.
+func recordStatefulSetOperations(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, oldSet, newSet *apps.StatefulSet) {
+	oldOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet)
+	newOrdinals := helper.GetPodOrdinals(*newSet.Spec.Replicas, newSet)
+
+	if scaleOut := newOrdinals.Difference(oldOrdinals); scaleOut.Len() > 0 {
+		trigger := v1alpha1.OperationTriggerSpecChange
+		if last := tc.LastOperation(memberType); last != nil && last.Type == v1alpha1.OperationFailover {
+			trigger = v1alpha1.OperationTriggerFailover
+		}
+		tc.StartOperation(v1alpha1.OperationScaleOut, memberType, trigger, scaleOut.List()...)
+	} else if op := tc.InProgressOperation(v1alpha1.OperationScaleOut, memberType); op != nil {
+		if abandoned := sets.NewInt32(op.Ordinals...).Difference(newOrdinals); abandoned.Len() > 0 {
+			tc.FinishOperation(v1alpha1.OperationScaleOut, memberType, v1alpha1.OperationCancelled,
+				fmt.Sprintf("the replicas are reverted before pods %v are added", abandoned.List()))
+		} else if scaledOut(tc, memberType, op.Ordinals) {
+			tc.FinishOperation(v1alpha1.OperationScaleOut, memberType, v1alpha1.OperationSucceeded, "")
+		}
+	}
+
+	if scaleIn := oldOrdinals.Difference(newOrdinals); scaleIn.Len() > 0 {
+		trigger := v1alpha1.OperationTriggerSpecChange
+		if last := tc.LastOperation(memberType); last != nil && last.Type == v1alpha1.OperationFailoverRecovery {
+			trigger = v1alpha1.OperationTriggerAutoRecovery
+		}
+		// the refused scale-in is recorded again only when it is retried by the scaler
+		if last := tc.LastOperationOfType(v1alpha1.OperationScaleIn, memberType); last == nil || last.Outcome != v1alpha1.OperationFailed ||
+			!sets.NewInt32(last.Ordinals...).Equal(scaleIn) {
+			tc.StartOperation(v1alpha1.OperationScaleIn, memberType, trigger, scaleIn.List()...)
+		}
+	} else if op := tc.InProgressOperation(v1alpha1.OperationScaleIn, memberType); op != nil {
+		if abandoned := sets.NewInt32(op.Ordinals...).Intersection(newOrdinals); abandoned.Len() > 0 {
+			tc.FinishOperation(v1alpha1.OperationScaleIn, memberType, v1alpha1.OperationCancelled,
+				fmt.Sprintf("the replicas are reverted before pods %v are removed", abandoned.List()))
+		} else {
+			tc.FinishOperation(v1alpha1.OperationScaleIn, memberType, v1alpha1.OperationSucceeded, "")
+		}
+	}
+
+	upgrading := false
+	if status := tc.ComponentStatus(memberType); status != nil {
+		upgrading = status.GetPhase() == v1alpha1.UpgradePhase
+	}
+	if !templateEqual(newSet, oldSet) || upgrading {
+		if tc.InProgressOperation(v1alpha1.OperationUpgrade, memberType) == nil {
+			// pods are restarted from the largest ordinal to the smallest one
+			ordinals := oldOrdinals.Intersection(newOrdinals).List()
+			sort.Slice(ordinals, func(i, j int) bool { return ordinals[i] > ordinals[j] })
+			tc.StartOperation(v1alpha1.OperationUpgrade, memberType, v1alpha1.OperationTriggerSpecChange, ordinals...)
+		}
+	} else if !mngerutils.StatefulSetIsUpgrading(oldSet) {
+		tc.FinishOperation(v1alpha1.OperationUpgrade, memberType, v1alpha1.OperationSucceeded, "")
+	}
+}
+
+// scaledOut returns whether the pods of the ordinals are added, the TiKV pods are added once their stores are Up
+func scaledOut(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, ordinals []int32) bool {
+	if memberType != v1alpha1.TiKVMemberType {
+		return true
+	}
+	up := sets.NewString()
+	for _, store := range tc.Status.TiKV.Stores {
+		if store.State == v1alpha1.TiKVStateUp {
+			up.Insert(store.PodName)
+		}
+	}
+	for _, ordinal := range ordinals {
+		if !up.Has(tikvGroupPodName(tc.GetName(), tc.TiKVGroupName(), ordinal)) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordScaleInRefused records that the scale-in of the pods of the component is refused, it is not recorded
+// again until the scale-in is retried
+func recordScaleInRefused(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, message string) {
+	tc.FinishOperation(v1alpha1.OperationScaleIn, memberType, v1alpha1.OperationFailed, message)
+}
+
+// recordScaleInRetried records the scale-in of the pods of the component again after it is refused
+func recordScaleInRetried(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, ordinals ...int32) {
+	if last := tc.LastOperationOfType(v1alpha1.OperationScaleIn, memberType); last != nil && last.Outcome == v1alpha1.OperationFailed {
+		tc.StartOperation(v1alpha1.OperationScaleIn, memberType, last.Trigger, ordinals...)
+	}
+}
+
+// recordFailoverOperation records that the failed pod of the component is marked to be replaced
+func recordFailoverOperation(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, podName, message string) {
+	tc.RecordOperation(v1alpha1.OperationFailover, memberType, v1alpha1.OperationTriggerFailover, message, podOrdinals(podName)...)
+}
+
+// recordFailoverRecoveryOperation records that the failure members of the component are cleared
+func recordFailoverRecoveryOperation(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, podNames ...string) {
+	if len(podNames) == 0 {
+		return
+	}
+	tc.RecordOperation(v1alpha1.OperationFailoverRecovery, memberType, v1alpha1.OperationTriggerAutoRecovery, "", podOrdinals(podNames...)...)
+}
+
+// podOrdinals returns the ordinals of the pods in ascending order
+func podOrdinals(podNames ...string) []int32 {
+	var ordinals []int32
+	for _, podName := range podNames {
+		ordinal, err := util.GetOrdinalFromPodName(podName)
+		if err != nil {
+			klog.Errorf("unexpected pod name %q: %v", podName, err)
+			continue
+		}
+		ordinals = append(ordinals, ordinal)
+	}
+	sort.Slice(ordinals, func(i, j int) bool { return ordinals[i] < ordinals[j] })
+	return ordinals
+}
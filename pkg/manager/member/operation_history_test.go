@@ -0,0 +1,135 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestRecordStatefulSetOperations(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := newTidbClusterForChangePlan()
+
+	// scale out
+	recordStatefulSetOperations(tc, v1alpha1.PDMemberType, newStatefulSetForChangePlan(3, "pd:v1"), newStatefulSetForChangePlan(5, "pd:v1"))
+	op := tc.InProgressOperation(v1alpha1.OperationScaleOut, v1alpha1.PDMemberType)
+	g.Expect(op).NotTo(BeNil())
+	g.Expect(op.Trigger).To(Equal(v1alpha1.OperationTriggerSpecChange))
+	g.Expect(op.Ordinals).To(Equal([]int32{3, 4}))
+
+	recordStatefulSetOperations(tc, v1alpha1.PDMemberType, newStatefulSetForChangePlan(4, "pd:v1"), newStatefulSetForChangePlan(5, "pd:v1"))
+	g.Expect(tc.Status.Operations).To(HaveLen(1))
+
+	recordStatefulSetOperations(tc, v1alpha1.PDMemberType, newStatefulSetForChangePlan(5, "pd:v1"), newStatefulSetForChangePlan(5, "pd:v1"))
+	g.Expect(tc.Status.Operations).To(HaveLen(1))
+	g.Expect(tc.Status.Operations[0].Outcome).To(Equal(v1alpha1.OperationSucceeded))
+
+	// upgrade
+	recordStatefulSetOperations(tc, v1alpha1.PDMemberType, newStatefulSetForChangePlan(3, "pd:v1"), newStatefulSetForChangePlan(3, "pd:v2"))
+	op = tc.InProgressOperation(v1alpha1.OperationUpgrade, v1alpha1.PDMemberType)
+	g.Expect(op).NotTo(BeNil())
+	g.Expect(op.Ordinals).To(Equal([]int32{2, 1, 0}))
+
+	tc.Status.PD.Phase = v1alpha1.UpgradePhase
+	recordStatefulSetOperations(tc, v1alpha1.PDMemberType, newStatefulSetForChangePlan(3, "pd:v2"), newStatefulSetForChangePlan(3, "pd:v2"))
+	g.Expect(tc.InProgressOperation(v1alpha1.OperationUpgrade, v1alpha1.PDMemberType)).NotTo(BeNil())
+
+	tc.Status.PD.Phase = v1alpha1.NormalPhase
+	recordStatefulSetOperations(tc, v1alpha1.PDMemberType, newStatefulSetForChangePlan(3, "pd:v2"), newStatefulSetForChangePlan(3, "pd:v2"))
+	g.Expect(tc.InProgressOperation(v1alpha1.OperationUpgrade, v1alpha1.PDMemberType)).To(BeNil())
+	g.Expect(tc.Status.Operations).To(HaveLen(2))
+
+	// the replacement of a failed member
+	recordFailoverOperation(tc, v1alpha1.PDMemberType, "test-pd-1", "pd member test-pd-1 is unhealthy")
+	recordStatefulSetOperations(tc, v1alpha1.PDMemberType, newStatefulSetForChangePlan(3, "pd:v2"), newStatefulSetForChangePlan(4, "pd:v2"))
+	op = tc.InProgressOperation(v1alpha1.OperationScaleOut, v1alpha1.PDMemberType)
+	g.Expect(op.Trigger).To(Equal(v1alpha1.OperationTriggerFailover))
+
+	// the replacement is removed after the failed member recovers
+	recordStatefulSetOperations(tc, v1alpha1.PDMemberType, newStatefulSetForChangePlan(4, "pd:v2"), newStatefulSetForChangePlan(4, "pd:v2"))
+	recordFailoverRecoveryOperation(tc, v1alpha1.PDMemberType, "test-pd-1")
+	recordStatefulSetOperations(tc, v1alpha1.PDMemberType, newStatefulSetForChangePlan(4, "pd:v2"), newStatefulSetForChangePlan(3, "pd:v2"))
+	op = tc.InProgressOperation(v1alpha1.OperationScaleIn, v1alpha1.PDMemberType)
+	g.Expect(op.Trigger).To(Equal(v1alpha1.OperationTriggerAutoRecovery))
+	g.Expect(op.Ordinals).To(Equal([]int32{3}))
+}
+
+func TestRecordStatefulSetOperationsCancelled(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := newTidbClusterForChangePlan()
+
+	// the scale-out is reverted before the pods are added
+	recordStatefulSetOperations(tc, v1alpha1.PDMemberType, newStatefulSetForChangePlan(3, "pd:v1"), newStatefulSetForChangePlan(5, "pd:v1"))
+	recordStatefulSetOperations(tc, v1alpha1.PDMemberType, newStatefulSetForChangePlan(4, "pd:v1"), newStatefulSetForChangePlan(4, "pd:v1"))
+	g.Expect(tc.Status.Operations).To(HaveLen(1))
+	g.Expect(tc.Status.Operations[0].Outcome).To(Equal(v1alpha1.OperationCancelled))
+	g.Expect(tc.Status.Operations[0].Message).To(ContainSubstring("[4]"))
+
+	// the scale-in is reverted before the pods are removed
+	recordStatefulSetOperations(tc, v1alpha1.PDMemberType, newStatefulSetForChangePlan(4, "pd:v1"), newStatefulSetForChangePlan(3, "pd:v1"))
+	recordStatefulSetOperations(tc, v1alpha1.PDMemberType, newStatefulSetForChangePlan(4, "pd:v1"), newStatefulSetForChangePlan(4, "pd:v1"))
+	g.Expect(tc.Status.Operations).To(HaveLen(2))
+	g.Expect(tc.Status.Operations[1].Type).To(Equal(v1alpha1.OperationScaleIn))
+	g.Expect(tc.Status.Operations[1].Outcome).To(Equal(v1alpha1.OperationCancelled))
+}
+
+func TestRecordStatefulSetOperationsScaleInRefused(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := newTidbClusterForChangePlan()
+
+	recordStatefulSetOperations(tc, v1alpha1.TiKVMemberType, newStatefulSetForChangePlan(4, "tikv:v1"), newStatefulSetForChangePlan(3, "tikv:v1"))
+	recordScaleInRefused(tc, v1alpha1.TiKVMemberType, "the remaining stores can not hold the data")
+	g.Expect(tc.Status.Operations).To(HaveLen(1))
+	g.Expect(tc.Status.Operations[0].Outcome).To(Equal(v1alpha1.OperationFailed))
+	g.Expect(tc.Status.Operations[0].Message).To(Equal("the remaining stores can not hold the data"))
+
+	// the refused scale-in is not recorded again in the following syncs
+	recordStatefulSetOperations(tc, v1alpha1.TiKVMemberType, newStatefulSetForChangePlan(4, "tikv:v1"), newStatefulSetForChangePlan(3, "tikv:v1"))
+	recordScaleInRefused(tc, v1alpha1.TiKVMemberType, "the remaining stores can not hold the data")
+	g.Expect(tc.Status.Operations).To(HaveLen(1))
+
+	// the scale-in is recorded again once it is retried
+	recordStatefulSetOperations(tc, v1alpha1.TiKVMemberType, newStatefulSetForChangePlan(4, "tikv:v1"), newStatefulSetForChangePlan(3, "tikv:v1"))
+	recordScaleInRetried(tc, v1alpha1.TiKVMemberType, 3)
+	g.Expect(tc.Status.Operations).To(HaveLen(2))
+	op := tc.InProgressOperation(v1alpha1.OperationScaleIn, v1alpha1.TiKVMemberType)
+	g.Expect(op).NotTo(BeNil())
+	g.Expect(op.Ordinals).To(Equal([]int32{3}))
+
+	recordStatefulSetOperations(tc, v1alpha1.TiKVMemberType, newStatefulSetForChangePlan(3, "tikv:v1"), newStatefulSetForChangePlan(3, "tikv:v1"))
+	g.Expect(tc.Status.Operations[1].Outcome).To(Equal(v1alpha1.OperationSucceeded))
+}
+
+func TestRecordStatefulSetOperationsTiKVScaleOut(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := newTidbClusterForChangePlan()
+
+	recordStatefulSetOperations(tc, v1alpha1.TiKVMemberType, newStatefulSetForChangePlan(3, "tikv:v1"), newStatefulSetForChangePlan(4, "tikv:v1"))
+
+	// the scale-out is not finished until the store of the new pod is Up
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"4": {ID: "4", PodName: "test-tikv-3", State: v1alpha1.TiKVStateOffline},
+	}
+	recordStatefulSetOperations(tc, v1alpha1.TiKVMemberType, newStatefulSetForChangePlan(4, "tikv:v1"), newStatefulSetForChangePlan(4, "tikv:v1"))
+	g.Expect(tc.InProgressOperation(v1alpha1.OperationScaleOut, v1alpha1.TiKVMemberType)).NotTo(BeNil())
+
+	tc.Status.TiKV.Stores["4"] = v1alpha1.TiKVStore{ID: "4", PodName: "test-tikv-3", State: v1alpha1.TiKVStateUp}
+	recordStatefulSetOperations(tc, v1alpha1.TiKVMemberType, newStatefulSetForChangePlan(4, "tikv:v1"), newStatefulSetForChangePlan(4, "tikv:v1"))
+	g.Expect(tc.InProgressOperation(v1alpha1.OperationScaleOut, v1alpha1.TiKVMemberType)).To(BeNil())
+	g.Expect(tc.Status.Operations).To(HaveLen(1))
+	g.Expect(tc.Status.Operations[0].Outcome).To(Equal(v1alpha1.OperationSucceeded))
+}
@@ -96,6 +96,11 @@ func (f *pdFailover) Failover(tc *v1alpha1.TidbCluster) error {
 }
 
 func (f *pdFailover) Recover(tc *v1alpha1.TidbCluster) {
+	podNames := make([]string, 0, len(tc.Status.PD.FailureMembers))
+	for _, member := range tc.Status.PD.FailureMembers {
+		podNames = append(podNames, member.PodName)
+	}
+	recordFailoverRecoveryOperation(tc, v1alpha1.PDMemberType, podNames...)
 	tc.Status.PD.FailureMembers = nil
 	klog.Infof("pd failover: clearing pd failoverMembers, %s/%s", tc.GetNamespace(), tc.GetName())
 }
@@ -147,6 +152,7 @@ func (f *pdFailover) tryToMarkAPeerAsFailure(tc *v1alpha1.TidbCluster) error {
 			MemberDeleted: false,
 			CreatedAt:     metav1.Now(),
 		}
+		recordFailoverOperation(tc, v1alpha1.PDMemberType, podName, fmt.Sprintf("pd member %s is unhealthy", pdMember.Name))
 		return controller.RequeueErrorf("marking Pod: %s/%s pd member: %s as failure", ns, podName, pdMember.Name)
 	}
 
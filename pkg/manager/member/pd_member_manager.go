@@ -37,6 +37,7 @@ import (
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -214,6 +215,14 @@ func (m *pdMemberManager) syncPDStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 	if err != nil {
 		return err
 	}
+	setStartBinary(tc, m.deps.CLIConfig.TiDBDiscoveryImage, &newPDSet.Spec.Template.Spec)
+	setPDModeAnnotation(tc, oldPDSet, newPDSet)
+
+	// in the plan mode, the changes driven by the spec are held until the plan is approved
+	if proceed, err := syncChangePlan(m.deps, tc, v1alpha1.PDMemberType, tc.Spec.PD.Replicas, oldPDSet, newPDSet); err != nil || !proceed {
+		return err
+	}
+
 	if setNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newPDSet)
 		if err != nil {
@@ -240,6 +249,8 @@ func (m *pdMemberManager) syncPDStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 		}
 	}
 
+	recordStatefulSetOperations(tc, v1alpha1.PDMemberType, oldPDSet, newPDSet)
+
 	// Scaling takes precedence over upgrading because:
 	// - if a pd fails in the upgrading, users may want to delete it or add
 	//   new replicas
@@ -462,6 +473,11 @@ func (m *pdMemberManager) syncPDConfigMap(tc *v1alpha1.TidbCluster, set *apps.St
 	if err != nil {
 		return nil, err
 	}
+
+	err = syncHotConfig(m.deps, tc, v1alpha1.PDMemberType, tc.BasePDSpec().ConfigUpdateStrategy(), inUseName, newCm)
+	if err != nil {
+		return nil, err
+	}
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
@@ -744,7 +760,7 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 		Name:            v1alpha1.PDMemberType.String(),
 		Image:           tc.PDImage(),
 		ImagePullPolicy: basePDSpec.ImagePullPolicy(),
-		Command:         []string{"/bin/sh", "/usr/local/bin/pd_start_script.sh"},
+		Command:         startScriptCommand(tc, "/usr/local/bin/pd_start_script.sh"),
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          "server",
@@ -931,6 +947,45 @@ func getPDConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 	return cm, nil
 }
 
+// setPDModeAnnotation sets the pd mode annotation of pd pods. The start script of pd depends on the mode,
+// the annotation makes sure that pd is restarted when it is switched between pd and pd microservices,
+// even with the InPlace config update strategy.
+func setPDModeAnnotation(tc *v1alpha1.TidbCluster, oldSet, newSet *apps.StatefulSet) {
+	mode, ok := pdModeAnnotation(tc, oldSet)
+	if !ok {
+		return
+	}
+	if newSet.Spec.Template.Annotations == nil {
+		newSet.Spec.Template.Annotations = map[string]string{}
+	}
+	newSet.Spec.Template.Annotations[label.AnnPDMode] = mode
+}
+
+// pdModeAnnotation returns the value of the pd mode annotation of pd pods. The annotation is only
+// changed in the stage that switches pd between pd and pd microservices, it is kept as it is in the
+// old StatefulSet in the other stages, so that pd is restarted only once by the migration and is
+// not restarted by upgrading the operator.
+func pdModeAnnotation(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSet) (string, bool) {
+	if tc.PDMSMigrationStage() == v1alpha1.PDMSMigrationSwitchingPD {
+		if tc.PDInMSMode() {
+			return v1alpha1.PDModeMS, true
+		}
+		return "pd", true
+	}
+	if oldSet != nil {
+		mode, ok := oldSet.Spec.Template.Annotations[label.AnnPDMode]
+		return mode, ok
+	}
+	// the StatefulSet is recreated after the migration
+	if meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.ConditionTypePDMSMigrating) == nil {
+		return "", false
+	}
+	if tc.Status.PD.Mode == v1alpha1.PDModeMS {
+		return v1alpha1.PDModeMS, true
+	}
+	return "pd", true
+}
+
 func clusterVersionGreaterThanOrEqualTo4(version, model string) (bool, error) {
 	// TODO: remove `model` and `nightly` when support pd microservice docker
 	if model == "ms" && version == "nightly" {
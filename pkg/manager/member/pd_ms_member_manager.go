@@ -65,7 +65,12 @@ func (m *pdMSMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 	}
 	// remove all microservice components if PDMS is not enabled
 	// PDMS need to be enabled when PD.Mode is ms && PDMS is not nil
-	if tc.Spec.PDMS == nil || (tc.Spec.PD != nil && tc.Spec.PD.Mode != "ms") {
+	if !tc.PDMSEnabled() {
+		if tc.Status.PD.Mode == v1alpha1.PDModeMS {
+			// PD servers still depend on the microservices, they are removed after PD is migrated
+			klog.Infof("PD of cluster [%s/%s] is running in the mode of microservices, skip removing PD microservices", tc.GetNamespace(), tc.GetName())
+			return nil
+		}
 		for _, comp := range tc.Status.PDMS {
 			ns := tc.GetNamespace()
 			tcName := tc.GetName()
@@ -294,6 +299,7 @@ func (m *pdMSMemberManager) syncPDMSStatefulSet(tc *v1alpha1.TidbCluster, curSpe
 	if err != nil {
 		return err
 	}
+	setStartBinary(tc, m.deps.CLIConfig.TiDBDiscoveryImage, &newPDMSSet.Spec.Template.Spec)
 	if setNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newPDMSSet)
 		if err != nil {
@@ -616,7 +622,7 @@ func (m *pdMSMemberManager) getNewPDMSStatefulSet(tc *v1alpha1.TidbCluster, cm *
 		Name:            v1alpha1.PDMSMemberType(curService).String(),
 		Image:           tc.PDMSImage(curSpec),
 		ImagePullPolicy: basePDMSSpec.ImagePullPolicy(),
-		Command:         []string{"/bin/sh", "/usr/local/bin/pdms_start_script.sh"},
+		Command:         startScriptCommand(tc, "/usr/local/bin/pdms_start_script.sh"),
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          "server",
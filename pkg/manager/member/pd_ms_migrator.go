@@ -0,0 +1,233 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// pdMSMigrator migrates a running cluster between PD and PD microservices online.
+//
+// Migrating from PD to PD microservices:
+//  1. start the PD microservices while PD is still serving TSO and scheduling
+//  2. restart PD servers in the mode of PD microservices, and verify the primaries of the microservices
+//
+// Migrating from PD microservices to PD:
+//  1. restart PD servers in the mode of PD, PD microservices keep running until all PD servers are restarted
+//  2. stop the PD microservices
+//
+// The progress is tracked by the `PDMSMigrating` condition of PD.
+type pdMSMigrator struct {
+	deps *controller.Dependencies
+}
+
+// NewPDMSMigrator returns a migrator between PD and PD microservices
+func NewPDMSMigrator(deps *controller.Dependencies) manager.Manager {
+	return &pdMSMigrator{deps: deps}
+}
+
+func (m *pdMSMigrator) Sync(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.PD == nil {
+		return nil
+	}
+
+	// the migration is driven by the member managers of PD and PD microservices, which are synced
+	// after the migrator, so waiting for them must not block the sync of the cluster
+	err := m.migrate(tc)
+	if controller.IsRequeueError(err) {
+		klog.Infof("%v", err)
+		return nil
+	}
+	return err
+}
+
+func (m *pdMSMigrator) migrate(tc *v1alpha1.TidbCluster) error {
+	target := tc.PDTargetMode()
+	cond := meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.ConditionTypePDMSMigrating)
+
+	// nothing to migrate for a new cluster or a cluster that was created in the mode of PD microservices
+	if tc.Status.PD.StatefulSet == nil || (cond == nil && tc.Status.PD.Mode == "" && target == v1alpha1.PDModeMS && m.pdMSRunning(tc)) {
+		tc.Status.PD.Mode = target
+		return nil
+	}
+
+	if tc.Status.PD.Mode == target {
+		if cond == nil || cond.Status != metav1.ConditionTrue {
+			return nil
+		}
+		// the migration is reverted while PD servers are being switched, switch them back first
+		if tc.PDMSMigrationStage() == v1alpha1.PDMSMigrationSwitchingPD {
+			if err := m.checkPDSwitched(tc); err != nil {
+				return err
+			}
+		}
+		// the migration is reverted before PD servers are switched, or PD microservices are not stopped yet
+		if target == "" && m.pdMSRunning(tc) {
+			m.setStage(tc, metav1.ConditionTrue, v1alpha1.PDMSMigrationStoppingServices, "waiting for PD microservices to be stopped")
+			return controller.RequeueErrorf("tidbcluster: [%s/%s], waiting for PD microservices to be stopped", tc.GetNamespace(), tc.GetName())
+		}
+		m.setStage(tc, metav1.ConditionFalse, v1alpha1.PDMSMigrationCompleted, fmt.Sprintf("PD is running in mode %q", target))
+		return nil
+	}
+
+	if target == v1alpha1.PDModeMS {
+		return m.migrateToMS(tc)
+	}
+	return m.migrateToPD(tc)
+}
+
+func (m *pdMSMigrator) migrateToMS(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	switch tc.PDMSMigrationStage() {
+	case "", v1alpha1.PDMSMigrationStoppingServices:
+		m.setStage(tc, metav1.ConditionTrue, v1alpha1.PDMSMigrationStartingServices, "starting PD microservices")
+		return controller.RequeueErrorf("tidbcluster: [%s/%s], starting PD microservices", ns, tcName)
+	case v1alpha1.PDMSMigrationStartingServices:
+		if err := m.checkPDMSReady(tc); err != nil {
+			return err
+		}
+		m.setStage(tc, metav1.ConditionTrue, v1alpha1.PDMSMigrationSwitchingPD, "restarting PD in mode of PD microservices")
+		return controller.RequeueErrorf("tidbcluster: [%s/%s], restarting PD in mode of PD microservices", ns, tcName)
+	default:
+		if err := m.checkPDSwitched(tc); err != nil {
+			return err
+		}
+		if err := m.checkPDMSReady(tc); err != nil {
+			return err
+		}
+		klog.Infof("tidbcluster: [%s/%s], PD is migrated to PD microservices", ns, tcName)
+		tc.Status.PD.Mode = v1alpha1.PDModeMS
+		m.setStage(tc, metav1.ConditionFalse, v1alpha1.PDMSMigrationCompleted, fmt.Sprintf("PD is running in mode %q", v1alpha1.PDModeMS))
+		return nil
+	}
+}
+
+func (m *pdMSMigrator) migrateToPD(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	if tc.PDMSMigrationStage() != v1alpha1.PDMSMigrationSwitchingPD {
+		m.setStage(tc, metav1.ConditionTrue, v1alpha1.PDMSMigrationSwitchingPD, "restarting PD in mode of PD")
+		return controller.RequeueErrorf("tidbcluster: [%s/%s], restarting PD in mode of PD", ns, tcName)
+	}
+	if err := m.checkPDSwitched(tc); err != nil {
+		return err
+	}
+	if !tc.PDAllMembersReady() {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s], waiting for all PD members to be ready", ns, tcName)
+	}
+
+	klog.Infof("tidbcluster: [%s/%s], PD is migrated from PD microservices, stopping PD microservices", ns, tcName)
+	tc.Status.PD.Mode = ""
+	m.setStage(tc, metav1.ConditionTrue, v1alpha1.PDMSMigrationStoppingServices, "waiting for PD microservices to be stopped")
+	return controller.RequeueErrorf("tidbcluster: [%s/%s], waiting for PD microservices to be stopped", ns, tcName)
+}
+
+// checkPDMSReady checks that all PD microservices are ready and their primaries are elected
+func (m *pdMSMigrator) checkPDMSReady(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	for _, spec := range tc.Spec.PDMS {
+		status, ok := tc.Status.PDMS[spec.Name]
+		if !ok || status.StatefulSet == nil {
+			return controller.RequeueErrorf("tidbcluster: [%s/%s], PD microservice %s is not created", ns, tcName, spec.Name)
+		}
+		sts := status.StatefulSet
+		if sts.Replicas == 0 || sts.ReadyReplicas != sts.Replicas {
+			return controller.RequeueErrorf("tidbcluster: [%s/%s], PD microservice %s is not ready", ns, tcName, spec.Name)
+		}
+		primary, err := pdClient.GetMSPrimary(spec.Name)
+		if err != nil {
+			return controller.RequeueErrorf("tidbcluster: [%s/%s], failed to get the primary of PD microservice %s: %v", ns, tcName, spec.Name, err)
+		}
+		if primary == "" {
+			return controller.RequeueErrorf("tidbcluster: [%s/%s], the primary of PD microservice %s is not elected", ns, tcName, spec.Name)
+		}
+	}
+	return nil
+}
+
+// checkPDSwitched checks that all PD servers are restarted in the mode expected by the current stage
+func (m *pdMSMigrator) checkPDSwitched(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	set, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(controller.PDMemberName(tcName))
+	if err != nil {
+		return fmt.Errorf("checkPDSwitched: failed to get sts %s for cluster %s/%s, error: %s", controller.PDMemberName(tcName), ns, tcName, err)
+	}
+
+	mode, _ := pdModeAnnotation(tc, set)
+	if set.Spec.Template.Annotations[label.AnnPDMode] != mode {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s], waiting for PD statefulset to be updated", ns, tcName)
+	}
+	if tc.Status.PD.Phase == v1alpha1.UpgradePhase ||
+		set.Status.ObservedGeneration < set.Generation ||
+		set.Status.CurrentRevision != set.Status.UpdateRevision ||
+		set.Status.UpdatedReplicas != set.Status.Replicas ||
+		set.Status.ReadyReplicas != set.Status.Replicas {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s], waiting for PD to be restarted", ns, tcName)
+	}
+	return nil
+}
+
+// pdMSRunning returns whether any PD microservice is still running
+func (m *pdMSMigrator) pdMSRunning(tc *v1alpha1.TidbCluster) bool {
+	for _, status := range tc.Status.PDMS {
+		if status.StatefulSet != nil && status.StatefulSet.Replicas > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *pdMSMigrator) setStage(tc *v1alpha1.TidbCluster, status metav1.ConditionStatus, stage, message string) {
+	if tc.PDMSMigrationStage() != stage {
+		klog.Infof("tidbcluster: [%s/%s], PD microservices migration stage: %s", tc.GetNamespace(), tc.GetName(), stage)
+	}
+	meta.SetStatusCondition(&tc.Status.PD.Conditions, metav1.Condition{
+		Type:    v1alpha1.ConditionTypePDMSMigrating,
+		Status:  status,
+		Reason:  stage,
+		Message: message,
+	})
+}
+
+type FakePDMSMigrator struct {
+	err error
+}
+
+func NewFakePDMSMigrator() *FakePDMSMigrator {
+	return &FakePDMSMigrator{}
+}
+
+func (m *FakePDMSMigrator) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakePDMSMigrator) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
@@ -0,0 +1,212 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newFakePDMSMigrator() (*pdMSMigrator, cache.Indexer) {
+	deps := controller.NewFakeDependencies()
+	setIndexer := deps.KubeInformerFactory.Apps().V1().StatefulSets().Informer().GetIndexer()
+	return &pdMSMigrator{deps: deps}, setIndexer
+}
+
+// newRolledOutPDSet returns a pd statefulset that is rolled out with the pd mode annotation
+func newRolledOutPDSet(tc *v1alpha1.TidbCluster, mode string) *apps.StatefulSet {
+	set := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      controller.PDMemberName(tc.GetName()),
+			Namespace: tc.GetNamespace(),
+		},
+		Status: apps.StatefulSetStatus{
+			Replicas:        1,
+			ReadyReplicas:   1,
+			UpdatedReplicas: 1,
+			CurrentRevision: "rev",
+			UpdateRevision:  "rev",
+		},
+	}
+	if mode != "" {
+		set.Spec.Template.Annotations = map[string]string{label.AnnPDMode: mode}
+	}
+	return set
+}
+
+func TestPDMSMigratorMigrate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPDMS()
+	tc.Status.PD.StatefulSet = &apps.StatefulSetStatus{Replicas: 1}
+	tc.Status.PD.Members = map[string]v1alpha1.PDMember{"test-pd-0": {Name: "test-pd-0", Health: true}}
+
+	m, setIndexer := newFakePDMSMigrator()
+	primary := ""
+	pdClient := controller.NewFakePDClient(m.deps.PDControl.(*pdapi.FakePDControl), tc)
+	pdClient.AddReaction(pdapi.GetPDMSPrimaryActionType, func(action *pdapi.Action) (interface{}, error) {
+		return primary, nil
+	})
+	g.Expect(setIndexer.Add(newRolledOutPDSet(tc, ""))).To(Succeed())
+
+	// start pd microservices first
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.PDMSMigrationStage()).To(Equal(v1alpha1.PDMSMigrationStartingServices))
+	g.Expect(tc.PDMSEnabled()).To(BeTrue())
+	g.Expect(tc.PDInMSMode()).To(BeFalse())
+
+	// wait for pd microservices to be ready and their primaries to be elected
+	tc.Status.PDMS = map[string]*v1alpha1.PDMSStatus{
+		tsoService: {Name: tsoService, StatefulSet: &apps.StatefulSetStatus{Replicas: 3, ReadyReplicas: 3}},
+	}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.PDMSMigrationStage()).To(Equal(v1alpha1.PDMSMigrationStartingServices))
+
+	primary = "http://test-tso-0:2379"
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.PDMSMigrationStage()).To(Equal(v1alpha1.PDMSMigrationSwitchingPD))
+	g.Expect(tc.PDInMSMode()).To(BeTrue())
+	mode, ok := pdModeAnnotation(tc, nil)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(mode).To(Equal(v1alpha1.PDModeMS))
+
+	// wait for pd to be restarted in the mode of pd microservices
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.PDMSMigrationStage()).To(Equal(v1alpha1.PDMSMigrationSwitchingPD))
+	g.Expect(tc.Status.PD.Mode).To(Equal(""))
+
+	g.Expect(setIndexer.Update(newRolledOutPDSet(tc, v1alpha1.PDModeMS))).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.PD.Mode).To(Equal(v1alpha1.PDModeMS))
+	cond := meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.ConditionTypePDMSMigrating)
+	g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(cond.Reason).To(Equal(v1alpha1.PDMSMigrationCompleted))
+
+	// migrate back to pd, pd microservices keep running until pd is restarted
+	tc.Spec.PD.Mode = ""
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.PDMSMigrationStage()).To(Equal(v1alpha1.PDMSMigrationSwitchingPD))
+	g.Expect(tc.PDMSEnabled()).To(BeTrue())
+	g.Expect(tc.PDInMSMode()).To(BeFalse())
+
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.PD.Mode).To(Equal(v1alpha1.PDModeMS))
+
+	g.Expect(setIndexer.Update(newRolledOutPDSet(tc, "pd"))).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.PD.Mode).To(Equal(""))
+	g.Expect(tc.PDMSMigrationStage()).To(Equal(v1alpha1.PDMSMigrationStoppingServices))
+	g.Expect(tc.PDMSEnabled()).To(BeFalse())
+
+	// wait for pd microservices to be stopped
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.PDMSMigrationStage()).To(Equal(v1alpha1.PDMSMigrationStoppingServices))
+
+	tc.Status.PDMS[tsoService].StatefulSet = &apps.StatefulSetStatus{}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	cond = meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.ConditionTypePDMSMigrating)
+	g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(cond.Reason).To(Equal(v1alpha1.PDMSMigrationCompleted))
+}
+
+func TestPDMSMigratorInitMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// a new cluster is created in the target mode
+	tc := newTidbClusterForPDMS()
+	m, _ := newFakePDMSMigrator()
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.PD.Mode).To(Equal(v1alpha1.PDModeMS))
+	g.Expect(tc.Status.PD.Conditions).To(BeEmpty())
+
+	// a cluster created in the mode of pd microservices by an older operator is not migrated
+	tc = newTidbClusterForPDMS()
+	tc.Status.PD.StatefulSet = &apps.StatefulSetStatus{Replicas: 1}
+	tc.Status.PDMS = map[string]*v1alpha1.PDMSStatus{
+		tsoService: {Name: tsoService, StatefulSet: &apps.StatefulSetStatus{Replicas: 3, ReadyReplicas: 3}},
+	}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.PD.Mode).To(Equal(v1alpha1.PDModeMS))
+	g.Expect(tc.Status.PD.Conditions).To(BeEmpty())
+	_, ok := pdModeAnnotation(tc, nil)
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestPDModeAnnotationOnlyChangedInSwitchingStage(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newSet := func(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSet) *apps.StatefulSet {
+		set, err := getNewPDSetForTidbCluster(tc, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		setPDModeAnnotation(tc, oldSet, set)
+		return set
+	}
+	setStage := func(tc *v1alpha1.TidbCluster, status metav1.ConditionStatus, stage string) {
+		meta.SetStatusCondition(&tc.Status.PD.Conditions, metav1.Condition{
+			Type:   v1alpha1.ConditionTypePDMSMigrating,
+			Status: status,
+			Reason: stage,
+		})
+	}
+
+	tc := newTidbClusterForPDMS()
+	tc.Spec.PD.Mode = ""
+	tc.Status.PD.StatefulSet = &apps.StatefulSetStatus{Replicas: 1}
+	oldSet := newSet(tc, nil)
+	g.Expect(oldSet.Spec.Template.Annotations).NotTo(HaveKey(label.AnnPDMode))
+
+	// pd is not restarted while the pd microservices are being started
+	tc.Spec.PD.Mode = v1alpha1.PDModeMS
+	setStage(tc, metav1.ConditionTrue, v1alpha1.PDMSMigrationStartingServices)
+	set := newSet(tc, oldSet)
+	g.Expect(set.Spec.Template).To(Equal(oldSet.Spec.Template))
+
+	// pd is restarted in the mode of pd microservices
+	setStage(tc, metav1.ConditionTrue, v1alpha1.PDMSMigrationSwitchingPD)
+	set = newSet(tc, oldSet)
+	g.Expect(set.Spec.Template.Annotations).To(HaveKeyWithValue(label.AnnPDMode, v1alpha1.PDModeMS))
+	oldSet = set
+
+	tc.Status.PD.Mode = v1alpha1.PDModeMS
+	setStage(tc, metav1.ConditionFalse, v1alpha1.PDMSMigrationCompleted)
+	set = newSet(tc, oldSet)
+	g.Expect(set.Spec.Template).To(Equal(oldSet.Spec.Template))
+
+	// pd is restarted in the mode of pd, and is not restarted again while the pd microservices are being stopped
+	tc.Spec.PD.Mode = ""
+	setStage(tc, metav1.ConditionTrue, v1alpha1.PDMSMigrationSwitchingPD)
+	set = newSet(tc, oldSet)
+	g.Expect(set.Spec.Template.Annotations).To(HaveKeyWithValue(label.AnnPDMode, "pd"))
+	oldSet = set
+
+	tc.Status.PD.Mode = ""
+	setStage(tc, metav1.ConditionTrue, v1alpha1.PDMSMigrationStoppingServices)
+	set = newSet(tc, oldSet)
+	g.Expect(set.Spec.Template).To(Equal(oldSet.Spec.Template))
+
+	// pd is not restarted while the pd microservices are being started again
+	tc.Spec.PD.Mode = v1alpha1.PDModeMS
+	setStage(tc, metav1.ConditionTrue, v1alpha1.PDMSMigrationStartingServices)
+	set = newSet(tc, oldSet)
+	g.Expect(set.Spec.Template).To(Equal(oldSet.Spec.Template))
+}
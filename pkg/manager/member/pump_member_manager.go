@@ -122,6 +122,13 @@ func (m *pumpMemberManager) syncPumpStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 	if err != nil {
 		return err
 	}
+	setStartBinary(tc, m.deps.CLIConfig.TiDBDiscoveryImage, &newSet.Spec.Template.Spec)
+
+	// in the plan mode, the changes driven by the spec are held until the plan is approved
+	if proceed, err := syncChangePlan(m.deps, tc, v1alpha1.PumpMemberType, tc.Spec.Pump.Replicas, oldSet, newSet); err != nil || !proceed {
+		return err
+	}
+
 	if notFound {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSet)
 		if err != nil {
@@ -130,6 +137,8 @@ func (m *pumpMemberManager) syncPumpStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 		return m.deps.StatefulSetControl.CreateStatefulSet(tc, newSet)
 	}
 
+	recordStatefulSetOperations(tc, v1alpha1.PumpMemberType, oldSet, newSet)
+
 	if err := m.scaler.Scale(tc, oldSet, newSet); err != nil {
 		return err
 	}
@@ -370,6 +379,7 @@ func getNewPumpStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*app
 	storageClass := tc.Spec.Pump.StorageClassName
 	podLabels := util.CombineStringMap(stsLabels.Labels(), spec.Labels())
 	podAnnos := util.CombineStringMap(spec.Annotations(), controller.AnnProm(v1alpha1.DefaultPumpPort, "/metrics"))
+	setTLSClusterCertAnnotation(tc, label.PumpLabelVal, podAnnos)
 	storageRequest, err := controller.ParseStorageRequest(tc.Spec.Pump.Requests)
 	if err != nil {
 		return nil, fmt.Errorf("cannot parse storage request for pump, tidbcluster %s/%s, error: %v", tc.Namespace, tc.Name, err)
@@ -452,11 +462,7 @@ func getNewPumpStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*app
 			Name:            "pump",
 			Image:           *tc.PumpImage(),
 			ImagePullPolicy: spec.ImagePullPolicy(),
-			Command: []string{
-				"/bin/sh",
-				"-c",
-				startScript,
-			},
+			Command:         inlineStartScriptCommand(tc, "/bin/sh", startScript),
 			Ports: []corev1.ContainerPort{{
 				Name:          "pump",
 				ContainerPort: v1alpha1.DefaultPumpPort,
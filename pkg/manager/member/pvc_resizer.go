@@ -152,6 +152,31 @@ func (p *pvcResizer) Sync(tc *v1alpha1.TidbCluster) error {
 		}
 	}
 
+	// the volumes of each TiDB group are resized through the view of the group
+	for _, group := range tc.Spec.TiDBGroups {
+		if group == nil {
+			continue
+		}
+		status := tc.Status.TiDBGroups[group.Name]
+		if status == nil {
+			continue
+		}
+		view := tc.TiDBGroupView(group)
+		ctx, err := p.buildContextForTC(view, &view.Status.TiDB)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("build ctx used by resize for %s/%s:%s group %s failed: %w", tc.GetNamespace(), tc.GetName(), v1alpha1.TiDBMemberType, group.Name, err))
+			continue
+		}
+
+		p.updateVolumeStatus(ctx)
+		err = p.resizeVolumes(ctx)
+		status.Volumes = view.Status.TiDB.Volumes
+		status.Conditions = view.Status.TiDB.Conditions
+		if err != nil {
+			errs = append(errs, fmt.Errorf("resize volumes for %s group %s failed: %w", ctx.ComponentID(), group.Name, err))
+		}
+	}
+
 	return errutil.NewAggregate(errs)
 }
 
@@ -217,13 +242,23 @@ func (p *pvcResizer) buildContextForTC(tc *v1alpha1.TidbCluster, status v1alpha1
 			}
 		}
 	case v1alpha1.TiDBMemberType:
-		ctx.selector = selector.Add(*tidbRequirement)
+		// only the volumes of the default TiDB or the TiDB group that tc stands for are resized
+		groupRequirement, err := label.TiDBGroupRequirement(tc.TiDBGroupName())
+		if err != nil {
+			return nil, err
+		}
+		ctx.selector = selector.Add(*tidbRequirement, *groupRequirement)
 		if tc.Status.TiDB.Volumes == nil {
 			tc.Status.TiDB.Volumes = map[v1alpha1.StorageVolumeName]*v1alpha1.StorageVolumeStatus{}
 		}
 		storageVolumes = tc.Spec.TiDB.StorageVolumes
 	case v1alpha1.TiKVMemberType:
-		ctx.selector = selector.Add(*tikvRequirement)
+		// volumes of TiKV groups are not resized with the default TiKV
+		groupRequirement, err := label.TiKVGroupRequirement("")
+		if err != nil {
+			return nil, err
+		}
+		ctx.selector = selector.Add(*tikvRequirement, *groupRequirement)
 		if tc.Status.TiKV.Volumes == nil {
 			tc.Status.TiKV.Volumes = map[v1alpha1.StorageVolumeName]*v1alpha1.StorageVolumeStatus{}
 		}
@@ -232,7 +267,12 @@ func (p *pvcResizer) buildContextForTC(tc *v1alpha1.TidbCluster, status v1alpha1
 		}
 		storageVolumes = tc.Spec.TiKV.StorageVolumes
 	case v1alpha1.TiFlashMemberType:
-		ctx.selector = selector.Add(*tiflashRequirement)
+		// TiFlash compute nodes have no persistent volumes
+		roleRequirement, err := label.TiFlashRoleRequirement("")
+		if err != nil {
+			return nil, err
+		}
+		ctx.selector = selector.Add(*tiflashRequirement, *roleRequirement)
 		if tc.Status.TiFlash.Volumes == nil {
 			tc.Status.TiFlash.Volumes = map[v1alpha1.StorageVolumeName]*v1alpha1.StorageVolumeStatus{}
 		}
@@ -436,6 +476,9 @@ func (p *pvcResizer) resizeVolumes(ctx *componentVolumeContext) error {
 
 	// check sts is desired
 	stsName := controller.MemberName(name, ctx.status.MemberType())
+	if tc, ok := ctx.cluster.(*v1alpha1.TidbCluster); ok && ctx.status.MemberType() == v1alpha1.TiDBMemberType {
+		stsName = controller.TiDBGroupMemberName(name, tc.TiDBGroupName())
+	}
 	sts, err := p.deps.StatefulSetLister.StatefulSets(ns).Get(stsName)
 	if err != nil {
 		klog.Warningf("skip to resize sts %s for component %s because %s", stsName, ctx.ComponentID(), err)
@@ -18,6 +18,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 
@@ -28,6 +29,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
@@ -1538,3 +1540,68 @@ func diffVolume(v1 *volume, v2 *volume) string {
 	}
 	return ""
 }
+
+func TestPVCResizerSyncTiDBGroup(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: v1.NamespaceDefault, Name: "test-cluster"},
+		Spec: v1alpha1.TidbClusterSpec{
+			TiDB: &v1alpha1.TiDBSpec{},
+			TiDBGroups: []*v1alpha1.TiDBGroupSpec{
+				{
+					Name: "olap",
+					TiDBSpec: v1alpha1.TiDBSpec{
+						StorageVolumes: []v1alpha1.StorageVolume{{Name: "log", StorageSize: "2Gi"}},
+					},
+				},
+			},
+		},
+		Status: v1alpha1.TidbClusterStatus{
+			TiDBGroups: map[string]*v1alpha1.TiDBGroupStatus{"olap": {Name: "olap"}},
+		},
+	}
+	volName := string(v1alpha1.GetStorageVolumeName("log", v1alpha1.TiDBMemberType))
+	newPod := func(name, pvcName string, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: v1.NamespaceDefault, Name: name, Labels: labels},
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{
+						Name: volName,
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	deps := controller.NewFakeDependencies()
+	podIndexer := deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	pvcIndexer := deps.KubeInformerFactory.Core().V1().PersistentVolumeClaims().Informer().GetIndexer()
+	defaultLabels := label.New().Instance(tc.GetInstanceName()).TiDB().Labels()
+	groupLabels := label.New().Instance(tc.GetInstanceName()).TiDB().TiDBGroup("olap").Labels()
+	g.Expect(podIndexer.Add(newPod("test-cluster-tidb-0", "log-test-cluster-tidb-0", defaultLabels))).To(Succeed())
+	g.Expect(podIndexer.Add(newPod("test-cluster-tidb-olap-0", "log-test-cluster-tidb-olap-0", groupLabels))).To(Succeed())
+	defaultPVC := newMockPVC("log-test-cluster-tidb-0", "sc", "1Gi", "1Gi")
+	defaultPVC.Labels = defaultLabels
+	g.Expect(pvcIndexer.Add(defaultPVC)).To(Succeed())
+	groupPVC := newMockPVC("log-test-cluster-tidb-olap-0", "sc", "1Gi", "1Gi")
+	groupPVC.Labels = groupLabels
+	g.Expect(pvcIndexer.Add(groupPVC)).To(Succeed())
+	scIndexer := deps.KubeInformerFactory.Storage().V1().StorageClasses().Informer().GetIndexer()
+	g.Expect(scIndexer.Add(newStorageClass("sc", true))).To(Succeed())
+
+	resizer := NewPVCResizer(deps)
+	err := resizer.Sync(tc)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("set condition before resizing volumes"))
+
+	status := tc.Status.TiDBGroups["olap"]
+	g.Expect(meta.IsStatusConditionTrue(status.Conditions, v1alpha1.ComponentVolumeResizing)).To(BeTrue())
+	g.Expect(status.Volumes).To(HaveKey(v1alpha1.StorageVolumeName(volName)))
+	g.Expect(status.Volumes[v1alpha1.StorageVolumeName(volName)].CurrentCount).To(Equal(1))
+	g.Expect(meta.IsStatusConditionTrue(tc.Status.TiDB.Conditions, v1alpha1.ComponentVolumeResizing)).To(BeFalse())
+}
@@ -73,7 +73,7 @@ func (s *generalScaler) deleteDeferDeletingPVC(controller runtime.Object, member
 	}
 	if len(pvcs) == 0 {
 		klog.Infof("%s %s/%s list pvc not found, selector: %s", kind, ns, meta.GetName(), selector)
-		podName := ordinalPodName(memberType, meta.GetName(), ordinal)
+		podName := metaOrdinalPodName(meta, memberType, ordinal)
 		skipReason[podName] = skipReasonScalerPVCNotFound
 		return skipReason, nil
 	}
@@ -172,6 +172,21 @@ func ordinalPodName(memberType v1alpha1.MemberType, tcName string, ordinal int32
 	return fmt.Sprintf("%s-%s-%d", tcName, memberType, ordinal)
 }
 
+// metaOrdinalPodName is like ordinalPodName, but takes the TiDB or TiKV group of the TidbCluster into account
+func metaOrdinalPodName(meta metav1.Object, memberType v1alpha1.MemberType, ordinal int32) string {
+	if tc, ok := meta.(*v1alpha1.TidbCluster); ok {
+		switch memberType {
+		case v1alpha1.TiDBMemberType:
+			return tidbGroupPodName(tc.GetName(), tc.TiDBGroupName(), ordinal)
+		case v1alpha1.TiKVMemberType:
+			return tikvGroupPodName(tc.GetName(), tc.TiKVGroupName(), ordinal)
+		case v1alpha1.TiFlashMemberType:
+			return tiflashRolePodName(tc.GetName(), tc.TiFlashRole(), ordinal)
+		}
+	}
+	return ordinalPodName(memberType, meta.GetName(), ordinal)
+}
+
 // scaleOne calculates desired replicas and delete slots from actual/desired
 // stateful sets by allowing only one pod to be deleted or created
 // it returns following values:
@@ -0,0 +1,82 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// startBinaryVolumeName is the name of the volume that shares the start binary with the containers
+	startBinaryVolumeName = "start-binary"
+	// startBinaryMountPath is the mount path of the volume of the start binary
+	startBinaryMountPath = "/usr/local/tidb-operator"
+	// startBinaryPath is the path of the start binary in the containers of the components
+	startBinaryPath = startBinaryMountPath + "/tidb-component-start"
+	// startBinaryImagePath is the path of the start binary in the image of tidb-operator
+	startBinaryImagePath = "/usr/local/bin/tidb-component-start"
+	// startBinaryInitContainerName is the name of the init container installing the start binary
+	startBinaryInitContainerName = "install-start-binary"
+)
+
+// startScriptCommand returns the command of the container to run the start script in the given path,
+// the file contains the config of the start binary if start script v3 is used.
+func startScriptCommand(tc *v1alpha1.TidbCluster, path string) []string {
+	if tc.StartScriptVersion() == v1alpha1.StartScriptV3 {
+		return []string{startBinaryPath, "-config", path}
+	}
+	return []string{"/bin/sh", path}
+}
+
+// inlineStartScriptCommand returns the command of the container to run the given start script,
+// the script is the config of the start binary if start script v3 is used.
+func inlineStartScriptCommand(tc *v1alpha1.TidbCluster, shell, script string) []string {
+	if tc.StartScriptVersion() == v1alpha1.StartScriptV3 {
+		return []string{startBinaryPath, "-config-data", script}
+	}
+	return []string{shell, "-c", script}
+}
+
+// setStartBinary installs the start binary from the given image by an init container if start script
+// v3 is used, and mounts it into the containers whose command runs it.
+func setStartBinary(tc *v1alpha1.TidbCluster, image string, podSpec *corev1.PodSpec) {
+	if tc.StartScriptVersion() != v1alpha1.StartScriptV3 {
+		return
+	}
+
+	mount := corev1.VolumeMount{Name: startBinaryVolumeName, MountPath: startBinaryMountPath}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: startBinaryVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			if len(containers[i].Command) > 0 && containers[i].Command[0] == startBinaryPath {
+				containers[i].VolumeMounts = append(containers[i].VolumeMounts, mount)
+			}
+		}
+	}
+	// the binary must be installed before the other init containers run it
+	podSpec.InitContainers = append([]corev1.Container{{
+		Name:            startBinaryInitContainerName,
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{startBinaryImagePath, "-install", startBinaryPath},
+		VolumeMounts:    []corev1.VolumeMount{mount},
+	}}, podSpec.InitContainers...)
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSetStartBinary(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	podSpec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init", Command: inlineStartScriptCommand(tc, "sh", "echo")}},
+		Containers:     []corev1.Container{{Name: "pd", Command: startScriptCommand(tc, "/usr/local/bin/pd_start_script.sh")}},
+	}
+	expected := podSpec.DeepCopy()
+	setStartBinary(tc, "pingcap/tidb-operator:latest", podSpec)
+	g.Expect(podSpec).To(Equal(expected))
+	g.Expect(podSpec.Containers[0].Command).To(Equal([]string{"/bin/sh", "/usr/local/bin/pd_start_script.sh"}))
+
+	tc.Spec.StartScriptVersion = v1alpha1.StartScriptV3
+	podSpec = &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init", Command: inlineStartScriptCommand(tc, "sh", "{}")}},
+		Containers: []corev1.Container{
+			{Name: "pd", Command: startScriptCommand(tc, "/usr/local/bin/pd_start_script.sh")},
+			{Name: "log", Command: []string{"/bin/sh", "-c", "tail -F /var/log/pd.log"}},
+		},
+	}
+	setStartBinary(tc, "pingcap/tidb-operator:latest", podSpec)
+
+	mount := corev1.VolumeMount{Name: startBinaryVolumeName, MountPath: startBinaryMountPath}
+	g.Expect(podSpec.Volumes).To(ContainElement(corev1.Volume{
+		Name:         startBinaryVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}))
+	g.Expect(podSpec.InitContainers).To(HaveLen(2))
+	g.Expect(podSpec.InitContainers[0].Image).To(Equal("pingcap/tidb-operator:latest"))
+	g.Expect(podSpec.InitContainers[0].Command).To(Equal([]string{"/usr/local/bin/tidb-component-start", "-install", "/usr/local/tidb-operator/tidb-component-start"}))
+	g.Expect(podSpec.InitContainers[1].Command).To(Equal([]string{"/usr/local/tidb-operator/tidb-component-start", "-config-data", "{}"}))
+	g.Expect(podSpec.InitContainers[1].VolumeMounts).To(ConsistOf(mount))
+	g.Expect(podSpec.Containers[0].Command).To(Equal([]string{"/usr/local/tidb-operator/tidb-component-start", "-config", "/usr/local/bin/pd_start_script.sh"}))
+	g.Expect(podSpec.Containers[0].VolumeMounts).To(ConsistOf(mount))
+	g.Expect(podSpec.Containers[1].VolumeMounts).To(BeEmpty())
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	v1 "github.com/pingcap/tidb-operator/pkg/manager/member/startscript/v1"
 	v2 "github.com/pingcap/tidb-operator/pkg/manager/member/startscript/v2"
+	v3 "github.com/pingcap/tidb-operator/pkg/manager/member/startscript/v3"
 )
 
 var (
@@ -33,10 +34,12 @@ var (
 	tikv = RenderMap{
 		v1alpha1.StartScriptV1: v1.RenderTiKVStartScript,
 		v1alpha1.StartScriptV2: v2.RenderTiKVStartScript,
+		v1alpha1.StartScriptV3: v3.RenderTiKVStartScript,
 	}
 	pd = RenderMap{
 		v1alpha1.StartScriptV1: v1.RenderPDStartScript,
 		v1alpha1.StartScriptV2: v2.RenderPDStartScript,
+		v1alpha1.StartScriptV3: v3.RenderPDStartScript,
 	}
 	pdMS = map[string]RenderMap{
 		"tso":        pdmsTSO,
@@ -45,30 +48,37 @@ var (
 	pdmsTSO = RenderMap{
 		v1alpha1.StartScriptV1: v2.RenderPDTSOStartScript,
 		v1alpha1.StartScriptV2: v2.RenderPDTSOStartScript,
+		v1alpha1.StartScriptV3: v3.RenderPDTSOStartScript,
 	}
 	pdmsScheduling = RenderMap{
 		v1alpha1.StartScriptV1: v2.RenderPDSchedulingStartScript,
 		v1alpha1.StartScriptV2: v2.RenderPDSchedulingStartScript,
+		v1alpha1.StartScriptV3: v3.RenderPDSchedulingStartScript,
 	}
 	tidb = RenderMap{
 		v1alpha1.StartScriptV1: v1.RenderTiDBStartScript,
 		v1alpha1.StartScriptV2: v2.RenderTiDBStartScript,
+		v1alpha1.StartScriptV3: v3.RenderTiDBStartScript,
 	}
 	pump = RenderMap{
 		v1alpha1.StartScriptV1: v1.RenderPumpStartScript,
 		v1alpha1.StartScriptV2: v2.RenderPumpStartScript,
+		v1alpha1.StartScriptV3: v3.RenderPumpStartScript,
 	}
 	ticdc = RenderMap{
 		v1alpha1.StartScriptV1: v1.RenderTiCDCStartScript,
 		v1alpha1.StartScriptV2: v2.RenderTiCDCStartScript,
+		v1alpha1.StartScriptV3: v3.RenderTiCDCStartScript,
 	}
 	tiflash = RenderMap{
 		v1alpha1.StartScriptV1: v1.RenderTiFlashStartScript,
 		v1alpha1.StartScriptV2: v2.RenderTiFlashStartScript,
+		v1alpha1.StartScriptV3: v3.RenderTiFlashStartScript,
 	}
 	tiflashInit = RenderMap{
 		v1alpha1.StartScriptV1: v1.RenderTiFlashInitScript,
 		v1alpha1.StartScriptV2: v2.RenderTiFlashInitScript,
+		v1alpha1.StartScriptV3: v3.RenderTiFlashInitScript,
 	}
 )
 
@@ -77,14 +87,17 @@ func RenderTiKVStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 }
 
 func RenderPDStartScript(tc *v1alpha1.TidbCluster) (string, error) {
-	// using start script v2 when enabled PDMS
-	if tc.Spec.PDMS != nil && (tc.Spec.PD != nil && tc.Spec.PD.Mode == "ms") {
+	// using start script v2 when enabled PDMS unless start script v3 is used
+	if tc.Spec.PDMS != nil && (tc.Spec.PD != nil && tc.Spec.PD.Mode == "ms") && tc.StartScriptVersion() != v1alpha1.StartScriptV3 {
 		return pd[v1alpha1.StartScriptV2](tc)
 	}
 	return pd[tc.StartScriptVersion()](tc)
 }
 
 func RenderPDMSStartScript(tc *v1alpha1.TidbCluster, name string) (string, error) {
+	if tc.StartScriptVersion() == v1alpha1.StartScriptV3 {
+		return pdMS[name][v1alpha1.StartScriptV3](tc)
+	}
 	return pdMS[name][v1alpha1.StartScriptV2](tc)
 }
 
@@ -112,6 +125,8 @@ func RenderTiProxyStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 	switch tc.StartScriptVersion() {
 	case v1alpha1.StartScriptV1, v1alpha1.StartScriptV2:
 		return v2.RenderTiProxyStartScript(tc)
+	case v1alpha1.StartScriptV3:
+		return v3.RenderTiProxyStartScript(tc)
 	default:
 		return "", ErrVersionNotFound
 	}
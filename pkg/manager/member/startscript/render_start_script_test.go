@@ -42,6 +42,11 @@ func TestRenderStartScriptRoute(t *testing.T) {
 			ver:       v1alpha1.StartScriptV2,
 			expectVer: v1alpha1.StartScriptV2,
 		},
+		{
+			name:      "v3",
+			ver:       v1alpha1.StartScriptV3,
+			expectVer: v1alpha1.StartScriptV3,
+		},
 		{
 			name:      "empty version",
 			ver:       v1alpha1.StartScriptVersion(""),
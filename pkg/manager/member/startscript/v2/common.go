@@ -123,7 +123,9 @@ func renderTemplateFunc(tpl *template.Template, model interface{}) (string, erro
 	return buff.String(), nil
 }
 
-func addressesWithSchemeAndPort(addresses []string, scheme string, port int32) []string {
+// AddressesWithSchemeAndPort returns the addresses with the given scheme and port, the scheme and
+// the port in the addresses are replaced
+func AddressesWithSchemeAndPort(addresses []string, scheme string, port int32) []string {
 	res := make([]string, len(addresses))
 	for i, a := range addresses {
 		u, err := url.Parse(a)
@@ -65,7 +65,7 @@ func validateScript(script string) error {
 func TestAddressesWithSchemeAndPort(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
-	result := addressesWithSchemeAndPort([]string{"example.com", "http://test.com:7777", "https://blah.com"}, "https://", 8080)
+	result := AddressesWithSchemeAndPort([]string{"example.com", "http://test.com:7777", "https://blah.com"}, "https://", 8080)
 
 	expected := []string{"https://example.com:8080", "https://test.com:8080", "https://blah.com:8080"}
 	g.Expect(result).Should(gomega.Equal(expected))
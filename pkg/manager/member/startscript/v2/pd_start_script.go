@@ -76,7 +76,7 @@ func RenderPDStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 	preferPDAddressesOverDiscovery := slices.Contains(
 		tc.Spec.StartScriptV2FeatureFlags, v1alpha1.StartScriptV2FeatureFlagPreferPDAddressesOverDiscovery)
 	if preferPDAddressesOverDiscovery {
-		pdAddressesWithSchemeAndPort := addressesWithSchemeAndPort(tc.Spec.PDAddresses, tc.Scheme()+"://", v1alpha1.DefaultPDPeerPort)
+		pdAddressesWithSchemeAndPort := AddressesWithSchemeAndPort(tc.Spec.PDAddresses, tc.Scheme()+"://", v1alpha1.DefaultPDPeerPort)
 		m.PDAddresses = strings.Join(pdAddressesWithSchemeAndPort, ",")
 	}
 
@@ -100,7 +100,7 @@ func RenderPDStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 		tc.Spec.StartScriptV2FeatureFlags, v1alpha1.StartScriptV2FeatureFlagWaitForDnsNameIpMatch)
 
 	mode := ""
-	if tc.Spec.PD.Mode == "ms" && tc.Spec.PDMS != nil {
+	if tc.PDInMSMode() {
 		mode = "api"
 		// default enabled the dns detection
 		waitForDnsNameIpMatchOnStartup = true
@@ -153,7 +153,7 @@ func renderPDMSStartScript(tc *v1alpha1.TidbCluster, name string) (string, error
 	preferPDAddressesOverDiscovery := slices.Contains(
 		tc.Spec.StartScriptV2FeatureFlags, v1alpha1.StartScriptV2FeatureFlagPreferPDAddressesOverDiscovery)
 	if preferPDAddressesOverDiscovery {
-		pdAddressesWithSchemeAndPort := addressesWithSchemeAndPort(tc.Spec.PDAddresses, "", v1alpha1.DefaultPDClientPort)
+		pdAddressesWithSchemeAndPort := AddressesWithSchemeAndPort(tc.Spec.PDAddresses, "", v1alpha1.DefaultPDClientPort)
 		m.PDAddresses = strings.Join(pdAddressesWithSchemeAndPort, ",")
 	}
 	if len(m.PDAddresses) == 0 {
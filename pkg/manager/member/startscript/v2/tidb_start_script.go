@@ -37,12 +37,12 @@ func RenderTiDBStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 	m := &TiDBStartScriptModel{}
 	tcName := tc.Name
 	tcNS := tc.Namespace
-	peerServiceName := controller.TiDBPeerMemberName(tcName)
+	peerServiceName := controller.TiDBGroupPeerMemberName(tcName, tc.TiDBGroupName())
 
 	preferPDAddressesOverDiscovery := slices.Contains(
 		tc.Spec.StartScriptV2FeatureFlags, v1alpha1.StartScriptV2FeatureFlagPreferPDAddressesOverDiscovery)
 	if preferPDAddressesOverDiscovery {
-		pdAddressesWithSchemeAndPort := addressesWithSchemeAndPort(tc.Spec.PDAddresses, "", v1alpha1.DefaultPDClientPort)
+		pdAddressesWithSchemeAndPort := AddressesWithSchemeAndPort(tc.Spec.PDAddresses, "", v1alpha1.DefaultPDClientPort)
 		m.PDAddresses = strings.Join(pdAddressesWithSchemeAndPort, ",")
 	}
 	if len(m.PDAddresses) == 0 {
@@ -19,6 +19,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 )
@@ -37,6 +38,10 @@ func RenderTiFlashStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 	m := &TiFlashStartScriptModel{}
 
 	m.ExtraArgs = ""
+	// the config items of the disaggregated TiFlash are passed after "--" to override the config file
+	if args := TiFlashDisaggregatedArgs(tc); len(args) > 0 {
+		m.ExtraArgs = "-- " + strings.Join(args, " ")
+	}
 
 	return renderTemplateFunc(tiflashStartScriptTpl, m)
 }
@@ -56,7 +61,7 @@ type TiFlashStartScriptWithStartArgsModel struct {
 func RenderTiFlashStartScriptWithStartArgs(tc *v1alpha1.TidbCluster) (string, error) {
 	m := &TiFlashStartScriptWithStartArgsModel{
 		AdvertiseAddr: fmt.Sprintf("${POD_NAME}.${HEADLESS_SERVICE_NAME}.${NAMESPACE}.svc%s:%d", controller.FormatClusterDomain(tc.Spec.ClusterDomain), v1alpha1.DefaultTiFlashProxyPort),
-		ExtraArgs:     "",
+		ExtraArgs:     strings.Join(TiFlashDisaggregatedArgs(tc), " "),
 	}
 	tcName := tc.Name
 	tcNS := tc.Namespace
@@ -69,7 +74,7 @@ func RenderTiFlashStartScriptWithStartArgs(tc *v1alpha1.TidbCluster) (string, er
 	preferPDAddressesOverDiscovery := slices.Contains(
 		tc.Spec.StartScriptV2FeatureFlags, v1alpha1.StartScriptV2FeatureFlagPreferPDAddressesOverDiscovery)
 	if preferPDAddressesOverDiscovery {
-		pdAddressesWithSchemeAndPort := addressesWithSchemeAndPort(tc.Spec.PDAddresses, "", v1alpha1.DefaultPDClientPort)
+		pdAddressesWithSchemeAndPort := AddressesWithSchemeAndPort(tc.Spec.PDAddresses, "", v1alpha1.DefaultPDClientPort)
 		m.PDAddresses = strings.Join(pdAddressesWithSchemeAndPort, ",")
 	}
 	if len(m.PDAddresses) == 0 {
@@ -91,6 +96,35 @@ func RenderTiFlashStartScriptWithStartArgs(tc *v1alpha1.TidbCluster) (string, er
 	return renderTemplateFunc(tiflashStartScriptWithStartArgsTpl, m)
 }
 
+// TiFlashDisaggregatedArgs returns the config items of the disaggregated TiFlash in
+// the form of command args, the S3 credentials are read from the env by TiFlash.
+func TiFlashDisaggregatedArgs(tc *v1alpha1.TidbCluster) []string {
+	disaggregated := tc.Spec.TiFlash.Disaggregated
+	if disaggregated == nil {
+		return nil
+	}
+
+	mode := "tiflash_write"
+	if tc.TiFlashRole() == label.TiFlashComputeRoleVal {
+		mode = "tiflash_compute"
+	}
+	args := []string{
+		fmt.Sprintf("--flash.disaggregated_mode=%s", mode),
+		fmt.Sprintf("--storage.s3.endpoint=%s", disaggregated.S3.Endpoint),
+		fmt.Sprintf("--storage.s3.bucket=%s", disaggregated.S3.Bucket),
+	}
+	if disaggregated.S3.Root != "" {
+		args = append(args, fmt.Sprintf("--storage.s3.root=%s", disaggregated.S3.Root))
+	}
+	if mode == "tiflash_compute" {
+		args = append(args,
+			"--flash.use_autoscaler=false",
+			"--storage.remote.cache.dir=/data0/remote_cache",
+		)
+	}
+	return args
+}
+
 const (
 	// tiflashStartSubScript contains optional subscripts used in start script.
 	tiflashStartSubScript = ``
@@ -16,6 +16,7 @@ package v2
 import (
 	"testing"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 
 	"github.com/google/go-cmp/cmp"
@@ -57,6 +58,40 @@ fi
 
 ARGS="--config-file /data0/config.toml"
 
+echo "starting tiflash-server ..."
+echo "/tiflash/tiflash ${ARGS}"
+exec /tiflash/tiflash server ${ARGS}
+`,
+		},
+		{
+			name: "disaggregated write node",
+			modifyTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiFlash.Disaggregated = &v1alpha1.TiFlashDisaggregatedSpec{
+					S3: v1alpha1.TiFlashS3Spec{Endpoint: "http://minio:9000", Bucket: "tiflash", Root: "/demo"},
+				}
+			},
+			expectScript: `#!/bin/sh
+
+set -uo pipefail
+
+ANNOTATIONS="/etc/podinfo/annotations"
+if [[ ! -f "${ANNOTATIONS}" ]]
+then
+    echo "${ANNOTATIONS} does't exist, exiting."
+    exit 1
+fi
+source ${ANNOTATIONS} 2>/dev/null
+
+runmode=${runmode:-normal}
+if [[ X${runmode} == Xdebug ]]
+then
+    echo "entering debug mode."
+    tail -f /dev/null
+fi
+
+ARGS="--config-file /data0/config.toml"
+ARGS="${ARGS} -- --flash.disaggregated_mode=tiflash_write --storage.s3.endpoint=http://minio:9000 --storage.s3.bucket=tiflash --storage.s3.root=/demo"
+
 echo "starting tiflash-server ..."
 echo "/tiflash/tiflash ${ARGS}"
 exec /tiflash/tiflash server ${ARGS}
@@ -333,6 +368,55 @@ if [ ! -z "${STORE_LABELS:-}" ]; then
   ARGS="${ARGS}${LABELS}"
 fi
 
+echo "starting tiflash-server ..."
+echo "/tiflash/tiflash ${ARGS}"
+exec /tiflash/tiflash ${ARGS}
+`,
+		},
+		{
+			name: "disaggregated compute node",
+			modifyTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiFlash.Disaggregated = &v1alpha1.TiFlashDisaggregatedSpec{
+					S3:      v1alpha1.TiFlashS3Spec{Endpoint: "http://minio:9000", Bucket: "tiflash"},
+					Compute: &v1alpha1.TiFlashComputeSpec{Replicas: 1},
+				}
+				tc.Annotations = map[string]string{label.AnnTiFlashRoleKey: label.TiFlashComputeRoleVal}
+			},
+			expectScript: `#!/bin/sh
+
+set -uo pipefail
+
+ANNOTATIONS="/etc/podinfo/annotations"
+if [[ ! -f "${ANNOTATIONS}" ]]
+then
+    echo "${ANNOTATIONS} does't exist, exiting."
+    exit 1
+fi
+source ${ANNOTATIONS} 2>/dev/null
+
+runmode=${runmode:-normal}
+if [[ X${runmode} == Xdebug ]]
+then
+    echo "entering debug mode."
+    tail -f /dev/null
+fi
+
+# Use HOSTNAME if POD_NAME is unset for backward compatibility.
+POD_NAME=${POD_NAME:-$HOSTNAME}
+PD_ADDRESS="start-script-test-pd:2379"
+ARGS="server --config-file /etc/tiflash/config_templ.toml \
+-- \
+--flash.proxy.advertise-addr=${POD_NAME}.${HEADLESS_SERVICE_NAME}.${NAMESPACE}.svc:20170 \
+--flash.service_addr=${POD_NAME}.${HEADLESS_SERVICE_NAME}.${NAMESPACE}.svc:3930 \
+--raft.pd_addr=${PD_ADDRESS}
+"
+ARGS="${ARGS} --flash.disaggregated_mode=tiflash_compute --storage.s3.endpoint=http://minio:9000 --storage.s3.bucket=tiflash --flash.use_autoscaler=false --storage.remote.cache.dir=/data0/remote_cache"
+
+if [ ! -z "${STORE_LABELS:-}" ]; then
+  LABELS=" --labels ${STORE_LABELS} "
+  ARGS="${ARGS}${LABELS}"
+fi
+
 echo "starting tiflash-server ..."
 echo "/tiflash/tiflash ${ARGS}"
 exec /tiflash/tiflash ${ARGS}
@@ -0,0 +1,90 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package v3 renders the configs of the start binary of the components, which do the same work as
+// the start scripts v2 without a shell. The configs are stored in the ConfigMaps of the components
+// in place of the start scripts.
+package v3
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	v2 "github.com/pingcap/tidb-operator/pkg/manager/member/startscript/v2"
+	"github.com/pingcap/tidb-operator/pkg/start"
+)
+
+// podName refers to the name of the pod in the configs
+var podName = "${" + start.VarPodName + "}"
+
+// discoveryAddr returns the address of the discovery service of the TidbCluster
+func discoveryAddr(tc *v1alpha1.TidbCluster) string {
+	return fmt.Sprintf("%s-discovery.%s:10261", tc.Name, tc.Namespace)
+}
+
+// podDomain returns the domain of the pod behind the given peer service
+func podDomain(tc *v1alpha1.TidbCluster, peerServiceName string) string {
+	domain := fmt.Sprintf("%s.%s.%s.svc", podName, peerServiceName, tc.Namespace)
+	if tc.Spec.ClusterDomain != "" {
+		domain = domain + "." + tc.Spec.ClusterDomain
+	}
+	return domain
+}
+
+// pdAddresses returns the PD addresses passed to the components other than PD, the addresses in
+// tc.Spec.PDAddresses are preferred if the feature flag is set.
+func pdAddresses(tc *v1alpha1.TidbCluster, withScheme bool) (string, *start.VerifyPD) {
+	if preferPDAddresses(tc) {
+		return strings.Join(v2.AddressesWithSchemeAndPort(tc.Spec.PDAddresses, "", v1alpha1.DefaultPDClientPort), ","), nil
+	}
+	return clusterPDAddresses(tc, withScheme)
+}
+
+// clusterPDAddresses returns the address of the PD service of the cluster. If the cluster is deployed
+// across Kubernetes clusters, the addresses are verified by the discovery service when the component
+// starts, and the returned VerifyPD is not nil.
+func clusterPDAddresses(tc *v1alpha1.TidbCluster, withScheme bool) (string, *start.VerifyPD) {
+	scheme := ""
+	if withScheme {
+		scheme = tc.Scheme() + "://"
+	}
+
+	if tc.AcrossK8s() {
+		return "${" + start.VarPDAddresses + "}", &start.VerifyPD{
+			DiscoveryAddr: discoveryAddr(tc),
+			PDAddr:        fmt.Sprintf("%s%s:%d", scheme, controller.PDMemberName(tc.Name), v1alpha1.DefaultPDClientPort),
+			TrimScheme:    !withScheme,
+		}
+	}
+	tcName := tc.Name
+	if tc.Heterogeneous() && tc.WithoutLocalPD() {
+		// use pd of reference cluster
+		tcName = tc.Spec.Cluster.Name
+	}
+	return fmt.Sprintf("%s%s:%d", scheme, controller.PDMemberName(tcName), v1alpha1.DefaultPDClientPort), nil
+}
+
+// preferPDAddresses returns whether the addresses in tc.Spec.PDAddresses are used instead of the
+// PD service of the cluster
+func preferPDAddresses(tc *v1alpha1.TidbCluster) bool {
+	return slices.Contains(tc.Spec.StartScriptV2FeatureFlags, v1alpha1.StartScriptV2FeatureFlagPreferPDAddressesOverDiscovery) &&
+		len(tc.Spec.PDAddresses) > 0
+}
+
+// waitForDNSNameIPMatch returns whether the component waits until its domain is resolved to its IP
+func waitForDNSNameIPMatch(tc *v1alpha1.TidbCluster) bool {
+	return slices.Contains(tc.Spec.StartScriptV2FeatureFlags, v1alpha1.StartScriptV2FeatureFlagWaitForDnsNameIpMatch)
+}
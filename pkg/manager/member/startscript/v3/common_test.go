@@ -0,0 +1,83 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/start"
+
+	"github.com/onsi/gomega"
+)
+
+func newTidbCluster() *v1alpha1.TidbCluster {
+	tc := &v1alpha1.TidbCluster{
+		Spec: v1alpha1.TidbClusterSpec{
+			PD:      &v1alpha1.PDSpec{},
+			TiKV:    &v1alpha1.TiKVSpec{},
+			TiDB:    &v1alpha1.TiDBSpec{},
+			TiFlash: &v1alpha1.TiFlashSpec{},
+			TiCDC:   &v1alpha1.TiCDCSpec{},
+			Pump:    &v1alpha1.PumpSpec{},
+		},
+	}
+	tc.Name = "start-script-test"
+	tc.Namespace = "start-script-test-ns"
+	tc.Spec.StartScriptVersion = v1alpha1.StartScriptV3
+	return tc
+}
+
+// render renders the start config and checks that it can be decoded by the start binary
+func render(g *gomega.WithT, f func(*v1alpha1.TidbCluster) (string, error), tc *v1alpha1.TidbCluster) *start.Config {
+	data, err := f(tc)
+	g.Expect(err).Should(gomega.Succeed())
+	cfg, err := start.Unmarshal([]byte(data))
+	g.Expect(err).Should(gomega.Succeed())
+	return cfg
+}
+
+func TestPDAddresses(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	addrs, verify := pdAddresses(tc, false)
+	g.Expect(addrs).Should(gomega.Equal("start-script-test-pd:2379"))
+	g.Expect(verify).Should(gomega.BeNil())
+
+	tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+	addrs, _ = pdAddresses(tc, true)
+	g.Expect(addrs).Should(gomega.Equal("https://start-script-test-pd:2379"))
+
+	tc.Spec.AcrossK8s = true
+	addrs, verify = pdAddresses(tc, false)
+	g.Expect(addrs).Should(gomega.Equal("${PD_ADDRESSES}"))
+	g.Expect(verify).Should(gomega.Equal(&start.VerifyPD{
+		DiscoveryAddr: "start-script-test-discovery.start-script-test-ns:10261",
+		PDAddr:        "start-script-test-pd:2379",
+		TrimScheme:    true,
+	}))
+
+	tc.Spec.StartScriptV2FeatureFlags = []v1alpha1.StartScriptV2FeatureFlag{v1alpha1.StartScriptV2FeatureFlagPreferPDAddressesOverDiscovery}
+	tc.Spec.PDAddresses = []string{"http://pd-0:2379", "pd-1"}
+	addrs, verify = pdAddresses(tc, true)
+	g.Expect(addrs).Should(gomega.Equal("pd-0:2379,pd-1:2379"))
+	g.Expect(verify).Should(gomega.BeNil())
+
+	tc = newTidbCluster()
+	tc.Spec.Cluster = &v1alpha1.TidbClusterRef{Name: "ref"}
+	tc.Spec.PD = nil
+	addrs, _ = pdAddresses(tc, false)
+	g.Expect(addrs).Should(gomega.Equal("ref-pd:2379"))
+}
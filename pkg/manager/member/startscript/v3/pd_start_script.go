@@ -0,0 +1,131 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager/member/constants"
+	v2 "github.com/pingcap/tidb-operator/pkg/manager/member/startscript/v2"
+	"github.com/pingcap/tidb-operator/pkg/start"
+	"github.com/pingcap/tidb-operator/pkg/util/cmpver"
+)
+
+// pdMaxStartDelaySeconds spreads the starts of the PD members
+const pdMaxStartDelaySeconds = 10
+
+// RenderPDStartScript renders the start config of PD from TidbCluster
+func RenderPDStartScript(tc *v1alpha1.TidbCluster) (string, error) {
+	domain := podDomain(tc, controller.PDPeerMemberName(tc.Name))
+	name := podName
+	if tc.AcrossK8s() || tc.Spec.ClusterDomain != "" {
+		name = domain
+	}
+	dataDir := filepath.Join(constants.PDDataVolumeMountPath, tc.Spec.PD.DataSubDir)
+
+	cfg := &start.Config{
+		Component: v1alpha1.PDMemberType.String(),
+		Binary:    "/pd-server",
+		WaitForDNS: &start.WaitForDNS{
+			Domain:         domain,
+			MatchIP:        waitForDNSNameIPMatch(tc),
+			TimeoutSeconds: tc.PDStartTimeout(),
+		},
+		MaxStartDelaySeconds: pdMaxStartDelaySeconds,
+	}
+	if tc.PDInMSMode() {
+		cfg.Args = append(cfg.Args, "services", "api")
+		// the dns detection is enabled by default in the mode of PD microservices
+		cfg.WaitForDNS.MatchIP = true
+	}
+	if cfg.WaitForDNS.MatchIP {
+		cfg.WaitForDNS.InitialDelaySeconds = tc.PDInitWaitTime()
+	}
+	cfg.Args = append(cfg.Args,
+		"--data-dir="+dataDir,
+		"--name="+name,
+		fmt.Sprintf("--peer-urls=%s://0.0.0.0:%d", tc.Scheme(), v1alpha1.DefaultPDPeerPort),
+		fmt.Sprintf("--advertise-peer-urls=%s://%s:%d", tc.Scheme(), domain, v1alpha1.DefaultPDPeerPort),
+		fmt.Sprintf("--client-urls=%s://0.0.0.0:%d", tc.Scheme(), v1alpha1.DefaultPDClientPort),
+		fmt.Sprintf("--advertise-client-urls=%s://%s:%d", tc.Scheme(), domain, v1alpha1.DefaultPDClientPort),
+		"--config=/etc/pd/pd.toml",
+	)
+
+	if preferPDAddresses(tc) {
+		addrs := v2.AddressesWithSchemeAndPort(tc.Spec.PDAddresses, tc.Scheme()+"://", v1alpha1.DefaultPDPeerPort)
+		cfg.Args = append(cfg.Args, "--join="+strings.Join(addrs, ","))
+	} else {
+		cfg.PDJoin = &start.PDJoin{
+			DataDir:           dataDir,
+			DiscoveryAddr:     discoveryAddr(tc),
+			AdvertisePeerAddr: fmt.Sprintf("%s:%d", domain, v1alpha1.DefaultPDPeerPort),
+		}
+	}
+
+	return cfg.Marshal()
+}
+
+// RenderPDTSOStartScript renders the start config of the TSO microservice from TidbCluster
+func RenderPDTSOStartScript(tc *v1alpha1.TidbCluster) (string, error) {
+	return renderPDMSStartScript(tc, "tso")
+}
+
+// RenderPDSchedulingStartScript renders the start config of the scheduling microservice from TidbCluster
+func RenderPDSchedulingStartScript(tc *v1alpha1.TidbCluster) (string, error) {
+	return renderPDMSStartScript(tc, "scheduling")
+}
+
+func renderPDMSStartScript(tc *v1alpha1.TidbCluster, name string) (string, error) {
+	domain := podDomain(tc, controller.PDMSPeerMemberName(tc.Name, name))
+	backends, verifyPD := pdAddresses(tc, true)
+
+	cfg := &start.Config{
+		Component: name,
+		Binary:    "/pd-server",
+		WaitForDNS: &start.WaitForDNS{
+			Domain:         domain,
+			MatchIP:        waitForDNSNameIPMatch(tc),
+			TimeoutSeconds: tc.PDStartTimeout(),
+		},
+		VerifyPD:             verifyPD,
+		Args:                 []string{"services", name},
+		MaxStartDelaySeconds: pdMaxStartDelaySeconds,
+	}
+	if cfg.WaitForDNS.MatchIP {
+		cfg.WaitForDNS.InitialDelaySeconds = tc.PDInitWaitTime()
+	}
+	if check, err := pdMSSupportMicroservicesWithName.Check(tc.PDMSVersion(name)); check && err == nil {
+		msName := podName
+		if tc.Spec.ClusterDomain != "" {
+			msName = domain
+		}
+		cfg.Args = append(cfg.Args, "--name="+msName)
+	}
+	cfg.Args = append(cfg.Args,
+		fmt.Sprintf("--listen-addr=%s://0.0.0.0:%d", tc.Scheme(), v1alpha1.DefaultPDClientPort),
+		fmt.Sprintf("--advertise-listen-addr=%s://%s:%d", tc.Scheme(), domain, v1alpha1.DefaultPDClientPort),
+		"--backend-endpoints="+backends,
+		"--config=/etc/pd/pd.toml",
+	)
+
+	return cfg.Marshal()
+}
+
+// pdMSSupportMicroservicesWithName returns true if the given version of PDMS supports microservices with name.
+// related https://github.com/tikv/pd/pull/8461.
+var pdMSSupportMicroservicesWithName, _ = cmpver.NewConstraint(cmpver.GreaterOrEqual, "v8.3.0")
@@ -0,0 +1,178 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/start"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/onsi/gomega"
+)
+
+func TestRenderPDStartScript(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type testcase struct {
+		name string
+
+		modifyTC     func(tc *v1alpha1.TidbCluster)
+		expectConfig *start.Config
+	}
+
+	cases := []testcase{
+		{
+			name:     "basic",
+			modifyTC: func(tc *v1alpha1.TidbCluster) {},
+			expectConfig: &start.Config{
+				Component: "pd",
+				WaitForDNS: &start.WaitForDNS{
+					Domain:         "${POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc",
+					TimeoutSeconds: 30,
+				},
+				PDJoin: &start.PDJoin{
+					DataDir:           "/var/lib/pd",
+					DiscoveryAddr:     "start-script-test-discovery.start-script-test-ns:10261",
+					AdvertisePeerAddr: "${POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc:2380",
+				},
+				Binary: "/pd-server",
+				Args: []string{
+					"--data-dir=/var/lib/pd",
+					"--name=${POD_NAME}",
+					"--peer-urls=http://0.0.0.0:2380",
+					"--advertise-peer-urls=http://${POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc:2380",
+					"--client-urls=http://0.0.0.0:2379",
+					"--advertise-client-urls=http://${POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc:2379",
+					"--config=/etc/pd/pd.toml",
+				},
+				MaxStartDelaySeconds: 10,
+			},
+		},
+		{
+			name: "cluster domain, tls, data sub dir and dns ip match",
+			modifyTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.ClusterDomain = "cluster.local"
+				tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+				tc.Spec.PD.DataSubDir = "data"
+				tc.Spec.StartScriptV2FeatureFlags = []v1alpha1.StartScriptV2FeatureFlag{v1alpha1.StartScriptV2FeatureFlagWaitForDnsNameIpMatch}
+			},
+			expectConfig: &start.Config{
+				Component: "pd",
+				WaitForDNS: &start.WaitForDNS{
+					Domain:              "${POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc.cluster.local",
+					MatchIP:             true,
+					InitialDelaySeconds: 0,
+					TimeoutSeconds:      30,
+				},
+				PDJoin: &start.PDJoin{
+					DataDir:           "/var/lib/pd/data",
+					DiscoveryAddr:     "start-script-test-discovery.start-script-test-ns:10261",
+					AdvertisePeerAddr: "${POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc.cluster.local:2380",
+				},
+				Binary: "/pd-server",
+				Args: []string{
+					"--data-dir=/var/lib/pd/data",
+					"--name=${POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc.cluster.local",
+					"--peer-urls=https://0.0.0.0:2380",
+					"--advertise-peer-urls=https://${POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc.cluster.local:2380",
+					"--client-urls=https://0.0.0.0:2379",
+					"--advertise-client-urls=https://${POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc.cluster.local:2379",
+					"--config=/etc/pd/pd.toml",
+				},
+				MaxStartDelaySeconds: 10,
+			},
+		},
+		{
+			name: "prefer pd addresses over discovery",
+			modifyTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.StartScriptV2FeatureFlags = []v1alpha1.StartScriptV2FeatureFlag{v1alpha1.StartScriptV2FeatureFlagPreferPDAddressesOverDiscovery}
+				tc.Spec.PDAddresses = []string{"http://pd-0:2379", "http://pd-1:2379"}
+			},
+			expectConfig: &start.Config{
+				Component: "pd",
+				WaitForDNS: &start.WaitForDNS{
+					Domain:         "${POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc",
+					TimeoutSeconds: 30,
+				},
+				Binary: "/pd-server",
+				Args: []string{
+					"--data-dir=/var/lib/pd",
+					"--name=${POD_NAME}",
+					"--peer-urls=http://0.0.0.0:2380",
+					"--advertise-peer-urls=http://${POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc:2380",
+					"--client-urls=http://0.0.0.0:2379",
+					"--advertise-client-urls=http://${POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc:2379",
+					"--config=/etc/pd/pd.toml",
+					"--join=http://pd-0:2380,http://pd-1:2380",
+				},
+				MaxStartDelaySeconds: 10,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Logf("test case: %s", c.name)
+
+		tc := newTidbCluster()
+		c.modifyTC(tc)
+
+		cfg := render(g, RenderPDStartScript, tc)
+		if diff := cmp.Diff(c.expectConfig, cfg); diff != "" {
+			t.Errorf("unexpected (-want, +got): %s", diff)
+		}
+	}
+}
+
+func TestRenderPDMSStartScript(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	tc.Spec.PD.Mode = "ms"
+	tc.Spec.PDMS = []*v1alpha1.PDMSSpec{{Name: "tso"}}
+	tc.Spec.Version = "v8.3.0"
+	tc.Spec.AcrossK8s = true
+
+	cfg := render(g, RenderPDTSOStartScript, tc)
+	expect := &start.Config{
+		Component: "tso",
+		WaitForDNS: &start.WaitForDNS{
+			Domain:         "${POD_NAME}.start-script-test-tso-peer.start-script-test-ns.svc",
+			TimeoutSeconds: 30,
+		},
+		VerifyPD: &start.VerifyPD{
+			DiscoveryAddr: "start-script-test-discovery.start-script-test-ns:10261",
+			PDAddr:        "http://start-script-test-pd:2379",
+		},
+		Binary: "/pd-server",
+		Args: []string{
+			"services", "tso",
+			"--name=${POD_NAME}",
+			"--listen-addr=http://0.0.0.0:2379",
+			"--advertise-listen-addr=http://${POD_NAME}.start-script-test-tso-peer.start-script-test-ns.svc:2379",
+			"--backend-endpoints=${PD_ADDRESSES}",
+			"--config=/etc/pd/pd.toml",
+		},
+		MaxStartDelaySeconds: 10,
+	}
+	if diff := cmp.Diff(expect, cfg); diff != "" {
+		t.Errorf("unexpected (-want, +got): %s", diff)
+	}
+
+	// PD starts the api service and always waits for the dns record in the mode of microservices
+	cfg = render(g, RenderPDStartScript, tc)
+	g.Expect(cfg.Args[:2]).Should(gomega.Equal([]string{"services", "api"}))
+	g.Expect(cfg.WaitForDNS.MatchIP).Should(gomega.BeTrue())
+}
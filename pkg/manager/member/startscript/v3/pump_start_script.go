@@ -0,0 +1,51 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/start"
+)
+
+// RenderPumpStartScript renders the start config of Pump from TidbCluster
+func RenderPumpStartScript(tc *v1alpha1.TidbCluster) (string, error) {
+	// Pump does not use the addresses in tc.Spec.PDAddresses
+	pdAddrs, verifyPD := clusterPDAddresses(tc, true)
+
+	advertiseHost := fmt.Sprintf("%s.%s", podName, controller.PumpPeerMemberName(tc.Name))
+	if tc.Spec.ClusterDomain != "" {
+		advertiseHost = advertiseHost + fmt.Sprintf(".%s.svc.%s", tc.Namespace, tc.Spec.ClusterDomain)
+	} else if tc.AcrossK8s() {
+		advertiseHost = advertiseHost + fmt.Sprintf(".%s.svc", tc.Namespace)
+	}
+
+	cfg := &start.Config{
+		Component: v1alpha1.PumpMemberType.String(),
+		Binary:    "/pump",
+		VerifyPD:  verifyPD,
+		Args: []string{
+			"-pd-urls=" + pdAddrs,
+			"-L", tc.PumpLogLevel(),
+			"-log-file=",
+			fmt.Sprintf("-advertise-addr=%s:%d", advertiseHost, v1alpha1.DefaultPumpPort),
+			"-data-dir=/data",
+			"--config=/etc/pump/pump.toml",
+		},
+	}
+
+	return cfg.Marshal()
+}
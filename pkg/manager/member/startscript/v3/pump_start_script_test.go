@@ -0,0 +1,53 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/start"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/onsi/gomega"
+)
+
+func TestRenderPumpStartScript(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	cfg := render(g, RenderPumpStartScript, tc)
+	expect := &start.Config{
+		Component: "pump",
+		Binary:    "/pump",
+		Args: []string{
+			"-pd-urls=http://start-script-test-pd:2379",
+			"-L", "info",
+			"-log-file=",
+			"-advertise-addr=${POD_NAME}.start-script-test-pump:8250",
+			"-data-dir=/data",
+			"--config=/etc/pump/pump.toml",
+		},
+	}
+	if diff := cmp.Diff(expect, cfg); diff != "" {
+		t.Errorf("unexpected (-want, +got): %s", diff)
+	}
+
+	tc.Spec.AcrossK8s = true
+	cfg = render(g, RenderPumpStartScript, tc)
+	g.Expect(cfg.VerifyPD).ShouldNot(gomega.BeNil())
+	g.Expect(cfg.Args).Should(gomega.ContainElements(
+		"-pd-urls=${PD_ADDRESSES}",
+		"-advertise-addr=${POD_NAME}.start-script-test-pump.start-script-test-ns.svc:8250",
+	))
+}
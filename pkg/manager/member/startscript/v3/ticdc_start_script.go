@@ -0,0 +1,69 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager/member/constants"
+	"github.com/pingcap/tidb-operator/pkg/start"
+)
+
+// RenderTiCDCStartScript renders the start config of TiCDC from TidbCluster
+func RenderTiCDCStartScript(tc *v1alpha1.TidbCluster) (string, error) {
+	// NB: TiCDC control relies the format.
+	advertiseAddr := fmt.Sprintf("%s:%d", podDomain(tc, controller.TiCDCPeerMemberName(tc.Name)), v1alpha1.DefaultTiCDCPort)
+
+	var pdAddrs string
+	var verifyPD *start.VerifyPD
+	if preferPDAddresses(tc) {
+		// TiCDC uses the addresses as they are
+		pdAddrs = strings.Join(tc.Spec.PDAddresses, ",")
+	} else {
+		pdAddrs, verifyPD = clusterPDAddresses(tc, true)
+	}
+
+	cfg := &start.Config{
+		Component: v1alpha1.TiCDCMemberType.String(),
+		Binary:    "/cdc",
+		VerifyPD:  verifyPD,
+		Args: []string{
+			"server",
+			fmt.Sprintf("--addr=0.0.0.0:%d", v1alpha1.DefaultTiCDCPort),
+			"--advertise-addr=" + advertiseAddr,
+			fmt.Sprintf("--gc-ttl=%d", tc.TiCDCGCTTL()),
+			"--log-file=" + tc.TiCDCLogFile(),
+			"--log-level=" + tc.TiCDCLogLevel(),
+			"--pd=" + pdAddrs,
+		},
+	}
+	if tc.IsTLSClusterEnabled() {
+		cfg.Args = append(cfg.Args,
+			fmt.Sprintf("--ca=%s", path.Join(constants.TiCDCCertPath, corev1.ServiceAccountRootCAKey)),
+			fmt.Sprintf("--cert=%s", path.Join(constants.TiCDCCertPath, corev1.TLSCertKey)),
+			fmt.Sprintf("--key=%s", path.Join(constants.TiCDCCertPath, corev1.TLSPrivateKeyKey)),
+		)
+	}
+	if tc.Spec.TiCDC.Config != nil && !tc.Spec.TiCDC.Config.OnlyOldItems() {
+		cfg.Args = append(cfg.Args, "--config=/etc/ticdc/ticdc.toml")
+	}
+
+	return cfg.Marshal()
+}
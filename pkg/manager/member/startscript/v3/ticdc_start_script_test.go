@@ -0,0 +1,57 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/start"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/onsi/gomega"
+)
+
+func TestRenderTiCDCStartScript(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+	cfg := render(g, RenderTiCDCStartScript, tc)
+	expect := &start.Config{
+		Component: "ticdc",
+		Binary:    "/cdc",
+		Args: []string{
+			"server",
+			"--addr=0.0.0.0:8301",
+			"--advertise-addr=${POD_NAME}.start-script-test-ticdc-peer.start-script-test-ns.svc:8301",
+			"--gc-ttl=86400",
+			"--log-file=",
+			"--log-level=info",
+			"--pd=https://start-script-test-pd:2379",
+			"--ca=/var/lib/ticdc-tls/ca.crt",
+			"--cert=/var/lib/ticdc-tls/tls.crt",
+			"--key=/var/lib/ticdc-tls/tls.key",
+		},
+	}
+	if diff := cmp.Diff(expect, cfg); diff != "" {
+		t.Errorf("unexpected (-want, +got): %s", diff)
+	}
+
+	// TiCDC uses the preferred PD addresses as they are
+	tc.Spec.StartScriptV2FeatureFlags = []v1alpha1.StartScriptV2FeatureFlag{v1alpha1.StartScriptV2FeatureFlagPreferPDAddressesOverDiscovery}
+	tc.Spec.PDAddresses = []string{"https://pd-0:2379", "https://pd-1:2379"}
+	cfg = render(g, RenderTiCDCStartScript, tc)
+	g.Expect(cfg.Args).Should(gomega.ContainElement("--pd=https://pd-0:2379,https://pd-1:2379"))
+}
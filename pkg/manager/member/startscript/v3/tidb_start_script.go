@@ -0,0 +1,52 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/start"
+)
+
+// RenderTiDBStartScript renders the start config of TiDB from TidbCluster
+func RenderTiDBStartScript(tc *v1alpha1.TidbCluster) (string, error) {
+	pdAddrs, verifyPD := pdAddresses(tc, false)
+
+	cfg := &start.Config{
+		Component: v1alpha1.TiDBMemberType.String(),
+		Binary:    "/tidb-server",
+		VerifyPD:  verifyPD,
+		Args: []string{
+			"--store=tikv",
+			"--advertise-address=" + podDomain(tc, controller.TiDBGroupPeerMemberName(tc.Name, tc.TiDBGroupName())),
+			"--host=0.0.0.0",
+			"--path=" + pdAddrs,
+			"--config=/etc/tidb/tidb.toml",
+		},
+		OptionalArgs: []start.OptionalArgs{
+			{Var: "SLOW_LOG_FILE", Args: []string{"--log-slow-query=${SLOW_LOG_FILE}"}},
+		},
+	}
+	if tc.IsTiDBBinlogEnabled() {
+		cfg.Args = append(cfg.Args, "--enable-binlog=true")
+	}
+	if plugins := tc.Spec.TiDB.Plugins; len(plugins) > 0 {
+		cfg.Args = append(cfg.Args, "--plugin-dir=/plugins", fmt.Sprintf("--plugin-load=%s", strings.Join(plugins, ",")))
+	}
+
+	return cfg.Marshal()
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/start"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/onsi/gomega"
+)
+
+func TestRenderTiDBStartScript(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	tc.Spec.TiDB.Plugins = []string{"audit", "whitelist"}
+	tc.Spec.ClusterDomain = "cluster.local"
+
+	cfg := render(g, RenderTiDBStartScript, tc)
+	expect := &start.Config{
+		Component: "tidb",
+		Binary:    "/tidb-server",
+		Args: []string{
+			"--store=tikv",
+			"--advertise-address=${POD_NAME}.start-script-test-tidb-peer.start-script-test-ns.svc.cluster.local",
+			"--host=0.0.0.0",
+			"--path=start-script-test-pd:2379",
+			"--config=/etc/tidb/tidb.toml",
+			"--enable-binlog=true",
+			"--plugin-dir=/plugins",
+			"--plugin-load=audit,whitelist",
+		},
+		OptionalArgs: []start.OptionalArgs{
+			{Var: "SLOW_LOG_FILE", Args: []string{"--log-slow-query=${SLOW_LOG_FILE}"}},
+		},
+	}
+	if diff := cmp.Diff(expect, cfg); diff != "" {
+		t.Errorf("unexpected (-want, +got): %s", diff)
+	}
+}
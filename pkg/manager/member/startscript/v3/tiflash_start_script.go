@@ -0,0 +1,90 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	v2 "github.com/pingcap/tidb-operator/pkg/manager/member/startscript/v2"
+	"github.com/pingcap/tidb-operator/pkg/start"
+)
+
+// RenderTiFlashStartScript renders the start config of TiFlash from TidbCluster
+func RenderTiFlashStartScript(tc *v1alpha1.TidbCluster) (string, error) {
+	cfg := &start.Config{
+		Component: v1alpha1.TiFlashMemberType.String(),
+		Binary:    "/tiflash/tiflash",
+	}
+
+	if !tc.Spec.TiFlash.DoesMountCMInTiflashContainer() {
+		// the config file is rendered by the init container
+		cfg.Args = []string{"server", "--config-file", "/data0/config.toml"}
+		// the config items of the disaggregated TiFlash are passed after "--" to override the config file
+		if args := v2.TiFlashDisaggregatedArgs(tc); len(args) > 0 {
+			cfg.Args = append(append(cfg.Args, "--"), args...)
+		}
+		return cfg.Marshal()
+	}
+
+	host := fmt.Sprintf("${POD_NAME}.${HEADLESS_SERVICE_NAME}.${NAMESPACE}.svc%s", controller.FormatClusterDomain(tc.Spec.ClusterDomain))
+	pdAddrs, verifyPD := pdAddresses(tc, false)
+
+	cfg.VerifyPD = verifyPD
+	cfg.Args = []string{
+		"server", "--config-file", "/etc/tiflash/config_templ.toml",
+		"--",
+		fmt.Sprintf("--flash.proxy.advertise-addr=%s:%d", host, v1alpha1.DefaultTiFlashProxyPort),
+	}
+	// only the tiflash learner supports dynamic configuration
+	if tc.Spec.EnableDynamicConfiguration != nil && *tc.Spec.EnableDynamicConfiguration {
+		cfg.Args = append(cfg.Args, fmt.Sprintf("--flash.proxy.advertise-status-addr=%s:%d", host, v1alpha1.DefaultTiFlashProxyStatusPort))
+	}
+	cfg.Args = append(cfg.Args,
+		fmt.Sprintf("--flash.service_addr=%s:%d", host, v1alpha1.DefaultTiFlashFlashPort),
+		"--raft.pd_addr="+pdAddrs,
+	)
+	cfg.Args = append(cfg.Args, v2.TiFlashDisaggregatedArgs(tc)...)
+	cfg.OptionalArgs = []start.OptionalArgs{
+		{Var: "STORE_LABELS", Args: []string{"--labels", "${STORE_LABELS}"}},
+	}
+
+	return cfg.Marshal()
+}
+
+// RenderTiFlashInitScript renders the config of the init container of TiFlash from TidbCluster, which
+// renders the config files of TiFlash from the templates
+func RenderTiFlashInitScript(tc *v1alpha1.TidbCluster) (string, error) {
+	replacements := map[string]string{
+		"POD_NUM": "${" + start.VarPodOrdinal + "}",
+	}
+	cfg := &start.Config{
+		Component: v1alpha1.TiFlashMemberType.String() + "-init",
+	}
+	if tc.AcrossK8s() {
+		cfg.VerifyPD = &start.VerifyPD{
+			DiscoveryAddr: discoveryAddr(tc),
+			PDAddr:        fmt.Sprintf("%s://%s:%d", tc.Scheme(), controller.PDMemberName(tc.Name), v1alpha1.DefaultPDClientPort),
+			TrimScheme:    true,
+		}
+		replacements["PD_ADDR"] = "${" + start.VarPDAddresses + "}"
+	}
+	cfg.Files = []start.File{
+		{Template: "/etc/tiflash/config_templ.toml", Path: "/data0/config.toml", Replacements: replacements},
+		{Template: "/etc/tiflash/proxy_templ.toml", Path: "/data0/proxy.toml", Replacements: replacements},
+	}
+
+	return cfg.Marshal()
+}
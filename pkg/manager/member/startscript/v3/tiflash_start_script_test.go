@@ -0,0 +1,108 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/start"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/onsi/gomega"
+)
+
+func TestRenderTiFlashStartScript(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	cfg := render(g, RenderTiFlashStartScript, tc)
+	expect := &start.Config{
+		Component: "tiflash",
+		Binary:    "/tiflash/tiflash",
+		Args:      []string{"server", "--config-file", "/data0/config.toml"},
+	}
+	if diff := cmp.Diff(expect, cfg); diff != "" {
+		t.Errorf("unexpected (-want, +got): %s", diff)
+	}
+
+	tc.Spec.TiFlash.Disaggregated = &v1alpha1.TiFlashDisaggregatedSpec{
+		S3: v1alpha1.TiFlashS3Spec{Endpoint: "http://minio:9000", Bucket: "tiflash"},
+	}
+	cfg = render(g, RenderTiFlashStartScript, tc)
+	g.Expect(cfg.Args).Should(gomega.Equal([]string{
+		"server", "--config-file", "/data0/config.toml",
+		"--",
+		"--flash.disaggregated_mode=tiflash_write",
+		"--storage.s3.endpoint=http://minio:9000",
+		"--storage.s3.bucket=tiflash",
+	}))
+
+	tc = newTidbCluster()
+	tc.Spec.TiFlash.Annotations = map[string]string{label.AnnTiflashMountCMInTiflashContainer: "true"}
+	tc.Spec.AcrossK8s = true
+	g.Expect(tc.Spec.TiFlash.DoesMountCMInTiflashContainer()).Should(gomega.BeTrue())
+	cfg = render(g, RenderTiFlashStartScript, tc)
+	expect = &start.Config{
+		Component: "tiflash",
+		VerifyPD: &start.VerifyPD{
+			DiscoveryAddr: "start-script-test-discovery.start-script-test-ns:10261",
+			PDAddr:        "start-script-test-pd:2379",
+			TrimScheme:    true,
+		},
+		Binary: "/tiflash/tiflash",
+		Args: []string{
+			"server", "--config-file", "/etc/tiflash/config_templ.toml",
+			"--",
+			"--flash.proxy.advertise-addr=${POD_NAME}.${HEADLESS_SERVICE_NAME}.${NAMESPACE}.svc:20170",
+			"--flash.service_addr=${POD_NAME}.${HEADLESS_SERVICE_NAME}.${NAMESPACE}.svc:3930",
+			"--raft.pd_addr=${PD_ADDRESSES}",
+		},
+		OptionalArgs: []start.OptionalArgs{
+			{Var: "STORE_LABELS", Args: []string{"--labels", "${STORE_LABELS}"}},
+		},
+	}
+	if diff := cmp.Diff(expect, cfg); diff != "" {
+		t.Errorf("unexpected (-want, +got): %s", diff)
+	}
+}
+
+func TestRenderTiFlashInitScript(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	cfg := render(g, RenderTiFlashInitScript, tc)
+	replacements := map[string]string{"POD_NUM": "${POD_ORDINAL}"}
+	expect := &start.Config{
+		Component: "tiflash-init",
+		Files: []start.File{
+			{Template: "/etc/tiflash/config_templ.toml", Path: "/data0/config.toml", Replacements: replacements},
+			{Template: "/etc/tiflash/proxy_templ.toml", Path: "/data0/proxy.toml", Replacements: replacements},
+		},
+	}
+	if diff := cmp.Diff(expect, cfg); diff != "" {
+		t.Errorf("unexpected (-want, +got): %s", diff)
+	}
+
+	tc.Spec.AcrossK8s = true
+	tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+	cfg = render(g, RenderTiFlashInitScript, tc)
+	g.Expect(cfg.VerifyPD).Should(gomega.Equal(&start.VerifyPD{
+		DiscoveryAddr: "start-script-test-discovery.start-script-test-ns:10261",
+		PDAddr:        "https://start-script-test-pd:2379",
+		TrimScheme:    true,
+	}))
+	g.Expect(cfg.Files[0].Replacements).Should(gomega.HaveKeyWithValue("PD_ADDR", "${PD_ADDRESSES}"))
+}
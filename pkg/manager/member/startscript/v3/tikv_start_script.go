@@ -0,0 +1,66 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager/member/constants"
+	"github.com/pingcap/tidb-operator/pkg/start"
+)
+
+// RenderTiKVStartScript renders the start config of TiKV from TidbCluster
+func RenderTiKVStartScript(tc *v1alpha1.TidbCluster) (string, error) {
+	advertiseHost := podDomain(tc, controller.TiKVGroupPeerMemberName(tc.Name, tc.TiKVGroupName()))
+	pdAddrs, verifyPD := pdAddresses(tc, false)
+
+	listenHost := "0.0.0.0"
+	if tc.Spec.PreferIPv6 {
+		listenHost = "[::]"
+	}
+
+	cfg := &start.Config{
+		Component: v1alpha1.TiKVMemberType.String(),
+		Binary:    "/tikv-server",
+		VerifyPD:  verifyPD,
+		Args: []string{
+			"--pd=" + pdAddrs,
+			fmt.Sprintf("--advertise-addr=%s:%d", advertiseHost, v1alpha1.DefaultTiKVServerPort),
+			fmt.Sprintf("--addr=%s:%d", listenHost, v1alpha1.DefaultTiKVServerPort),
+			fmt.Sprintf("--status-addr=%s:%d", listenHost, v1alpha1.DefaultTiKVStatusPort),
+			"--data-dir=" + filepath.Join(constants.TiKVDataVolumeMountPath, tc.Spec.TiKV.DataSubDir),
+			"--capacity=${CAPACITY}",
+			"--config=/etc/tikv/tikv.toml",
+		},
+		OptionalArgs: []start.OptionalArgs{
+			{Var: "STORE_LABELS", Args: []string{"--labels", "${STORE_LABELS}"}},
+		},
+	}
+	if tc.Spec.EnableDynamicConfiguration != nil && *tc.Spec.EnableDynamicConfiguration {
+		cfg.Args = append(cfg.Args, fmt.Sprintf("--advertise-status-addr=%s:%d", advertiseHost, v1alpha1.DefaultTiKVStatusPort))
+	}
+	// TiKV only waits for its domain if the feature flag is set for backward compatibility
+	if waitForDNSNameIPMatch(tc) {
+		cfg.WaitForDNS = &start.WaitForDNS{
+			Domain:         advertiseHost,
+			MatchIP:        true,
+			TimeoutSeconds: tc.PDStartTimeout(),
+		}
+	}
+
+	return cfg.Marshal()
+}
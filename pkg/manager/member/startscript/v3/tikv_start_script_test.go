@@ -0,0 +1,108 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/start"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestRenderTiKVStartScript(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type testcase struct {
+		name string
+
+		modifyTC     func(tc *v1alpha1.TidbCluster)
+		expectConfig *start.Config
+	}
+
+	cases := []testcase{
+		{
+			name:     "basic",
+			modifyTC: func(tc *v1alpha1.TidbCluster) {},
+			expectConfig: &start.Config{
+				Component: "tikv",
+				Binary:    "/tikv-server",
+				Args: []string{
+					"--pd=start-script-test-pd:2379",
+					"--advertise-addr=${POD_NAME}.start-script-test-tikv-peer.start-script-test-ns.svc:20160",
+					"--addr=0.0.0.0:20160",
+					"--status-addr=0.0.0.0:20180",
+					"--data-dir=/var/lib/tikv",
+					"--capacity=${CAPACITY}",
+					"--config=/etc/tikv/tikv.toml",
+				},
+				OptionalArgs: []start.OptionalArgs{
+					{Var: "STORE_LABELS", Args: []string{"--labels", "${STORE_LABELS}"}},
+				},
+			},
+		},
+		{
+			name: "across k8s, ipv6, dynamic configuration and dns ip match",
+			modifyTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.AcrossK8s = true
+				tc.Spec.ClusterDomain = "cluster.local"
+				tc.Spec.PreferIPv6 = true
+				tc.Spec.EnableDynamicConfiguration = pointer.BoolPtr(true)
+				tc.Spec.StartScriptV2FeatureFlags = []v1alpha1.StartScriptV2FeatureFlag{v1alpha1.StartScriptV2FeatureFlagWaitForDnsNameIpMatch}
+			},
+			expectConfig: &start.Config{
+				Component: "tikv",
+				WaitForDNS: &start.WaitForDNS{
+					Domain:         "${POD_NAME}.start-script-test-tikv-peer.start-script-test-ns.svc.cluster.local",
+					MatchIP:        true,
+					TimeoutSeconds: 30,
+				},
+				VerifyPD: &start.VerifyPD{
+					DiscoveryAddr: "start-script-test-discovery.start-script-test-ns:10261",
+					PDAddr:        "start-script-test-pd:2379",
+					TrimScheme:    true,
+				},
+				Binary: "/tikv-server",
+				Args: []string{
+					"--pd=${PD_ADDRESSES}",
+					"--advertise-addr=${POD_NAME}.start-script-test-tikv-peer.start-script-test-ns.svc.cluster.local:20160",
+					"--addr=[::]:20160",
+					"--status-addr=[::]:20180",
+					"--data-dir=/var/lib/tikv",
+					"--capacity=${CAPACITY}",
+					"--config=/etc/tikv/tikv.toml",
+					"--advertise-status-addr=${POD_NAME}.start-script-test-tikv-peer.start-script-test-ns.svc.cluster.local:20180",
+				},
+				OptionalArgs: []start.OptionalArgs{
+					{Var: "STORE_LABELS", Args: []string{"--labels", "${STORE_LABELS}"}},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Logf("test case: %s", c.name)
+
+		tc := newTidbCluster()
+		c.modifyTC(tc)
+
+		cfg := render(g, RenderTiKVStartScript, tc)
+		if diff := cmp.Diff(c.expectConfig, cfg); diff != "" {
+			t.Errorf("unexpected (-want, +got): %s", diff)
+		}
+	}
+}
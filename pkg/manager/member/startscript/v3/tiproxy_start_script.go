@@ -0,0 +1,36 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/start"
+)
+
+// RenderTiProxyStartScript renders the start config of TiProxy from TidbCluster
+func RenderTiProxyStartScript(tc *v1alpha1.TidbCluster) (string, error) {
+	cfg := &start.Config{
+		Component: v1alpha1.TiProxyMemberType.String(),
+		Binary:    "/bin/tiproxy",
+		Args:      []string{"--config=/etc/proxy/proxy.toml"},
+		// Old TiProxy versions don't support advertise-addr, and it can't be added to the config file
+		// because the file is read-only.
+		SupportedArgs: []start.SupportedArgs{
+			{Flag: "advertise-addr", Args: []string{"--advertise-addr=" + podDomain(tc, controller.TiProxyPeerMemberName(tc.Name))}},
+		},
+	}
+
+	return cfg.Marshal()
+}
@@ -0,0 +1,45 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/start"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/onsi/gomega"
+)
+
+func TestRenderTiProxyStartScript(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	tc.Spec.ClusterDomain = "cluster.local"
+	cfg := render(g, RenderTiProxyStartScript, tc)
+	expect := &start.Config{
+		Component: "tiproxy",
+		Binary:    "/bin/tiproxy",
+		Args:      []string{"--config=/etc/proxy/proxy.toml"},
+		SupportedArgs: []start.SupportedArgs{
+			{
+				Flag: "advertise-addr",
+				Args: []string{"--advertise-addr=${POD_NAME}.start-script-test-tiproxy-peer.start-script-test-ns.svc.cluster.local"},
+			},
+		},
+	}
+	if diff := cmp.Diff(expect, cfg); diff != "" {
+		t.Errorf("unexpected (-want, +got): %s", diff)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/third_party/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+type ticdcFailover struct {
+	deps *controller.Dependencies
+}
+
+// NewTiCDCFailover returns a ticdcFailover instance
+func NewTiCDCFailover(deps *controller.Dependencies) Failover {
+	return &ticdcFailover{
+		deps: deps,
+	}
+}
+
+// Failover adds a replacement capture for a capture that is not ready for the TiCDC failover period.
+// The failing capture is drained first if it is still reachable, so that its tables are moved to the
+// other captures in time.
+func (f *ticdcFailover) Failover(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	if tc.Spec.TiCDC.MaxFailoverCount == nil || *tc.Spec.TiCDC.MaxFailoverCount <= 0 {
+		klog.V(4).Infof("ticdc failover is disabled for %s/%s, skipped", ns, tcName)
+		return nil
+	}
+	if tc.Status.TiCDC.FailureMembers == nil {
+		tc.Status.TiCDC.FailureMembers = map[string]v1alpha1.TiCDCFailureMember{}
+	}
+
+	maxFailoverCount := *tc.Spec.TiCDC.MaxFailoverCount
+	for podName, capture := range tc.Status.TiCDC.Captures {
+		if _, exist := tc.Status.TiCDC.FailureMembers[podName]; exist {
+			continue
+		}
+		if capture.Ready {
+			continue
+		}
+
+		deadline := capture.LastTransitionTime.Add(f.deps.CLIConfig.TiCDCFailoverPeriod)
+		if !time.Now().After(deadline) {
+			continue
+		}
+		if len(tc.Status.TiCDC.FailureMembers) >= int(maxFailoverCount) {
+			klog.Warningf("%s/%s ticdc failover count reaches the limit (%d), no more failover pods will be created", ns, tcName, maxFailoverCount)
+			break
+		}
+
+		pod, err := f.deps.PodLister.Pods(ns).Get(podName)
+		if err != nil {
+			return fmt.Errorf("ticdcFailover.Failover: failed to get pod %s for cluster %s/%s, error: %s", podName, ns, tcName, err)
+		}
+		_, condition := k8s.GetPodCondition(&pod.Status, corev1.PodScheduled)
+		if condition == nil || condition.Status != corev1.ConditionTrue {
+			// if a capture is not ready because it's not scheduled yet, we
+			// should not create failover pod for it
+			klog.Warningf("pod %s/%s is not scheduled yet, skipping failover", ns, podName)
+			continue
+		}
+
+		ordinal, err := parserOrdinal(podName)
+		if err != nil {
+			return err
+		}
+		f.drainCapture(tc, podName, ordinal)
+
+		tc.Status.TiCDC.FailureMembers[podName] = v1alpha1.TiCDCFailureMember{
+			PodName:   podName,
+			CreatedAt: metav1.Now(),
+		}
+		msg := fmt.Sprintf("ticdc capture[%s] is not ready for %s", podName, f.deps.CLIConfig.TiCDCFailoverPeriod)
+		f.deps.Recorder.Event(tc, corev1.EventTypeWarning, unHealthEventReason, fmt.Sprintf(unHealthEventMsgPattern, "ticdc", podName, msg))
+		recordFailoverOperation(tc, v1alpha1.TiCDCMemberType, podName, msg)
+		break
+	}
+
+	return nil
+}
+
+// drainCapture moves the ownership and the tables out of the failing capture, it is best-effort as
+// the capture is likely unreachable, the tables of a lost capture are rescheduled by the owner anyway.
+func (f *ticdcFailover) drainCapture(tc *v1alpha1.TidbCluster, podName string, ordinal int32) {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	if _, err := f.deps.CDCControl.ResignOwner(tc, ordinal); err != nil {
+		klog.Infof("ticdc failover: resign owner from capture %s of %s/%s failed, skip draining, err: %v", podName, ns, tcName, err)
+		return
+	}
+	tableCount, _, err := f.deps.CDCControl.DrainCapture(tc, ordinal)
+	if err != nil {
+		klog.Infof("ticdc failover: drain capture %s of %s/%s failed, skip draining, err: %v", podName, ns, tcName, err)
+		return
+	}
+	klog.Infof("ticdc failover: capture %s of %s/%s is draining, %d tables remaining", podName, ns, tcName, tableCount)
+}
+
+// Recover removes all the failure members, the replacement captures are scaled in gracefully then.
+func (f *ticdcFailover) Recover(tc *v1alpha1.TidbCluster) {
+	if len(tc.Status.TiCDC.FailureMembers) == 0 {
+		return
+	}
+	klog.Infof("ticdc failover: recover the failure members %v of %s/%s", tc.Status.TiCDC.FailureMembers, tc.GetNamespace(), tc.GetName())
+	podNames := make([]string, 0, len(tc.Status.TiCDC.FailureMembers))
+	for _, member := range tc.Status.TiCDC.FailureMembers {
+		podNames = append(podNames, member.PodName)
+	}
+	recordFailoverRecoveryOperation(tc, v1alpha1.TiCDCMemberType, podNames...)
+	tc.Status.TiCDC.FailureMembers = nil
+}
+
+// RemoveUndesiredFailures removes the failure members whose pods are scaled in by users.
+func (f *ticdcFailover) RemoveUndesiredFailures(tc *v1alpha1.TidbCluster) {
+	desired := tc.TiCDCStsDesiredOrdinals(true)
+	for podName := range tc.Status.TiCDC.FailureMembers {
+		ordinal, err := parserOrdinal(podName)
+		if err != nil {
+			klog.Warningf("ticdc failover: invalid failure member %s of %s/%s, err: %v", podName, tc.GetNamespace(), tc.GetName(), err)
+			continue
+		}
+		if !desired.Has(ordinal) {
+			delete(tc.Status.TiCDC.FailureMembers, podName)
+		}
+	}
+}
+
+type fakeTiCDCFailover struct{}
+
+// NewFakeTiCDCFailover returns a fake Failover
+func NewFakeTiCDCFailover() Failover {
+	return &fakeTiCDCFailover{}
+}
+
+func (fcf *fakeTiCDCFailover) Failover(_ *v1alpha1.TidbCluster) error {
+	return nil
+}
+
+func (fcf *fakeTiCDCFailover) Recover(tc *v1alpha1.TidbCluster) {
+	tc.Status.TiCDC.FailureMembers = nil
+}
+
+func (fcf *fakeTiCDCFailover) RemoveUndesiredFailures(_ *v1alpha1.TidbCluster) {
+}
@@ -0,0 +1,191 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestTiCDCFailoverFailover(t *testing.T) {
+	tests := []struct {
+		name      string
+		update    func(*v1alpha1.TidbCluster)
+		scheduled bool
+		expectFn  func(*GomegaWithT, *v1alpha1.TidbCluster, *int)
+	}{
+		{
+			name:      "all captures are ready",
+			update:    func(tc *v1alpha1.TidbCluster) {},
+			scheduled: true,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, drained *int) {
+				g.Expect(tc.Status.TiCDC.FailureMembers).To(BeEmpty())
+				g.Expect(*drained).To(Equal(0))
+			},
+		},
+		{
+			name: "failover is disabled",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiCDC.MaxFailoverCount = pointer.Int32(0)
+				setTiCDCCaptureNotReady(tc, "test-ticdc-1", time.Hour)
+			},
+			scheduled: true,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, drained *int) {
+				g.Expect(tc.Status.TiCDC.FailureMembers).To(BeEmpty())
+			},
+		},
+		{
+			name: "capture is not ready within the failover period",
+			update: func(tc *v1alpha1.TidbCluster) {
+				setTiCDCCaptureNotReady(tc, "test-ticdc-1", time.Minute)
+			},
+			scheduled: true,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, drained *int) {
+				g.Expect(tc.Status.TiCDC.FailureMembers).To(BeEmpty())
+			},
+		},
+		{
+			name: "capture is not ready beyond the failover period",
+			update: func(tc *v1alpha1.TidbCluster) {
+				setTiCDCCaptureNotReady(tc, "test-ticdc-1", time.Hour)
+			},
+			scheduled: true,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, drained *int) {
+				g.Expect(tc.Status.TiCDC.FailureMembers).To(HaveLen(1))
+				g.Expect(tc.Status.TiCDC.FailureMembers).To(HaveKey("test-ticdc-1"))
+				g.Expect(*drained).To(Equal(1))
+				g.Expect(tc.TiCDCDeployDesiredReplicas()).To(Equal(int32(4)))
+			},
+		},
+		{
+			name: "pod is not scheduled",
+			update: func(tc *v1alpha1.TidbCluster) {
+				setTiCDCCaptureNotReady(tc, "test-ticdc-1", time.Hour)
+			},
+			scheduled: false,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, drained *int) {
+				g.Expect(tc.Status.TiCDC.FailureMembers).To(BeEmpty())
+			},
+		},
+		{
+			name: "failover count reaches the limit",
+			update: func(tc *v1alpha1.TidbCluster) {
+				setTiCDCCaptureNotReady(tc, "test-ticdc-1", time.Hour)
+				setTiCDCCaptureNotReady(tc, "test-ticdc-2", time.Hour)
+				tc.Status.TiCDC.FailureMembers = map[string]v1alpha1.TiCDCFailureMember{
+					"test-ticdc-2": {PodName: "test-ticdc-2"},
+				}
+			},
+			scheduled: true,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, drained *int) {
+				g.Expect(tc.Status.TiCDC.FailureMembers).To(HaveLen(1))
+				g.Expect(tc.Status.TiCDC.FailureMembers).To(HaveKey("test-ticdc-2"))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			deps := controller.NewFakeDependencies()
+			deps.CLIConfig.TiCDCFailoverPeriod = 5 * time.Minute
+			drained := 0
+			cdcControl := deps.CDCControl.(*controller.FakeTiCDCControl)
+			cdcControl.ResignOwnerFn = func(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
+				return true, nil
+			}
+			cdcControl.DrainCaptureFn = func(tc *v1alpha1.TidbCluster, ordinal int32) (int, bool, error) {
+				drained++
+				return 0, false, nil
+			}
+
+			tc := newTidbClusterForTiCDCFailover()
+			test.update(tc)
+			podIndexer := deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+			for podName := range tc.Status.TiCDC.Captures {
+				status := corev1.ConditionFalse
+				if test.scheduled {
+					status = corev1.ConditionTrue
+				}
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Namespace: tc.Namespace, Name: podName},
+					Status: corev1.PodStatus{
+						Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: status}},
+					},
+				}
+				g.Expect(podIndexer.Add(pod)).To(Succeed())
+			}
+
+			g.Expect(NewTiCDCFailover(deps).Failover(tc)).To(Succeed())
+			test.expectFn(g, tc, &drained)
+		})
+	}
+}
+
+func TestTiCDCFailoverRemoveUndesiredFailures(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiCDCFailover()
+	tc.Spec.TiCDC.Replicas = 2
+	tc.Status.TiCDC.FailureMembers = map[string]v1alpha1.TiCDCFailureMember{
+		"test-ticdc-1": {PodName: "test-ticdc-1"},
+		"test-ticdc-2": {PodName: "test-ticdc-2"},
+	}
+	NewTiCDCFailover(controller.NewFakeDependencies()).RemoveUndesiredFailures(tc)
+	g.Expect(tc.Status.TiCDC.FailureMembers).To(HaveLen(1))
+	g.Expect(tc.Status.TiCDC.FailureMembers).To(HaveKey("test-ticdc-1"))
+}
+
+func TestTiCDCFailoverRecover(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiCDCFailover()
+	tc.Status.TiCDC.FailureMembers = map[string]v1alpha1.TiCDCFailureMember{
+		"test-ticdc-1": {PodName: "test-ticdc-1"},
+	}
+	NewTiCDCFailover(controller.NewFakeDependencies()).Recover(tc)
+	g.Expect(tc.Status.TiCDC.FailureMembers).To(BeEmpty())
+}
+
+func newTidbClusterForTiCDCFailover() *v1alpha1.TidbCluster {
+	tc := newTidbClusterForCDC()
+	tc.Spec.TiCDC.MaxFailoverCount = pointer.Int32(1)
+	tc.Status.TiCDC.Captures = map[string]v1alpha1.TiCDCCapture{}
+	for i := 0; i < 3; i++ {
+		podName := fmt.Sprintf("test-ticdc-%d", i)
+		tc.Status.TiCDC.Captures[podName] = v1alpha1.TiCDCCapture{
+			PodName:            podName,
+			Ready:              true,
+			LastTransitionTime: metav1.Now(),
+		}
+	}
+	return tc
+}
+
+func setTiCDCCaptureNotReady(tc *v1alpha1.TidbCluster, podName string, since time.Duration) {
+	tc.Status.TiCDC.Captures[podName] = v1alpha1.TiCDCCapture{
+		PodName:            podName,
+		Ready:              false,
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-since)),
+	}
+}
@@ -27,6 +27,7 @@ import (
 	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
 	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/third_party/k8s"
 	"github.com/pingcap/tidb-operator/pkg/util"
 
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
@@ -48,6 +49,7 @@ const (
 // ticdcMemberManager implements manager.Manager.
 type ticdcMemberManager struct {
 	deps                     *controller.Dependencies
+	ticdcFailover            Failover
 	scaler                   Scaler
 	ticdcUpgrader            Upgrader
 	suspender                suspender.Suspender
@@ -89,9 +91,10 @@ func getTiCDCConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 }
 
 // NewTiCDCMemberManager returns a *ticdcMemberManager
-func NewTiCDCMemberManager(deps *controller.Dependencies, scaler Scaler, ticdcUpgrader Upgrader, spder suspender.Suspender, pvm volumes.PodVolumeModifier) manager.Manager {
+func NewTiCDCMemberManager(deps *controller.Dependencies, ticdcFailover Failover, scaler Scaler, ticdcUpgrader Upgrader, spder suspender.Suspender, pvm volumes.PodVolumeModifier) manager.Manager {
 	m := &ticdcMemberManager{
 		deps:              deps,
+		ticdcFailover:     ticdcFailover,
 		scaler:            scaler,
 		ticdcUpgrader:     ticdcUpgrader,
 		suspender:         spder,
@@ -192,10 +195,24 @@ func (m *ticdcMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
+	// Recover failed captures if any before generating desired statefulset
+	if len(tc.Status.TiCDC.FailureMembers) > 0 {
+		m.ticdcFailover.RemoveUndesiredFailures(tc)
+	}
+	if len(tc.Status.TiCDC.FailureMembers) > 0 && tc.Spec.TiCDC.RecoverFailover && m.shouldRecover(tc) {
+		m.ticdcFailover.Recover(tc)
+	}
+
 	newSts, err := getNewTiCDCStatefulSet(tc, cm)
 	if err != nil {
 		return err
 	}
+	setStartBinary(tc, m.deps.CLIConfig.TiDBDiscoveryImage, &newSts.Spec.Template.Spec)
+
+	// in the plan mode, the changes driven by the spec are held until the plan is approved
+	if proceed, err := syncChangePlan(m.deps, tc, v1alpha1.TiCDCMemberType, tc.Spec.TiCDC.Replicas, oldSts, newSts); err != nil || !proceed {
+		return err
+	}
 
 	if stsNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSts)
@@ -209,6 +226,8 @@ func (m *ticdcMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 		return nil
 	}
 
+	recordStatefulSetOperations(tc, v1alpha1.TiCDCMemberType, oldSts, newSts)
+
 	// Scaling takes precedence over upgrading because:
 	// - if a pod fails in the upgrading, users may want to delete it or add
 	//   new replicas
@@ -218,6 +237,17 @@ func (m *ticdcMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
+	// Perform failover logic if necessary. Note that this will only update
+	// TidbCluster status. The actual scaling performs in next sync loop (if a
+	// new replica needs to be added).
+	if m.deps.CLIConfig.AutoFailover && tc.Spec.TiCDC.MaxFailoverCount != nil {
+		if tc.TiCDCAllPodsStarted() && !tc.TiCDCAllCapturesReady() {
+			if err := m.ticdcFailover.Failover(tc); err != nil {
+				return err
+			}
+		}
+	}
+
 	if !templateEqual(newSts, oldSts) || tc.Status.TiCDC.Phase == v1alpha1.UpgradePhase {
 		if err := m.ticdcUpgrader.Upgrade(tc, oldSts, newSts); err != nil {
 			return err
@@ -274,6 +304,12 @@ func (m *ticdcMemberManager) syncTiCDCStatus(tc *v1alpha1.TidbCluster, sts *apps
 			capture.Ready = true
 		}
 
+		oldCapture, exist := tc.Status.TiCDC.Captures[podName]
+		capture.LastTransitionTime = metav1.Now()
+		if exist && oldCapture.Ready == capture.Ready && !oldCapture.LastTransitionTime.IsZero() {
+			capture.LastTransitionTime = oldCapture.LastTransitionTime
+		}
+
 		ticdcCaptures[podName] = capture
 	}
 
@@ -288,6 +324,40 @@ func (m *ticdcMemberManager) syncTiCDCStatus(tc *v1alpha1.TidbCluster, sts *apps
 	return nil
 }
 
+// shouldRecover returns whether all the desired captures (excluding failover pods) are ready and the
+// TiCDC cluster is healthy, so that the failover pods can be removed.
+func (m *ticdcMemberManager) shouldRecover(tc *v1alpha1.TidbCluster) bool {
+	var ownerOrdinal *int32
+	for ordinal := range tc.TiCDCStsDesiredOrdinals(true) {
+		podName := fmt.Sprintf("%s-%d", controller.TiCDCMemberName(tc.GetName()), ordinal)
+		pod, err := m.deps.PodLister.Pods(tc.GetNamespace()).Get(podName)
+		if err != nil {
+			klog.Errorf("pod %s/%s does not exist: %v", tc.GetNamespace(), podName, err)
+			return false
+		}
+		if !k8s.IsPodReady(pod) {
+			return false
+		}
+		capture, ok := tc.Status.TiCDC.Captures[podName]
+		if !ok || !capture.Ready {
+			return false
+		}
+		if ownerOrdinal == nil || capture.IsOwner {
+			o := ordinal
+			ownerOrdinal = &o
+		}
+	}
+	if ownerOrdinal == nil {
+		return true
+	}
+	healthy, err := m.deps.CDCControl.IsHealthy(tc, *ownerOrdinal)
+	if err != nil {
+		klog.Warningf("ticdc cluster %s/%s is not healthy, skip recovering failover, err: %v", tc.GetNamespace(), tc.GetName(), err)
+		return false
+	}
+	return healthy
+}
+
 func (m *ticdcMemberManager) syncCDCHeadlessService(tc *v1alpha1.TidbCluster) error {
 	if tc.Spec.Paused {
 		klog.Infof("TidbCluster %s/%s is paused, skip syncing ticdc service", tc.GetNamespace(), tc.GetName())
@@ -458,7 +528,7 @@ func getNewTiCDCStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*ap
 		Name:            v1alpha1.TiCDCMemberType.String(),
 		Image:           tc.TiCDCImage(),
 		ImagePullPolicy: baseTiCDCSpec.ImagePullPolicy(),
-		Command:         []string{"/bin/sh", "-c", script},
+		Command:         inlineStartScriptCommand(tc, "/bin/sh", script),
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          "ticdc",
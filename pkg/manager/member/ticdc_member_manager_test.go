@@ -596,6 +596,7 @@ func newFakeTiCDCMemberManager() (*ticdcMemberManager, *controller.FakeStatefulS
 	fakeDeps := controller.NewFakeDependencies()
 	tmm := &ticdcMemberManager{
 		deps:              fakeDeps,
+		ticdcFailover:     NewFakeTiCDCFailover(),
 		scaler:            NewTiCDCScaler(fakeDeps),
 		suspender:         suspender.NewFakeSuspender(),
 		podVolumeModifier: &volumes.FakePodVolumeModifier{},
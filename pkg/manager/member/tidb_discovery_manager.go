@@ -35,6 +35,10 @@ import (
 
 const (
 	PdTlsCertPath = "/var/lib/pd-tls"
+
+	// DiscoveryActivatorPort is the port of the activator in the discovery service,
+	// which wakes up the idle TiDB and forwards the client connections to it
+	DiscoveryActivatorPort = 10263
 )
 
 type TidbDiscoveryManager interface {
@@ -71,6 +75,10 @@ func (m *realTidbDiscoveryManager) Reconcile(obj client.Object) error {
 			ResourceNames: []string{metaObj.GetName()},
 			Verbs:         []string{"get"},
 		}
+		if isTiDBActivatorEnabled(cluster) {
+			// the activator patches the TidbCluster to wake up the idle TiDB
+			clusterPolicyRule.Verbs = append(clusterPolicyRule.Verbs, "patch")
+		}
 		preferIPv6 = cluster.Spec.PreferIPv6
 	case *v1alpha1.DMCluster:
 		clusterPolicyRule = rbacv1.PolicyRule{
@@ -96,6 +104,13 @@ func (m *realTidbDiscoveryManager) Reconcile(obj client.Object) error {
 				Resources: []string{"secrets"},
 				Verbs:     []string{"get", "list", "watch"},
 			},
+			{
+				// the bootstrap states are persisted in the ConfigMap
+				APIGroups:     []string{corev1.GroupName},
+				Resources:     []string{"configmaps"},
+				ResourceNames: []string{meta.Name},
+				Verbs:         []string{"get", "update"},
+			},
 		},
 	})
 	if err != nil {
@@ -122,6 +137,9 @@ func (m *realTidbDiscoveryManager) Reconcile(obj client.Object) error {
 	if err != nil {
 		return controller.RequeueErrorf("error creating or updating discovery rolebinding: %v", err)
 	}
+	if err := m.syncStateConfigMap(obj, meta); err != nil {
+		return controller.RequeueErrorf("error creating or updating discovery configmap: %v", err)
+	}
 	d, err := m.getTidbDiscoveryDeployment(metaObj)
 	if err != nil {
 		return controller.RequeueErrorf("error generating discovery deployment: %v", err)
@@ -138,6 +156,16 @@ func (m *realTidbDiscoveryManager) Reconcile(obj client.Object) error {
 	return nil
 }
 
+// syncStateConfigMap ensures the ConfigMap in which the discovery service persists the bootstrap states,
+// the data is owned by the discovery service and never overwritten here.
+func (m *realTidbDiscoveryManager) syncStateConfigMap(obj client.Object, meta metav1.ObjectMeta) error {
+	_, err := m.deps.GenericControl.CreateOrUpdate(obj, &corev1.ConfigMap{ObjectMeta: meta}, func(existing, desired client.Object) error {
+		existing.SetLabels(desired.GetLabels())
+		return nil
+	}, true)
+	return err
+}
+
 func getTidbDiscoveryService(obj metav1.Object, deploy *appsv1.Deployment, preferIPv6 bool) *corev1.Service {
 	meta, _ := getDiscoveryMeta(obj, controller.DiscoveryMemberName)
 	svc := &corev1.Service{
@@ -175,6 +203,7 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 		podSpec       corev1.PodSpec
 		readinessProb *corev1.Probe
 		livenessProbe *corev1.Probe
+		replicas      int32
 	)
 
 	switch cluster := obj.(type) {
@@ -183,6 +212,7 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 		timezone = cluster.Timezone()
 		baseSpec = cluster.BaseDiscoverySpec()
 		podSpec = baseSpec.BuildPodSpec()
+		replicas = cluster.DiscoveryReplicas()
 		if cluster.Spec.Discovery.ComponentSpec != nil && cluster.Spec.Discovery.ComponentSpec.ReadinessProbe != nil {
 			readinessProb = buildDiscoveryProb(cluster.Spec.Discovery.ComponentSpec.ReadinessProbe)
 		}
@@ -194,6 +224,7 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 		timezone = cluster.Timezone()
 		baseSpec = cluster.BaseDiscoverySpec()
 		podSpec = baseSpec.BuildPodSpec()
+		replicas = cluster.DiscoveryReplicas()
 		if cluster.Spec.Discovery.ComponentSpec != nil && cluster.Spec.Discovery.ComponentSpec.ReadinessProbe != nil {
 			readinessProb = buildDiscoveryProb(cluster.Spec.Discovery.ComponentSpec.ReadinessProbe)
 		}
@@ -223,6 +254,10 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 			Name:  "TC_NAME",
 			Value: obj.GetName(), // for DmCluster, we still name it as TC_NAME because only ProxyServer use it now.
 		},
+		{
+			Name:  "DISCOVERY_STATE_CONFIGMAP",
+			Value: meta.Name,
+		},
 	}
 	envs = util.AppendEnv(envs, baseSpec.Env())
 	volMounts := []corev1.VolumeMount{}
@@ -251,9 +286,18 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 			},
 		},
 	}
-	if readinessProb != nil {
-		discoveryContainer.ReadinessProbe = readinessProb
+	if readinessProb == nil {
+		// the discovery is ready when the persisted states can be accessed
+		readinessProb = &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/readyz",
+					Port: intstr.FromInt(10261),
+				},
+			},
+		}
 	}
+	discoveryContainer.ReadinessProbe = readinessProb
 	if livenessProbe != nil {
 		discoveryContainer.LivenessProbe = livenessProbe
 	}
@@ -271,6 +315,17 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 	podSpec.ServiceAccountName = meta.Name
 
 	podSpec.Volumes = append(podSpec.Volumes, baseSpec.AdditionalVolumes()...)
+	if tc, ok := obj.(*v1alpha1.TidbCluster); ok && isTiDBActivatorEnabled(tc) {
+		podSpec.Containers[0].Ports = append(podSpec.Containers[0].Ports, corev1.ContainerPort{
+			Name:          "activator",
+			Protocol:      corev1.ProtocolTCP,
+			ContainerPort: DiscoveryActivatorPort,
+		})
+		podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
+			Name:  "TC_TIDB_ACTIVATOR_ENABLED",
+			Value: strconv.FormatBool(true),
+		})
+	}
 	if tc, ok := obj.(*v1alpha1.TidbCluster); ok && tc.IsTLSClusterEnabled() && !tc.WithoutLocalPD() {
 		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
 			Name: "pd-tls",
@@ -292,12 +347,17 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 	}
 
 	podLabels := util.CombineStringMap(l.Labels(), baseSpec.Labels())
-	podAnnotations := baseSpec.Annotations()
+	podAnnotations := util.CombineStringMap(baseSpec.Annotations(), controller.AnnProm(10261, "/metrics"))
+	// a single replica is recreated as before, multiple replicas are rolled one by one to keep serving
+	strategy := appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+	if replicas > 1 {
+		strategy = appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}
+	}
 	d := &appsv1.Deployment{
 		ObjectMeta: meta,
 		Spec: appsv1.DeploymentSpec{
-			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
-			Replicas: pointer.Int32Ptr(1),
+			Strategy: strategy,
+			Replicas: pointer.Int32Ptr(replicas),
 			Selector: l.LabelSelector(),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
@@ -321,6 +381,11 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 	return d, nil
 }
 
+// isTiDBActivatorEnabled returns whether the activator should be started in the discovery service
+func isTiDBActivatorEnabled(tc *v1alpha1.TidbCluster) bool {
+	return tc.Spec.TiDB != nil && tc.Spec.TiDB.Idle != nil && tc.Spec.TiDB.Idle.IsWakeOnConnectEnabled()
+}
+
 func buildDiscoveryProb(probSpec *v1alpha1.Probe) *corev1.Probe {
 	if probSpec == nil {
 		return nil
@@ -23,7 +23,10 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 )
 
 func TestTidbDiscoveryManager_Reconcile(t *testing.T) {
@@ -58,6 +61,8 @@ func TestTidbDiscoveryManager_Reconcile(t *testing.T) {
 				g.Expect(err).To(Succeed())
 				g.Expect(deploys).To(HaveLen(1))
 				g.Expect(deploys[0].Name).To((Equal("test-discovery")))
+				g.Expect(*deploys[0].Spec.Replicas).To(Equal(int32(1)))
+				g.Expect(deploys[0].Spec.Strategy.Type).To(Equal(appsv1.RecreateDeploymentStrategyType))
 			},
 			errOnCreateOrUpdate: false,
 		},
@@ -96,6 +101,41 @@ func TestTidbDiscoveryManager_Reconcile(t *testing.T) {
 			},
 			errOnCreateOrUpdate: false,
 		},
+		{
+			name: "Enable the activator for the idle TiDB",
+			prepare: func(tc *v1alpha1.TidbCluster, ctrl *controller.FakeGenericControl) {
+				tc.Spec.TiDB.Idle = &v1alpha1.TiDBIdlePolicy{}
+			},
+			expect: func(deploys []appsv1.Deployment, tc *v1alpha1.TidbCluster, err error) {
+				g.Expect(err).To(Succeed())
+				g.Expect(deploys).To(HaveLen(1))
+				container := deploys[0].Spec.Template.Spec.Containers[0]
+				g.Expect(container.Ports).To(ContainElement(corev1.ContainerPort{
+					Name:          "activator",
+					Protocol:      corev1.ProtocolTCP,
+					ContainerPort: DiscoveryActivatorPort,
+				}))
+				g.Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "TC_TIDB_ACTIVATOR_ENABLED", Value: "true"}))
+			},
+			errOnCreateOrUpdate: false,
+		},
+		{
+			name: "Multiple replicas",
+			prepare: func(tc *v1alpha1.TidbCluster, ctrl *controller.FakeGenericControl) {
+				tc.Spec.Discovery.Replicas = pointer.Int32Ptr(3)
+			},
+			expect: func(deploys []appsv1.Deployment, tc *v1alpha1.TidbCluster, err error) {
+				g.Expect(err).To(Succeed())
+				g.Expect(deploys).To(HaveLen(1))
+				g.Expect(*deploys[0].Spec.Replicas).To(Equal(int32(3)))
+				g.Expect(deploys[0].Spec.Strategy.Type).To(Equal(appsv1.RollingUpdateDeploymentStrategyType))
+				g.Expect(deploys[0].Spec.Template.Annotations).To(HaveKeyWithValue("prometheus.io/path", "/metrics"))
+				container := deploys[0].Spec.Template.Spec.Containers[0]
+				g.Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "DISCOVERY_STATE_CONFIGMAP", Value: "test-discovery"}))
+				g.Expect(container.ReadinessProbe.HTTPGet.Path).To(Equal("/readyz"))
+			},
+			errOnCreateOrUpdate: false,
+		},
 		{
 			name: "Create or update resource error",
 			expect: func(deploys []appsv1.Deployment, tc *v1alpha1.TidbCluster, err error) {
@@ -194,6 +234,35 @@ func TestTidbDiscoveryManager_ReconcileDM(t *testing.T) {
 	}
 }
 
+func TestTidbDiscoveryManager_ReconcileStateConfigMap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	dm, ctrl := newFakeTidbDiscoveryManager()
+	g.Expect(dm.Reconcile(tc)).To(Succeed())
+
+	role := &rbacv1.Role{}
+	g.Expect(ctrl.FakeCli.Get(context.TODO(), types.NamespacedName{Namespace: tc.Namespace, Name: "test-discovery"}, role)).To(Succeed())
+	g.Expect(role.Rules).To(ContainElement(rbacv1.PolicyRule{
+		APIGroups:     []string{corev1.GroupName},
+		Resources:     []string{"configmaps"},
+		ResourceNames: []string{"test-discovery"},
+		Verbs:         []string{"get", "update"},
+	}))
+
+	// the states written by the discovery service are kept
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: tc.Namespace, Name: "test-discovery"}
+	g.Expect(ctrl.FakeCli.Get(context.TODO(), key, cm)).To(Succeed())
+	g.Expect(cm.OwnerReferences).To(HaveLen(1))
+	cm.Data = map[string]string{"pd.test": `{"resourceVersion":"1","peers":["test-pd-0"]}`}
+	g.Expect(ctrl.FakeCli.Update(context.TODO(), cm)).To(Succeed())
+
+	g.Expect(dm.Reconcile(tc)).To(Succeed())
+	g.Expect(ctrl.FakeCli.Get(context.TODO(), key, cm)).To(Succeed())
+	g.Expect(cm.Data).To(HaveKeyWithValue("pd.test", `{"resourceVersion":"1","peers":["test-pd-0"]}`))
+}
+
 func newFakeTidbDiscoveryManager() (*realTidbDiscoveryManager, *controller.FakeGenericControl) {
 	fakeDeps := controller.NewFakeDependencies()
 	ctrl := fakeDeps.GenericControl.(*controller.FakeGenericControl)
@@ -91,6 +91,7 @@ func (f *tidbFailover) Failover(tc *v1alpha1.TidbCluster) error {
 			}
 			msg := fmt.Sprintf("tidb[%s] is unhealthy", tidbMember.Name)
 			f.deps.Recorder.Event(tc, corev1.EventTypeWarning, unHealthEventReason, fmt.Sprintf(unHealthEventMsgPattern, "tidb", tidbMember.Name, msg))
+			recordFailoverOperation(tc, v1alpha1.TiDBMemberType, tidbMember.Name, msg)
 			break
 		}
 	}
@@ -99,6 +100,11 @@ func (f *tidbFailover) Failover(tc *v1alpha1.TidbCluster) error {
 }
 
 func (f *tidbFailover) Recover(tc *v1alpha1.TidbCluster) {
+	podNames := make([]string, 0, len(tc.Status.TiDB.FailureMembers))
+	for _, member := range tc.Status.TiDB.FailureMembers {
+		podNames = append(podNames, member.PodName)
+	}
+	recordFailoverRecoveryOperation(tc, v1alpha1.TiDBMemberType, podNames...)
 	tc.Status.TiDB.FailureMembers = nil
 }
 
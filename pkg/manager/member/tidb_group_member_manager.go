@@ -0,0 +1,192 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/manager/suspender"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+type tidbGroupMemberManager struct {
+	deps   *controller.Dependencies
+	scaler Scaler
+	// tidbMemberManager syncs a TiDB group through the view built by TidbCluster.TiDBGroupView
+	tidbMemberManager *tidbMemberManager
+}
+
+// NewTiDBGroupMemberManager returns a *tidbGroupMemberManager
+func NewTiDBGroupMemberManager(deps *controller.Dependencies, scaler Scaler, tidbUpgrader Upgrader, tidbFailover Failover, spder suspender.Suspender, pvm volumes.PodVolumeModifier) manager.Manager {
+	return &tidbGroupMemberManager{
+		deps:   deps,
+		scaler: scaler,
+		tidbMemberManager: &tidbMemberManager{
+			deps:                         deps,
+			scaler:                       scaler,
+			tidbUpgrader:                 tidbUpgrader,
+			tidbFailover:                 tidbFailover,
+			suspender:                    spder,
+			podVolumeModifier:            pvm,
+			tidbStatefulSetIsUpgradingFn: tidbStatefulSetIsUpgrading,
+		},
+	}
+}
+
+// Sync for all TiDB groups.
+func (m *tidbGroupMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
+	// scale in the TiDB groups that have been removed from the spec
+	for name := range tc.Status.TiDBGroups {
+		if tc.TiDBGroup(name) != nil {
+			continue
+		}
+		if err := m.syncRemovedGroup(tc, name); err != nil {
+			return err
+		}
+	}
+
+	if len(tc.Spec.TiDBGroups) == 0 {
+		return nil
+	}
+
+	if tc.Status.TiDBGroups == nil {
+		tc.Status.TiDBGroups = make(map[string]*v1alpha1.TiDBGroupStatus)
+	}
+
+	for _, group := range tc.Spec.TiDBGroups {
+		if err := m.syncGroup(tc, group); err != nil {
+			metrics.ClusterUpdateErrors.WithLabelValues(tc.GetNamespace(), tc.GetName(), group.Name).Inc()
+			klog.Errorf("syncGroup failed, error: %v, TiDB group: %s for cluster %s/%s", err, group.Name, tc.GetNamespace(), tc.GetName())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncGroup syncs a single TiDB group by running the TiDB member manager
+// against the view of the group and copying the status back to tc.
+func (m *tidbGroupMemberManager) syncGroup(tc *v1alpha1.TidbCluster, group *v1alpha1.TiDBGroupSpec) error {
+	view := tc.TiDBGroupView(group)
+	err := m.tidbMemberManager.Sync(view)
+
+	tc.Status.TiDBGroups[group.Name] = &v1alpha1.TiDBGroupStatus{
+		Name:       group.Name,
+		TiDBStatus: view.Status.TiDB,
+	}
+	tc.Status.ChangePlan = view.Status.ChangePlan
+	tc.Status.Operations = view.Status.Operations
+	return err
+}
+
+// syncRemovedGroup scales the StatefulSet of a removed TiDB group to 0, the
+// StatefulSet, Services and ConfigMaps of the group are deleted and the status
+// of the group is dropped once all of its pods are gone.
+func (m *tidbGroupMemberManager) syncRemovedGroup(tc *v1alpha1.TidbCluster, name string) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	setName := controller.TiDBGroupMemberName(tcName, name)
+
+	oldSetTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(setName)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("syncRemovedGroup: fail to get sts %s of TiDB group %s for cluster [%s/%s], error: %s",
+			setName, name, ns, tcName, err)
+	}
+	if errors.IsNotFound(err) || (oldSetTmp.Status.Replicas == 0 && *oldSetTmp.Spec.Replicas == 0) {
+		if err := m.deleteGroup(tc, name); err != nil {
+			return err
+		}
+		delete(tc.Status.TiDBGroups, name)
+		return nil
+	}
+
+	oldSet := oldSetTmp.DeepCopy()
+	newSet := oldSetTmp.DeepCopy()
+
+	view := tc.TiDBGroupView(&v1alpha1.TiDBGroupSpec{Name: name})
+	*newSet.Spec.Replicas = 0
+	err = m.scaler.Scale(view, oldSet, newSet)
+	if status := tc.Status.TiDBGroups[name]; status != nil {
+		status.TiDBStatus = view.Status.TiDB
+	}
+	tc.Status.Operations = view.Status.Operations
+	if err != nil {
+		return err
+	}
+	return mngerutils.UpdateStatefulSetWithPrecheck(m.deps, view, "FailedUpdateTiDBSTS", newSet, oldSet)
+}
+
+// deleteGroup deletes the StatefulSet, Services and ConfigMaps of a removed TiDB group.
+func (m *tidbGroupMemberManager) deleteGroup(tc *v1alpha1.TidbCluster, name string) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	set, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiDBGroupMemberName(tcName, name))
+	if err == nil {
+		err = m.deps.StatefulSetControl.DeleteStatefulSet(tc, set, metav1.DeleteOptions{})
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	for _, svcName := range []string{controller.TiDBGroupMemberName(tcName, name), controller.TiDBGroupPeerMemberName(tcName, name)} {
+		svc, err := m.deps.ServiceLister.Services(ns).Get(svcName)
+		if err == nil {
+			err = m.deps.ServiceControl.DeleteService(tc, svc)
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	selector, err := label.New().Instance(tc.GetInstanceName()).TiDB().TiDBGroup(name).Selector()
+	if err != nil {
+		return err
+	}
+	cms, err := m.deps.ConfigMapLister.ConfigMaps(ns).List(selector)
+	if err != nil {
+		return err
+	}
+	for _, cm := range cms {
+		if err := m.deps.ConfigMapControl.DeleteConfigMap(tc, cm); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+type FakeTiDBGroupMemberManager struct {
+	err error
+}
+
+func NewFakeTiDBGroupMemberManager() *FakeTiDBGroupMemberManager {
+	return &FakeTiDBGroupMemberManager{}
+}
+
+func (m *FakeTiDBGroupMemberManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeTiDBGroupMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
+	return m.err
+}
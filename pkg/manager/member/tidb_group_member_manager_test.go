@@ -0,0 +1,93 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	apps "k8s.io/api/apps/v1"
+)
+
+func newFakeTiDBGroupMemberManager() *tidbGroupMemberManager {
+	tmm, _, _, _ := newFakeTiDBMemberManager()
+	return &tidbGroupMemberManager{
+		deps:              tmm.deps,
+		scaler:            tmm.scaler,
+		tidbMemberManager: tmm,
+	}
+}
+
+func TestTiDBGroupMemberManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"tikv-0": {PodName: "tikv-0", State: v1alpha1.TiKVStateUp},
+	}
+	tc.Status.TiKV.StatefulSet = &apps.StatefulSetStatus{ReadyReplicas: 1}
+	tc.Spec.TiDBGroups = []*v1alpha1.TiDBGroupSpec{
+		{Name: "olap", TiDBSpec: *tc.Spec.TiDB.DeepCopy()},
+	}
+	tc.Spec.TiDBGroups[0].Replicas = 2
+	oldSpec := tc.Spec.DeepCopy()
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	gmm := newFakeTiDBGroupMemberManager()
+	err := gmm.Sync(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(&tc.Spec).To(Equal(oldSpec))
+	g.Expect(tc.Annotations).NotTo(HaveKey(label.AnnTiDBGroupKey))
+
+	set, err := gmm.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiDBGroupMemberName(tcName, "olap"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*set.Spec.Replicas).To(Equal(int32(2)))
+	g.Expect(set.Spec.ServiceName).To(Equal(controller.TiDBGroupPeerMemberName(tcName, "olap")))
+	g.Expect(set.Spec.Template.Labels).To(HaveKeyWithValue(label.TiDBGroupLabelKey, "olap"))
+	g.Expect(set.Spec.Selector.MatchLabels).To(HaveKeyWithValue(label.TiDBGroupLabelKey, "olap"))
+
+	_, err = gmm.deps.ServiceLister.Services(ns).Get(controller.TiDBGroupPeerMemberName(tcName, "olap"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// the default TiDB is synced by tidbMemberManager
+	_, err = gmm.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiDBMemberName(tcName))
+	expectErrIsNotFound(g, err)
+
+	g.Expect(tc.Status.TiDBGroups).To(HaveKey("olap"))
+	g.Expect(tc.Status.TiDBGroups["olap"].Name).To(Equal("olap"))
+	g.Expect(tc.Status.TiDBGroups["olap"].StatefulSet).NotTo(BeNil())
+	g.Expect(tc.Status.TiDB.StatefulSet).To(BeNil())
+
+	// a removed group is deleted with its status dropped once it is scaled in
+	set = set.DeepCopy()
+	*set.Spec.Replicas = 0
+	g.Expect(gmm.deps.KubeInformerFactory.Apps().V1().StatefulSets().Informer().GetIndexer().Update(set)).To(Succeed())
+	tc.Spec.TiDBGroups = nil
+	err = gmm.Sync(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Status.TiDBGroups).NotTo(HaveKey("olap"))
+
+	// the status of a removed group is dropped when its StatefulSet is gone
+	tc = newTidbClusterForTiDB()
+	tc.Status.TiDBGroups = map[string]*v1alpha1.TiDBGroupStatus{
+		"oltp": {Name: "oltp"},
+	}
+	err = gmm.Sync(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Status.TiDBGroups).NotTo(HaveKey("oltp"))
+}
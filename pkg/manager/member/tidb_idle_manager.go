@@ -0,0 +1,165 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	tidbIdleEvent   = "TiDBIdle"
+	tidbWakeUpEvent = "TiDBWakeUp"
+	tidbAwakeEvent  = "TiDBAwake"
+)
+
+// tidbIdleManager moves TiDB between the phases of the idle policy:
+//   - Active -> Idle: TiDB has no client connections for the timeout of the policy
+//   - Idle -> Waking: TiDB is requested to wake up by the annotation `tidb.pingcap.com/tidb-wake-up`
+//   - Waking -> Active: all the TiDB members are ready
+//
+// TiDB is scaled to zero by the TiDB member manager in the Idle phase, and the TiDB service is
+// pointed to the activator in the discovery service when it is not Active if wake-on-connect is enabled.
+type tidbIdleManager struct {
+	deps *controller.Dependencies
+}
+
+// NewTiDBIdleManager returns a *tidbIdleManager
+func NewTiDBIdleManager(deps *controller.Dependencies) manager.Manager {
+	return &tidbIdleManager{
+		deps: deps,
+	}
+}
+
+func (m *tidbIdleManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.TiDB == nil || tc.Spec.TiDB.Idle == nil {
+		tc.Status.TiDB.Idle = nil
+		return nil
+	}
+
+	now := metav1.Now()
+	status := tc.Status.TiDB.Idle
+	if status == nil {
+		status = &v1alpha1.TiDBIdleStatus{
+			Phase:              v1alpha1.TiDBIdlePhaseActive,
+			LastActiveTime:     now,
+			LastTransitionTime: now,
+		}
+		tc.Status.TiDB.Idle = status
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	switch status.Phase {
+	case v1alpha1.TiDBIdlePhaseIdle:
+		if !wakeUpRequested(tc, status.LastTransitionTime.Time) {
+			return nil
+		}
+		klog.Infof("TidbCluster: [%s/%s], TiDB is requested to wake up", ns, tcName)
+		m.deps.Recorder.Event(tc, corev1.EventTypeNormal, tidbWakeUpEvent, "TiDB is requested to wake up, scale it back up")
+		status.Phase = v1alpha1.TiDBIdlePhaseWaking
+		status.LastActiveTime = now
+		status.LastTransitionTime = now
+	case v1alpha1.TiDBIdlePhaseWaking:
+		if !tc.TiDBAllMembersReady() {
+			return nil
+		}
+		m.deps.Recorder.Event(tc, corev1.EventTypeNormal, tidbAwakeEvent, "TiDB is ready after waking up")
+		status.Phase = v1alpha1.TiDBIdlePhaseActive
+		status.LastActiveTime = now
+		status.LastTransitionTime = now
+	default:
+		connections, err := m.connections(tc)
+		if err != nil {
+			// do not scale TiDB to zero if it is unknown whether there are client connections, and the
+			// idle timeout restarts once the connections of all the members are known
+			klog.Warningf("TidbCluster: [%s/%s], get connections of TiDB failed, err: %v", ns, tcName, err)
+			status.LastActiveTime = now
+			return nil
+		}
+		if connections > 0 {
+			status.LastActiveTime = now
+			return nil
+		}
+		timeout := tc.Spec.TiDB.Idle.GetTimeout()
+		if now.Sub(status.LastActiveTime.Time) < timeout {
+			return nil
+		}
+		klog.Infof("TidbCluster: [%s/%s], TiDB has no client connections for %s, scale it to zero", ns, tcName, timeout)
+		m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, tidbIdleEvent, "TiDB has no client connections for %s, scale it to zero", timeout)
+		status.Phase = v1alpha1.TiDBIdlePhaseIdle
+		status.LastTransitionTime = now
+	}
+	return nil
+}
+
+// connections returns the number of the client connections of all the TiDB members, the connections are
+// unknown unless all the desired members are healthy and report their connections
+func (m *tidbIdleManager) connections(tc *v1alpha1.TidbCluster) (int, error) {
+	if len(tc.Status.TiDB.Members) == 0 || !tc.TiDBAllMembersReady() {
+		return 0, fmt.Errorf("not all the %d TiDB members are healthy", tc.TiDBStsDesiredReplicas())
+	}
+	connections := 0
+	for name := range tc.Status.TiDB.Members {
+		ordinal, err := parserOrdinal(name)
+		if err != nil {
+			return 0, err
+		}
+		status, err := m.deps.TiDBControl.GetStatus(tc, ordinal)
+		if err != nil {
+			return 0, fmt.Errorf("get status of %s failed, err: %v", name, err)
+		}
+		connections += status.Connections
+	}
+	return connections, nil
+}
+
+// wakeUpRequested returns whether TiDB is requested to wake up after the given time
+func wakeUpRequested(tc *v1alpha1.TidbCluster, since time.Time) bool {
+	v, ok := tc.GetAnnotations()[label.AnnTiDBWakeUp]
+	if !ok {
+		return false
+	}
+	wakeUpTime, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		klog.Warningf("TidbCluster: [%s/%s], invalid annotation %s: %q, err: %v", tc.GetNamespace(), tc.GetName(), label.AnnTiDBWakeUp, v, err)
+		return false
+	}
+	// the time in the annotation is truncated to seconds
+	return !wakeUpTime.Before(since.Truncate(time.Second))
+}
+
+type FakeTiDBIdleManager struct {
+	err error
+}
+
+func NewFakeTiDBIdleManager() *FakeTiDBIdleManager {
+	return &FakeTiDBIdleManager{}
+}
+
+func (m *FakeTiDBIdleManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeTiDBIdleManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
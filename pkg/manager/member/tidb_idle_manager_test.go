@@ -0,0 +1,137 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestTiDBIdleManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	tc.Spec.TiDB.Replicas = 1
+	tc.Spec.TiDB.Idle = &v1alpha1.TiDBIdlePolicy{Timeout: &metav1.Duration{Duration: time.Minute}}
+	tc.Status.TiDB.Members = map[string]v1alpha1.TiDBMember{
+		"test-tidb-0": {Name: "test-tidb-0", Health: true},
+	}
+
+	deps := controller.NewFakeDependencies()
+	tidbControl := deps.TiDBControl.(*controller.FakeTiDBControl)
+	m := NewTiDBIdleManager(deps)
+
+	// the status is initialized
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.TiDB.Idle).NotTo(BeNil())
+	g.Expect(tc.TiDBIdlePhase()).To(Equal(v1alpha1.TiDBIdlePhaseActive))
+
+	// TiDB stays active if the connections are unknown
+	tc.Status.TiDB.Idle.LastActiveTime = metav1.NewTime(time.Now().Add(-time.Hour))
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.TiDBIdlePhase()).To(Equal(v1alpha1.TiDBIdlePhaseActive))
+	g.Expect(time.Since(tc.Status.TiDB.Idle.LastActiveTime.Time)).To(BeNumerically("<", time.Minute))
+
+	// TiDB stays active if not all the members are healthy and report their connections
+	tidbControl.SetStatus(0, &controller.DBStatus{Connections: 0})
+	for _, members := range []map[string]v1alpha1.TiDBMember{
+		nil,
+		{"test-tidb-0": {Name: "test-tidb-0", Health: false}},
+	} {
+		tc.Status.TiDB.Members = members
+		tc.Status.TiDB.Idle.LastActiveTime = metav1.NewTime(time.Now().Add(-time.Hour))
+		g.Expect(m.Sync(tc)).To(Succeed())
+		g.Expect(tc.TiDBIdlePhase()).To(Equal(v1alpha1.TiDBIdlePhaseActive))
+		g.Expect(time.Since(tc.Status.TiDB.Idle.LastActiveTime.Time)).To(BeNumerically("<", time.Minute))
+	}
+	tc.Status.TiDB.Members = map[string]v1alpha1.TiDBMember{
+		"test-tidb-0": {Name: "test-tidb-0", Health: true},
+	}
+
+	// TiDB stays active if there are client connections
+	tidbControl.SetStatus(0, &controller.DBStatus{Connections: 2})
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.TiDBIdlePhase()).To(Equal(v1alpha1.TiDBIdlePhaseActive))
+	g.Expect(time.Since(tc.Status.TiDB.Idle.LastActiveTime.Time)).To(BeNumerically("<", time.Minute))
+
+	// TiDB is idle after no client connections for the timeout
+	tidbControl.SetStatus(0, &controller.DBStatus{Connections: 0})
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.TiDBIdlePhase()).To(Equal(v1alpha1.TiDBIdlePhaseActive))
+	tc.Status.TiDB.Idle.LastActiveTime = metav1.NewTime(time.Now().Add(-time.Hour))
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.TiDBIdlePhase()).To(Equal(v1alpha1.TiDBIdlePhaseIdle))
+	g.Expect(tc.TiDBIdle()).To(BeTrue())
+	g.Expect(tc.TiDBStsDesiredReplicas()).To(Equal(int32(0)))
+	g.Expect(tc.TiDBWakeOnConnect()).To(BeTrue())
+
+	// the wake-up request before TiDB is idle is ignored
+	tc.Status.TiDB.Members = nil
+	tc.Annotations = map[string]string{label.AnnTiDBWakeUp: time.Now().Add(-time.Hour).Format(time.RFC3339)}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.TiDBIdlePhase()).To(Equal(v1alpha1.TiDBIdlePhaseIdle))
+
+	// TiDB is waking after it is requested to wake up
+	tc.Annotations[label.AnnTiDBWakeUp] = time.Now().Format(time.RFC3339)
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.TiDBIdlePhase()).To(Equal(v1alpha1.TiDBIdlePhaseWaking))
+	g.Expect(tc.TiDBStsDesiredReplicas()).To(Equal(int32(1)))
+	g.Expect(tc.TiDBWakeOnConnect()).To(BeTrue())
+
+	// TiDB is active after all the members are ready
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.TiDBIdlePhase()).To(Equal(v1alpha1.TiDBIdlePhaseWaking))
+	tc.Status.TiDB.Members = map[string]v1alpha1.TiDBMember{
+		"test-tidb-0": {Name: "test-tidb-0", Health: true},
+	}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.TiDBIdlePhase()).To(Equal(v1alpha1.TiDBIdlePhaseActive))
+	g.Expect(tc.TiDBWakeOnConnect()).To(BeFalse())
+
+	// the status is cleared after the policy is removed
+	tc.Spec.TiDB.Idle = nil
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.TiDB.Idle).To(BeNil())
+}
+
+func TestGetNewTiDBServiceForIdleTiDB(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	tc.Spec.TiDB.Service = &v1alpha1.TiDBServiceSpec{}
+	tc.Spec.TiDB.Idle = &v1alpha1.TiDBIdlePolicy{}
+
+	// the connections are routed to TiDB when it is active
+	svc := getNewTiDBServiceOrNil(tc, false)
+	g.Expect(svc.Spec.Selector).To(HaveKeyWithValue(label.ComponentLabelKey, label.TiDBLabelVal))
+	g.Expect(svc.Spec.Ports[0].TargetPort).To(Equal(intstr.FromInt(int(v1alpha1.DefaultTiDBServerPort))))
+
+	// the connections are routed to the activator when TiDB is idle
+	tc.Status.TiDB.Idle = &v1alpha1.TiDBIdleStatus{Phase: v1alpha1.TiDBIdlePhaseIdle}
+	svc = getNewTiDBServiceOrNil(tc, false)
+	g.Expect(svc.Spec.Selector).To(HaveKeyWithValue(label.ComponentLabelKey, label.DiscoveryLabelVal))
+	g.Expect(svc.Spec.Ports[0].TargetPort).To(Equal(intstr.FromInt(DiscoveryActivatorPort)))
+
+	// the connections are routed to TiDB if wake-on-connect is disabled
+	tc.Spec.TiDB.Idle.WakeOnConnect = new(bool)
+	svc = getNewTiDBServiceOrNil(tc, false)
+	g.Expect(svc.Spec.Selector).To(HaveKeyWithValue(label.ComponentLabelKey, label.TiDBLabelVal))
+}
@@ -159,7 +159,7 @@ func (m *tidbMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 		}
 	}
 
-	if tc.NeedToSyncTiDBInitializer() {
+	if tc.TiDBGroupName() == "" && tc.NeedToSyncTiDBInitializer() {
 		m.syncInitializer(tc)
 	}
 
@@ -209,8 +209,12 @@ func (m *tidbMemberManager) syncTiDBHeadlessServiceForTidbCluster(tc *v1alpha1.T
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
-	newSvc := getNewTiDBHeadlessServiceForTidbCluster(tc)
-	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(controller.TiDBPeerMemberName(tcName))
+	defaultGroupOnly, err := m.tidbServiceSelectsDefaultGroupOnly(tc)
+	if err != nil {
+		return err
+	}
+	newSvc := getNewTiDBHeadlessServiceForTidbCluster(tc, defaultGroupOnly)
+	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(newSvc.Name)
 	if errors.IsNotFound(err) {
 		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
 		if err != nil {
@@ -219,7 +223,7 @@ func (m *tidbMemberManager) syncTiDBHeadlessServiceForTidbCluster(tc *v1alpha1.T
 		return m.deps.ServiceControl.CreateService(tc, newSvc)
 	}
 	if err != nil {
-		return fmt.Errorf("syncTiDBHeadlessServiceForTidbCluster: failed to get svc %s for cluster %s/%s, error: %s", controller.TiDBPeerMemberName(tcName), ns, tcName, err)
+		return fmt.Errorf("syncTiDBHeadlessServiceForTidbCluster: failed to get svc %s for cluster %s/%s, error: %s", newSvc.Name, ns, tcName, err)
 	}
 
 	oldSvc := oldSvcTmp.DeepCopy()
@@ -246,9 +250,10 @@ func (m *tidbMemberManager) syncTiDBStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
-	oldTiDBSetTemp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiDBMemberName(tcName))
+	setName := controller.TiDBGroupMemberName(tcName, tc.TiDBGroupName())
+	oldTiDBSetTemp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(setName)
 	if err != nil && !errors.IsNotFound(err) {
-		return fmt.Errorf("syncTiDBStatefulSetForTidbCluster: failed to get sts %s for cluster %s/%s, error: %s", controller.TiDBMemberName(tcName), ns, tcName, err)
+		return fmt.Errorf("syncTiDBStatefulSetForTidbCluster: failed to get sts %s for cluster %s/%s, error: %s", setName, ns, tcName, err)
 	}
 	setNotExist := errors.IsNotFound(err)
 
@@ -271,6 +276,12 @@ func (m *tidbMemberManager) syncTiDBStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 	if err != nil {
 		return err
 	}
+	setStartBinary(tc, m.deps.CLIConfig.TiDBDiscoveryImage, &newTiDBSet.Spec.Template.Spec)
+
+	// in the plan mode, the changes driven by the spec are held until the plan is approved
+	if proceed, err := syncChangePlan(m.deps, tc, v1alpha1.TiDBMemberType, tc.Spec.TiDB.Replicas, oldTiDBSet, newTiDBSet); err != nil || !proceed {
+		return err
+	}
 
 	if setNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newTiDBSet)
@@ -289,6 +300,8 @@ func (m *tidbMemberManager) syncTiDBStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 		return err
 	}
 
+	recordStatefulSetOperations(tc, v1alpha1.TiDBMemberType, oldTiDBSet, newTiDBSet)
+
 	// Scaling takes precedence over upgrading because:
 	// - if a pod fails in the upgrading, users may want to delete it or add
 	//   new replicas
@@ -447,7 +460,7 @@ func (m *tidbMemberManager) shouldRecover(tc *v1alpha1.TidbCluster) bool {
 	// Note that failover pods may fail (e.g. lack of resources) and we don't care
 	// about them because we're going to delete them.
 	for ordinal := range tc.TiDBStsDesiredOrdinals(true) {
-		name := fmt.Sprintf("%s-%d", controller.TiDBMemberName(tc.GetName()), ordinal)
+		name := tidbGroupPodName(tc.GetName(), tc.TiDBGroupName(), ordinal)
 		pod, err := m.deps.PodLister.Pods(tc.Namespace).Get(name)
 		if err != nil {
 			klog.Errorf("pod %s/%s does not exist: %v", tc.Namespace, name, err)
@@ -470,7 +483,11 @@ func (m *tidbMemberManager) syncTiDBService(tc *v1alpha1.TidbCluster) error {
 		return nil
 	}
 
-	newSvc := getNewTiDBServiceOrNil(tc)
+	defaultGroupOnly, err := m.tidbServiceSelectsDefaultGroupOnly(tc)
+	if err != nil {
+		return err
+	}
+	newSvc := getNewTiDBServiceOrNil(tc, defaultGroupOnly)
 	// TODO: delete tidb service if user remove the service spec deliberately
 	if newSvc == nil {
 		return nil
@@ -553,7 +570,7 @@ func (m *tidbMemberManager) syncTiDBConfigMap(tc *v1alpha1.TidbCluster, set *app
 	var inUseName string
 	if set != nil {
 		inUseName = mngerutils.FindConfigMapVolume(&set.Spec.Template.Spec, func(name string) bool {
-			return strings.HasPrefix(name, controller.TiDBMemberName(tc.Name))
+			return strings.HasPrefix(name, controller.TiDBGroupMemberName(tc.Name, tc.TiDBGroupName()))
 		})
 	} else {
 		inUseName, err = mngerutils.FindConfigMapNameFromTCAnno(context.Background(), m.deps.ConfigMapLister, tc, v1alpha1.TiDBMemberType, newCm)
@@ -568,6 +585,11 @@ func (m *tidbMemberManager) syncTiDBConfigMap(tc *v1alpha1.TidbCluster, set *app
 	if err != nil {
 		return nil, err
 	}
+
+	err = syncHotConfig(m.deps, tc, v1alpha1.TiDBMemberType, tc.BaseTiDBSpec().ConfigUpdateStrategy(), inUseName, newCm)
+	if err != nil {
+		return nil, err
+	}
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
@@ -603,6 +625,10 @@ func getTiDBConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 	if tc.Spec.TiDB.IsBootstrapSQLEnabled() {
 		config.Set("initialize-sql-file", path.Join(bootstrapSQLFilePath, bootstrapSQLFileName))
 	}
+	// TiDB sends the MPP tasks to TiFlash compute nodes only in the disaggregated mode
+	if tc.Spec.TiFlash != nil && tc.Spec.TiFlash.Disaggregated != nil {
+		config.SetIfNil("disaggregated-tiflash", true)
+	}
 
 	// `DefaultTiDBServerPort`/`DefaultTiDBStatusPort` may be changed when building the binary
 	if v1alpha1.DefaultTiDBServerPort != int32(4000) {
@@ -626,9 +652,9 @@ func getTiDBConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 		"config-file":    string(confText),
 		"startup-script": startScript,
 	}
-	name := controller.TiDBMemberName(tc.Name)
+	name := controller.TiDBGroupMemberName(tc.Name, tc.TiDBGroupName())
 	instanceName := tc.GetInstanceName()
-	tidbLabels := label.New().Instance(instanceName).TiDB().Labels()
+	tidbLabels := label.New().Instance(instanceName).TiDB().TiDBGroup(tc.TiDBGroupName()).Labels()
 
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -643,7 +669,38 @@ func getTiDBConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 	return cm, nil
 }
 
-func getNewTiDBServiceOrNil(tc *v1alpha1.TidbCluster) *corev1.Service {
+// tidbServiceSelectsDefaultGroupOnly returns whether the services of the default TiDB select only the
+// pods of the default TiDB by the default group label. It is true when the cluster has TiDB groups and
+// all the pods of the default TiDB have been rolled with the label, so that the pods of the default TiDB
+// are not dropped from the services while the label is being added.
+func (m *tidbMemberManager) tidbServiceSelectsDefaultGroupOnly(tc *v1alpha1.TidbCluster) (bool, error) {
+	if tc.TiDBGroupName() != "" || len(tc.Spec.TiDBGroups) == 0 {
+		return false, nil
+	}
+	selector, err := label.New().Instance(tc.GetInstanceName()).TiDB().Selector()
+	if err != nil {
+		return false, err
+	}
+	groupRequirement, err := label.TiDBGroupRequirement("")
+	if err != nil {
+		return false, err
+	}
+	selector = selector.Add(*groupRequirement)
+	pods, err := m.deps.PodLister.Pods(tc.GetNamespace()).List(selector)
+	if err != nil {
+		return false, fmt.Errorf("tidbServiceSelectsDefaultGroupOnly: failed to list pods for cluster %s/%s, selector %s, error: %s", tc.GetNamespace(), tc.GetName(), selector, err)
+	}
+	for _, pod := range pods {
+		if pod.Labels[label.TiDBDefaultGroupLabelKey] != "true" {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// getNewTiDBServiceOrNil returns the service of the default TiDB or the TiDB group that tc stands for,
+// the service only selects the pods of the default TiDB by the default group label if defaultGroupOnly is true.
+func getNewTiDBServiceOrNil(tc *v1alpha1.TidbCluster, defaultGroupOnly bool) *corev1.Service {
 
 	svcSpec := tc.Spec.TiDB.Service
 	if svcSpec == nil {
@@ -653,8 +710,8 @@ func getNewTiDBServiceOrNil(tc *v1alpha1.TidbCluster) *corev1.Service {
 	ns := tc.Namespace
 	tcName := tc.Name
 	instanceName := tc.GetInstanceName()
-	tidbSelector := label.New().Instance(instanceName).TiDB()
-	svcName := controller.TiDBMemberName(tcName)
+	tidbSelector := label.New().Instance(instanceName).TiDB().TiDBGroup(tc.TiDBGroupName())
+	svcName := controller.TiDBGroupMemberName(tcName, tc.TiDBGroupName())
 	tidbLabels := util.CombineStringMap(tidbSelector.Copy().UsedByEndUser().Labels(), svcSpec.Labels)
 	ports := []corev1.ServicePort{
 		{
@@ -666,6 +723,16 @@ func getNewTiDBServiceOrNil(tc *v1alpha1.TidbCluster) *corev1.Service {
 		},
 	}
 	ports = append(ports, tc.Spec.TiDB.Service.AdditionalPorts...)
+	selector := tidbSelector.Labels()
+	if defaultGroupOnly {
+		selector[label.TiDBDefaultGroupLabelKey] = "true"
+	}
+	if tc.TiDBWakeOnConnect() {
+		// route the client connections to the activator in the discovery service
+		// to wake up TiDB, the activator forwards them after TiDB is ready
+		selector = label.New().Instance(instanceName).Discovery().Labels()
+		ports[0].TargetPort = intstr.FromInt(DiscoveryActivatorPort)
+	}
 	if svcSpec.ShouldExposeStatus() {
 		ports = append(ports, corev1.ServicePort{
 			Name:       "status",
@@ -687,7 +754,7 @@ func getNewTiDBServiceOrNil(tc *v1alpha1.TidbCluster) *corev1.Service {
 		Spec: corev1.ServiceSpec{
 			Type:     svcSpec.Type,
 			Ports:    ports,
-			Selector: tidbSelector.Labels(),
+			Selector: selector,
 		},
 	}
 	if svcSpec.Type == corev1.ServiceTypeLoadBalancer {
@@ -714,13 +781,16 @@ func getNewTiDBServiceOrNil(tc *v1alpha1.TidbCluster) *corev1.Service {
 	return tidbSvc
 }
 
-func getNewTiDBHeadlessServiceForTidbCluster(tc *v1alpha1.TidbCluster) *corev1.Service {
+func getNewTiDBHeadlessServiceForTidbCluster(tc *v1alpha1.TidbCluster, defaultGroupOnly bool) *corev1.Service {
 	ns := tc.Namespace
 	tcName := tc.Name
 	instanceName := tc.GetInstanceName()
-	svcName := controller.TiDBPeerMemberName(tcName)
-	tidbSelector := label.New().Instance(instanceName).TiDB()
+	svcName := controller.TiDBGroupPeerMemberName(tcName, tc.TiDBGroupName())
+	tidbSelector := label.New().Instance(instanceName).TiDB().TiDBGroup(tc.TiDBGroupName())
 	tidbLabel := tidbSelector.Copy().UsedByPeer().Labels()
+	if defaultGroupOnly {
+		tidbSelector = tidbSelector.TiDBDefaultGroup()
+	}
 
 	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -753,8 +823,8 @@ func getNewTiDBHeadlessServiceForTidbCluster(tc *v1alpha1.TidbCluster) *corev1.S
 func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.StatefulSet, error) {
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
-	setName := controller.TiDBMemberName(tcName)
-	headlessSvcName := controller.TiDBPeerMemberName(tcName)
+	setName := controller.TiDBGroupMemberName(tcName, tc.TiDBGroupName())
+	headlessSvcName := controller.TiDBGroupPeerMemberName(tcName, tc.TiDBGroupName())
 	baseTiDBSpec := tc.BaseTiDBSpec()
 	instanceName := tc.GetInstanceName()
 	tidbConfigMap := controller.MemberConfigMapName(tc, v1alpha1.TiDBMemberType)
@@ -988,7 +1058,7 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 	c := corev1.Container{
 		Name:            v1alpha1.TiDBMemberType.String(),
 		Image:           tc.TiDBImage(),
-		Command:         []string{"/bin/sh", "/usr/local/bin/tidb_start_script.sh"},
+		Command:         startScriptCommand(tc, "/usr/local/bin/tidb_start_script.sh"),
 		ImagePullPolicy: baseTiDBSpec.ImagePullPolicy(),
 		Ports: []corev1.ContainerPort{
 			{
@@ -1088,8 +1158,12 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 		podSpec.ServiceAccountName = tc.Spec.ServiceAccount
 	}
 
-	stsLabels := label.New().Instance(instanceName).TiDB()
+	stsLabels := label.New().Instance(instanceName).TiDB().TiDBGroup(tc.TiDBGroupName())
 	podLabels := util.CombineStringMap(stsLabels, baseTiDBSpec.Labels())
+	if tc.TiDBGroupName() == "" && len(tc.Spec.TiDBGroups) > 0 {
+		// the services of the default TiDB select the pods by this label to exclude the pods of TiDB groups
+		podLabels[label.TiDBDefaultGroupLabelKey] = "true"
+	}
 	podAnnotations := util.CombineStringMap(baseTiDBSpec.Annotations(), controller.AnnProm(v1alpha1.DefaultTiDBStatusPort, "/metrics"))
 	stsAnnotations := getStsAnnotations(tc.Annotations, label.TiDBLabelVal)
 
@@ -1128,7 +1202,7 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 				},
 				Spec: podSpec,
 			},
-			ServiceName:         controller.TiDBPeerMemberName(tcName),
+			ServiceName:         headlessSvcName,
 			PodManagementPolicy: baseTiDBSpec.PodManagementPolicy(),
 			UpdateStrategy:      updateStrategy,
 		},
@@ -1162,7 +1236,7 @@ func (m *tidbMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 
 	tidbStatus := map[string]v1alpha1.TiDBMember{}
 	for id := range helper.GetPodOrdinals(tc.Status.TiDB.StatefulSet.Replicas, set) {
-		name := fmt.Sprintf("%s-%d", controller.TiDBMemberName(tc.GetName()), id)
+		name := tidbGroupPodName(tc.GetName(), tc.TiDBGroupName(), id)
 		health, err := m.deps.TiDBControl.GetHealth(tc, int32(id))
 		if err != nil {
 			return err
@@ -1313,6 +1387,11 @@ func tidbStatefulSetIsUpgrading(podLister corelisters.PodLister, set *apps.State
 	if err != nil {
 		return false, err
 	}
+	groupRequirement, err := label.TiDBGroupRequirement(tc.TiDBGroupName())
+	if err != nil {
+		return false, err
+	}
+	selector = selector.Add(*groupRequirement)
 	tidbPods, err := podLister.Pods(tc.GetNamespace()).List(selector)
 	if err != nil {
 		return false, fmt.Errorf("tidbStatefulSetIsUpgrading: failed to get pods for cluster %s/%s, selector %s, error: %s", tc.GetNamespace(), tc.GetInstanceName(), selector, err)
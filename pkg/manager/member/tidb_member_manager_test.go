@@ -676,6 +676,56 @@ func TestTiDBMemberManagerSyncTidbService(t *testing.T) {
 				g.Expect(svc.Annotations).NotTo(HaveKey("k"), "Expected updating service will reconcile annotations")
 			},
 		},
+		{
+			name: "Select the pods of TiDB groups until the pods of the default TiDB are labeled",
+			prepare: func(tc *v1alpha1.TidbCluster, indexers *fakeIndexers) {
+				tc.Spec.TiDB.Service = &v1alpha1.TiDBServiceSpec{}
+				tc.Spec.TiDBGroups = []*v1alpha1.TiDBGroupSpec{{Name: "olap"}}
+				_ = indexers.pod.Add(&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      controller.TiDBMemberName(tc.Name) + "-0",
+						Namespace: tc.Namespace,
+						Labels:    label.New().Instance(tc.GetInstanceName()).TiDB().Labels(),
+					},
+				})
+				_ = indexers.pod.Add(&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      controller.TiDBMemberName(tc.Name) + "-1",
+						Namespace: tc.Namespace,
+						Labels:    label.New().Instance(tc.GetInstanceName()).TiDB().TiDBDefaultGroup().Labels(),
+					},
+				})
+			},
+			expectFn: func(g *GomegaWithT, err error, svc *corev1.Service) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(svc.Spec.Selector).NotTo(HaveKey(label.TiDBDefaultGroupLabelKey))
+			},
+		},
+		{
+			name: "Exclude the pods of TiDB groups",
+			prepare: func(tc *v1alpha1.TidbCluster, indexers *fakeIndexers) {
+				tc.Spec.TiDB.Service = &v1alpha1.TiDBServiceSpec{}
+				tc.Spec.TiDBGroups = []*v1alpha1.TiDBGroupSpec{{Name: "olap"}}
+				_ = indexers.pod.Add(&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      controller.TiDBMemberName(tc.Name) + "-0",
+						Namespace: tc.Namespace,
+						Labels:    label.New().Instance(tc.GetInstanceName()).TiDB().TiDBDefaultGroup().Labels(),
+					},
+				})
+				_ = indexers.pod.Add(&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      controller.TiDBGroupMemberName(tc.Name, "olap") + "-0",
+						Namespace: tc.Namespace,
+						Labels:    label.New().Instance(tc.GetInstanceName()).TiDB().TiDBGroup("olap").Labels(),
+					},
+				})
+			},
+			expectFn: func(g *GomegaWithT, err error, svc *corev1.Service) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(svc.Spec.Selector).To(HaveKeyWithValue(label.TiDBDefaultGroupLabelKey, "true"))
+			},
+		},
 		{
 			name:            "Do not create TiDB service when the spec is absent",
 			expectSvcAbsent: true,
@@ -939,7 +989,7 @@ func TestGetNewTiDBHeadlessServiceForTidbCluster(t *testing.T) {
 	for i := range tests {
 		tt := tests[i]
 		t.Run(tt.name, func(t *testing.T) {
-			svc := getNewTiDBHeadlessServiceForTidbCluster(&tt.tc)
+			svc := getNewTiDBHeadlessServiceForTidbCluster(&tt.tc, false)
 			if diff := cmp.Diff(tt.expected, *svc); diff != "" {
 				t.Errorf("unexpected plugin configuration (-want, +got): %s", diff)
 			}
@@ -972,6 +1022,26 @@ func TestGetNewTiDBSetForTidbCluster(t *testing.T) {
 			},
 			testSts: testHostNetwork(t, false, ""),
 		},
+		{
+			name: "tidb pods of the default TiDB are labeled when the cluster has TiDB groups",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tc",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					TiDB:       &v1alpha1.TiDBSpec{},
+					PD:         &v1alpha1.PDSpec{},
+					TiKV:       &v1alpha1.TiKVSpec{},
+					TiDBGroups: []*v1alpha1.TiDBGroupSpec{{Name: "olap"}},
+				},
+			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Labels).To(HaveKeyWithValue(label.TiDBDefaultGroupLabelKey, "true"))
+				g.Expect(sts.Spec.Selector.MatchLabels).NotTo(HaveKey(label.TiDBDefaultGroupLabelKey))
+			},
+		},
 		{
 			name: "tidb network is host",
 			tc: v1alpha1.TidbCluster{
@@ -2049,7 +2119,7 @@ func TestGetNewTiDBService(t *testing.T) {
 	for i := range testCases {
 		tt := testCases[i]
 		t.Run(tt.name, func(t *testing.T) {
-			svc := getNewTiDBServiceOrNil(&tt.tc)
+			svc := getNewTiDBServiceOrNil(&tt.tc, false)
 			if tt.expected == nil {
 				g.Expect(svc).To(BeNil())
 				return
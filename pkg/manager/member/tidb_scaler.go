@@ -89,8 +89,8 @@ func (s *tidbScaler) scaleOutOne(tc *v1alpha1.TidbCluster, ordinal int32) error
 	skipReason, err := s.deleteDeferDeletingPVC(tc, v1alpha1.TiDBMemberType, ordinal)
 	if err != nil {
 		return err
-	} else if len(skipReason) != 1 || skipReason[ordinalPodName(
-		v1alpha1.TiDBMemberType, tc.GetName(), ordinal)] != skipReasonScalerPVCNotFound {
+	} else if len(skipReason) != 1 || skipReason[tidbGroupPodName(
+		tc.GetName(), tc.TiDBGroupName(), ordinal)] != skipReasonScalerPVCNotFound {
 		// wait for all PVCs to be deleted
 		return controller.RequeueErrorf(
 			"tidbScaler.ScaleOut, cluster %s/%s ready to scale out, skip reason %v, wait for next round",
@@ -142,7 +142,7 @@ func (s *tidbScaler) scaleInOne(tc *v1alpha1.TidbCluster, ordinal int32, scaleIn
 	tcName := tc.GetName()
 
 	// We need to remove member from cluster before reducing statefulset replicas
-	podName := ordinalPodName(v1alpha1.TiDBMemberType, tcName, ordinal)
+	podName := tidbGroupPodName(tcName, tc.TiDBGroupName(), ordinal)
 	pod, err := s.deps.PodLister.Pods(ns).Get(podName)
 	if err != nil {
 		return fmt.Errorf("tidbScaler.ScaleIn: failed to get pods %s for cluster %s/%s, error: %s", podName, ns, tcName, err)
@@ -0,0 +1,288 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/manager/suspender"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// keyspaceStateOrder is the order of keyspace states, a keyspace can only be
+// moved forward except that a DISABLED keyspace can be enabled again
+var keyspaceStateOrder = map[pdapi.KeyspaceState]int{
+	pdapi.KeyspaceStateEnabled:   0,
+	pdapi.KeyspaceStateDisabled:  1,
+	pdapi.KeyspaceStateArchived:  2,
+	pdapi.KeyspaceStateTombstone: 3,
+}
+
+type tidbTenantMemberManager struct {
+	deps   *controller.Dependencies
+	scaler Scaler
+	// tidbMemberManager syncs the TiDB servers of a tenant through the view built by TidbTenant.TidbClusterView
+	tidbMemberManager *tidbMemberManager
+}
+
+// NewTiDBTenantMemberManager returns a *tidbTenantMemberManager
+func NewTiDBTenantMemberManager(deps *controller.Dependencies, scaler Scaler, tidbUpgrader Upgrader, tidbFailover Failover, spder suspender.Suspender, pvm volumes.PodVolumeModifier) manager.TiDBTenantManager {
+	return &tidbTenantMemberManager{
+		deps:   deps,
+		scaler: scaler,
+		tidbMemberManager: &tidbMemberManager{
+			deps:                         deps,
+			scaler:                       scaler,
+			tidbUpgrader:                 tidbUpgrader,
+			tidbFailover:                 tidbFailover,
+			suspender:                    spder,
+			podVolumeModifier:            pvm,
+			tidbStatefulSetIsUpgradingFn: tidbStatefulSetIsUpgrading,
+		},
+	}
+}
+
+// Sync creates or enables the keyspace of the tenant and syncs its TiDB servers,
+// an archived tenant has its TiDB servers scaled in before the keyspace is archived.
+func (m *tidbTenantMemberManager) Sync(tt *v1alpha1.TidbTenant, tc *v1alpha1.TidbCluster) error {
+	ns := tt.GetNamespace()
+	name := tt.GetName()
+	keyspace := tt.GetKeyspaceName()
+
+	if tt.Status.KeyspaceName != "" && tt.Status.KeyspaceName != keyspace {
+		return fmt.Errorf("TidbTenant: [%s/%s], keyspace can not be changed from %s to %s", ns, name, tt.Status.KeyspaceName, keyspace)
+	}
+	if tc.Spec.TiKV == nil {
+		return fmt.Errorf("TidbTenant: [%s/%s], TidbCluster %s/%s has no TiKV cluster", ns, name, tc.GetNamespace(), tc.GetName())
+	}
+	if tc.TiDBGroup(tt.TiDBGroupName()) != nil {
+		return fmt.Errorf("TidbTenant: [%s/%s], TiDB group %s already exists in TidbCluster %s/%s", ns, name, tt.TiDBGroupName(), tc.GetNamespace(), tc.GetName())
+	}
+	if !tc.PDIsAvailable() {
+		return controller.RequeueErrorf("TidbTenant: [%s/%s], waiting for PD cluster running", ns, name)
+	}
+
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	meta, err := pdClient.GetKeyspace(keyspace)
+	if err != nil {
+		return fmt.Errorf("TidbTenant: [%s/%s], failed to get keyspace %s: %v", ns, name, keyspace, err)
+	}
+
+	if tt.Spec.Archived {
+		if meta == nil {
+			// nothing to archive as the keyspace has never been created
+			return nil
+		}
+		m.setKeyspaceStatus(tt, meta)
+		if keyspaceStateOrder[meta.State] >= keyspaceStateOrder[pdapi.KeyspaceStateArchived] {
+			return nil
+		}
+		if err := m.scaleInTiDB(tt, tc); err != nil {
+			return err
+		}
+		return m.transitKeyspace(tt, pdClient, meta, pdapi.KeyspaceStateArchived)
+	}
+
+	if meta == nil {
+		meta, err = pdClient.CreateKeyspace(keyspace, tt.Spec.KeyspaceConfig)
+		if err != nil {
+			return fmt.Errorf("TidbTenant: [%s/%s], failed to create keyspace %s: %v", ns, name, keyspace, err)
+		}
+		klog.Infof("TidbTenant: [%s/%s], keyspace %s is created with id %d", ns, name, keyspace, meta.ID)
+	}
+	m.setKeyspaceStatus(tt, meta)
+	if err := m.transitKeyspace(tt, pdClient, meta, pdapi.KeyspaceStateEnabled); err != nil {
+		return err
+	}
+
+	view := tt.TidbClusterView(tc)
+	err = m.tidbMemberManager.Sync(view)
+	tt.Status.TiDB = view.Status.TiDB
+	return err
+}
+
+// Reclaim scales in and removes the TiDB servers of the tenant, then reclaims
+// the keyspace according to the reclaim policy of the tenant.
+func (m *tidbTenantMemberManager) Reclaim(tt *v1alpha1.TidbTenant, tc *v1alpha1.TidbCluster) error {
+	if err := m.scaleInTiDB(tt, tc); err != nil {
+		return err
+	}
+	if err := m.deleteTiDB(tt, tc); err != nil {
+		return err
+	}
+
+	var target pdapi.KeyspaceState
+	switch tt.GetKeyspaceReclaimPolicy() {
+	case v1alpha1.KeyspaceReclaimArchive:
+		target = pdapi.KeyspaceStateArchived
+	case v1alpha1.KeyspaceReclaimDelete:
+		target = pdapi.KeyspaceStateTombstone
+	default:
+		return nil
+	}
+
+	keyspace := tt.GetKeyspaceName()
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	meta, err := pdClient.GetKeyspace(keyspace)
+	if err != nil {
+		return fmt.Errorf("TidbTenant: [%s/%s], failed to get keyspace %s: %v", tt.GetNamespace(), tt.GetName(), keyspace, err)
+	}
+	if meta == nil {
+		return nil
+	}
+	return m.transitKeyspace(tt, pdClient, meta, target)
+}
+
+// transitKeyspace moves the keyspace to the target state step by step.
+func (m *tidbTenantMemberManager) transitKeyspace(tt *v1alpha1.TidbTenant, pdClient pdapi.PDClient, meta *pdapi.KeyspaceMeta, target pdapi.KeyspaceState) error {
+	for meta.State != target {
+		var next pdapi.KeyspaceState
+		switch {
+		case meta.State == pdapi.KeyspaceStateDisabled && target == pdapi.KeyspaceStateEnabled:
+			next = pdapi.KeyspaceStateEnabled
+		case keyspaceStateOrder[meta.State] < keyspaceStateOrder[target]:
+			next = nextKeyspaceState(meta.State)
+		default:
+			return fmt.Errorf("TidbTenant: [%s/%s], keyspace %s is %s and can not be %s", tt.GetNamespace(), tt.GetName(), meta.Name, meta.State, target)
+		}
+
+		updated, err := pdClient.UpdateKeyspaceState(meta.Name, next)
+		if err != nil {
+			return fmt.Errorf("TidbTenant: [%s/%s], failed to update the state of keyspace %s to %s: %v", tt.GetNamespace(), tt.GetName(), meta.Name, next, err)
+		}
+		klog.Infof("TidbTenant: [%s/%s], keyspace %s is %s", tt.GetNamespace(), tt.GetName(), meta.Name, updated.State)
+		meta = updated
+		m.setKeyspaceStatus(tt, meta)
+	}
+	return nil
+}
+
+func nextKeyspaceState(state pdapi.KeyspaceState) pdapi.KeyspaceState {
+	switch state {
+	case pdapi.KeyspaceStateEnabled:
+		return pdapi.KeyspaceStateDisabled
+	case pdapi.KeyspaceStateDisabled:
+		return pdapi.KeyspaceStateArchived
+	default:
+		return pdapi.KeyspaceStateTombstone
+	}
+}
+
+func (m *tidbTenantMemberManager) setKeyspaceStatus(tt *v1alpha1.TidbTenant, meta *pdapi.KeyspaceMeta) {
+	tt.Status.KeyspaceName = meta.Name
+	tt.Status.KeyspaceID = meta.ID
+	tt.Status.KeyspaceState = string(meta.State)
+}
+
+// scaleInTiDB scales the StatefulSet of the TiDB servers of the tenant to 0,
+// a requeue error is returned until all of the pods are gone.
+func (m *tidbTenantMemberManager) scaleInTiDB(tt *v1alpha1.TidbTenant, tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	setName := controller.TiDBGroupMemberName(tc.GetName(), tt.TiDBGroupName())
+
+	oldSetTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(setName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("scaleInTiDB: fail to get sts %s of TidbTenant [%s/%s], error: %s", setName, tt.GetNamespace(), tt.GetName(), err)
+	}
+
+	oldSet := oldSetTmp.DeepCopy()
+	newSet := oldSetTmp.DeepCopy()
+	if oldSet.Status.Replicas == 0 && *oldSet.Spec.Replicas == 0 {
+		return nil
+	}
+
+	view := tt.TidbClusterView(tc)
+	*newSet.Spec.Replicas = 0
+	err = m.scaler.Scale(view, oldSet, newSet)
+	tt.Status.TiDB = view.Status.TiDB
+	if err != nil {
+		return err
+	}
+	if err := mngerutils.UpdateStatefulSetWithPrecheck(m.deps, view, "FailedUpdateTiDBSTS", newSet, oldSet); err != nil {
+		return err
+	}
+	return controller.RequeueErrorf("TidbTenant: [%s/%s], waiting for TiDB servers to be scaled in", tt.GetNamespace(), tt.GetName())
+}
+
+// deleteTiDB deletes the StatefulSet, services and ConfigMaps of the TiDB servers of the tenant.
+func (m *tidbTenantMemberManager) deleteTiDB(tt *v1alpha1.TidbTenant, tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	group := tt.TiDBGroupName()
+
+	set, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiDBGroupMemberName(tcName, group))
+	if err == nil {
+		err = m.deps.StatefulSetControl.DeleteStatefulSet(tt, set, metav1.DeleteOptions{})
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	for _, svcName := range []string{controller.TiDBGroupMemberName(tcName, group), controller.TiDBGroupPeerMemberName(tcName, group)} {
+		svc, err := m.deps.ServiceLister.Services(ns).Get(svcName)
+		if err == nil {
+			err = m.deps.ServiceControl.DeleteService(tt, svc)
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	selector, err := label.New().Instance(tt.GetInstanceName(tc)).TiDB().Selector()
+	if err != nil {
+		return err
+	}
+	cms, err := m.deps.ConfigMapLister.ConfigMaps(ns).List(selector)
+	if err != nil {
+		return err
+	}
+	for _, cm := range cms {
+		if err := m.deps.ConfigMapControl.DeleteConfigMap(tt, cm); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+type FakeTiDBTenantMemberManager struct {
+	err error
+}
+
+func NewFakeTiDBTenantMemberManager() *FakeTiDBTenantMemberManager {
+	return &FakeTiDBTenantMemberManager{}
+}
+
+func (m *FakeTiDBTenantMemberManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeTiDBTenantMemberManager) Sync(_ *v1alpha1.TidbTenant, _ *v1alpha1.TidbCluster) error {
+	return m.err
+}
+
+func (m *FakeTiDBTenantMemberManager) Reclaim(_ *v1alpha1.TidbTenant, _ *v1alpha1.TidbCluster) error {
+	return m.err
+}
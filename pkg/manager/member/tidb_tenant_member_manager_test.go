@@ -0,0 +1,155 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newFakeTiDBTenantMemberManager() *tidbTenantMemberManager {
+	tmm, _, _, _ := newFakeTiDBMemberManager()
+	return &tidbTenantMemberManager{
+		deps:              tmm.deps,
+		scaler:            tmm.scaler,
+		tidbMemberManager: tmm,
+	}
+}
+
+func newTidbTenantForTidbCluster(tc *v1alpha1.TidbCluster) *v1alpha1.TidbTenant {
+	return &v1alpha1.TidbTenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tenant-a",
+			Namespace: tc.GetNamespace(),
+		},
+		Spec: v1alpha1.TidbTenantSpec{
+			Cluster:        v1alpha1.TidbClusterRef{Name: tc.GetName(), Namespace: tc.GetNamespace()},
+			KeyspaceConfig: map[string]string{"gc_life_time": "6000"},
+			TiDB:           *tc.Spec.TiDB.DeepCopy(),
+		},
+	}
+}
+
+// fakeKeyspaces serves the keyspace API of the fake PD client from a map
+func fakeKeyspaces(pdClient *pdapi.FakePDClient, keyspaces map[string]*pdapi.KeyspaceMeta) {
+	pdClient.AddReaction(pdapi.GetKeyspaceActionType, func(action *pdapi.Action) (interface{}, error) {
+		return keyspaces[action.Name], nil
+	})
+	pdClient.AddReaction(pdapi.CreateKeyspaceActionType, func(action *pdapi.Action) (interface{}, error) {
+		meta := &pdapi.KeyspaceMeta{ID: uint32(len(keyspaces) + 1), Name: action.Name, State: pdapi.KeyspaceStateEnabled, Config: action.KeyspaceConfig}
+		keyspaces[action.Name] = meta
+		return meta, nil
+	})
+	pdClient.AddReaction(pdapi.UpdateKeyspaceStateActionType, func(action *pdapi.Action) (interface{}, error) {
+		meta := keyspaces[action.Name]
+		meta.State = action.KeyspaceState
+		return meta, nil
+	})
+}
+
+func TestTiDBTenantMemberManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	tc.Spec.PD.Replicas = 1
+	tc.Status.PD.Members = map[string]v1alpha1.PDMember{"pd-0": {Name: "pd-0", Health: true}}
+	tc.Status.PD.StatefulSet = &apps.StatefulSetStatus{ReadyReplicas: 1}
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"tikv-0": {PodName: "tikv-0", State: v1alpha1.TiKVStateUp},
+	}
+	tc.Status.TiKV.StatefulSet = &apps.StatefulSetStatus{ReadyReplicas: 1}
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	tt := newTidbTenantForTidbCluster(tc)
+	tt.Spec.TiDB.Replicas = 2
+
+	ttm := newFakeTiDBTenantMemberManager()
+	keyspaces := map[string]*pdapi.KeyspaceMeta{}
+	fakeKeyspaces(controller.NewFakePDClient(ttm.deps.PDControl.(*pdapi.FakePDControl), tc), keyspaces)
+
+	err := ttm.Sync(tt, tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(keyspaces).To(HaveKey("tenant-a"))
+	g.Expect(keyspaces["tenant-a"].Config).To(HaveKeyWithValue("gc_life_time", "6000"))
+	g.Expect(tt.Status.KeyspaceName).To(Equal("tenant-a"))
+	g.Expect(tt.Status.KeyspaceID).To(Equal(uint32(1)))
+	g.Expect(tt.Status.KeyspaceState).To(Equal(string(pdapi.KeyspaceStateEnabled)))
+	g.Expect(tt.Status.TiDB.StatefulSet).NotTo(BeNil())
+
+	set, err := ttm.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiDBGroupMemberName(tcName, "tenant-a"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*set.Spec.Replicas).To(Equal(int32(2)))
+	g.Expect(set.Spec.Template.Labels).To(HaveKeyWithValue(label.InstanceLabelKey, tt.GetInstanceName(tc)))
+	g.Expect(set.Spec.Selector.MatchLabels).To(HaveKeyWithValue(label.InstanceLabelKey, tt.GetInstanceName(tc)))
+
+	// the keyspace is not archived until the TiDB servers are scaled in
+	tt.Spec.Archived = true
+	err = ttm.Sync(tt, tc)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(keyspaces["tenant-a"].State).To(Equal(pdapi.KeyspaceStateEnabled))
+
+	set = set.DeepCopy()
+	set.Spec.Replicas = new(int32)
+	set.Status.Replicas = 0
+	g.Expect(ttm.deps.KubeInformerFactory.Apps().V1().StatefulSets().Informer().GetIndexer().Update(set)).To(Succeed())
+	err = ttm.Sync(tt, tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(keyspaces["tenant-a"].State).To(Equal(pdapi.KeyspaceStateArchived))
+	g.Expect(tt.Status.KeyspaceState).To(Equal(string(pdapi.KeyspaceStateArchived)))
+
+	// the keyspace can not be renamed
+	tt.Spec.KeyspaceName = "tenant-b"
+	err = ttm.Sync(tt, tc)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("keyspace can not be changed"))
+}
+
+func TestTiDBTenantMemberManagerReclaim(t *testing.T) {
+	tests := []struct {
+		policy   v1alpha1.KeyspaceReclaimPolicy
+		expected pdapi.KeyspaceState
+	}{
+		{v1alpha1.KeyspaceReclaimRetain, pdapi.KeyspaceStateEnabled},
+		{v1alpha1.KeyspaceReclaimArchive, pdapi.KeyspaceStateArchived},
+		{v1alpha1.KeyspaceReclaimDelete, pdapi.KeyspaceStateTombstone},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.policy), func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			tc := newTidbClusterForTiDB()
+			tt := newTidbTenantForTidbCluster(tc)
+			tt.Spec.KeyspaceReclaimPolicy = test.policy
+
+			ttm := newFakeTiDBTenantMemberManager()
+			keyspaces := map[string]*pdapi.KeyspaceMeta{
+				"tenant-a": {ID: 1, Name: "tenant-a", State: pdapi.KeyspaceStateEnabled},
+			}
+			fakeKeyspaces(controller.NewFakePDClient(ttm.deps.PDControl.(*pdapi.FakePDControl), tc), keyspaces)
+
+			err := ttm.Reclaim(tt, tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(keyspaces["tenant-a"].State).To(Equal(test.expected))
+		})
+	}
+}
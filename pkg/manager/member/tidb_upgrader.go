@@ -49,7 +49,8 @@ func NewTiDBUpgrader(deps *controller.Dependencies) Upgrader {
 func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
 	// when scale replica to 0 , all nodes crash and tidb is in upgrade phase, this method will throw error about pod is upgrade.
 	// so  directly return nil when scale replica to 0.
-	if tc.Spec.TiDB.Replicas == int32(0) {
+	// it is the same when TiDB is scaled to zero because it is idle.
+	if tc.Spec.TiDB.Replicas == int32(0) || tc.TiDBIdle() {
 		return nil
 	}
 
@@ -60,12 +61,12 @@ func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSe
 		tc.Status.TiKV.Phase == v1alpha1.UpgradePhase || tc.Status.TiKV.Phase == v1alpha1.ScalePhase ||
 		tc.Status.TiFlash.Phase == v1alpha1.UpgradePhase || tc.Status.TiFlash.Phase == v1alpha1.ScalePhase ||
 		tc.Status.Pump.Phase == v1alpha1.UpgradePhase || tc.Status.Pump.Phase == v1alpha1.ScalePhase ||
-		tc.TiDBScaling() {
+		!tc.TiKVGroupsNormal() || tc.TiDBScaling() {
 		klog.Infof("TidbCluster: [%s/%s]'s pd status is %s, "+
-			"tikv status is %s, tiflash status is %s, pump status is %s, "+
+			"tikv status is %s, tikv groups are normal: %t, tiflash status is %s, pump status is %s, "+
 			"tidb status is %s, can not upgrade tidb",
 			ns, tcName,
-			tc.Status.PD.Phase, tc.Status.TiKV.Phase, tc.Status.TiFlash.Phase,
+			tc.Status.PD.Phase, tc.Status.TiKV.Phase, tc.TiKVGroupsNormal(), tc.Status.TiFlash.Phase,
 			tc.Status.Pump.Phase, tc.Status.TiDB.Phase)
 		_, podSpec, err := GetLastAppliedConfig(oldSet)
 		if err != nil {
@@ -105,7 +106,7 @@ func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSe
 	podOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet).List()
 	for _i := len(podOrdinals) - 1; _i >= 0; _i-- {
 		i := podOrdinals[_i]
-		podName := tidbPodName(tcName, i)
+		podName := tidbGroupPodName(tcName, tc.TiDBGroupName(), i)
 		pod, err := u.deps.PodLister.Pods(ns).Get(podName)
 		if err != nil {
 			return fmt.Errorf("tidbUpgrader.Upgrade: failed to get pods %s for cluster %s/%s, error: %s", podName, ns, tcName, err)
@@ -0,0 +1,149 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/manager/suspender"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+)
+
+type tiflashComputeMemberManager struct {
+	deps   *controller.Dependencies
+	scaler Scaler
+	// tiflashMemberManager syncs the TiFlash compute nodes through the view built by TidbCluster.TiFlashComputeView
+	tiflashMemberManager *tiflashMemberManager
+}
+
+// NewTiFlashComputeMemberManager returns a *tiflashComputeMemberManager
+func NewTiFlashComputeMemberManager(deps *controller.Dependencies, failover Failover, scaler Scaler, upgrader Upgrader, spder suspender.Suspender, pvm volumes.PodVolumeModifier) manager.Manager {
+	return &tiflashComputeMemberManager{
+		deps:   deps,
+		scaler: scaler,
+		tiflashMemberManager: &tiflashMemberManager{
+			deps:                     deps,
+			failover:                 failover,
+			scaler:                   scaler,
+			upgrader:                 upgrader,
+			suspender:                spder,
+			podVolumeModifier:        pvm,
+			statefulSetIsUpgradingFn: tiflashStatefulSetIsUpgrading,
+		},
+	}
+}
+
+// Sync for TiFlash compute nodes.
+func (m *tiflashComputeMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if tc.TiFlashComputeSpec() == nil {
+		// scale in the TiFlash compute nodes that have been removed from the spec
+		if tc.Status.TiFlashCompute == nil {
+			return nil
+		}
+		return m.syncRemoved(tc)
+	}
+
+	view := tc.TiFlashComputeView()
+
+	mm := *m.tiflashMemberManager
+	mm.upgrader = &tiflashComputeUpgrader{Upgrader: m.tiflashMemberManager.upgrader, tc: tc}
+	err := mm.Sync(view)
+
+	tc.Status.TiFlashCompute = &view.Status.TiFlash
+	tc.Status.ChangePlan = view.Status.ChangePlan
+	tc.Status.Operations = view.Status.Operations
+	return err
+}
+
+// syncRemoved scales the StatefulSet of the removed TiFlash compute nodes to 0, the
+// status of the compute nodes is dropped once all of their pods are gone.
+func (m *tiflashComputeMemberManager) syncRemoved(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	setName := controller.TiFlashRoleMemberName(tcName, label.TiFlashComputeRoleVal)
+
+	oldSetTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(setName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			tc.Status.TiFlashCompute = nil
+			return nil
+		}
+		return fmt.Errorf("syncRemoved: fail to get sts %s of TiFlash compute nodes for cluster [%s/%s], error: %s",
+			setName, ns, tcName, err)
+	}
+
+	oldSet := oldSetTmp.DeepCopy()
+	newSet := oldSetTmp.DeepCopy()
+	if oldSet.Status.Replicas == 0 && *oldSet.Spec.Replicas == 0 {
+		tc.Status.TiFlashCompute = nil
+		return nil
+	}
+
+	view := tc.TiFlashComputeView()
+	*newSet.Spec.Replicas = 0
+	err = m.scaler.Scale(view, oldSet, newSet)
+	tc.Status.TiFlashCompute = &view.Status.TiFlash
+	tc.Status.Operations = view.Status.Operations
+	if err != nil {
+		return err
+	}
+	return mngerutils.UpdateStatefulSetWithPrecheck(m.deps, view, "FailedUpdateTiFlashSTS", newSet, oldSet)
+}
+
+// tiflashComputeUpgrader upgrades the TiFlash compute nodes only when the TiFlash
+// write nodes are not upgrading, so that the compute nodes are upgraded after the
+// write nodes.
+type tiflashComputeUpgrader struct {
+	Upgrader
+	// tc is the TidbCluster the view being upgraded is built from
+	tc *v1alpha1.TidbCluster
+}
+
+func (u *tiflashComputeUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	if tc.Status.TiFlash.Phase != v1alpha1.UpgradePhase && u.tc.Status.TiFlash.Phase == v1alpha1.UpgradePhase {
+		klog.Infof("TidbCluster: [%s/%s], can not upgrade tiflash compute nodes because: tiflash status is %s",
+			tc.GetNamespace(), tc.GetName(), u.tc.Status.TiFlash.Phase)
+		_, podSpec, err := GetLastAppliedConfig(oldSet)
+		if err != nil {
+			return err
+		}
+		newSet.Spec.Template.Spec = *podSpec
+		return nil
+	}
+	return u.Upgrader.Upgrade(tc, oldSet, newSet)
+}
+
+type FakeTiFlashComputeMemberManager struct {
+	err error
+}
+
+func NewFakeTiFlashComputeMemberManager() *FakeTiFlashComputeMemberManager {
+	return &FakeTiFlashComputeMemberManager{}
+}
+
+func (m *FakeTiFlashComputeMemberManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeTiFlashComputeMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
+	return m.err
+}
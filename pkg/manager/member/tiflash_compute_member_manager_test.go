@@ -0,0 +1,153 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newFakeTiFlashComputeMemberManager(tc *v1alpha1.TidbCluster) *tiflashComputeMemberManager {
+	tmm, _, _, _, _, _ := newFakeTiFlashMemberManager(tc)
+	return &tiflashComputeMemberManager{
+		deps:                 tmm.deps,
+		scaler:               tmm.scaler,
+		tiflashMemberManager: tmm,
+	}
+}
+
+func TestTiFlashComputeMemberManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiflash()
+	tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+		"pd-0": {Name: "pd-0", Health: true},
+		"pd-1": {Name: "pd-1", Health: true},
+		"pd-2": {Name: "pd-2", Health: true},
+	}
+	tc.Status.PD.StatefulSet = &apps.StatefulSetStatus{ReadyReplicas: 3}
+	tc.Spec.StartScriptVersion = v1alpha1.StartScriptV2
+	tc.Spec.TiFlash.Disaggregated = &v1alpha1.TiFlashDisaggregatedSpec{
+		S3: v1alpha1.TiFlashS3Spec{Endpoint: "http://minio:9000", Bucket: "tiflash", SecretName: "s3-secret"},
+		Compute: &v1alpha1.TiFlashComputeSpec{
+			ComponentSpec: v1alpha1.ComponentSpec{Image: "tiflash-test-image"},
+			Replicas:      2,
+		},
+	}
+	oldSpec := tc.Spec.DeepCopy()
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	cmm := newFakeTiFlashComputeMemberManager(tc)
+	err := cmm.Sync(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(&tc.Spec).To(Equal(oldSpec))
+	g.Expect(tc.Annotations).NotTo(HaveKey(label.AnnTiFlashRoleKey))
+
+	set, err := cmm.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiFlashRoleMemberName(tcName, label.TiFlashComputeRoleVal))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*set.Spec.Replicas).To(Equal(int32(2)))
+	g.Expect(set.Spec.ServiceName).To(Equal(controller.TiFlashRolePeerMemberName(tcName, label.TiFlashComputeRoleVal)))
+	g.Expect(set.Spec.Template.Labels).To(HaveKeyWithValue(label.TiFlashRoleLabelKey, label.TiFlashComputeRoleVal))
+	g.Expect(set.Spec.Selector.MatchLabels).To(HaveKeyWithValue(label.TiFlashRoleLabelKey, label.TiFlashComputeRoleVal))
+	// compute nodes are stateless
+	g.Expect(set.Spec.VolumeClaimTemplates).To(BeEmpty())
+	g.Expect(set.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+		Name:         "data0",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}))
+	env := set.Spec.Template.Spec.Containers[0].Env
+	g.Expect(env).To(ContainElement(HaveField("Name", "S3_ACCESS_KEY_ID")))
+	g.Expect(env).To(ContainElement(HaveField("Name", "S3_SECRET_ACCESS_KEY")))
+	g.Expect(set.Spec.Template.Spec.Containers[0].Command[2]).To(ContainSubstring("--flash.disaggregated_mode=tiflash_compute"))
+
+	svc, err := cmm.deps.ServiceLister.Services(ns).Get(controller.TiFlashRolePeerMemberName(tcName, label.TiFlashComputeRoleVal))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(svc.Spec.Selector).To(HaveKeyWithValue(label.TiFlashRoleLabelKey, label.TiFlashComputeRoleVal))
+
+	// the TiFlash write nodes are synced by tiflashMemberManager
+	_, err = cmm.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiFlashMemberName(tcName))
+	expectErrIsNotFound(g, err)
+
+	g.Expect(tc.Status.TiFlashCompute).NotTo(BeNil())
+	g.Expect(tc.Status.TiFlashCompute.StatefulSet).NotTo(BeNil())
+	g.Expect(tc.Status.TiFlash.StatefulSet).To(BeNil())
+
+	// the status of the removed compute nodes is dropped when their StatefulSet is gone
+	tc = newTidbClusterForTiflash()
+	tc.Status.TiFlashCompute = &v1alpha1.TiFlashStatus{}
+	cmm = newFakeTiFlashComputeMemberManager(tc)
+	err = cmm.Sync(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Status.TiFlashCompute).To(BeNil())
+}
+
+func TestTiFlashStorePattern(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiflash()
+	tc.Name = "demo"
+	tc.Namespace = "ns"
+	tc.Spec.TiFlash.Disaggregated = &v1alpha1.TiFlashDisaggregatedSpec{
+		Compute: &v1alpha1.TiFlashComputeSpec{Replicas: 1},
+	}
+	writeAddr := "demo-tiflash-0.demo-tiflash-peer.ns.svc:3930"
+	computeAddr := "demo-tiflash-compute-0.demo-tiflash-compute-peer.ns.svc:3930"
+
+	pattern, err := tiflashStorePattern(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pattern.MatchString(writeAddr)).To(BeTrue())
+	g.Expect(pattern.MatchString(computeAddr)).To(BeFalse())
+
+	pattern, err = tiflashStorePattern(tc.TiFlashComputeView())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pattern.MatchString(writeAddr)).To(BeFalse())
+	g.Expect(pattern.MatchString(computeAddr)).To(BeTrue())
+}
+
+func TestTiFlashComputeUpgrader(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiflash()
+	tc.Spec.TiFlash.Disaggregated = &v1alpha1.TiFlashDisaggregatedSpec{
+		Compute: &v1alpha1.TiFlashComputeSpec{Replicas: 1},
+	}
+	u := &tiflashComputeUpgrader{Upgrader: NewFakeTiFlashUpgrader(), tc: tc}
+
+	newSets := func() (*apps.StatefulSet, *apps.StatefulSet) {
+		oldSet := newStatefulSetForTiFlashUpgrader()
+		mngerutils.SetStatefulSetLastAppliedConfigAnnotation(oldSet)
+		newSet := oldSet.DeepCopy()
+		newSet.Spec.Template.Spec.Containers[0].Image = "tiflash-test-image:v2"
+		return oldSet, newSet
+	}
+
+	// the compute nodes wait for the upgrade of the write nodes
+	tc.Status.TiFlash.Phase = v1alpha1.UpgradePhase
+	oldSet, newSet := newSets()
+	g.Expect(u.Upgrade(tc.TiFlashComputeView(), oldSet, newSet)).To(Succeed())
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal(oldSet.Spec.Template.Spec.Containers[0].Image))
+
+	tc.Status.TiFlash.Phase = v1alpha1.NormalPhase
+	oldSet, newSet = newSets()
+	g.Expect(u.Upgrade(tc.TiFlashComputeView(), oldSet, newSet)).To(Succeed())
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("tiflash-test-image:v2"))
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/backup/constants"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/manager"
 	"github.com/pingcap/tidb-operator/pkg/manager/member/startscript"
@@ -45,7 +46,7 @@ import (
 
 const (
 	// find a better way to manage store only managed by tiflash in Operator
-	tiflashStoreLimitPattern = `%s-tiflash-\d+\.%s-tiflash-peer\.%s\.svc%s:\d+`
+	tiflashStoreLimitPattern = `%s-\d+\.%s\.%s\.svc%s:\d+`
 	tiflashCertPath          = "/var/lib/tiflash-tls"
 	tiflashCertVolumeName    = "tiflash-tls"
 )
@@ -170,8 +171,10 @@ func (m *tiflashMemberManager) syncHeadlessService(tc *v1alpha1.TidbCluster) err
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
+	svcName := controller.TiFlashRolePeerMemberName(tcName, tc.TiFlashRole())
+
 	newSvc := getNewHeadlessService(tc)
-	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(controller.TiFlashPeerMemberName(tcName))
+	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(svcName)
 	if errors.IsNotFound(err) {
 		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
 		if err != nil {
@@ -180,7 +183,7 @@ func (m *tiflashMemberManager) syncHeadlessService(tc *v1alpha1.TidbCluster) err
 		return m.deps.ServiceControl.CreateService(tc, newSvc)
 	}
 	if err != nil {
-		return fmt.Errorf("syncHeadlessService: failed to get svc %s for cluster %s/%s, error: %s", controller.TiFlashPeerMemberName(tcName), ns, tcName, err)
+		return fmt.Errorf("syncHeadlessService: failed to get svc %s for cluster %s/%s, error: %s", svcName, ns, tcName, err)
 	}
 
 	oldSvc := oldSvcTmp.DeepCopy()
@@ -202,9 +205,11 @@ func (m *tiflashMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
-	oldSetTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiFlashMemberName(tcName))
+	setName := controller.TiFlashRoleMemberName(tcName, tc.TiFlashRole())
+
+	oldSetTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(setName)
 	if err != nil && !errors.IsNotFound(err) {
-		return fmt.Errorf("syncStatefulSet: fail to get sts %s for cluster %s/%s, error: %s", controller.TiFlashMemberName(tcName), ns, tcName, err)
+		return fmt.Errorf("syncStatefulSet: fail to get sts %s for cluster %s/%s, error: %s", setName, ns, tcName, err)
 	}
 	setNotExist := errors.IsNotFound(err)
 
@@ -212,9 +217,9 @@ func (m *tiflashMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 	// to avoid some fileds (e.g storage request) reused and cause unexpected behavior (e.g scale down).
 	if oldSetTmp != nil && *oldSetTmp.Spec.Replicas == 0 && oldSetTmp.Status.UpdatedReplicas == 0 && tc.Spec.TiFlash.Replicas > 0 {
 		if err := m.deps.StatefulSetControl.DeleteStatefulSet(tc, oldSetTmp, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
-			return fmt.Errorf("syncStatefulSet: fail to delete sts %s for cluster %s/%s, error: %s", controller.TiFlashMemberName(tcName), ns, tcName, err)
+			return fmt.Errorf("syncStatefulSet: fail to delete sts %s for cluster %s/%s, error: %s", setName, ns, tcName, err)
 		}
-		return controller.RequeueErrorf("wait for previous sts %s for cluster %s/%s to be deleted", controller.TiFlashMemberName(tcName), ns, tcName)
+		return controller.RequeueErrorf("wait for previous sts %s for cluster %s/%s to be deleted", setName, ns, tcName)
 	}
 
 	oldSet := oldSetTmp.DeepCopy()
@@ -247,6 +252,13 @@ func (m *tiflashMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 	if err != nil {
 		return err
 	}
+	setStartBinary(tc, m.deps.CLIConfig.TiDBDiscoveryImage, &newSet.Spec.Template.Spec)
+
+	// in the plan mode, the changes driven by the spec are held until the plan is approved
+	if proceed, err := syncChangePlan(m.deps, tc, v1alpha1.TiFlashMemberType, tc.Spec.TiFlash.Replicas, oldSet, newSet); err != nil || !proceed {
+		return err
+	}
+
 	if setNotExist {
 		if !tc.PDIsAvailable() {
 			klog.Infof("TidbCluster: %s/%s, waiting for PD cluster running", ns, tcName)
@@ -268,6 +280,8 @@ func (m *tiflashMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
+	recordStatefulSetOperations(tc, v1alpha1.TiFlashMemberType, oldSet, newSet)
+
 	// Scaling takes precedence over upgrading because:
 	// - if a tiflash fails in the upgrading, users may want to delete it or add
 	//   new replicas
@@ -303,7 +317,7 @@ func (m *tiflashMemberManager) syncConfigMap(tc *v1alpha1.TidbCluster, set *apps
 	var inUseName string
 	if set != nil {
 		inUseName = mngerutils.FindConfigMapVolume(&set.Spec.Template.Spec, func(name string) bool {
-			return strings.HasPrefix(name, controller.TiFlashMemberName(tc.Name))
+			return strings.HasPrefix(name, controller.TiFlashRoleMemberName(tc.Name, tc.TiFlashRole()))
 		})
 	} else {
 		inUseName, err = mngerutils.FindConfigMapNameFromTCAnno(context.Background(), m.deps.ConfigMapLister, tc, v1alpha1.TiFlashMemberType, newCm)
@@ -323,8 +337,8 @@ func getNewHeadlessService(tc *v1alpha1.TidbCluster) *corev1.Service {
 	ns := tc.Namespace
 	tcName := tc.Name
 	instanceName := tc.GetInstanceName()
-	svcName := controller.TiFlashPeerMemberName(tcName)
-	svcLabel := label.New().Instance(instanceName).TiFlash().Labels()
+	svcName := controller.TiFlashRolePeerMemberName(tcName, tc.TiFlashRole())
+	svcLabel := label.New().Instance(instanceName).TiFlash().TiFlashRole(tc.TiFlashRole()).Labels()
 
 	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -386,8 +400,11 @@ func getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.St
 		tiflashConfigMap = cm.Name
 	}
 
+	// TiFlash compute nodes are stateless, their data and the cache of the data in S3 are kept in an emptyDir
+	isCompute := tc.TiFlashRole() == label.TiFlashComputeRoleVal
+
 	// This should not happen as we have validaton for this field
-	if len(spec.StorageClaims) < 1 {
+	if len(spec.StorageClaims) < 1 && !isCompute {
 		return nil, fmt.Errorf("storageClaims should be configured at least one item for tiflash, tidbcluster %s/%s", tc.Namespace, tc.Name)
 	}
 	pvcs, err := flashVolumeClaimTemplate(tc.Spec.TiFlash.StorageClaims)
@@ -402,6 +419,9 @@ func getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.St
 		volMounts = append(volMounts, corev1.VolumeMount{
 			Name: fmt.Sprintf("data%d", k), MountPath: fmt.Sprintf("/data%d", k)})
 	}
+	if isCompute {
+		volMounts = append(volMounts, corev1.VolumeMount{Name: "data0", MountPath: "/data0"})
+	}
 	volMounts = append(volMounts, tc.Spec.TiFlash.AdditionalVolumeMounts...)
 
 	if tc.IsTLSClusterEnabled() {
@@ -428,6 +448,14 @@ func getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.St
 		},
 	}
 
+	if isCompute {
+		vols = append(vols, corev1.Volume{
+			Name: "data0", VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
 	if tc.IsTLSClusterEnabled() {
 		vols = append(vols, corev1.Volume{
 			Name: tiflashCertVolumeName, VolumeSource: corev1.VolumeSource{
@@ -503,13 +531,9 @@ func getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.St
 		}
 
 		initializer := corev1.Container{
-			Name:  "init",
-			Image: tc.HelperImage(),
-			Command: []string{
-				"sh",
-				"-c",
-				initScript,
-			},
+			Name:         "init",
+			Image:        tc.HelperImage(),
+			Command:      inlineStartScriptCommand(tc, "sh", initScript),
 			Env:          initEnv,
 			VolumeMounts: initVolMounts,
 		}
@@ -520,13 +544,14 @@ func getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.St
 	}
 
 	stsLabels := labelTiFlash(tc)
-	setName := controller.TiFlashMemberName(tcName)
+	setName := controller.TiFlashRoleMemberName(tcName, tc.TiFlashRole())
 	podLabels := util.CombineStringMap(stsLabels, baseTiFlashSpec.Labels())
 	podAnnotations := util.CombineStringMap(baseTiFlashSpec.Annotations(), controller.AnnProm(v1alpha1.DefaultTiFlashMetricsPort, "/metrics"))
 	podAnnotations = util.CombineStringMap(controller.AnnAdditionalProm("tiflash.proxy", v1alpha1.DefaultTiFlashProxyStatusPort), podAnnotations)
+	setTLSClusterCertAnnotation(tc, label.TiFlashLabelVal, podAnnotations)
 	stsAnnotations := getStsAnnotations(tc.Annotations, label.TiFlashLabelVal)
 	capacity := controller.TiKVCapacity(tc.Spec.TiFlash.Limits)
-	headlessSvcName := controller.TiFlashPeerMemberName(tcName)
+	headlessSvcName := controller.TiFlashRolePeerMemberName(tcName, tc.TiFlashRole())
 
 	deleteSlotsNumber, err := util.GetDeleteSlotsNumber(stsAnnotations)
 	if err != nil {
@@ -559,6 +584,9 @@ func getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.St
 			Value: tc.Timezone(),
 		},
 	}
+	if disaggregated := spec.Disaggregated; disaggregated != nil && disaggregated.S3.SecretName != "" {
+		env = append(env, tiflashS3CredentialEnv(disaggregated.S3.SecretName)...)
+	}
 
 	startScript, err := startscript.RenderTiFlashStartScript(tc)
 	if err != nil {
@@ -569,7 +597,7 @@ func getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.St
 		Name:            v1alpha1.TiFlashMemberType.String(),
 		Image:           tc.TiFlashImage(),
 		ImagePullPolicy: baseTiFlashSpec.ImagePullPolicy(),
-		Command:         []string{"/bin/sh", "-c", startScript},
+		Command:         inlineStartScriptCommand(tc, "/bin/sh", startScript),
 		SecurityContext: &corev1.SecurityContext{
 			Privileged: tc.TiFlashContainerPrivilege(),
 		},
@@ -711,10 +739,10 @@ func getTiFlashConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 	}
 
 	instanceName := tc.GetInstanceName()
-	tiflashLabel := label.New().Instance(instanceName).TiFlash().Labels()
+	tiflashLabel := label.New().Instance(instanceName).TiFlash().TiFlashRole(tc.TiFlashRole()).Labels()
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            controller.TiFlashMemberName(tc.Name),
+			Name:            controller.TiFlashRoleMemberName(tc.Name, tc.TiFlashRole()),
 			Namespace:       tc.Namespace,
 			Labels:          tiflashLabel,
 			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
@@ -730,7 +758,34 @@ func getTiFlashConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 
 func labelTiFlash(tc *v1alpha1.TidbCluster) label.Label {
 	instanceName := tc.GetInstanceName()
-	return label.New().Instance(instanceName).TiFlash()
+	return label.New().Instance(instanceName).TiFlash().TiFlashRole(tc.TiFlashRole())
+}
+
+// tiflashS3CredentialEnv returns the env of the S3 access key and secret key read
+// from the secret, which are used by TiFlash to access the S3 storage. The keys
+// in the secret are the same as the secret of S3 backup storage.
+func tiflashS3CredentialEnv(secretName string) []corev1.EnvVar {
+	secretKeyRef := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		}
+	}
+	return []corev1.EnvVar{
+		{Name: "S3_ACCESS_KEY_ID", ValueFrom: secretKeyRef(constants.S3AccessKey)},
+		{Name: "S3_SECRET_ACCESS_KEY", ValueFrom: secretKeyRef(constants.S3SecretKey)},
+	}
+}
+
+// tiflashStorePattern returns the pattern of the store addresses of the TiFlash nodes
+// with the role that tc stands for.
+func tiflashStorePattern(tc *v1alpha1.TidbCluster) (*regexp.Regexp, error) {
+	role := tc.TiFlashRole()
+	return regexp.Compile(fmt.Sprintf(tiflashStoreLimitPattern,
+		controller.TiFlashRoleMemberName(tc.Name, role), controller.TiFlashRolePeerMemberName(tc.Name, role),
+		tc.Namespace, controller.FormatClusterDomainForRegex(tc.Spec.ClusterDomain)))
 }
 
 func (m *tiflashMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) error {
@@ -767,7 +822,7 @@ func (m *tiflashMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, s
 		return err
 	}
 
-	pattern, err := regexp.Compile(fmt.Sprintf(tiflashStoreLimitPattern, tc.Name, tc.Name, tc.Namespace, controller.FormatClusterDomainForRegex(tc.Spec.ClusterDomain)))
+	pattern, err := tiflashStorePattern(tc)
 	if err != nil {
 		return err
 	}
@@ -881,7 +936,7 @@ func (m *tiflashMemberManager) setStoreLabelsForTiFlash(tc *v1alpha1.TidbCluster
 		return setCount, nil
 	}
 
-	pattern, err := regexp.Compile(fmt.Sprintf(tiflashStoreLimitPattern, tc.Name, tc.Name, tc.Namespace, controller.FormatClusterDomainForRegex(tc.Spec.ClusterDomain)))
+	pattern, err := tiflashStorePattern(tc)
 	if err != nil {
 		return -1, err
 	}
@@ -948,6 +1003,11 @@ func tiflashStatefulSetIsUpgrading(podLister corelisters.PodLister, pdControl pd
 	if err != nil {
 		return false, err
 	}
+	roleRequirement, err := label.TiFlashRoleRequirement(tc.TiFlashRole())
+	if err != nil {
+		return false, err
+	}
+	selector = selector.Add(*roleRequirement)
 	tiflashPods, err := podLister.Pods(tc.GetNamespace()).List(selector)
 	if err != nil {
 		return false, fmt.Errorf("tiflashStatefulSetIsUpgrading: failed to list pods for cluster %s/%s, selector %s, error: %v", tc.GetNamespace(), instanceName, selector, err)
@@ -129,6 +129,10 @@ func (s *tiflashScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, ne
 }
 
 func (s *tiflashScaler) scaleInOne(tc *v1alpha1.TidbCluster, ordinal int32) error {
+	if tc.TiFlashRole() == label.TiFlashComputeRoleVal {
+		return s.scaleInComputeOne(tc, ordinal)
+	}
+
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 	podName := ordinalPodName(v1alpha1.TiFlashMemberType, tcName, ordinal)
@@ -201,6 +205,30 @@ func (s *tiflashScaler) scaleInOne(tc *v1alpha1.TidbCluster, ordinal int32) erro
 	return fmt.Errorf("tiflash %s/%s no store found in cluster", ns, podName)
 }
 
+// scaleInComputeOne scales in a TiFlash compute node. Compute nodes hold no data,
+// so the store is deleted from PD without waiting for it to become tombstone and
+// the pod can be removed immediately.
+func (s *tiflashScaler) scaleInComputeOne(tc *v1alpha1.TidbCluster, ordinal int32) error {
+	ns := tc.GetNamespace()
+	podName := metaOrdinalPodName(tc, v1alpha1.TiFlashMemberType, ordinal)
+
+	for _, store := range tc.Status.TiFlash.Stores {
+		if store.PodName != podName || store.State == v1alpha1.TiKVStateOffline {
+			continue
+		}
+		id, err := strconv.ParseUint(store.ID, 10, 64)
+		if err != nil {
+			return err
+		}
+		if err := controller.GetPDClient(s.deps.PDControl, tc).DeleteStore(id); err != nil {
+			klog.Errorf("tiflash compute scale in: failed to delete store %d, %v", id, err)
+			return err
+		}
+		klog.Infof("tiflash compute scale in: delete store %d for tiflash %s/%s successfully", id, ns, podName)
+	}
+	return nil
+}
+
 type fakeTiFlashScaler struct{}
 
 // NewFakeTiFlashScaler returns a fake tiflash Scaler
@@ -105,12 +105,12 @@ func (u *tiflashUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Statefu
 	podOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet).List()
 	for _i := len(podOrdinals) - 1; _i >= 0; _i-- {
 		i := podOrdinals[_i]
-		store := getTiFlashStoreByOrdinal(tc.GetName(), tc.Status.TiFlash, i)
+		store := getTiFlashStoreByOrdinal(tc, i)
 		if store == nil {
 			mngerutils.SetUpgradePartition(newSet, i)
 			continue
 		}
-		podName := TiFlashPodName(tcName, i)
+		podName := tiflashRolePodName(tcName, tc.TiFlashRole(), i)
 		pod, err := u.deps.PodLister.Pods(ns).Get(podName)
 		if err != nil {
 			return fmt.Errorf("TiFlashUpgrader.Upgrade: failed to get pods %s for cluster %s/%s, error: %s", podName, ns, tcName, err)
@@ -154,9 +154,9 @@ func (u *tiflashUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Statefu
 	return nil
 }
 
-func getTiFlashStoreByOrdinal(name string, status v1alpha1.TiFlashStatus, ordinal int32) *v1alpha1.TiKVStore {
-	podName := TiFlashPodName(name, ordinal)
-	for _, store := range status.Stores {
+func getTiFlashStoreByOrdinal(tc *v1alpha1.TidbCluster, ordinal int32) *v1alpha1.TiKVStore {
+	podName := tiflashRolePodName(tc.GetName(), tc.TiFlashRole(), ordinal)
+	for _, store := range tc.Status.TiFlash.Stores {
 		if store.PodName == podName {
 			return &store
 		}
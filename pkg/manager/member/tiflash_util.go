@@ -194,8 +194,8 @@ func getTiFlashConfigV2(tc *v1alpha1.TidbCluster) *v1alpha1.TiFlashConfigWraper
 		common.SetIfNil("flash.flash_cluster.log", defaultClusterLog)
 		common.SetIfNil("flash.proxy.addr", fmt.Sprintf("%s:%d", listenHost, v1alpha1.DefaultTiFlashProxyPort))
 		if !mountCMInTiflashContainer {
-			common.SetIfNil("flash.proxy.advertise-addr", fmt.Sprintf("%s-POD_NUM.%s.%s.svc%s:%d", controller.TiFlashMemberName(name),
-				controller.TiFlashPeerMemberName(name), ns, controller.FormatClusterDomain(clusterDomain), v1alpha1.DefaultTiFlashProxyPort))
+			common.SetIfNil("flash.proxy.advertise-addr", fmt.Sprintf("%s-POD_NUM.%s.%s.svc%s:%d", controller.TiFlashRoleMemberName(name, tc.TiFlashRole()),
+				controller.TiFlashRolePeerMemberName(name, tc.TiFlashRole()), ns, controller.FormatClusterDomain(clusterDomain), v1alpha1.DefaultTiFlashProxyPort))
 		}
 		common.SetIfNil("flash.proxy.data-dir", "/data0/proxy")
 		if !mountCMInTiflashContainer {
@@ -219,7 +219,7 @@ func getTiFlashConfigV2(tc *v1alpha1.TidbCluster) *v1alpha1.TiFlashConfigWraper
 
 		preferPDAddressesOverDiscovery := slices.Contains(
 			tc.Spec.StartScriptV2FeatureFlags, v1alpha1.StartScriptV2FeatureFlagPreferPDAddressesOverDiscovery)
-		if preferPDAddressesOverDiscovery && tc.Spec.StartScriptVersion == v1alpha1.StartScriptV2 {
+		if preferPDAddressesOverDiscovery && (tc.Spec.StartScriptVersion == v1alpha1.StartScriptV2 || tc.Spec.StartScriptVersion == v1alpha1.StartScriptV3) {
 			pdAddr = strings.Join(tc.Spec.PDAddresses, ",")
 		}
 		// tiflash require at least one configuration item in ["raft"] config group, otherwise
@@ -237,9 +237,9 @@ func getTiFlashConfigV2(tc *v1alpha1.TidbCluster) *v1alpha1.TiFlashConfigWraper
 	{
 		proxy.SetIfNil("log-level", "info")
 		if !mountCMInTiflashContainer {
-			proxy.SetIfNil("server.engine-addr", fmt.Sprintf("%s-POD_NUM.%s.%s.svc%s:%d", controller.TiFlashMemberName(name), controller.TiFlashPeerMemberName(name), ns,
+			proxy.SetIfNil("server.engine-addr", fmt.Sprintf("%s-POD_NUM.%s.%s.svc%s:%d", controller.TiFlashRoleMemberName(name, tc.TiFlashRole()), controller.TiFlashRolePeerMemberName(name, tc.TiFlashRole()), ns,
 				controller.FormatClusterDomain(clusterDomain), v1alpha1.DefaultTiFlashFlashPort))
-			proxy.SetIfNil("server.advertise-status-addr", fmt.Sprintf("%s-POD_NUM.%s.%s.svc%s:%d", controller.TiFlashMemberName(name), controller.TiFlashPeerMemberName(name), ns,
+			proxy.SetIfNil("server.advertise-status-addr", fmt.Sprintf("%s-POD_NUM.%s.%s.svc%s:%d", controller.TiFlashRoleMemberName(name, tc.TiFlashRole()), controller.TiFlashRolePeerMemberName(name, tc.TiFlashRole()), ns,
 				controller.FormatClusterDomain(clusterDomain), v1alpha1.DefaultTiFlashProxyStatusPort))
 		}
 		proxy.SetIfNil("server.status-addr", fmt.Sprintf("%s:%d", listenHost, v1alpha1.DefaultTiFlashProxyStatusPort))
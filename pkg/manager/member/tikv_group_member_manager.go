@@ -0,0 +1,222 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/manager/suspender"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+type tikvGroupMemberManager struct {
+	deps   *controller.Dependencies
+	scaler Scaler
+	// tikvMemberManager syncs a TiKV group through the view built by TidbCluster.TiKVGroupView
+	tikvMemberManager *tikvMemberManager
+}
+
+// NewTiKVGroupMemberManager returns a *tikvGroupMemberManager
+func NewTiKVGroupMemberManager(deps *controller.Dependencies, failover Failover, scaler Scaler, upgrader TiKVUpgrader, spder suspender.Suspender, pvm volumes.PodVolumeModifier) manager.Manager {
+	return &tikvGroupMemberManager{
+		deps:   deps,
+		scaler: scaler,
+		tikvMemberManager: &tikvMemberManager{
+			deps:                     deps,
+			failover:                 failover,
+			scaler:                   scaler,
+			upgrader:                 upgrader,
+			suspender:                spder,
+			podVolumeModifier:        pvm,
+			statefulSetIsUpgradingFn: tikvStatefulSetIsUpgrading,
+		},
+	}
+}
+
+// Sync for all TiKV groups.
+func (m *tikvGroupMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
+	// scale in the TiKV groups that have been removed from the spec
+	for name := range tc.Status.TiKVGroups {
+		if tc.TiKVGroup(name) != nil {
+			continue
+		}
+		if err := m.syncRemovedGroup(tc, name); err != nil {
+			return err
+		}
+	}
+
+	if len(tc.Spec.TiKVGroups) > 0 && tc.Status.TiKVGroups == nil {
+		tc.Status.TiKVGroups = make(map[string]*v1alpha1.TiKVGroupStatus)
+	}
+
+	for _, group := range tc.Spec.TiKVGroups {
+		if err := m.syncGroup(tc, group); err != nil {
+			metrics.ClusterUpdateErrors.WithLabelValues(tc.GetNamespace(), tc.GetName(), group.Name).Inc()
+			klog.Errorf("syncGroup failed, error: %v, TiKV group: %s for cluster %s/%s", err, group.Name, tc.GetNamespace(), tc.GetName())
+			return err
+		}
+	}
+	return nil
+}
+
+// syncGroup syncs a single TiKV group by running the TiKV member manager
+// against the view of the group and copying the status back to tc.
+func (m *tikvGroupMemberManager) syncGroup(tc *v1alpha1.TidbCluster, group *v1alpha1.TiKVGroupSpec) error {
+	view := tc.TiKVGroupView(group)
+
+	mm := *m.tikvMemberManager
+	mm.upgrader = &tikvGroupUpgrader{TiKVUpgrader: m.tikvMemberManager.upgrader, tc: tc}
+	err := mm.Sync(view)
+
+	tc.Status.TiKVGroups[group.Name] = &v1alpha1.TiKVGroupStatus{
+		Name:       group.Name,
+		TiKVStatus: view.Status.TiKV,
+	}
+	tc.Status.ChangePlan = view.Status.ChangePlan
+	tc.Status.Operations = view.Status.Operations
+	return err
+}
+
+// syncRemovedGroup scales the StatefulSet of a removed TiKV group to 0, the
+// StatefulSet, Services and ConfigMaps of the group are deleted and the status
+// of the group is dropped once all of its pods are gone.
+func (m *tikvGroupMemberManager) syncRemovedGroup(tc *v1alpha1.TidbCluster, name string) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	setName := controller.TiKVGroupMemberName(tcName, name)
+
+	oldSetTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(setName)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("syncRemovedGroup: fail to get sts %s of TiKV group %s for cluster [%s/%s], error: %s",
+			setName, name, ns, tcName, err)
+	}
+	if errors.IsNotFound(err) || (oldSetTmp.Status.Replicas == 0 && *oldSetTmp.Spec.Replicas == 0) {
+		if err := m.deleteGroup(tc, name); err != nil {
+			return err
+		}
+		delete(tc.Status.TiKVGroups, name)
+		return nil
+	}
+
+	oldSet := oldSetTmp.DeepCopy()
+	newSet := oldSetTmp.DeepCopy()
+
+	view := tc.TiKVGroupView(&v1alpha1.TiKVGroupSpec{Name: name})
+	*newSet.Spec.Replicas = 0
+	err = m.scaler.Scale(view, oldSet, newSet)
+	if status := tc.Status.TiKVGroups[name]; status != nil {
+		status.TiKVStatus = view.Status.TiKV
+	}
+	tc.Status.Operations = view.Status.Operations
+	if err != nil {
+		return err
+	}
+	return mngerutils.UpdateStatefulSetWithPrecheck(m.deps, view, "FailedUpdateTiKVSTS", newSet, oldSet)
+}
+
+// deleteGroup deletes the StatefulSet, Services and ConfigMaps of a removed TiKV group.
+func (m *tikvGroupMemberManager) deleteGroup(tc *v1alpha1.TidbCluster, name string) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	set, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiKVGroupMemberName(tcName, name))
+	if err == nil {
+		err = m.deps.StatefulSetControl.DeleteStatefulSet(tc, set, metav1.DeleteOptions{})
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	for _, svcName := range []string{controller.TiKVGroupMemberName(tcName, name), controller.TiKVGroupPeerMemberName(tcName, name)} {
+		svc, err := m.deps.ServiceLister.Services(ns).Get(svcName)
+		if err == nil {
+			err = m.deps.ServiceControl.DeleteService(tc, svc)
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	selector, err := label.New().Instance(tc.GetInstanceName()).TiKV().TiKVGroup(name).Selector()
+	if err != nil {
+		return err
+	}
+	cms, err := m.deps.ConfigMapLister.ConfigMaps(ns).List(selector)
+	if err != nil {
+		return err
+	}
+	for _, cm := range cms {
+		if err := m.deps.ConfigMapControl.DeleteConfigMap(tc, cm); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// tikvGroupUpgrader upgrades a TiKV group only when neither the default TiKV
+// nor any other TiKV group is upgrading or scaling, so that at most one TiKV
+// StatefulSet rolls at a time.
+type tikvGroupUpgrader struct {
+	TiKVUpgrader
+	// tc is the TidbCluster the view being upgraded is built from
+	tc *v1alpha1.TidbCluster
+}
+
+func (u *tikvGroupUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	view, ok := meta.(*v1alpha1.TidbCluster)
+	if !ok {
+		return fmt.Errorf("cluster[%s/%s] failed to upgrading tikv group due to converting", meta.GetNamespace(), meta.GetName())
+	}
+	if notReadyReason := u.isTiKVGroupReadyToUpgrade(view); notReadyReason != "" {
+		klog.Infof("TidbCluster: [%s/%s], can not upgrade tikv group %s because: %s",
+			view.GetNamespace(), view.GetName(), view.TiKVGroupName(), notReadyReason)
+		_, podSpec, err := GetLastAppliedConfig(oldSet)
+		if err != nil {
+			return err
+		}
+		newSet.Spec.Template.Spec = *podSpec
+		return nil
+	}
+	return u.TiKVUpgrader.Upgrade(meta, oldSet, newSet)
+}
+
+func (u *tikvGroupUpgrader) isTiKVGroupReadyToUpgrade(view *v1alpha1.TidbCluster) string {
+	return otherTiKVSetsNotReadyReason(u.tc, view.TiKVGroupName(), view.Status.TiKV.Phase)
+}
+
+type FakeTiKVGroupMemberManager struct {
+	err error
+}
+
+func NewFakeTiKVGroupMemberManager() *FakeTiKVGroupMemberManager {
+	return &FakeTiKVGroupMemberManager{}
+}
+
+func (m *FakeTiKVGroupMemberManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeTiKVGroupMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
+	return m.err
+}
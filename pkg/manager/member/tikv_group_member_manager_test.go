@@ -0,0 +1,228 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newFakeTiKVGroupMemberManager(tc *v1alpha1.TidbCluster) (*tikvGroupMemberManager, *pdapi.FakePDClient) {
+	tmm, _, _, pdClient, _, _ := newFakeTiKVMemberManager(tc)
+	return &tikvGroupMemberManager{
+		deps:              tmm.deps,
+		scaler:            tmm.scaler,
+		tikvMemberManager: tmm,
+	}, pdClient
+}
+
+func TestTiKVGroupMemberManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+		"pd-0": {Name: "pd-0", Health: true},
+		"pd-1": {Name: "pd-1", Health: true},
+		"pd-2": {Name: "pd-2", Health: true},
+	}
+	tc.Status.PD.StatefulSet = &apps.StatefulSetStatus{ReadyReplicas: 3}
+	tc.Spec.TiKVGroups = []*v1alpha1.TiKVGroupSpec{
+		{Name: "nvme", TiKVSpec: *tc.Spec.TiKV.DeepCopy()},
+	}
+	tc.Spec.TiKVGroups[0].Replicas = 2
+	oldSpec := tc.Spec.DeepCopy()
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	gmm, _ := newFakeTiKVGroupMemberManager(tc)
+	err := gmm.Sync(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(&tc.Spec).To(Equal(oldSpec))
+	g.Expect(tc.Annotations).NotTo(HaveKey(label.AnnTiKVGroupKey))
+
+	set, err := gmm.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiKVGroupMemberName(tcName, "nvme"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*set.Spec.Replicas).To(Equal(int32(2)))
+	g.Expect(set.Spec.ServiceName).To(Equal(controller.TiKVGroupPeerMemberName(tcName, "nvme")))
+	g.Expect(set.Spec.Template.Labels).To(HaveKeyWithValue(label.TiKVGroupLabelKey, "nvme"))
+	g.Expect(set.Spec.Selector.MatchLabels).To(HaveKeyWithValue(label.TiKVGroupLabelKey, "nvme"))
+	g.Expect(set.Spec.VolumeClaimTemplates[0].Name).To(Equal("tikv"))
+
+	svc, err := gmm.deps.ServiceLister.Services(ns).Get(controller.TiKVGroupPeerMemberName(tcName, "nvme"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(svc.Spec.Selector).To(HaveKeyWithValue(label.TiKVGroupLabelKey, "nvme"))
+
+	// the default TiKV is synced by tikvMemberManager
+	_, err = gmm.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiKVMemberName(tcName))
+	expectErrIsNotFound(g, err)
+
+	g.Expect(tc.Status.TiKVGroups).To(HaveKey("nvme"))
+	g.Expect(tc.Status.TiKVGroups["nvme"].Name).To(Equal("nvme"))
+	g.Expect(tc.Status.TiKVGroups["nvme"].StatefulSet).NotTo(BeNil())
+	g.Expect(tc.Status.TiKV.StatefulSet).To(BeNil())
+
+	// a removed group is deleted with its status dropped once it is scaled in
+	set = set.DeepCopy()
+	*set.Spec.Replicas = 0
+	g.Expect(gmm.deps.KubeInformerFactory.Apps().V1().StatefulSets().Informer().GetIndexer().Update(set)).To(Succeed())
+	tc.Spec.TiKVGroups = nil
+	err = gmm.Sync(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Status.TiKVGroups).NotTo(HaveKey("nvme"))
+
+	// the status of a removed group is dropped when its StatefulSet is gone
+	tc = newTidbClusterForPD()
+	tc.Status.TiKVGroups = map[string]*v1alpha1.TiKVGroupStatus{
+		"hdd": {Name: "hdd"},
+	}
+	err = gmm.Sync(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Status.TiKVGroups).NotTo(HaveKey("hdd"))
+}
+
+func TestTiKVStorePattern(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Name = "demo"
+	tc.Namespace = "ns"
+	tc.Spec.TiKVGroups = []*v1alpha1.TiKVGroupSpec{{Name: "nvme"}}
+	defaultAddr := "demo-tikv-0.demo-tikv-peer.ns.svc:20160"
+	groupAddr := "demo-tikv-nvme-0.demo-tikv-nvme-peer.ns.svc:20160"
+
+	pattern, err := tikvStorePattern(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pattern.MatchString(defaultAddr)).To(BeTrue())
+	g.Expect(pattern.MatchString(groupAddr)).To(BeFalse())
+
+	pattern, err = tikvStorePattern(tc.TiKVGroupView(tc.Spec.TiKVGroups[0]))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pattern.MatchString(defaultAddr)).To(BeFalse())
+	g.Expect(pattern.MatchString(groupAddr)).To(BeTrue())
+}
+
+func TestTiKVGroupUpgraderIsReadyToUpgrade(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.TiKVGroups = []*v1alpha1.TiKVGroupSpec{{Name: "nvme"}, {Name: "hdd"}}
+	tc.Status.TiKVGroups = map[string]*v1alpha1.TiKVGroupStatus{
+		"nvme": {Name: "nvme"},
+		"hdd":  {Name: "hdd"},
+	}
+	u := &tikvGroupUpgrader{TiKVUpgrader: NewFakeTiKVUpgrader(), tc: tc}
+	view := func(name string) *v1alpha1.TidbCluster {
+		return tc.TiKVGroupView(tc.TiKVGroup(name))
+	}
+
+	g.Expect(u.isTiKVGroupReadyToUpgrade(view("nvme"))).To(BeEmpty())
+
+	// the default TiKV is upgrading
+	tc.Status.TiKV.Phase = v1alpha1.UpgradePhase
+	g.Expect(u.isTiKVGroupReadyToUpgrade(view("nvme"))).To(ContainSubstring("tikv status"))
+	tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+
+	// another TiKV group is upgrading
+	tc.Status.TiKVGroups["hdd"].Phase = v1alpha1.UpgradePhase
+	g.Expect(u.isTiKVGroupReadyToUpgrade(view("nvme"))).To(ContainSubstring("tikv group hdd"))
+	g.Expect(u.isTiKVGroupReadyToUpgrade(view("hdd"))).To(BeEmpty())
+
+	// the default TiKV waits for the TiKV groups
+	tu := &tikvUpgrader{}
+	g.Expect(tu.isTiKVReadyToUpgrade(tc)).To(ContainSubstring("tikv group hdd"))
+}
+
+func TestTiKVSetsUpgradeOneByOne(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.TiKVGroups = []*v1alpha1.TiKVGroupSpec{{Name: "nvme"}, {Name: "hdd"}}
+	tc.Status.TiKVGroups = map[string]*v1alpha1.TiKVGroupStatus{
+		"nvme": {Name: "nvme"},
+		"hdd":  {Name: "hdd"},
+	}
+	tu := &tikvUpgrader{}
+	u := &tikvGroupUpgrader{TiKVUpgrader: NewFakeTiKVUpgrader(), tc: tc}
+	phase := func(name string) *v1alpha1.MemberPhase {
+		if name == "" {
+			return &tc.Status.TiKV.Phase
+		}
+		return &tc.Status.TiKVGroups[name].Phase
+	}
+
+	// all the TiKV sets have a new pod template to be rolled
+	pending := map[string]bool{"": true, "nvme": true, "hdd": true}
+	// sync the TiKV sets that are pending and return the ones that are upgrading
+	sync := func() []string {
+		if pending[""] && tu.isTiKVReadyToUpgrade(tc) == "" {
+			tc.Status.TiKV.Phase = v1alpha1.UpgradePhase
+		}
+		for _, group := range tc.Spec.TiKVGroups {
+			if !pending[group.Name] {
+				continue
+			}
+			view := tc.TiKVGroupView(group)
+			oldSet := newStatefulSetForPDScale()
+			g.Expect(mngerutils.SetStatefulSetLastAppliedConfigAnnotation(oldSet)).To(Succeed())
+			newSet := oldSet.DeepCopy()
+			newSet.Spec.Template.Spec.Containers = []corev1.Container{{Name: "tikv", Image: "tikv:new"}}
+			g.Expect(u.Upgrade(view, oldSet, newSet)).To(Succeed())
+			if view.Status.TiKV.Phase == v1alpha1.UpgradePhase {
+				g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("tikv:new"))
+			} else {
+				g.Expect(newSet.Spec.Template.Spec).To(Equal(oldSet.Spec.Template.Spec))
+			}
+			tc.Status.TiKVGroups[group.Name].TiKVStatus = view.Status.TiKV
+		}
+		var upgrading []string
+		for _, name := range []string{"", "nvme", "hdd"} {
+			if *phase(name) == v1alpha1.UpgradePhase {
+				upgrading = append(upgrading, name)
+			}
+		}
+		return upgrading
+	}
+	// finish the upgrade of the TiKV set
+	finish := func(name string) {
+		*phase(name) = v1alpha1.NormalPhase
+		delete(pending, name)
+	}
+
+	// the default TiKV is upgraded first while the TiKV groups wait for it
+	g.Expect(sync()).To(Equal([]string{""}))
+	g.Expect(sync()).To(Equal([]string{""}))
+	finish("")
+
+	// then the TiKV groups are upgraded one by one
+	g.Expect(sync()).To(Equal([]string{"nvme"}))
+	g.Expect(sync()).To(Equal([]string{"nvme"}))
+	finish("nvme")
+	g.Expect(sync()).To(Equal([]string{"hdd"}))
+	finish("hdd")
+	g.Expect(sync()).To(BeEmpty())
+
+	// no TiKV set is upgraded while another one is scaling
+	pending = map[string]bool{"": true, "nvme": true}
+	*phase("hdd") = v1alpha1.ScalePhase
+	g.Expect(sync()).To(BeEmpty())
+	g.Expect(tu.isTiKVReadyToUpgrade(tc)).To(ContainSubstring("tikv group hdd status is Scale"))
+}
@@ -52,7 +52,7 @@ const (
 	tikvClusterCertPath = "/var/lib/tikv-tls"
 
 	// find a better way to manage store only managed by tikv in Operator
-	tikvStoreLimitPattern = `%s-tikv-\d+\.%s-tikv-peer\.%s\.svc%s\:\d+`
+	tikvStoreLimitPattern = `%s-\d+\.%s\.%s\.svc%s\:\d+`
 )
 
 // tikvMemberManager implements manager.Manager.
@@ -114,7 +114,7 @@ func (m *tikvMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 		return controller.RequeueErrorf("TidbCluster: [%s/%s], waiting for PD cluster running", ns, tcName)
 	}
 	// Check if all PD microservices are available
-	if tc.Spec.PDMS != nil && (tc.Spec.PD != nil && tc.Spec.PD.Mode == "ms") {
+	if tc.PDMSEnabled() {
 		for _, pdms := range tc.Spec.PDMS {
 			if cli := controller.GetPDMSClient(m.deps.PDControl, tc, pdms.Name); cli == nil {
 				return controller.RequeueErrorf("PDMS component %s for TidbCluster: [%s/%s], "+
@@ -128,13 +128,14 @@ func (m *tikvMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
+	group := tc.TiKVGroupName()
 	svcList := []SvcConfig{
 		{
 			Name:       "peer",
 			Port:       v1alpha1.DefaultTiKVServerPort,
 			Headless:   true,
-			SvcLabel:   func(l label.Label) label.Label { return l.TiKV() },
-			MemberName: controller.TiKVPeerMemberName,
+			SvcLabel:   func(l label.Label) label.Label { return l.TiKV().TiKVGroup(group) },
+			MemberName: func(name string) string { return controller.TiKVGroupPeerMemberName(name, group) },
 		},
 	}
 	for _, svc := range svcList {
@@ -204,9 +205,10 @@ func (m *tikvMemberManager) syncStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
-	oldSetTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiKVMemberName(tcName))
+	setName := controller.TiKVGroupMemberName(tcName, tc.TiKVGroupName())
+	oldSetTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(setName)
 	if err != nil && !errors.IsNotFound(err) {
-		return fmt.Errorf("syncStatefulSetForTidbCluster: failed to get sts %s for cluster %s/%s, error: %s", controller.TiKVMemberName(tcName), ns, tcName, err)
+		return fmt.Errorf("syncStatefulSetForTidbCluster: failed to get sts %s for cluster %s/%s, error: %s", setName, ns, tcName, err)
 	}
 	setNotExist := errors.IsNotFound(err)
 
@@ -240,6 +242,13 @@ func (m *tikvMemberManager) syncStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 	if err != nil {
 		return err
 	}
+	setStartBinary(tc, m.deps.CLIConfig.TiDBDiscoveryImage, &newSet.Spec.Template.Spec)
+
+	// in the plan mode, the changes driven by the spec are held until the plan is approved
+	if proceed, err := syncChangePlan(m.deps, tc, v1alpha1.TiKVMemberType, tc.Spec.TiKV.Replicas, oldSet, newSet); err != nil || !proceed {
+		return err
+	}
+
 	if setNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSet)
 		if err != nil {
@@ -257,6 +266,8 @@ func (m *tikvMemberManager) syncStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 		return err
 	}
 
+	recordStatefulSetOperations(tc, v1alpha1.TiKVMemberType, oldSet, newSet)
+
 	// Scaling takes precedence over upgrading because:
 	// - if a store fails in the upgrading, users may want to delete it or add
 	//   new replicas
@@ -309,7 +320,7 @@ func (m *tikvMemberManager) syncTiKVConfigMap(tc *v1alpha1.TidbCluster, set *app
 	var inUseName string
 	if set != nil {
 		inUseName = mngerutils.FindConfigMapVolume(&set.Spec.Template.Spec, func(name string) bool {
-			return strings.HasPrefix(name, controller.TiKVMemberName(tc.Name))
+			return strings.HasPrefix(name, controller.TiKVGroupMemberName(tc.Name, tc.TiKVGroupName()))
 		})
 	} else {
 		inUseName, err = mngerutils.FindConfigMapNameFromTCAnno(context.Background(), m.deps.ConfigMapLister, tc, v1alpha1.TiKVMemberType, newCm)
@@ -323,6 +334,11 @@ func (m *tikvMemberManager) syncTiKVConfigMap(tc *v1alpha1.TidbCluster, set *app
 		return nil, err
 	}
 
+	err = syncHotConfig(m.deps, tc, v1alpha1.TiKVMemberType, tc.BaseTiKVSpec().ConfigUpdateStrategy(), inUseName, newCm)
+	if err != nil {
+		return nil, err
+	}
+
 	err = m.applyPiTRConfigOverride(tc, newCm)
 	if err != nil {
 		return nil, err
@@ -496,11 +512,11 @@ func getNewTiKVSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 
 	stsLabels := labelTiKV(tc)
 	podLabels := util.CombineStringMap(stsLabels.Labels(), baseTiKVSpec.Labels())
-	setName := controller.TiKVMemberName(tcName)
+	setName := controller.TiKVGroupMemberName(tcName, tc.TiKVGroupName())
 	podAnnotations := util.CombineStringMap(baseTiKVSpec.Annotations(), controller.AnnProm(v1alpha1.DefaultTiKVStatusPort, "/metrics"))
 	stsAnnotations := getStsAnnotations(tc.Annotations, label.TiKVLabelVal)
 	capacity := controller.TiKVCapacity(tc.Spec.TiKV.Limits)
-	headlessSvcName := controller.TiKVPeerMemberName(tcName)
+	headlessSvcName := controller.TiKVGroupPeerMemberName(tcName, tc.TiKVGroupName())
 
 	deleteSlotsNumber, err := util.GetDeleteSlotsNumber(stsAnnotations)
 	if err != nil {
@@ -637,7 +653,7 @@ func getNewTiKVSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 		Name:            v1alpha1.TiKVMemberType.String(),
 		Image:           tc.TiKVImage(),
 		ImagePullPolicy: baseTiKVSpec.ImagePullPolicy(),
-		Command:         []string{"/bin/sh", "/usr/local/bin/tikv_start_script.sh"},
+		Command:         startScriptCommand(tc, "/usr/local/bin/tikv_start_script.sh"),
 		SecurityContext: &corev1.SecurityContext{
 			Privileged: tc.TiKVContainerPrivilege(),
 		},
@@ -801,9 +817,9 @@ func getTikVConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 		return nil, err
 	}
 	instanceName := tc.GetInstanceName()
-	tikvLabel := label.New().Instance(instanceName).TiKV().Labels()
+	tikvLabel := label.New().Instance(instanceName).TiKV().TiKVGroup(tc.TiKVGroupName()).Labels()
 	cm.ObjectMeta = metav1.ObjectMeta{
-		Name:            controller.TiKVMemberName(tc.Name),
+		Name:            controller.TiKVGroupMemberName(tc.Name, tc.TiKVGroupName()),
 		Namespace:       tc.Namespace,
 		Labels:          tikvLabel,
 		OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
@@ -814,7 +830,7 @@ func getTikVConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 
 func labelTiKV(tc *v1alpha1.TidbCluster) label.Label {
 	instanceName := tc.GetInstanceName()
-	return label.New().Instance(instanceName).TiKV()
+	return label.New().Instance(instanceName).TiKV().TiKVGroup(tc.TiKVGroupName())
 }
 
 func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) error {
@@ -872,10 +888,12 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 		return err
 	}
 
-	pattern, err := regexp.Compile(fmt.Sprintf(tikvStoreLimitPattern, tc.Name, tc.Name, tc.Namespace, controller.FormatClusterDomainForRegex(tc.Spec.ClusterDomain)))
+	pattern, err := tikvStorePattern(tc)
 	if err != nil {
 		return err
 	}
+	now := metav1.Now()
+	migrationTarget := newTiKVMigrationTarget(storesInfo.Stores)
 	for _, store := range storesInfo.Stores {
 		status := getTiKVStore(store)
 		if status == nil {
@@ -900,6 +918,11 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 		// So we check the store owner to make sure it.
 		if store.Store != nil {
 			if pattern.Match([]byte(store.Store.Address)) {
+				status.Group = tc.TiKVGroupName()
+				// the stores that appear after TiKV is bootstrapped are scaled out
+				_, existed := previousStores[status.ID]
+				isNew := !existed && len(previousStores) > 0
+				status.Migration = getTiKVStoreMigration(store, previousStores[status.ID].Migration, isNew, migrationTarget, now)
 				stores[status.ID] = *status
 			} else if util.MatchLabelFromStoreLabels(store.Store.Labels, label.TiKVLabelVal) {
 				peerStores[status.ID] = *status
@@ -922,6 +945,7 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 			continue
 		}
 
+		status.Group = tc.TiKVGroupName()
 		oldStore, exist := previousTombstoneStores[status.ID]
 		status.LastTransitionTime = metav1.Now()
 		if exist && status.State == oldStore.State {
@@ -930,6 +954,7 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 		tombstoneStores[status.ID] = *status
 	}
 
+	updateTiKVStoreMigrationMetrics(tc, previousStores, stores)
 	tc.Status.TiKV.Synced = true
 	tc.Status.TiKV.Stores = stores
 	tc.Status.TiKV.PeerStores = peerStores
@@ -949,6 +974,15 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 	return nil
 }
 
+// tikvStorePattern returns the pattern matching the address of the stores
+// managed by the TiKV StatefulSet of tc, a TiKV group only matches its own stores.
+func tikvStorePattern(tc *v1alpha1.TidbCluster) (*regexp.Regexp, error) {
+	group := tc.TiKVGroupName()
+	return regexp.Compile(fmt.Sprintf(tikvStoreLimitPattern,
+		controller.TiKVGroupMemberName(tc.Name, group), controller.TiKVGroupPeerMemberName(tc.Name, group),
+		tc.Namespace, controller.FormatClusterDomainForRegex(tc.Spec.ClusterDomain)))
+}
+
 func getTiKVStore(store *pdapi.StoreInfo) *v1alpha1.TiKVStore {
 	if store.Store == nil || store.Status == nil {
 		return nil
@@ -997,7 +1031,7 @@ func (m *tikvMemberManager) setStoreLabelsForTiKV(tc *v1alpha1.TidbCluster) (int
 		return setCount, nil
 	}
 
-	pattern, err := regexp.Compile(fmt.Sprintf(tikvStoreLimitPattern, tc.Name, tc.Name, tc.Namespace, controller.FormatClusterDomainForRegex(tc.Spec.ClusterDomain)))
+	pattern, err := tikvStorePattern(tc)
 	if err != nil {
 		return -1, err
 	}
@@ -1066,6 +1100,11 @@ func tikvStatefulSetIsUpgrading(podLister corelisters.PodLister, pdControl pdapi
 	if err != nil {
 		return false, err
 	}
+	groupRequirement, err := label.TiKVGroupRequirement(tc.TiKVGroupName())
+	if err != nil {
+		return false, err
+	}
+	selector = selector.Add(*groupRequirement)
 	tikvPods, err := podLister.Pods(tc.GetNamespace()).List(selector)
 	if err != nil {
 		return false, fmt.Errorf("tikvStatefulSetIsUpgrading: failed to get pods for cluster %s/%s, selector %s, error: %s", tc.GetNamespace(), instanceName, selector, err)
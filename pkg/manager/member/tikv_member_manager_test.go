@@ -437,6 +437,62 @@ func TestTiKVMemberManagerSyncUpdate(t *testing.T) {
 	}
 }
 
+// recordingFailover records the calls of Failover
+type recordingFailover struct {
+	fakeStoreFailover
+	failoverCount int
+}
+
+func (f *recordingFailover) Failover(_ *v1alpha1.TidbCluster) error {
+	f.failoverCount++
+	return nil
+}
+
+func TestTiKVMemberManagerSyncFailoverWithPendingChangePlan(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.TiKV.MaxFailoverCount = pointer.Int32Ptr(3)
+	tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+		"pd-0": {Name: "pd-0", Health: true},
+		"pd-1": {Name: "pd-1", Health: true},
+		"pd-2": {Name: "pd-2", Health: true},
+	}
+	tc.Status.PD.StatefulSet = &apps.StatefulSetStatus{ReadyReplicas: 3}
+
+	tkmm, fakeSetControl, _, pdClient, _, _ := newFakeTiKVMemberManager(tc)
+	failover := &recordingFailover{}
+	tkmm.failover = failover
+	tkmm.deps.CLIConfig.AutoFailover = true
+	pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdapi.PDConfigFromAPI{Replication: &pdapi.PDReplicationConfig{}}, nil
+	})
+	// none of the stores is up
+	pdClient.AddReaction(pdapi.GetStoresActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdapi.StoresInfo{Stores: []*pdapi.StoreInfo{}}, nil
+	})
+	pdClient.AddReaction(pdapi.GetTombStoneStoresActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdapi.StoresInfo{Stores: []*pdapi.StoreInfo{}}, nil
+	})
+	fakeSetControl.SetStatusChange(func(set *apps.StatefulSet) {
+		set.Status.Replicas = *set.Spec.Replicas
+		set.Status.ObservedGeneration = 1
+	})
+	g.Expect(tkmm.Sync(tc)).To(Succeed())
+
+	// the upgrade in the spec waits for the approval of the plan, while the failover still runs
+	tc1 := tc.DeepCopy()
+	tc1.Annotations = map[string]string{label.AnnChangePlan: "true"}
+	tc1.Spec.TiKV.Image = "pingcap/tikv:v8.1.0"
+	tc1.Status.PD.Phase = v1alpha1.NormalPhase
+	g.Expect(tkmm.Sync(tc1)).To(Succeed())
+	g.Expect(tc1.IsChangePlanPending()).To(BeTrue())
+	g.Expect(failover.failoverCount).To(Equal(1))
+	set, err := tkmm.deps.StatefulSetLister.StatefulSets(tc.Namespace).Get(controller.TiKVMemberName(tc.Name))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(set.Spec.Template.Spec.Containers[0].Image).NotTo(Equal("pingcap/tikv:v8.1.0"))
+}
+
 func TestTiKVMemberManagerTiKVStatefulSetIsUpgrading(t *testing.T) {
 	g := NewGomegaWithT(t)
 	type testcase struct {
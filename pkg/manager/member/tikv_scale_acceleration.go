@@ -0,0 +1,289 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	tikvScaleAcceleratedEvent = "ScaleAccelerated"
+	tikvScaleRestoredEvent    = "ScaleAccelerationRestored"
+
+	pdRegionScheduleLimitKey  = "schedule.region-schedule-limit"
+	pdReplicaScheduleLimitKey = "schedule.replica-schedule-limit"
+)
+
+// tikvScaleAccelerationManager accelerates the scheduling of PD while TiKV is being scaled or failed over.
+// It runs before the TiKV member managers, which return a requeue error while the scaling is in progress.
+type tikvScaleAccelerationManager struct {
+	deps *controller.Dependencies
+}
+
+// NewTiKVScaleAccelerationManager returns a *tikvScaleAccelerationManager
+func NewTiKVScaleAccelerationManager(deps *controller.Dependencies) manager.Manager {
+	return &tikvScaleAccelerationManager{
+		deps: deps,
+	}
+}
+
+func (m *tikvScaleAccelerationManager) Sync(tc *v1alpha1.TidbCluster) error {
+	return syncTiKVScaleAcceleration(m.deps, tc)
+}
+
+// syncTiKVScaleAcceleration raises the scheduling limits of PD to the acceleration profile in the scale
+// policy while TiKV is being scaled or failed over, and restores the previous limits after that.
+//
+// The scheduling limits are shared by all the TiKV StatefulSets of the cluster, so it is synced once for
+// the default TiKV and all the TiKV groups with the root tc: the state is only recorded in the status of
+// the default TiKV, the highest limits among the StatefulSets in progress are used, and the previous
+// limits are restored only after none of them is in progress.
+//
+// A limit is recorded in the status before it is raised, and it is raised only after the status is
+// persisted, i.e. in the next round, so the previous limits are never lost even if the operator
+// restarts in between. The limits are never lowered by the acceleration.
+func syncTiKVScaleAcceleration(deps *controller.Dependencies, tc *v1alpha1.TidbCluster) error {
+	var acceleration *v1alpha1.ScaleAcceleration
+	var stores []string
+	for _, tikv := range tikvSpecsAndStatuses(tc) {
+		if tikv.spec.ScalePolicy.Acceleration == nil || !tikvScaleOrFailoverInProgress(tikv.status) {
+			continue
+		}
+		acceleration = mergeScaleAcceleration(acceleration, tikv.spec.ScalePolicy.Acceleration)
+		for id := range tikv.status.Stores {
+			stores = append(stores, id)
+		}
+	}
+	status := tc.Status.TiKV.ScaleAcceleration
+	if acceleration == nil {
+		if status == nil {
+			return nil
+		}
+		return restoreTiKVScaleAcceleration(deps, tc, status)
+	}
+
+	if status == nil {
+		status = &v1alpha1.ScaleAccelerationStatus{StartTime: metav1.Now()}
+		tc.Status.TiKV.ScaleAcceleration = status
+		deps.Recorder.Event(tc, corev1.EventTypeNormal, tikvScaleAcceleratedEvent, "TiKV is being scaled or failed over, raise the scheduling limits of PD")
+	}
+
+	pdCli := controller.GetPDClient(deps.PDControl, tc)
+	if acceleration.StoreLimit != nil {
+		limits, err := pdCli.GetStoreLimits()
+		if err != nil {
+			return fmt.Errorf("get store limits failed, err: %v", err)
+		}
+		for _, id := range stores {
+			storeID, err := strconv.ParseUint(id, 10, 64)
+			if err != nil {
+				return err
+			}
+			limit, ok := limits[storeID]
+			if !ok {
+				continue
+			}
+			previous, ok := status.PreviousStoreLimits[id]
+			if !ok {
+				if status.PreviousStoreLimits == nil {
+					status.PreviousStoreLimits = map[string]v1alpha1.StoreLimitStatus{}
+				}
+				status.PreviousStoreLimits[id] = v1alpha1.StoreLimitStatus{AddPeer: limit.AddPeer, RemovePeer: limit.RemovePeer}
+				continue
+			}
+			rate := float64(*acceleration.StoreLimit)
+			if err := setStoreLimit(pdCli, storeID, pdapi.StoreLimitAddPeer, limit.AddPeer, max(rate, previous.AddPeer)); err != nil {
+				return err
+			}
+			if err := setStoreLimit(pdCli, storeID, pdapi.StoreLimitRemovePeer, limit.RemovePeer, max(rate, previous.RemovePeer)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if acceleration.RegionScheduleLimit == nil && acceleration.ReplicaScheduleLimit == nil {
+		return nil
+	}
+	config, err := pdCli.GetConfig()
+	if err != nil {
+		return fmt.Errorf("get config of PD failed, err: %v", err)
+	}
+	if config.Schedule == nil {
+		return nil
+	}
+	update := map[string]interface{}{}
+	raiseScheduleLimit(update, pdRegionScheduleLimitKey, acceleration.RegionScheduleLimit, config.Schedule.RegionScheduleLimit, &status.PreviousRegionScheduleLimit)
+	raiseScheduleLimit(update, pdReplicaScheduleLimitKey, acceleration.ReplicaScheduleLimit, config.Schedule.ReplicaScheduleLimit, &status.PreviousReplicaScheduleLimit)
+	if len(update) == 0 {
+		return nil
+	}
+	if err := pdCli.UpdateConfig(update); err != nil {
+		return fmt.Errorf("raise schedule limits of PD to %v failed, err: %v", update, err)
+	}
+	klog.Infof("TidbCluster: [%s/%s], raised schedule limits of PD to %v", tc.GetNamespace(), tc.GetName(), update)
+	return nil
+}
+
+type tikvSpecAndStatus struct {
+	spec   *v1alpha1.TiKVSpec
+	status *v1alpha1.TiKVStatus
+}
+
+// tikvSpecsAndStatuses returns the specs and the statuses of the default TiKV and the TiKV groups of tc
+func tikvSpecsAndStatuses(tc *v1alpha1.TidbCluster) []tikvSpecAndStatus {
+	var tikvs []tikvSpecAndStatus
+	if tc.Spec.TiKV != nil {
+		tikvs = append(tikvs, tikvSpecAndStatus{spec: tc.Spec.TiKV, status: &tc.Status.TiKV})
+	}
+	for _, group := range tc.Spec.TiKVGroups {
+		if group == nil {
+			continue
+		}
+		if status := tc.Status.TiKVGroups[group.Name]; status != nil {
+			tikvs = append(tikvs, tikvSpecAndStatus{spec: &group.TiKVSpec, status: &status.TiKVStatus})
+		}
+	}
+	return tikvs
+}
+
+// mergeScaleAcceleration returns the highest limits of both acceleration profiles
+func mergeScaleAcceleration(merged, acceleration *v1alpha1.ScaleAcceleration) *v1alpha1.ScaleAcceleration {
+	if merged == nil {
+		return acceleration.DeepCopy()
+	}
+	maxLimit := func(a, b *int32) *int32 {
+		if a == nil || (b != nil && *b > *a) {
+			return b
+		}
+		return a
+	}
+	merged.StoreLimit = maxLimit(merged.StoreLimit, acceleration.StoreLimit)
+	merged.RegionScheduleLimit = maxLimit(merged.RegionScheduleLimit, acceleration.RegionScheduleLimit)
+	merged.ReplicaScheduleLimit = maxLimit(merged.ReplicaScheduleLimit, acceleration.ReplicaScheduleLimit)
+	return merged
+}
+
+// restoreTiKVScaleAcceleration restores the scheduling limits of PD recorded in the status, the status is
+// cleared only after all the limits are restored.
+func restoreTiKVScaleAcceleration(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, status *v1alpha1.ScaleAccelerationStatus) error {
+	pdCli := controller.GetPDClient(deps.PDControl, tc)
+	if len(status.PreviousStoreLimits) > 0 {
+		limits, err := pdCli.GetStoreLimits()
+		if err != nil {
+			return fmt.Errorf("get store limits failed, err: %v", err)
+		}
+		for id, previous := range status.PreviousStoreLimits {
+			storeID, err := strconv.ParseUint(id, 10, 64)
+			if err != nil {
+				return err
+			}
+			limit, ok := limits[storeID]
+			if !ok {
+				// the store has been removed
+				continue
+			}
+			if err := setStoreLimit(pdCli, storeID, pdapi.StoreLimitAddPeer, limit.AddPeer, previous.AddPeer); err != nil {
+				return err
+			}
+			if err := setStoreLimit(pdCli, storeID, pdapi.StoreLimitRemovePeer, limit.RemovePeer, previous.RemovePeer); err != nil {
+				return err
+			}
+		}
+	}
+
+	update := map[string]interface{}{}
+	if status.PreviousRegionScheduleLimit != nil {
+		update[pdRegionScheduleLimitKey] = *status.PreviousRegionScheduleLimit
+	}
+	if status.PreviousReplicaScheduleLimit != nil {
+		update[pdReplicaScheduleLimitKey] = *status.PreviousReplicaScheduleLimit
+	}
+	if len(update) > 0 {
+		if err := pdCli.UpdateConfig(update); err != nil {
+			return fmt.Errorf("restore schedule limits of PD to %v failed, err: %v", update, err)
+		}
+	}
+
+	tc.Status.TiKV.ScaleAcceleration = nil
+	deps.Recorder.Event(tc, corev1.EventTypeNormal, tikvScaleRestoredEvent, "TiKV is not being scaled or failed over, restore the scheduling limits of PD")
+	return nil
+}
+
+// tikvScaleOrFailoverInProgress returns whether a TiKV StatefulSet is being scaled or failed over, which
+// includes the migration of the data of the stores and the replenishment of the replicas of the failure stores.
+func tikvScaleOrFailoverInProgress(status *v1alpha1.TiKVStatus) bool {
+	if status.Phase == v1alpha1.ScalePhase {
+		return true
+	}
+	for id, store := range status.Stores {
+		if store.Migration != nil {
+			return true
+		}
+		if _, ok := status.FailureStores[id]; ok && store.State == v1alpha1.TiKVStateDown {
+			return true
+		}
+	}
+	return false
+}
+
+func setStoreLimit(pdCli pdapi.PDClient, storeID uint64, limitType pdapi.StoreLimitType, current, rate float64) error {
+	if current == rate {
+		return nil
+	}
+	if err := pdCli.SetStoreLimit(storeID, limitType, rate); err != nil {
+		return fmt.Errorf("set %s limit of store %d to %v failed, err: %v", limitType, storeID, rate, err)
+	}
+	return nil
+}
+
+// raiseScheduleLimit records the current limit in previous if it is not recorded yet, or adds the limit
+// to the update if it is lower than the accelerated one.
+func raiseScheduleLimit(update map[string]interface{}, key string, accelerated *int32, current *uint64, previous **int64) {
+	if accelerated == nil || current == nil {
+		return
+	}
+	if *previous == nil {
+		v := int64(*current)
+		*previous = &v
+		return
+	}
+	if want := max(int64(*accelerated), **previous); int64(*current) != want {
+		update[key] = want
+	}
+}
+
+type FakeTiKVScaleAccelerationManager struct {
+	err error
+}
+
+func NewFakeTiKVScaleAccelerationManager() *FakeTiKVScaleAccelerationManager {
+	return &FakeTiKVScaleAccelerationManager{}
+}
+
+func (m *FakeTiKVScaleAccelerationManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeTiKVScaleAccelerationManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
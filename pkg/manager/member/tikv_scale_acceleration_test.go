@@ -0,0 +1,206 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+)
+
+func TestSyncTiKVScaleAcceleration(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	tc := newTidbClusterForTiKV()
+	tc.Spec.TiKV.ScalePolicy.Acceleration = &v1alpha1.ScaleAcceleration{
+		StoreLimit:           pointer.Int32(60),
+		RegionScheduleLimit:  pointer.Int32(100),
+		ReplicaScheduleLimit: pointer.Int32(32),
+	}
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"1": {ID: "1", State: v1alpha1.TiKVStateUp},
+		"2": {ID: "2", State: v1alpha1.TiKVStateUp},
+	}
+
+	// the fake PD
+	limits := map[uint64]pdapi.StoreLimit{
+		1: {AddPeer: 15, RemovePeer: 15},
+		2: {AddPeer: 15, RemovePeer: 80},
+		// the store of TiFlash
+		3: {AddPeer: 15, RemovePeer: 15},
+	}
+	var regionScheduleLimit, replicaScheduleLimit uint64 = 20, 64
+	setStoreLimitCount := 0
+	pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), tc)
+	pdClient.AddReaction(pdapi.GetStoreLimitsActionType, func(action *pdapi.Action) (interface{}, error) {
+		result := map[uint64]pdapi.StoreLimit{}
+		for id, limit := range limits {
+			result[id] = limit
+		}
+		return result, nil
+	})
+	pdClient.AddReaction(pdapi.SetStoreLimitActionType, func(action *pdapi.Action) (interface{}, error) {
+		setStoreLimitCount++
+		limit := limits[action.ID]
+		switch action.StoreLimitType {
+		case pdapi.StoreLimitAddPeer:
+			limit.AddPeer = action.Rate
+		case pdapi.StoreLimitRemovePeer:
+			limit.RemovePeer = action.Rate
+		}
+		limits[action.ID] = limit
+		return nil, nil
+	})
+	pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+		region, replica := regionScheduleLimit, replicaScheduleLimit
+		return &pdapi.PDConfigFromAPI{
+			Schedule: &pdapi.PDScheduleConfig{RegionScheduleLimit: &region, ReplicaScheduleLimit: &replica},
+		}, nil
+	})
+	pdClient.AddReaction(pdapi.UpdateConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+		for k, v := range action.Config {
+			switch k {
+			case pdRegionScheduleLimitKey:
+				regionScheduleLimit = uint64(v.(int64))
+			case pdReplicaScheduleLimitKey:
+				replicaScheduleLimit = uint64(v.(int64))
+			default:
+				return nil, fmt.Errorf("unexpected config %s", k)
+			}
+		}
+		return nil, nil
+	})
+
+	// not in progress
+	g.Expect(syncTiKVScaleAcceleration(deps, tc)).To(Succeed())
+	g.Expect(tc.Status.TiKV.ScaleAcceleration).To(BeNil())
+
+	// the previous limits are recorded first
+	tc.Status.TiKV.Phase = v1alpha1.ScalePhase
+	g.Expect(syncTiKVScaleAcceleration(deps, tc)).To(Succeed())
+	status := tc.Status.TiKV.ScaleAcceleration
+	g.Expect(status).NotTo(BeNil())
+	g.Expect(status.PreviousStoreLimits).To(Equal(map[string]v1alpha1.StoreLimitStatus{
+		"1": {AddPeer: 15, RemovePeer: 15},
+		"2": {AddPeer: 15, RemovePeer: 80},
+	}))
+	g.Expect(*status.PreviousRegionScheduleLimit).To(Equal(int64(20)))
+	g.Expect(*status.PreviousReplicaScheduleLimit).To(Equal(int64(64)))
+	g.Expect(setStoreLimitCount).To(Equal(0))
+	g.Expect(regionScheduleLimit).To(Equal(uint64(20)))
+
+	// the limits are raised in the next round, but never lowered
+	g.Expect(syncTiKVScaleAcceleration(deps, tc)).To(Succeed())
+	g.Expect(limits[1]).To(Equal(pdapi.StoreLimit{AddPeer: 60, RemovePeer: 60}))
+	g.Expect(limits[2]).To(Equal(pdapi.StoreLimit{AddPeer: 60, RemovePeer: 80}))
+	g.Expect(limits[3]).To(Equal(pdapi.StoreLimit{AddPeer: 15, RemovePeer: 15}))
+	g.Expect(regionScheduleLimit).To(Equal(uint64(100)))
+	g.Expect(replicaScheduleLimit).To(Equal(uint64(64)))
+	g.Expect(setStoreLimitCount).To(Equal(3))
+
+	// nothing is changed if the limits are raised already
+	g.Expect(syncTiKVScaleAcceleration(deps, tc)).To(Succeed())
+	g.Expect(setStoreLimitCount).To(Equal(3))
+
+	// the data is still being migrated after the StatefulSet is scaled
+	tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+	tc.Status.TiKV.Stores["2"] = v1alpha1.TiKVStore{ID: "2", State: v1alpha1.TiKVStateUp, Migration: &v1alpha1.TiKVStoreMigration{Direction: v1alpha1.TiKVStoreMigrationIn}}
+	g.Expect(syncTiKVScaleAcceleration(deps, tc)).To(Succeed())
+	g.Expect(tc.Status.TiKV.ScaleAcceleration).NotTo(BeNil())
+
+	// the previous limits are restored after the scale is completed
+	tc.Status.TiKV.Stores["2"] = v1alpha1.TiKVStore{ID: "2", State: v1alpha1.TiKVStateUp}
+	g.Expect(syncTiKVScaleAcceleration(deps, tc)).To(Succeed())
+	g.Expect(tc.Status.TiKV.ScaleAcceleration).To(BeNil())
+	g.Expect(limits[1]).To(Equal(pdapi.StoreLimit{AddPeer: 15, RemovePeer: 15}))
+	g.Expect(limits[2]).To(Equal(pdapi.StoreLimit{AddPeer: 15, RemovePeer: 80}))
+	g.Expect(regionScheduleLimit).To(Equal(uint64(20)))
+	g.Expect(replicaScheduleLimit).To(Equal(uint64(64)))
+
+	events := collectEvents(deps.Recorder.(*record.FakeRecorder).Events)
+	g.Expect(events).To(HaveLen(2))
+	g.Expect(events[0]).To(ContainSubstring(tikvScaleAcceleratedEvent))
+	g.Expect(events[1]).To(ContainSubstring(tikvScaleRestoredEvent))
+}
+
+func TestSyncTiKVScaleAccelerationWithTiKVGroups(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	tc := newTidbClusterForTiKV()
+	tc.Spec.TiKV.ScalePolicy.Acceleration = &v1alpha1.ScaleAcceleration{RegionScheduleLimit: pointer.Int32(100)}
+	group := &v1alpha1.TiKVGroupSpec{Name: "g1"}
+	group.ScalePolicy.Acceleration = &v1alpha1.ScaleAcceleration{RegionScheduleLimit: pointer.Int32(200)}
+	tc.Spec.TiKVGroups = []*v1alpha1.TiKVGroupSpec{group}
+	tc.Status.TiKVGroups = map[string]*v1alpha1.TiKVGroupStatus{"g1": {Name: "g1"}}
+
+	var regionScheduleLimit uint64 = 20
+	pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), tc)
+	pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+		region := regionScheduleLimit
+		return &pdapi.PDConfigFromAPI{Schedule: &pdapi.PDScheduleConfig{RegionScheduleLimit: &region}}, nil
+	})
+	pdClient.AddReaction(pdapi.UpdateConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+		regionScheduleLimit = uint64(action.Config[pdRegionScheduleLimitKey].(int64))
+		return nil, nil
+	})
+
+	// the default TiKV is being scaled
+	tc.Status.TiKV.Phase = v1alpha1.ScalePhase
+	g.Expect(syncTiKVScaleAcceleration(deps, tc)).To(Succeed())
+	g.Expect(syncTiKVScaleAcceleration(deps, tc)).To(Succeed())
+	g.Expect(regionScheduleLimit).To(Equal(uint64(100)))
+
+	// the TiKV group is being scaled too, the highest limit is used and the previous one is kept
+	tc.Status.TiKVGroups["g1"].Phase = v1alpha1.ScalePhase
+	g.Expect(syncTiKVScaleAcceleration(deps, tc)).To(Succeed())
+	g.Expect(regionScheduleLimit).To(Equal(uint64(200)))
+	g.Expect(*tc.Status.TiKV.ScaleAcceleration.PreviousRegionScheduleLimit).To(Equal(int64(20)))
+
+	// the limits are kept until no TiKV StatefulSet is being scaled
+	tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+	g.Expect(syncTiKVScaleAcceleration(deps, tc)).To(Succeed())
+	g.Expect(regionScheduleLimit).To(Equal(uint64(200)))
+	tc.Status.TiKVGroups["g1"].Phase = v1alpha1.NormalPhase
+	g.Expect(syncTiKVScaleAcceleration(deps, tc)).To(Succeed())
+	g.Expect(regionScheduleLimit).To(Equal(uint64(20)))
+	g.Expect(tc.Status.TiKV.ScaleAcceleration).To(BeNil())
+	g.Expect(tc.Status.TiKVGroups["g1"].ScaleAcceleration).To(BeNil())
+}
+
+func TestTiKVScaleOrFailoverInProgress(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiKV()
+	tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"1": {ID: "1", State: v1alpha1.TiKVStateUp},
+		"2": {ID: "2", State: v1alpha1.TiKVStateDown},
+	}
+	g.Expect(tikvScaleOrFailoverInProgress(&tc.Status.TiKV)).To(BeFalse())
+
+	tc.Status.TiKV.FailureStores = map[string]v1alpha1.TiKVFailureStore{"2": {StoreID: "2"}}
+	g.Expect(tikvScaleOrFailoverInProgress(&tc.Status.TiKV)).To(BeTrue())
+
+	tc.Status.TiKV.FailureStores = nil
+	tc.Status.TiKV.Phase = v1alpha1.ScalePhase
+	g.Expect(tikvScaleOrFailoverInProgress(&tc.Status.TiKV)).To(BeTrue())
+}
@@ -0,0 +1,126 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// the reasons of the TiKVScaleInBlocked condition
+	tikvScaleInInsufficientCapacity = "InsufficientCapacity"
+	tikvScaleInPlacementViolation   = "PlacementViolation"
+
+	// defaultLowSpaceRatio is the default low-space-ratio of PD, PD stops scheduling regions
+	// to the stores whose usage is over it
+	defaultLowSpaceRatio = 0.8
+)
+
+// checkTiKVScaleInFeasibility checks whether the TiKV stores remaining after the given stores are scaled in
+// can hold all the data with the headroom below the low-space-ratio of PD, and can still place the replicas
+// in distinct topology domains at the isolation level or the first location label.
+//
+// It returns the reason and message if the scale-in is infeasible, or empty strings otherwise.
+func checkTiKVScaleInFeasibility(stores []*pdapi.StoreInfo, scaleInStoreIDs sets.String, config *pdapi.PDConfigFromAPI) (reason, message string) {
+	var remaining, current []*pdapi.StoreInfo
+	var used uint64
+	for _, store := range stores {
+		if store.Store == nil || !util.MatchLabelFromStoreLabels(store.Store.Labels, label.TiKVLabelVal) {
+			continue
+		}
+		switch store.Store.StateName {
+		case v1alpha1.TiKVStateUp:
+			current = append(current, store)
+			if !scaleInStoreIDs.Has(strconv.FormatUint(store.Store.Id, 10)) {
+				remaining = append(remaining, store)
+			}
+		case v1alpha1.TiKVStateOffline:
+			// the data of the offline stores is being moved to the remaining stores too
+		default:
+			continue
+		}
+		if store.Status != nil {
+			used += uint64(store.Status.UsedSize)
+		}
+	}
+	ids := strings.Join(scaleInStoreIDs.List(), ",")
+
+	// check capacity, skip it if the capacity of any remaining store is unknown
+	var capacity uint64
+	for _, store := range remaining {
+		if store.Status == nil || store.Status.Capacity == 0 {
+			capacity = 0
+			break
+		}
+		capacity += uint64(store.Status.Capacity)
+	}
+	if capacity > 0 {
+		ratio := defaultLowSpaceRatio
+		if config.Schedule != nil && config.Schedule.LowSpaceRatio != nil {
+			ratio = *config.Schedule.LowSpaceRatio
+		}
+		if float64(used) > ratio*float64(capacity) {
+			return tikvScaleInInsufficientCapacity, fmt.Sprintf("after scaling in stores [%s], the remaining %d stores would hold %d bytes of data with %d bytes of capacity, "+
+				"the usage %.2f is more than the low-space-ratio %.2f of PD", ids, len(remaining), used, capacity, float64(used)/float64(capacity), ratio)
+		}
+	}
+
+	// check placement
+	if config.Replication == nil || config.Replication.MaxReplicas == nil || len(config.Replication.LocationLabels) == 0 {
+		return "", ""
+	}
+	maxReplicas := int(*config.Replication.MaxReplicas)
+	locationLabels := []string(config.Replication.LocationLabels)
+	level := locationLabels[0]
+	if l := config.Replication.IsolationLevel; l != "" {
+		level = l
+	}
+	before := countTopologyDomains(current, locationLabels, level)
+	after := countTopologyDomains(remaining, locationLabels, level)
+	// do not refuse the scale-in if the location constraints are not satisfied before scaling in
+	if before >= maxReplicas && after < maxReplicas {
+		return tikvScaleInPlacementViolation, fmt.Sprintf("after scaling in stores [%s], the remaining stores are in %d distinct %q, less than max-replicas %d of PD",
+			ids, after, level, maxReplicas)
+	}
+	return "", ""
+}
+
+// countTopologyDomains returns the number of the distinct domains of the stores at the level, a domain is
+// identified by the values of the location labels from the first one to the level.
+func countTopologyDomains(stores []*pdapi.StoreInfo, locationLabels []string, level string) int {
+	domains := sets.NewString()
+	for _, store := range stores {
+		labels := map[string]string{}
+		for _, l := range store.Store.Labels {
+			labels[l.Key] = l.Value
+		}
+		var values []string
+		for _, key := range locationLabels {
+			values = append(values, labels[key])
+			if key == level {
+				break
+			}
+		}
+		domains.Insert(strings.Join(values, "/"))
+	}
+	return domains.Len()
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+	"github.com/tikv/pd/pkg/typeutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+)
+
+const gib = 1 << 30
+
+func newStoreForScaleInCheck(id uint64, state, zone string, capacity, used uint64) *pdapi.StoreInfo {
+	return &pdapi.StoreInfo{
+		Store: &pdapi.MetaStore{
+			StateName: state,
+			Store: &metapb.Store{
+				Id:     id,
+				Labels: []*metapb.StoreLabel{{Key: "zone", Value: zone}, {Key: "host", Value: "host" + zone}},
+			},
+		},
+		Status: &pdapi.StoreStatus{Capacity: typeutil.ByteSize(capacity), UsedSize: typeutil.ByteSize(used)},
+	}
+}
+
+func TestCheckTiKVScaleInFeasibility(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var maxReplicas uint64 = 3
+	config := &pdapi.PDConfigFromAPI{
+		Replication: &pdapi.PDReplicationConfig{
+			MaxReplicas:    &maxReplicas,
+			LocationLabels: pdapi.StringSlice{"zone", "host"},
+		},
+	}
+	tests := []struct {
+		name    string
+		stores  []*pdapi.StoreInfo
+		ids     []string
+		config  *pdapi.PDConfigFromAPI
+		reason  string
+		message string
+	}{
+		{
+			name: "feasible",
+			stores: []*pdapi.StoreInfo{
+				newStoreForScaleInCheck(1, v1alpha1.TiKVStateUp, "a", 100*gib, 20*gib),
+				newStoreForScaleInCheck(2, v1alpha1.TiKVStateUp, "b", 100*gib, 20*gib),
+				newStoreForScaleInCheck(3, v1alpha1.TiKVStateUp, "c", 100*gib, 20*gib),
+				newStoreForScaleInCheck(4, v1alpha1.TiKVStateUp, "c", 100*gib, 20*gib),
+			},
+			ids:    []string{"4"},
+			config: config,
+		},
+		{
+			name: "insufficient capacity",
+			stores: []*pdapi.StoreInfo{
+				newStoreForScaleInCheck(1, v1alpha1.TiKVStateUp, "a", 100*gib, 70*gib),
+				newStoreForScaleInCheck(2, v1alpha1.TiKVStateUp, "b", 100*gib, 70*gib),
+				newStoreForScaleInCheck(3, v1alpha1.TiKVStateUp, "c", 100*gib, 70*gib),
+				newStoreForScaleInCheck(4, v1alpha1.TiKVStateUp, "c", 100*gib, 70*gib),
+			},
+			ids:     []string{"4"},
+			config:  config,
+			reason:  tikvScaleInInsufficientCapacity,
+			message: "the remaining 3 stores",
+		},
+		{
+			name: "the data of offline stores is counted",
+			stores: []*pdapi.StoreInfo{
+				newStoreForScaleInCheck(1, v1alpha1.TiKVStateUp, "a", 100*gib, 60*gib),
+				newStoreForScaleInCheck(2, v1alpha1.TiKVStateUp, "b", 100*gib, 60*gib),
+				newStoreForScaleInCheck(3, v1alpha1.TiKVStateUp, "c", 100*gib, 60*gib),
+				newStoreForScaleInCheck(4, v1alpha1.TiKVStateUp, "c", 100*gib, 30*gib),
+				newStoreForScaleInCheck(5, v1alpha1.TiKVStateOffline, "c", 100*gib, 40*gib),
+			},
+			ids:    []string{"4"},
+			config: config,
+			reason: tikvScaleInInsufficientCapacity,
+		},
+		{
+			name: "capacity is unknown",
+			stores: []*pdapi.StoreInfo{
+				{Store: &pdapi.MetaStore{StateName: v1alpha1.TiKVStateUp, Store: &metapb.Store{Id: 1}}},
+				{Store: &pdapi.MetaStore{StateName: v1alpha1.TiKVStateUp, Store: &metapb.Store{Id: 2}}},
+			},
+			ids:    []string{"2"},
+			config: &pdapi.PDConfigFromAPI{},
+		},
+		{
+			name: "low space ratio of PD is used",
+			stores: []*pdapi.StoreInfo{
+				newStoreForScaleInCheck(1, v1alpha1.TiKVStateUp, "a", 100*gib, 30*gib),
+				newStoreForScaleInCheck(2, v1alpha1.TiKVStateUp, "b", 100*gib, 30*gib),
+			},
+			ids:    []string{"2"},
+			config: &pdapi.PDConfigFromAPI{Schedule: &pdapi.PDScheduleConfig{LowSpaceRatio: pointer.Float64(0.5)}},
+			reason: tikvScaleInInsufficientCapacity,
+		},
+		{
+			name: "placement violation",
+			stores: []*pdapi.StoreInfo{
+				newStoreForScaleInCheck(1, v1alpha1.TiKVStateUp, "a", 100*gib, 10*gib),
+				newStoreForScaleInCheck(2, v1alpha1.TiKVStateUp, "b", 100*gib, 10*gib),
+				newStoreForScaleInCheck(3, v1alpha1.TiKVStateUp, "c", 100*gib, 10*gib),
+				newStoreForScaleInCheck(4, v1alpha1.TiKVStateUp, "a", 100*gib, 10*gib),
+			},
+			ids:     []string{"3"},
+			config:  config,
+			reason:  tikvScaleInPlacementViolation,
+			message: `the remaining stores are in 2 distinct "zone"`,
+		},
+		{
+			name: "placement is not satisfied before scaling in",
+			stores: []*pdapi.StoreInfo{
+				newStoreForScaleInCheck(1, v1alpha1.TiKVStateUp, "a", 100*gib, 10*gib),
+				newStoreForScaleInCheck(2, v1alpha1.TiKVStateUp, "b", 100*gib, 10*gib),
+				newStoreForScaleInCheck(3, v1alpha1.TiKVStateUp, "b", 100*gib, 10*gib),
+			},
+			ids:    []string{"3"},
+			config: config,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, message := checkTiKVScaleInFeasibility(tt.stores, sets.NewString(tt.ids...), tt.config)
+			g.Expect(reason).To(Equal(tt.reason))
+			g.Expect(message).To(ContainSubstring(tt.message))
+		})
+	}
+}
+
+func TestTiKVScalerCheckScaleInFeasibility(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"4": {ID: "4", PodName: ordinalPodName(v1alpha1.TiKVMemberType, tc.GetName(), 3), State: v1alpha1.TiKVStateUp},
+	}
+	storesInfo := &pdapi.StoresInfo{
+		Stores: []*pdapi.StoreInfo{
+			newStoreForScaleInCheck(1, v1alpha1.TiKVStateUp, "a", 100*gib, 70*gib),
+			newStoreForScaleInCheck(2, v1alpha1.TiKVStateUp, "b", 100*gib, 70*gib),
+			newStoreForScaleInCheck(3, v1alpha1.TiKVStateUp, "c", 100*gib, 70*gib),
+			newStoreForScaleInCheck(4, v1alpha1.TiKVStateUp, "c", 100*gib, 70*gib),
+		},
+	}
+	config := &pdapi.PDConfigFromAPI{}
+	scaler, _, _, _, _ := newFakeTiKVScaler()
+
+	// the infeasible scale-in is refused with the condition
+	err := scaler.checkScaleInFeasibility(tc, []int32{3}, storesInfo, config)
+	g.Expect(err).To(HaveOccurred())
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterTiKVScaleInBlocked)
+	g.Expect(cond).NotTo(BeNil())
+	g.Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(cond.Reason).To(Equal(tikvScaleInInsufficientCapacity))
+	g.Expect(cond.Message).To(ContainSubstring(label.AnnTiKVForceScaleIn))
+	events := collectEvents(scaler.deps.Recorder.(*record.FakeRecorder).Events)
+	g.Expect(events).To(HaveLen(1))
+	g.Expect(events[0]).To(ContainSubstring("FailedScaleIn"))
+
+	// the event is not recorded again
+	g.Expect(scaler.checkScaleInFeasibility(tc, []int32{3}, storesInfo, config)).NotTo(Succeed())
+	g.Expect(collectEvents(scaler.deps.Recorder.(*record.FakeRecorder).Events)).To(BeEmpty())
+
+	// the check is skipped by the annotation
+	tc.Annotations = map[string]string{label.AnnTiKVForceScaleIn: "true"}
+	g.Expect(scaler.checkScaleInFeasibility(tc, []int32{3}, storesInfo, config)).To(Succeed())
+	g.Expect(utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterTiKVScaleInBlocked)).To(BeNil())
+
+	// the store that is not up is not checked
+	tc.Annotations = nil
+	store := tc.Status.TiKV.Stores["4"]
+	store.State = v1alpha1.TiKVStateOffline
+	tc.Status.TiKV.Stores["4"] = store
+	g.Expect(scaler.checkScaleInFeasibility(tc, []int32{3}, storesInfo, config)).To(Succeed())
+}
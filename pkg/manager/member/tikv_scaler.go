@@ -18,11 +18,14 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
 	"github.com/pingcap/tidb-operator/pkg/third_party/k8s"
 	"github.com/pingcap/tidb-operator/pkg/util"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -47,6 +50,10 @@ func NewTiKVScaler(deps *controller.Dependencies) *tikvScaler {
 
 func (s *tikvScaler) Scale(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
 	scaling, _, _, _ := scaleOne(oldSet, newSet)
+	if tc, ok := meta.(*v1alpha1.TidbCluster); ok && scaling >= 0 {
+		// the refused scale-in is reverted or finished
+		utiltidbcluster.RemoveTidbClusterCondition(&tc.Status, v1alpha1.TidbClusterTiKVScaleInBlocked)
+	}
 	if scaling > 0 {
 		return s.ScaleOut(meta, oldSet, newSet)
 	} else if scaling < 0 {
@@ -91,7 +98,7 @@ func (s *tikvScaler) ScaleOut(meta metav1.Object, oldSet *apps.StatefulSet, newS
 }
 
 func (s *tikvScaler) scaleOutOne(tc *v1alpha1.TidbCluster, ordinal int32) error {
-	pvcName := fmt.Sprintf("tikv-%s-tikv-%d", tc.GetName(), ordinal)
+	pvcName := fmt.Sprintf("tikv-%s", tikvGroupPodName(tc.GetName(), tc.TiKVGroupName(), ordinal))
 	_, err := s.deps.PVCLister.PersistentVolumeClaims(tc.GetNamespace()).Get(pvcName)
 	if err == nil {
 		_, err = s.deleteDeferDeletingPVC(tc, v1alpha1.TiKVMemberType, ordinal)
@@ -147,7 +154,13 @@ func (s *tikvScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSe
 				upTikvStoreCount++
 			}
 		}
+		if err := s.checkScaleInFeasibility(tc, ordinals, storesInfo, config); err != nil {
+			resetReplicas(newSet, oldSet)
+			return err
+		}
 	}
+	scaleInOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet).Difference(helper.GetPodOrdinals(*newSet.Spec.Replicas, newSet))
+	recordScaleInRetried(tc, v1alpha1.TiKVMemberType, scaleInOrdinals.List()...)
 
 	var (
 		errs                         []error
@@ -181,7 +194,7 @@ func (s *tikvScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSe
 func (s *tikvScaler) scaleInOne(tc *v1alpha1.TidbCluster, skipPreCheck bool, upTikvStoreCount, deletedUpStoreCount, maxReplicas int, ordinal int32, currentTime time.Time) (deletedUpStore int, err error) {
 	tcName := tc.GetName()
 	ns := tc.GetNamespace()
-	podName := ordinalPodName(v1alpha1.TiKVMemberType, tcName, ordinal)
+	podName := tikvGroupPodName(tcName, tc.TiKVGroupName(), ordinal)
 	pod, err := s.deps.PodLister.Pods(ns).Get(podName)
 	if err != nil {
 		return deletedUpStore, fmt.Errorf("tikvScaler.ScaleIn: failed to get pods %s for cluster %s/%s, error: %s", podName, ns, tcName, err)
@@ -392,6 +405,48 @@ func (s *tikvScaler) preCheckUpStores(tc *v1alpha1.TidbCluster, podName string,
 	return true
 }
 
+// checkScaleInFeasibility refuses to scale in the Up stores of the ordinals if the remaining stores can not hold
+// the data or satisfy the location constraints, the TiKVScaleInBlocked condition is set in this case. The check
+// can be skipped by the annotation `tidb.pingcap.com/tikv-force-scale-in`.
+func (s *tikvScaler) checkScaleInFeasibility(tc *v1alpha1.TidbCluster, ordinals []int32, storesInfo *pdapi.StoresInfo, config *pdapi.PDConfigFromAPI) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	if tc.Annotations[label.AnnTiKVForceScaleIn] == "true" {
+		klog.Infof("tikvScaler.ScaleIn: skip the feasibility check for cluster %s/%s because of annotation %s", ns, tcName, label.AnnTiKVForceScaleIn)
+		utiltidbcluster.RemoveTidbClusterCondition(&tc.Status, v1alpha1.TidbClusterTiKVScaleInBlocked)
+		return nil
+	}
+
+	storeIDs := sets.NewString()
+	for _, ordinal := range ordinals {
+		podName := tikvGroupPodName(tcName, tc.TiKVGroupName(), ordinal)
+		for _, store := range tc.Status.TiKV.Stores {
+			if store.PodName == podName && store.State == v1alpha1.TiKVStateUp {
+				storeIDs.Insert(store.ID)
+			}
+		}
+	}
+	if storeIDs.Len() == 0 {
+		// the stores are already being deleted
+		return nil
+	}
+
+	reason, msg := checkTiKVScaleInFeasibility(storesInfo.Stores, storeIDs, config)
+	if reason == "" {
+		utiltidbcluster.RemoveTidbClusterCondition(&tc.Status, v1alpha1.TidbClusterTiKVScaleInBlocked)
+		return nil
+	}
+	msg = fmt.Sprintf("%s, set annotation %s: \"true\" to force the scale-in", msg, label.AnnTiKVForceScaleIn)
+	old := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterTiKVScaleInBlocked)
+	cond := utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterTiKVScaleInBlocked, v1.ConditionTrue, reason, msg)
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
+	if old == nil || old.Reason != reason {
+		s.deps.Recorder.Event(tc, v1.EventTypeWarning, "FailedScaleIn", msg)
+	}
+	recordScaleInRefused(tc, v1alpha1.TiKVMemberType, msg)
+	return fmt.Errorf("tikvScaler.ScaleIn: refuse to scale in TiKV of cluster %s/%s: %s", ns, tcName, msg)
+}
+
 type fakeTiKVScaler struct{}
 
 // NewFakeTiKVScaler returns a fake tikv Scaler
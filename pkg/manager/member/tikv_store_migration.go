@@ -0,0 +1,169 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"math"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// tikvMigrationRateWeight is the weight of the latest sample in the moving average of the migration rate
+	tikvMigrationRateWeight = 0.5
+	// tikvMigrationInCompletedRatio is the ratio of the remaining regions to the target regions, under which
+	// the rebalancing toward a store that is scaled out is regarded as completed
+	tikvMigrationInCompletedRatio = 0.05
+)
+
+// tikvMigrationTarget is the average load of the Up TiKV stores, the stores that are scaled out are
+// rebalanced toward it
+type tikvMigrationTarget struct {
+	regions int
+	leaders int
+	bytes   int64
+}
+
+func newTiKVMigrationTarget(stores []*pdapi.StoreInfo) tikvMigrationTarget {
+	var target tikvMigrationTarget
+	count := 0
+	for _, store := range stores {
+		if store.Store == nil || store.Status == nil || store.Store.StateName != v1alpha1.TiKVStateUp ||
+			!util.MatchLabelFromStoreLabels(store.Store.Labels, label.TiKVLabelVal) {
+			continue
+		}
+		target.regions += store.Status.RegionCount
+		target.leaders += store.Status.LeaderCount
+		target.bytes += store.Status.RegionSize
+		count++
+	}
+	if count == 0 {
+		return target
+	}
+	target.regions /= count
+	target.leaders /= count
+	target.bytes = target.bytes / int64(count) * 1024 * 1024
+	return target
+}
+
+// getTiKVStoreMigration returns the migration progress of the store sampled at now:
+//   - the data is migrated out of the Offline store until it becomes Tombstone
+//   - the data is rebalanced into the new Up store until its regions are close to the target
+//
+// The moving average rate and the ETA are computed from the previous sample.
+func getTiKVStoreMigration(store *pdapi.StoreInfo, old *v1alpha1.TiKVStoreMigration, isNew bool, target tikvMigrationTarget, now metav1.Time) *v1alpha1.TiKVStoreMigration {
+	if store.Store == nil || store.Status == nil {
+		return nil
+	}
+
+	migration := &v1alpha1.TiKVStoreMigration{
+		StartTime:      now,
+		LastSampleTime: now,
+	}
+	switch {
+	case store.Store.StateName == v1alpha1.TiKVStateOffline:
+		migration.Direction = v1alpha1.TiKVStoreMigrationOut
+		migration.RemainingRegions = int32(store.Status.RegionCount)
+		migration.RemainingLeaders = int32(store.Status.LeaderCount)
+		migration.RemainingBytes = store.Status.RegionSize * 1024 * 1024
+	case store.Store.StateName == v1alpha1.TiKVStateUp && (isNew || (old != nil && old.Direction == v1alpha1.TiKVStoreMigrationIn)):
+		migration.Direction = v1alpha1.TiKVStoreMigrationIn
+		migration.RemainingRegions = int32(max(target.regions-store.Status.RegionCount, 0))
+		migration.RemainingLeaders = int32(max(target.leaders-store.Status.LeaderCount, 0))
+		migration.RemainingBytes = max(target.bytes-store.Status.RegionSize*1024*1024, 0)
+		if float64(migration.RemainingRegions) <= float64(target.regions)*tikvMigrationInCompletedRatio {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	if old == nil || old.Direction != migration.Direction {
+		return migration
+	}
+	migration.StartTime = old.StartTime
+	migration.RegionRate = old.RegionRate
+	migration.ByteRate = old.ByteRate
+	migration.EstimatedCompletionTime = old.EstimatedCompletionTime
+
+	elapsed := now.Sub(old.LastSampleTime.Time).Seconds()
+	if elapsed <= 0 {
+		migration.LastSampleTime = old.LastSampleTime
+		return migration
+	}
+	regionRate := movingAverageRate(old.RegionRate, float64(old.RemainingRegions-migration.RemainingRegions)/elapsed)
+	byteRate := movingAverageRate(old.ByteRate, float64(old.RemainingBytes-migration.RemainingBytes)/elapsed)
+	migration.RegionRate = resource.NewMilliQuantity(int64(math.Round(regionRate*1000)), resource.DecimalSI)
+	migration.ByteRate = resource.NewQuantity(int64(math.Round(byteRate)), resource.BinarySI)
+	migration.EstimatedCompletionTime = nil
+	if migration.RemainingRegions == 0 {
+		migration.EstimatedCompletionTime = &now
+	} else if regionRate > 0 {
+		eta := metav1.NewTime(now.Add(time.Duration(float64(migration.RemainingRegions) / regionRate * float64(time.Second))))
+		migration.EstimatedCompletionTime = &eta
+	}
+	return migration
+}
+
+// movingAverageRate returns the exponential moving average of the rate, the negative rate,
+// e.g. new regions are written to the store being scaled in, is regarded as 0.
+func movingAverageRate(old *resource.Quantity, latest float64) float64 {
+	latest = math.Max(latest, 0)
+	if old == nil {
+		return latest
+	}
+	return tikvMigrationRateWeight*latest + (1-tikvMigrationRateWeight)*old.AsApproximateFloat64()
+}
+
+// updateTiKVStoreMigrationMetrics exports the migration progress of the stores, the metrics of the
+// previous stores are deleted first in case the migration is completed.
+func updateTiKVStoreMigrationMetrics(tc *v1alpha1.TidbCluster, previousStores, stores map[string]v1alpha1.TiKVStore) {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	for id, store := range previousStores {
+		if store.Migration == nil {
+			continue
+		}
+		labels := prometheus.Labels{metrics.LabelNamespace: ns, metrics.LabelName: tcName, metrics.LabelStore: id}
+		metrics.TiKVStoreMigrationRemainingRegions.DeletePartialMatch(labels)
+		metrics.TiKVStoreMigrationRemainingLeaders.DeletePartialMatch(labels)
+		metrics.TiKVStoreMigrationRemainingBytes.DeletePartialMatch(labels)
+		metrics.TiKVStoreMigrationRegionRate.DeletePartialMatch(labels)
+		metrics.TiKVStoreMigrationETASeconds.DeletePartialMatch(labels)
+	}
+	for id, store := range stores {
+		migration := store.Migration
+		if migration == nil {
+			continue
+		}
+		direction := string(migration.Direction)
+		metrics.TiKVStoreMigrationRemainingRegions.WithLabelValues(ns, tcName, id, direction).Set(float64(migration.RemainingRegions))
+		metrics.TiKVStoreMigrationRemainingLeaders.WithLabelValues(ns, tcName, id, direction).Set(float64(migration.RemainingLeaders))
+		metrics.TiKVStoreMigrationRemainingBytes.WithLabelValues(ns, tcName, id, direction).Set(float64(migration.RemainingBytes))
+		if migration.RegionRate != nil {
+			metrics.TiKVStoreMigrationRegionRate.WithLabelValues(ns, tcName, id, direction).Set(migration.RegionRate.AsApproximateFloat64())
+		}
+		if migration.EstimatedCompletionTime != nil {
+			metrics.TiKVStoreMigrationETASeconds.WithLabelValues(ns, tcName, id, direction).Set(math.Max(time.Until(migration.EstimatedCompletionTime.Time).Seconds(), 0))
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newStoreForMigration(id uint64, state string, regions, leaders int, size int64) *pdapi.StoreInfo {
+	return &pdapi.StoreInfo{
+		Store: &pdapi.MetaStore{
+			StateName: state,
+			Store:     &metapb.Store{Id: id},
+		},
+		Status: &pdapi.StoreStatus{RegionCount: regions, LeaderCount: leaders, RegionSize: size},
+	}
+}
+
+func TestGetTiKVStoreMigrationOut(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	start := metav1.NewTime(time.Unix(1000, 0))
+	target := tikvMigrationTarget{regions: 100, leaders: 50, bytes: 100 << 20}
+
+	store := newStoreForMigration(1, v1alpha1.TiKVStateOffline, 100, 0, 1000)
+	migration := getTiKVStoreMigration(store, nil, false, target, start)
+	g.Expect(migration).NotTo(BeNil())
+	g.Expect(migration.Direction).To(Equal(v1alpha1.TiKVStoreMigrationOut))
+	g.Expect(migration.StartTime).To(Equal(start))
+	g.Expect(migration.RemainingRegions).To(Equal(int32(100)))
+	g.Expect(migration.RemainingBytes).To(Equal(int64(1000 << 20)))
+	g.Expect(migration.RegionRate).To(BeNil())
+	g.Expect(migration.EstimatedCompletionTime).To(BeNil())
+
+	// 20 regions are moved out in 10s
+	now := metav1.NewTime(start.Add(10 * time.Second))
+	store = newStoreForMigration(1, v1alpha1.TiKVStateOffline, 80, 0, 800)
+	migration = getTiKVStoreMigration(store, migration, false, target, now)
+	g.Expect(migration.StartTime).To(Equal(start))
+	g.Expect(migration.LastSampleTime).To(Equal(now))
+	g.Expect(migration.RegionRate.AsApproximateFloat64()).To(Equal(2.0))
+	g.Expect(migration.ByteRate.Value()).To(Equal(int64(20 << 20)))
+	g.Expect(migration.EstimatedCompletionTime.Time).To(Equal(now.Add(40 * time.Second)))
+
+	// 10 regions are moved out in the next 10s, the rate is averaged
+	next := metav1.NewTime(now.Add(10 * time.Second))
+	store = newStoreForMigration(1, v1alpha1.TiKVStateOffline, 70, 0, 700)
+	migration = getTiKVStoreMigration(store, migration, false, target, next)
+	g.Expect(migration.RegionRate.AsApproximateFloat64()).To(Equal(1.5))
+	g.Expect(migration.EstimatedCompletionTime.Time.Unix()).To(Equal(next.Add(46 * time.Second).Unix()))
+
+	// the migration is completed after the store becomes Tombstone
+	store = newStoreForMigration(1, v1alpha1.TiKVStateTombstone, 0, 0, 0)
+	g.Expect(getTiKVStoreMigration(store, migration, false, target, next)).To(BeNil())
+}
+
+func TestGetTiKVStoreMigrationIn(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	start := metav1.NewTime(time.Unix(1000, 0))
+	target := tikvMigrationTarget{regions: 100, leaders: 50, bytes: 100 << 20}
+
+	// the existing stores are not tracked
+	store := newStoreForMigration(4, v1alpha1.TiKVStateUp, 0, 0, 0)
+	g.Expect(getTiKVStoreMigration(store, nil, false, target, start)).To(BeNil())
+
+	migration := getTiKVStoreMigration(store, nil, true, target, start)
+	g.Expect(migration).NotTo(BeNil())
+	g.Expect(migration.Direction).To(Equal(v1alpha1.TiKVStoreMigrationIn))
+	g.Expect(migration.RemainingRegions).To(Equal(int32(100)))
+	g.Expect(migration.RemainingLeaders).To(Equal(int32(50)))
+	g.Expect(migration.RemainingBytes).To(Equal(int64(100 << 20)))
+
+	// the store is tracked by the previous migration after it is not new
+	now := metav1.NewTime(start.Add(10 * time.Second))
+	store = newStoreForMigration(4, v1alpha1.TiKVStateUp, 50, 20, 50)
+	migration = getTiKVStoreMigration(store, migration, false, target, now)
+	g.Expect(migration).NotTo(BeNil())
+	g.Expect(migration.RemainingRegions).To(Equal(int32(50)))
+	g.Expect(migration.RegionRate.AsApproximateFloat64()).To(Equal(5.0))
+	g.Expect(migration.EstimatedCompletionTime.Time).To(Equal(now.Add(10 * time.Second)))
+
+	// the migration is completed when the regions are close to the target
+	store = newStoreForMigration(4, v1alpha1.TiKVStateUp, 96, 48, 96)
+	g.Expect(getTiKVStoreMigration(store, migration, false, target, now)).To(BeNil())
+}
+
+func TestNewTiKVMigrationTarget(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	target := newTiKVMigrationTarget([]*pdapi.StoreInfo{
+		newStoreForMigration(1, v1alpha1.TiKVStateUp, 100, 40, 100),
+		newStoreForMigration(2, v1alpha1.TiKVStateUp, 200, 60, 300),
+		newStoreForMigration(3, v1alpha1.TiKVStateOffline, 300, 0, 300),
+	})
+	g.Expect(target).To(Equal(tikvMigrationTarget{regions: 150, leaders: 50, bytes: 200 << 20}))
+}
+
+func TestUpdateTiKVStoreMigrationMetrics(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiKV()
+	eta := metav1.NewTime(time.Now().Add(time.Hour))
+	stores := map[string]v1alpha1.TiKVStore{
+		"1": {ID: "1", Migration: &v1alpha1.TiKVStoreMigration{
+			Direction:               v1alpha1.TiKVStoreMigrationOut,
+			RemainingRegions:        10,
+			EstimatedCompletionTime: &eta,
+		}},
+		"2": {ID: "2"},
+	}
+	updateTiKVStoreMigrationMetrics(tc, nil, stores)
+	gauge := metrics.TiKVStoreMigrationRemainingRegions.WithLabelValues(tc.GetNamespace(), tc.GetName(), "1", "Out")
+	g.Expect(testutil.ToFloat64(gauge)).To(Equal(10.0))
+	g.Expect(testutil.ToFloat64(metrics.TiKVStoreMigrationETASeconds.WithLabelValues(tc.GetNamespace(), tc.GetName(), "1", "Out"))).To(BeNumerically(">", 3500))
+
+	// the metrics are deleted after the migration is completed
+	updateTiKVStoreMigrationMetrics(tc, stores, map[string]v1alpha1.TiKVStore{"1": {ID: "1"}, "2": {ID: "2"}})
+	g.Expect(testutil.CollectAndCount(metrics.TiKVStoreMigrationRemainingRegions)).To(Equal(0))
+	g.Expect(testutil.CollectAndCount(metrics.TiKVStoreMigrationETASeconds)).To(Equal(0))
+}
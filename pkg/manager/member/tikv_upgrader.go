@@ -16,11 +16,13 @@ package member
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
@@ -122,12 +124,12 @@ func (u *tikvUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, new
 	podOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet).List()
 	for _i := len(podOrdinals) - 1; _i >= 0; _i-- {
 		i := podOrdinals[_i]
-		store := getStoreByOrdinal(meta.GetName(), *status, i)
+		store := getStoreByOrdinal(tc, i)
 		if store == nil {
 			mngerutils.SetUpgradePartition(newSet, i)
 			continue
 		}
-		podName := TikvPodName(tcName, i)
+		podName := tikvGroupPodName(tcName, tc.TiKVGroupName(), i)
 		pod, err := u.deps.PodLister.Pods(ns).Get(podName)
 		if err != nil {
 			return fmt.Errorf("tikvUpgrader.Upgrade: failed to get pods %s for cluster %s/%s, error: %s", podName, ns, tcName, err)
@@ -179,7 +181,7 @@ func (u *tikvUpgrader) isClusterStable(tc *v1alpha1.TidbCluster) string {
 func (u *tikvUpgrader) upgradeTiKVPod(tc *v1alpha1.TidbCluster, ordinal int32, newSet *apps.StatefulSet) error {
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
-	upgradePodName := TikvPodName(tcName, ordinal)
+	upgradePodName := tikvGroupPodName(tcName, tc.TiKVGroupName(), ordinal)
 	upgradePod, err := u.deps.PodLister.Pods(ns).Get(upgradePodName)
 	if err != nil {
 		return fmt.Errorf("upgradeTiKVPod: failed to get pod %s for tc %s/%s, error: %s", upgradePodName, ns, tcName, err)
@@ -242,8 +244,8 @@ func (u *tikvUpgrader) evictLeaderBeforeUpgrade(tc *v1alpha1.TidbCluster, upgrad
 		}
 	}
 
-	leaderCount, err := u.deps.TiKVControl.GetTiKVPodClient(tc.Namespace, tc.Name,
-		upgradePod.Name, tc.Spec.ClusterDomain, tc.IsTLSClusterEnabled()).GetLeaderCount()
+	leaderCount, err := controller.GetTiKVPodClient(u.deps.TiKVControl, tc,
+		upgradePod.Labels[label.TiKVGroupLabelKey], upgradePod.Name).GetLeaderCount()
 	if err != nil {
 		klog.Warningf("%s: failed to get leader count, error: %v", logPrefix, err)
 		return false, nil
@@ -365,7 +367,7 @@ func (u *tikvUpgrader) triggerForceFlush(tc *v1alpha1.TidbCluster, pod *corev1.P
 		logger.Info("Skipped flush log backup.", "tc", tc.Name, "namespace", tc.Namespace)
 		return nil
 	}
-	kvcli := u.deps.TiKVControl.GetTiKVPodClient(tc.GetNamespace(), tc.GetName(), pod.GetName(), tc.Spec.ClusterDomain, tc.IsTLSClusterEnabled())
+	kvcli := controller.GetTiKVPodClient(u.deps.TiKVControl, tc, pod.Labels[label.TiKVGroupLabelKey], pod.GetName())
 	return kvcli.FlushLogBackupTasks(timeoutCx)
 }
 
@@ -525,7 +527,7 @@ func isPodAvailable(pod *corev1.Pod, minReadySeconds int, tc *v1alpha1.TidbClust
 func endEvictLeader(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, ordinal int32) error {
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
-	podName := TikvPodName(tcName, ordinal)
+	podName := tikvGroupPodName(tcName, tc.TiKVGroupName(), ordinal)
 
 	minReadySeconds := getMinReadySeconds(tc)
 
@@ -537,7 +539,7 @@ func endEvictLeader(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, ord
 		return err
 	}
 
-	store := getStoreByOrdinal(tc.GetName(), tc.Status.TiKV, ordinal)
+	store := getStoreByOrdinal(tc, ordinal)
 	if store == nil {
 		klog.Errorf("endEvictLeader: no store found for TiKV ordinal %v of %s/%s", ordinal, tc.Namespace, tc.Name)
 		return nil
@@ -566,9 +568,9 @@ func endEvictLeaderbyStoreID(deps *controller.Dependencies, tc *v1alpha1.TidbClu
 	return nil
 }
 
-func getStoreByOrdinal(name string, status v1alpha1.TiKVStatus, ordinal int32) *v1alpha1.TiKVStore {
-	podName := TikvPodName(name, ordinal)
-	for _, store := range status.Stores {
+func getStoreByOrdinal(tc *v1alpha1.TidbCluster, ordinal int32) *v1alpha1.TiKVStore {
+	podName := tikvGroupPodName(tc.GetName(), tc.TiKVGroupName(), ordinal)
+	for _, store := range tc.Status.TiKV.Stores {
 		if store.PodName == podName {
 			return &store
 		}
@@ -586,6 +588,36 @@ func (u *tikvUpgrader) isTiKVReadyToUpgrade(tc *v1alpha1.TidbCluster) string {
 	if tc.TiKVScaling() {
 		return fmt.Sprintf("tikv status is %s", tc.Status.TiKV.Phase)
 	}
+	// a TiKV group is checked against the other TiKV sets by tikvGroupUpgrader, as the status
+	// of the default TiKV is not in the view of the group
+	if tc.TiKVGroupName() == "" {
+		return otherTiKVSetsNotReadyReason(tc, "", tc.Status.TiKV.Phase)
+	}
+	return ""
+}
+
+// otherTiKVSetsNotReadyReason returns the reason why the TiKV set of the given group, "" for the
+// default TiKV, can not be upgraded because of the other TiKV sets of tc. The TiKV sets are upgraded
+// one by one, and none of them is upgraded while any other is scaling. A set whose upgrade is already
+// in progress is not held by the other sets that start to upgrade later.
+func otherTiKVSetsNotReadyReason(tc *v1alpha1.TidbCluster, group string, phase v1alpha1.MemberPhase) string {
+	notReady := func(p v1alpha1.MemberPhase) bool {
+		return p == v1alpha1.ScalePhase || (p == v1alpha1.UpgradePhase && phase != v1alpha1.UpgradePhase)
+	}
+	if group != "" && notReady(tc.Status.TiKV.Phase) {
+		return fmt.Sprintf("tikv status is %s", tc.Status.TiKV.Phase)
+	}
+	names := make([]string, 0, len(tc.Status.TiKVGroups))
+	for name := range tc.Status.TiKVGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		status := tc.Status.TiKVGroups[name]
+		if name != group && status != nil && notReady(status.Phase) {
+			return fmt.Sprintf("tikv group %s status is %s", name, status.Phase)
+		}
+	}
 	return ""
 }
 
@@ -262,6 +262,12 @@ func (m *tiproxyMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 	if err != nil {
 		return err
 	}
+	setStartBinary(tc, m.deps.CLIConfig.TiDBDiscoveryImage, &newSts.Spec.Template.Spec)
+
+	// in the plan mode, the changes driven by the spec are held until the plan is approved
+	if proceed, err := syncChangePlan(m.deps, tc, v1alpha1.TiProxyMemberType, tc.Spec.TiProxy.Replicas, oldStatefulSet, newSts); err != nil || !proceed {
+		return err
+	}
 
 	if stsNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSts)
@@ -280,6 +286,8 @@ func (m *tiproxyMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 		klog.Errorf("set labels for TiProxy sts %s/%s failed, error: %v", ns, tcName, err)
 	}
 
+	recordStatefulSetOperations(tc, v1alpha1.TiProxyMemberType, oldStatefulSet, newSts)
+
 	// Scaling takes precedence over upgrading because:
 	// - if a pod fails in the upgrading, users may want to delete it or add
 	//   new replicas
@@ -509,7 +517,7 @@ func (m *tiproxyMemberManager) getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *c
 		Name:            v1alpha1.TiProxyMemberType.String(),
 		Image:           tc.TiProxyImage(),
 		ImagePullPolicy: baseTiProxySpec.ImagePullPolicy(),
-		Command:         []string{"/bin/sh", "/etc/proxy/start.sh"},
+		Command:         startScriptCommand(tc, "/etc/proxy/start.sh"),
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          "tiproxy",
@@ -0,0 +1,350 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"github.com/pingcap/tidb-operator/pkg/util/crypto"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// tlsClusterCADuration is the validity duration of the CA generated by the operator,
+	// the CA is not rotated, delete the CA Secret to regenerate it and reissue all certificates
+	tlsClusterCADuration = 10 * 365 * 24 * time.Hour
+	// tlsClusterClientCertName is the key of the client certificate in the status
+	tlsClusterClientCertName = "client"
+)
+
+// tlsClusterCertRestartComponents are the components that can not reload the certificates online,
+// they are restarted by the upgraders after the certificates are renewed.
+var tlsClusterCertRestartComponents = map[string]bool{
+	label.TiFlashLabelVal: true,
+	label.PumpLabelVal:    true,
+}
+
+// tlsCertManager generates a CA for the TidbCluster and issues the certificates of TLSCluster
+// with the CA when `spec.tlsCluster.issuer` is set.
+type tlsCertManager struct {
+	deps *controller.Dependencies
+}
+
+// NewTLSCertManager returns a manager that issues and renews the certificates of TLSCluster
+func NewTLSCertManager(deps *controller.Dependencies) manager.Manager {
+	return &tlsCertManager{deps: deps}
+}
+
+// tlsClusterCert is a certificate to be issued
+type tlsClusterCert struct {
+	name       string
+	secretName string
+	hosts      []string
+}
+
+func (m *tlsCertManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if !tc.IsTLSClusterIssuedByOperator() {
+		return nil
+	}
+
+	caCert, caKey, err := m.syncCA(tc)
+	if err != nil {
+		return err
+	}
+
+	tenants, err := m.tenantsOf(tc)
+	if err != nil {
+		return err
+	}
+
+	issuer := tc.Spec.TLSCluster.Issuer
+	certs := map[string]v1alpha1.IssuedCertificate{}
+	for _, cert := range tlsClusterCerts(tc, tenants) {
+		issued, err := m.syncCert(tc, cert, caCert, caKey, issuer)
+		if err != nil {
+			return err
+		}
+		if issued != nil {
+			certs[cert.name] = *issued
+		}
+	}
+
+	if tc.Status.TLSCluster == nil {
+		tc.Status.TLSCluster = &v1alpha1.TLSClusterStatus{}
+	}
+	tc.Status.TLSCluster.Certificates = certs
+	return nil
+}
+
+// tenantsOf returns the TidbTenants whose TiDB servers are served by the PD and TiKV of tc
+func (m *tlsCertManager) tenantsOf(tc *v1alpha1.TidbCluster) ([]*v1alpha1.TidbTenant, error) {
+	all, err := m.deps.TiDBTenantLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the tenants of cluster %s/%s, error: %v", tc.GetNamespace(), tc.GetName(), err)
+	}
+	var tenants []*v1alpha1.TidbTenant
+	for _, tt := range all {
+		ns, name := controller.ClusterRefKey(tt.Namespace, tt.Spec.Cluster)
+		if ns == tc.GetNamespace() && name == tc.GetName() {
+			tenants = append(tenants, tt)
+		}
+	}
+	return tenants, nil
+}
+
+// syncCA generates the CA if it does not exist and returns the certificate and the key of the CA
+func (m *tlsCertManager) syncCA(tc *v1alpha1.TidbCluster) ([]byte, []byte, error) {
+	ns := tc.GetNamespace()
+	secretName := util.ClusterTLSCASecretName(tc.GetName())
+
+	// a CA Secret created by users is also used to issue the certificates
+	secret, err := m.deps.SecretLister.Secrets(ns).Get(secretName)
+	if err == nil {
+		caCert, caKey := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+		if len(caCert) == 0 || len(caKey) == 0 {
+			return nil, nil, fmt.Errorf("syncCA: %s or %s does not exist in secret %s/%s", corev1.TLSCertKey, corev1.TLSPrivateKeyKey, ns, secretName)
+		}
+		return caCert, caKey, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf("syncCA: failed to get secret %s/%s, error: %v", ns, secretName, err)
+	}
+
+	caCert, caKey, err := crypto.NewCA(fmt.Sprintf("%s CA", tc.GetName()), tlsClusterCADuration)
+	if err != nil {
+		return nil, nil, fmt.Errorf("syncCA: failed to generate CA for tidbcluster %s/%s, error: %v", ns, tc.GetName(), err)
+	}
+	secret = newTLSClusterSecret(tc, secretName, map[string][]byte{
+		corev1.TLSCertKey:       caCert,
+		corev1.TLSPrivateKeyKey: caKey,
+	})
+	if _, err := m.deps.KubeClientset.CoreV1().Secrets(ns).Create(context.TODO(), secret, metav1.CreateOptions{}); err != nil {
+		return nil, nil, fmt.Errorf("syncCA: failed to create secret %s/%s, error: %v", ns, secretName, err)
+	}
+	klog.Infof("tidbcluster: [%s/%s], CA is generated in secret %s", ns, tc.GetName(), secretName)
+	return caCert, caKey, nil
+}
+
+// syncCert issues the certificate if it does not exist, or renews it if it is going to expire, is signed by
+// another CA or does not match the hosts. Certificates in Secrets that are not created by the operator are ignored.
+func (m *tlsCertManager) syncCert(tc *v1alpha1.TidbCluster, cert tlsClusterCert, caCert, caKey []byte, issuer *v1alpha1.TLSClusterIssuer) (*v1alpha1.IssuedCertificate, error) {
+	ns := tc.GetNamespace()
+
+	secret, err := m.deps.SecretLister.Secrets(ns).Get(cert.secretName)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("syncCert: failed to get secret %s/%s, error: %v", ns, cert.secretName, err)
+	}
+	if err == nil {
+		if !metav1.IsControlledBy(secret, tc) {
+			klog.V(4).Infof("tidbcluster: [%s/%s], secret %s is not created by the operator, skip issuing certificate", ns, tc.GetName(), cert.secretName)
+			return nil, nil
+		}
+		reason := certRenewReason(secret, cert.hosts, caCert, issuer.GetRenewBefore())
+		if reason == "" {
+			return issuedCertificate(secret)
+		}
+		klog.Infof("tidbcluster: [%s/%s], renew the certificate in secret %s, %s", ns, tc.GetName(), cert.secretName, reason)
+	}
+
+	certPEM, keyPEM, err := crypto.NewSignedCert(caCert, caKey, cert.name, cert.hosts, []string{"127.0.0.1", "::1"}, issuer.GetDuration())
+	if err != nil {
+		return nil, fmt.Errorf("syncCert: failed to issue certificate for secret %s/%s, error: %v", ns, cert.secretName, err)
+	}
+	desired := newTLSClusterSecret(tc, cert.secretName, map[string][]byte{
+		corev1.ServiceAccountRootCAKey: caCert,
+		corev1.TLSCertKey:              certPEM,
+		corev1.TLSPrivateKeyKey:        keyPEM,
+	})
+	if secret == nil {
+		secret, err = m.deps.KubeClientset.CoreV1().Secrets(ns).Create(context.TODO(), desired, metav1.CreateOptions{})
+	} else {
+		update := secret.DeepCopy()
+		update.Data = desired.Data
+		secret, err = m.deps.KubeClientset.CoreV1().Secrets(ns).Update(context.TODO(), update, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("syncCert: failed to save certificate to secret %s/%s, error: %v", ns, cert.secretName, err)
+	}
+	m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, "CertificateIssued", "certificate in secret %s is issued", cert.secretName)
+	return issuedCertificate(secret)
+}
+
+// certRenewReason returns why the certificate in the secret should be renewed, or empty if it is still valid
+func certRenewReason(secret *corev1.Secret, hosts []string, caCert []byte, renewBefore time.Duration) string {
+	cert, err := crypto.ParseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return fmt.Sprintf("failed to parse certificate: %v", err)
+	}
+	if !bytes.Equal(secret.Data[corev1.ServiceAccountRootCAKey], caCert) {
+		return "the CA is changed"
+	}
+	if time.Now().Add(renewBefore).After(cert.NotAfter) {
+		return fmt.Sprintf("the certificate expires at %s", cert.NotAfter.Format(time.RFC3339))
+	}
+	current := append([]string{}, cert.DNSNames...)
+	sort.Strings(current)
+	if !equality.Semantic.DeepEqual(current, hosts) {
+		return "the hosts of the certificate are changed"
+	}
+	return ""
+}
+
+func issuedCertificate(secret *corev1.Secret) (*v1alpha1.IssuedCertificate, error) {
+	cert, err := crypto.ParseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate in secret %s/%s, error: %v", secret.Namespace, secret.Name, err)
+	}
+	return &v1alpha1.IssuedCertificate{
+		SecretName:   secret.Name,
+		SerialNumber: cert.SerialNumber.Text(16),
+		NotAfter:     metav1.NewTime(cert.NotAfter),
+	}, nil
+}
+
+func newTLSClusterSecret(tc *v1alpha1.TidbCluster, name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       tc.GetNamespace(),
+			Labels:          label.New().Instance(tc.GetInstanceName()).Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+}
+
+// tlsClusterCerts returns the certificates of all components in the TidbCluster and the client certificate,
+// the TiDB servers of the tenants share the certificate of TiDB
+func tlsClusterCerts(tc *v1alpha1.TidbCluster, tenants []*v1alpha1.TidbTenant) []tlsClusterCert {
+	tcName := tc.GetName()
+
+	var certs []tlsClusterCert
+	add := func(component string, services ...string) {
+		certs = append(certs, tlsClusterCert{
+			name:       component,
+			secretName: util.ClusterTLSSecretName(tcName, component),
+			hosts:      tlsClusterCertHosts(tc, services...),
+		})
+	}
+
+	if tc.Spec.PD != nil || len(tc.Spec.PDMS) > 0 {
+		// pd microservices share the certificate of pd
+		services := []string{controller.PDMemberName(tcName), controller.PDPeerMemberName(tcName)}
+		for _, ms := range tc.Spec.PDMS {
+			services = append(services, controller.PDMSMemberName(tcName, ms.Name), controller.PDMSPeerMemberName(tcName, ms.Name))
+		}
+		add(label.PDLabelVal, services...)
+	}
+	if tc.Spec.TiKV != nil || len(tc.Spec.TiKVGroups) > 0 {
+		services := []string{controller.TiKVMemberName(tcName), controller.TiKVPeerMemberName(tcName)}
+		for _, group := range tc.Spec.TiKVGroups {
+			services = append(services, controller.TiKVGroupMemberName(tcName, group.Name), controller.TiKVGroupPeerMemberName(tcName, group.Name))
+		}
+		add(label.TiKVLabelVal, services...)
+	}
+	if tc.Spec.TiDB != nil || len(tc.Spec.TiDBGroups) > 0 || len(tenants) > 0 {
+		services := []string{controller.TiDBMemberName(tcName), controller.TiDBPeerMemberName(tcName)}
+		for _, group := range tc.Spec.TiDBGroups {
+			services = append(services, controller.TiDBGroupMemberName(tcName, group.Name), controller.TiDBGroupPeerMemberName(tcName, group.Name))
+		}
+		for _, tt := range tenants {
+			services = append(services, controller.TiDBGroupMemberName(tcName, tt.TiDBGroupName()), controller.TiDBGroupPeerMemberName(tcName, tt.TiDBGroupName()))
+		}
+		add(label.TiDBLabelVal, services...)
+	}
+	if tc.Spec.TiFlash != nil {
+		// the TiFlash compute nodes share the certificate of the TiFlash write nodes
+		services := []string{controller.TiFlashMemberName(tcName), controller.TiFlashPeerMemberName(tcName)}
+		if tc.TiFlashComputeSpec() != nil {
+			services = append(services, controller.TiFlashRoleMemberName(tcName, label.TiFlashComputeRoleVal),
+				controller.TiFlashRolePeerMemberName(tcName, label.TiFlashComputeRoleVal))
+		}
+		add(label.TiFlashLabelVal, services...)
+	}
+	if tc.Spec.TiCDC != nil {
+		add(label.TiCDCLabelVal, controller.TiCDCMemberName(tcName), controller.TiCDCPeerMemberName(tcName))
+	}
+	if tc.Spec.TiProxy != nil {
+		add(label.TiProxyLabelVal, controller.TiProxyMemberName(tcName), controller.TiProxyPeerMemberName(tcName))
+	}
+	if tc.Spec.Pump != nil {
+		add(label.PumpLabelVal, controller.PumpMemberName(tcName), controller.PumpPeerMemberName(tcName))
+	}
+
+	certs = append(certs, tlsClusterCert{
+		name:       tlsClusterClientCertName,
+		secretName: util.ClusterClientTLSSecretName(tcName),
+		hosts:      []string{"localhost"},
+	})
+	return certs
+}
+
+// tlsClusterCertHosts returns the sorted hosts of the services and the pods behind them
+func tlsClusterCertHosts(tc *v1alpha1.TidbCluster, services ...string) []string {
+	ns := tc.GetNamespace()
+
+	hosts := []string{"localhost"}
+	for _, svc := range services {
+		for _, name := range []string{svc, "*." + svc} {
+			hosts = append(hosts, name, fmt.Sprintf("%s.%s", name, ns), fmt.Sprintf("%s.%s.svc", name, ns))
+			if tc.Spec.ClusterDomain != "" {
+				hosts = append(hosts, fmt.Sprintf("%s.%s.svc.%s", name, ns, tc.Spec.ClusterDomain))
+			}
+		}
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// setTLSClusterCertAnnotation sets the serial number of the certificate issued by the operator to the pod
+// annotations of the components that can not reload certificates, so that they are restarted after renewal
+func setTLSClusterCertAnnotation(tc *v1alpha1.TidbCluster, component string, podAnnotations map[string]string) {
+	if !tc.IsTLSClusterIssuedByOperator() || !tlsClusterCertRestartComponents[component] || tc.Status.TLSCluster == nil {
+		return
+	}
+	if cert, ok := tc.Status.TLSCluster.Certificates[component]; ok {
+		podAnnotations[label.AnnTLSClusterCertSerial] = cert.SerialNumber
+	}
+}
+
+type FakeTLSCertManager struct {
+	err error
+}
+
+func NewFakeTLSCertManager() *FakeTLSCertManager {
+	return &FakeTLSCertManager{}
+}
+
+func (m *FakeTLSCertManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeTLSCertManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"github.com/pingcap/tidb-operator/pkg/util/crypto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newFakeTLSCertManager() *tlsCertManager {
+	return &tlsCertManager{deps: controller.NewFakeDependencies()}
+}
+
+// syncSecretsToLister adds the secrets created by the fake clientset to the secret lister
+func syncSecretsToLister(g *GomegaWithT, m *tlsCertManager, ns string) {
+	secrets, err := m.deps.KubeClientset.CoreV1().Secrets(ns).List(context.TODO(), metav1.ListOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	indexer := m.deps.KubeInformerFactory.Core().V1().Secrets().Informer().GetIndexer()
+	for i := range secrets.Items {
+		g.Expect(indexer.Add(&secrets.Items[i])).To(Succeed())
+	}
+}
+
+func TestTLSCertManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	tc.Spec.TiFlash = &v1alpha1.TiFlashSpec{
+		Disaggregated: &v1alpha1.TiFlashDisaggregatedSpec{Compute: &v1alpha1.TiFlashComputeSpec{}},
+	}
+	tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true, Issuer: &v1alpha1.TLSClusterIssuer{}}
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	m := newFakeTLSCertManager()
+	tenant := &v1alpha1.TidbTenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "t1", Namespace: "tenants"},
+		Spec:       v1alpha1.TidbTenantSpec{Cluster: v1alpha1.TidbClusterRef{Name: tcName, Namespace: ns}},
+	}
+	g.Expect(m.deps.InformerFactory.Pingcap().V1alpha1().TidbTenants().Informer().GetIndexer().Add(tenant)).To(Succeed())
+	// the secret of tikv is provided by users
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: util.ClusterTLSSecretName(tcName, label.TiKVLabelVal), Namespace: ns},
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("user")},
+	}
+	_, err := m.deps.KubeClientset.CoreV1().Secrets(ns).Create(context.TODO(), userSecret, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	syncSecretsToLister(g, m, ns)
+
+	g.Expect(m.Sync(tc)).To(Succeed())
+	syncSecretsToLister(g, m, ns)
+	certs := tc.Status.TLSCluster.Certificates
+	g.Expect(certs).To(HaveKey(label.PDLabelVal))
+	g.Expect(certs).To(HaveKey(label.TiDBLabelVal))
+	g.Expect(certs).To(HaveKey(label.TiFlashLabelVal))
+	g.Expect(certs).To(HaveKey(tlsClusterClientCertName))
+	g.Expect(certs).NotTo(HaveKey(label.TiKVLabelVal))
+
+	secret, err := m.deps.SecretLister.Secrets(ns).Get(util.ClusterTLSSecretName(tcName, label.TiKVLabelVal))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(secret.Data[corev1.TLSCertKey]).To(Equal([]byte("user")))
+
+	// the certificate of pd is valid for the pods behind the peer service
+	secret, err = m.deps.SecretLister.Secrets(ns).Get(util.ClusterTLSSecretName(tcName, label.PDLabelVal))
+	g.Expect(err).NotTo(HaveOccurred())
+	cert, err := crypto.ParseCertificate(secret.Data[corev1.TLSCertKey])
+	g.Expect(err).NotTo(HaveOccurred())
+	ca, err := crypto.ParseCertificate(secret.Data[corev1.ServiceAccountRootCAKey])
+	g.Expect(err).NotTo(HaveOccurred())
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	for _, host := range []string{"test-pd-0.test-pd-peer.default.svc", "test-pd", "localhost"} {
+		_, err = cert.Verify(x509.VerifyOptions{DNSName: host, Roots: pool})
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	g.Expect(cert.NotAfter).To(BeTemporally("~", time.Now().Add(365*24*time.Hour), time.Hour))
+
+	// the TiDB servers of the tenants and the TiFlash compute nodes share the certificates
+	for component, host := range map[string]string{
+		label.TiDBLabelVal:    "test-tidb-t1-0.test-tidb-t1-peer.default.svc",
+		label.TiFlashLabelVal: "test-tiflash-compute-0.test-tiflash-compute-peer.default.svc",
+	} {
+		secret, err = m.deps.SecretLister.Secrets(ns).Get(util.ClusterTLSSecretName(tcName, component))
+		g.Expect(err).NotTo(HaveOccurred())
+		cert, err = crypto.ParseCertificate(secret.Data[corev1.TLSCertKey])
+		g.Expect(err).NotTo(HaveOccurred())
+		_, err = cert.Verify(x509.VerifyOptions{DNSName: host, Roots: pool})
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+
+	// the certificates are not reissued if they are valid
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.TLSCluster.Certificates).To(Equal(certs))
+
+	// the certificates are renewed before they expire
+	annotations := map[string]string{}
+	setTLSClusterCertAnnotation(tc, label.TiFlashLabelVal, annotations)
+	g.Expect(annotations).To(HaveKeyWithValue(label.AnnTLSClusterCertSerial, certs[label.TiFlashLabelVal].SerialNumber))
+
+	tc.Spec.TLSCluster.Issuer.RenewBefore = &metav1.Duration{Duration: 366 * 24 * time.Hour}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	renewed := tc.Status.TLSCluster.Certificates
+	g.Expect(renewed[label.TiFlashLabelVal].SerialNumber).NotTo(Equal(certs[label.TiFlashLabelVal].SerialNumber))
+	setTLSClusterCertAnnotation(tc, label.TiFlashLabelVal, annotations)
+	g.Expect(annotations).To(HaveKeyWithValue(label.AnnTLSClusterCertSerial, renewed[label.TiFlashLabelVal].SerialNumber))
+
+	// the components that reload certificates online are not restarted
+	annotations = map[string]string{}
+	setTLSClusterCertAnnotation(tc, label.TiDBLabelVal, annotations)
+	g.Expect(annotations).To(BeEmpty())
+}
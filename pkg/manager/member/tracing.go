@@ -0,0 +1,152 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The wrappers below trace the steps of the reconciles of the TidbCluster as children of the root span
+// started by the tidbcluster controller. They must only wrap the managers used by the tidbcluster
+// controller, and they return the wrapped one as is if tracing is not enabled.
+
+// TraceManager traces the syncs of the manager with the given name
+func TraceManager(name string, m manager.Manager) manager.Manager {
+	if !tracing.Enabled() {
+		return m
+	}
+	return &tracedManager{name: name, Manager: m}
+}
+
+// TraceScaler traces the scaling of the component
+func TraceScaler(memberType v1alpha1.MemberType, s Scaler) Scaler {
+	if !tracing.Enabled() {
+		return s
+	}
+	return &tracedScaler{memberType: memberType, Scaler: s}
+}
+
+// TraceUpgrader traces the upgrades of the component
+func TraceUpgrader(memberType v1alpha1.MemberType, u Upgrader) Upgrader {
+	if !tracing.Enabled() {
+		return u
+	}
+	return &tracedUpgrader{memberType: memberType, Upgrader: u}
+}
+
+// TraceTiKVUpgrader traces the upgrades of TiKV
+func TraceTiKVUpgrader(memberType v1alpha1.MemberType, u TiKVUpgrader) TiKVUpgrader {
+	if !tracing.Enabled() {
+		return u
+	}
+	return &tracedTiKVUpgrader{memberType: memberType, TiKVUpgrader: u}
+}
+
+// TraceFailover traces the failover and the recovery of the component
+func TraceFailover(memberType v1alpha1.MemberType, f Failover) Failover {
+	if !tracing.Enabled() {
+		return f
+	}
+	return &tracedFailover{memberType: memberType, failover: f}
+}
+
+type tracedManager struct {
+	name string
+	manager.Manager
+}
+
+func (m *tracedManager) Sync(tc *v1alpha1.TidbCluster) (err error) {
+	end := tracing.StartSpan(tc, m.name+".Sync")
+	defer func() { end(err) }()
+	return m.Manager.Sync(tc)
+}
+
+type tracedScaler struct {
+	memberType v1alpha1.MemberType
+	Scaler
+}
+
+func (s *tracedScaler) Scale(meta metav1.Object, actual *apps.StatefulSet, desired *apps.StatefulSet) (err error) {
+	end := tracing.StartSpan(meta, "Scaler.Scale", append(componentAttributes(s.memberType),
+		attribute.Int("tidb.replicas.actual", int(*actual.Spec.Replicas)),
+		attribute.Int("tidb.replicas.desired", int(*desired.Spec.Replicas)))...)
+	defer func() { end(err) }()
+	return s.Scaler.Scale(meta, actual, desired)
+}
+
+func (s *tracedScaler) ScaleOut(meta metav1.Object, actual *apps.StatefulSet, desired *apps.StatefulSet) (err error) {
+	end := tracing.StartSpan(meta, "Scaler.ScaleOut", componentAttributes(s.memberType)...)
+	defer func() { end(err) }()
+	return s.Scaler.ScaleOut(meta, actual, desired)
+}
+
+func (s *tracedScaler) ScaleIn(meta metav1.Object, actual *apps.StatefulSet, desired *apps.StatefulSet) (err error) {
+	end := tracing.StartSpan(meta, "Scaler.ScaleIn", componentAttributes(s.memberType)...)
+	defer func() { end(err) }()
+	return s.Scaler.ScaleIn(meta, actual, desired)
+}
+
+type tracedUpgrader struct {
+	memberType v1alpha1.MemberType
+	Upgrader
+}
+
+func (u *tracedUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) (err error) {
+	end := tracing.StartSpan(tc, "Upgrader.Upgrade", componentAttributes(u.memberType)...)
+	defer func() { end(err) }()
+	return u.Upgrader.Upgrade(tc, oldSet, newSet)
+}
+
+type tracedTiKVUpgrader struct {
+	memberType v1alpha1.MemberType
+	TiKVUpgrader
+}
+
+func (u *tracedTiKVUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) (err error) {
+	end := tracing.StartSpan(meta, "Upgrader.Upgrade", componentAttributes(u.memberType)...)
+	defer func() { end(err) }()
+	return u.TiKVUpgrader.Upgrade(meta, oldSet, newSet)
+}
+
+type tracedFailover struct {
+	memberType v1alpha1.MemberType
+	failover   Failover
+}
+
+func (f *tracedFailover) Failover(tc *v1alpha1.TidbCluster) (err error) {
+	end := tracing.StartSpan(tc, "Failover.Failover", componentAttributes(f.memberType)...)
+	defer func() { end(err) }()
+	return f.failover.Failover(tc)
+}
+
+func (f *tracedFailover) Recover(tc *v1alpha1.TidbCluster) {
+	end := tracing.StartSpan(tc, "Failover.Recover", componentAttributes(f.memberType)...)
+	defer end(nil)
+	f.failover.Recover(tc)
+}
+
+func (f *tracedFailover) RemoveUndesiredFailures(tc *v1alpha1.TidbCluster) {
+	end := tracing.StartSpan(tc, "Failover.RemoveUndesiredFailures", componentAttributes(f.memberType)...)
+	defer end(nil)
+	f.failover.RemoveUndesiredFailures(tc)
+}
+
+func componentAttributes(memberType v1alpha1.MemberType) []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.String("tidb.component", memberType.String())}
+}
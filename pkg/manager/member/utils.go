@@ -115,11 +115,19 @@ func MemberPodName(controllerName, controllerKind string, ordinal int32, memberT
 }
 
 func TiFlashPodName(tcName string, ordinal int32) string {
-	return fmt.Sprintf("%s-%d", controller.TiFlashMemberName(tcName), ordinal)
+	return tiflashRolePodName(tcName, "", ordinal)
+}
+
+func tiflashRolePodName(tcName, role string, ordinal int32) string {
+	return fmt.Sprintf("%s-%d", controller.TiFlashRoleMemberName(tcName, role), ordinal)
 }
 
 func TikvPodName(tcName string, ordinal int32) string {
-	return fmt.Sprintf("%s-%d", controller.TiKVMemberName(tcName), ordinal)
+	return tikvGroupPodName(tcName, "", ordinal)
+}
+
+func tikvGroupPodName(tcName, groupName string, ordinal int32) string {
+	return fmt.Sprintf("%s-%d", controller.TiKVGroupMemberName(tcName, groupName), ordinal)
 }
 
 func PdPodName(tcName string, ordinal int32) string {
@@ -131,7 +139,11 @@ func PDMSPodName(tcName string, ordinal int32, component string) string {
 }
 
 func tidbPodName(tcName string, ordinal int32) string {
-	return fmt.Sprintf("%s-%d", controller.TiDBMemberName(tcName), ordinal)
+	return tidbGroupPodName(tcName, "", ordinal)
+}
+
+func tidbGroupPodName(tcName, groupName string, ordinal int32) string {
+	return fmt.Sprintf("%s-%d", controller.TiDBGroupMemberName(tcName, groupName), ordinal)
 }
 
 func ticdcPodName(tcName string, ordinal int32) string {
@@ -300,12 +312,12 @@ func shouldRecover(tc *v1alpha1.TidbCluster, component string, podLister corelis
 		stores = tc.Status.TiKV.Stores
 		failureStores = tc.Status.TiKV.FailureStores
 		ordinals = tc.TiKVStsDesiredOrdinals(true)
-		podPrefix = controller.TiKVMemberName(tc.Name)
+		podPrefix = controller.TiKVGroupMemberName(tc.Name, tc.TiKVGroupName())
 	case label.TiFlashLabelVal:
 		stores = tc.Status.TiFlash.Stores
 		failureStores = tc.Status.TiFlash.FailureStores
 		ordinals = tc.TiFlashStsDesiredOrdinals(true)
-		podPrefix = controller.TiFlashMemberName(tc.Name)
+		podPrefix = controller.TiFlashRoleMemberName(tc.Name, tc.TiFlashRole())
 	default:
 		klog.Warningf("Unexpected component %s for %s/%s in shouldRecover", component, tc.Namespace, tc.Name)
 		return false
@@ -483,7 +495,7 @@ func GetPVCSelectorForPod(controller runtime.Object, memberType v1alpha1.MemberT
 	var l label.Label
 	switch controller.(type) {
 	case *v1alpha1.TidbCluster:
-		podName = ordinalPodName(memberType, meta.GetName(), ordinal)
+		podName = metaOrdinalPodName(meta, memberType, ordinal)
 		l = label.New().Instance(meta.GetName())
 		l[label.AnnPodNameKey] = podName
 	case *v1alpha1.DMCluster:
@@ -0,0 +1,383 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	utildmcluster "github.com/pingcap/tidb-operator/pkg/util/dmcluster"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// Reasons of the CertificateExpiringSoon condition
+const (
+	// CertificatesValid is the reason when all certificates are valid for longer than the warning threshold
+	CertificatesValid = "CertificatesValid"
+	// CertificateExpiringSoon is the reason when any certificate expires within the warning threshold
+	CertificateExpiringSoon = "CertificateExpiringSoon"
+	// CertificateExpired is the reason when any certificate is already expired
+	CertificateExpired = v1alpha1.CertificateExpiredReason
+)
+
+// certExpiryManager tracks the expiry of the certificates in the TLS secrets consumed by an object:
+//   - export the time to expiry of the certificates in `tidb_operator_certificate_expiry_seconds`
+//   - set the `CertificateExpiringSoon` condition and record an event when any certificate expires soon
+//   - set the reason of the condition to `CertificateExpired` when any certificate is expired, the
+//     member managers hold the rolling update on it so that pods are not rolled onto the expired certificate
+type certExpiryManager struct {
+	deps *controller.Dependencies
+}
+
+// NewCertExpiryManager returns a *certExpiryManager
+func NewCertExpiryManager(deps *controller.Dependencies) *certExpiryManager {
+	return &certExpiryManager{
+		deps: deps,
+	}
+}
+
+func (m *certExpiryManager) Sync(tc *v1alpha1.TidbCluster) error {
+	result, err := m.check(v1alpha1.TiDBClusterKind, tc, tidbClusterTLSSecrets(tc))
+	if err != nil {
+		return err
+	}
+	old := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterCertificateExpiringSoon)
+	if result.checked == 0 && old == nil {
+		return nil
+	}
+	cond := utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterCertificateExpiringSoon, corev1.ConditionStatus(result.status()), result.reason(), result.message())
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
+	if old == nil || old.Reason != result.reason() {
+		m.recordEvent(tc, result)
+	}
+	return nil
+}
+
+func (m *certExpiryManager) SyncDM(dc *v1alpha1.DMCluster) error {
+	result, err := m.check(v1alpha1.DMClusterKind, dc, dmClusterTLSSecrets(dc))
+	if err != nil {
+		return err
+	}
+	old := utildmcluster.GetDMClusterCondition(dc.Status, v1alpha1.DMClusterCertificateExpiringSoon)
+	if result.checked == 0 && old == nil {
+		return nil
+	}
+	cond := utildmcluster.NewDMClusterCondition(v1alpha1.DMClusterCertificateExpiringSoon, corev1.ConditionStatus(result.status()), result.reason(), result.message())
+	utildmcluster.SetDMClusterCondition(&dc.Status, *cond)
+	if old == nil || old.Reason != result.reason() {
+		m.recordEvent(dc, result)
+	}
+	return nil
+}
+
+func (m *certExpiryManager) SyncMonitor(tm *v1alpha1.TidbMonitor) error {
+	var secrets []types.NamespacedName
+	for _, ref := range tm.Spec.Clusters {
+		tc, err := m.deps.TiDBClusterLister.TidbClusters(ref.Namespace).Get(ref.Name)
+		if err != nil {
+			return fmt.Errorf("certExpiryManager.SyncMonitor: failed to get tc %s/%s for tm %s/%s, error: %s", ref.Namespace, ref.Name, tm.Namespace, tm.Name, err)
+		}
+		if tc.IsTLSClusterEnabled() {
+			secrets = append(secrets, types.NamespacedName{Namespace: tc.Namespace, Name: util.ClusterClientTLSSecretName(tc.Name)})
+		}
+	}
+	if tm.Spec.DM != nil {
+		for _, ref := range tm.Spec.DM.Clusters {
+			dc, err := m.deps.DMClusterLister.DMClusters(ref.Namespace).Get(ref.Name)
+			if err != nil {
+				return fmt.Errorf("certExpiryManager.SyncMonitor: failed to get dc %s/%s for tm %s/%s, error: %s", ref.Namespace, ref.Name, tm.Namespace, tm.Name, err)
+			}
+			if dc.IsTLSClusterEnabled() {
+				secrets = append(secrets, types.NamespacedName{Namespace: dc.Namespace, Name: util.DMClientTLSSecretName(dc.Name)})
+			}
+		}
+	}
+
+	result, err := m.check(v1alpha1.TiDBMonitorKind, tm, secrets)
+	if err != nil {
+		return err
+	}
+	return m.syncCondition(tm, &tm.Status.Conditions, result)
+}
+
+func (m *certExpiryManager) SyncTiDBDashboard(td *v1alpha1.TidbDashboard, tc *v1alpha1.TidbCluster) error {
+	var secrets []types.NamespacedName
+	if tc.IsTLSClusterEnabled() {
+		secrets = append(secrets, types.NamespacedName{Namespace: tc.Namespace, Name: util.ClusterClientTLSSecretName(tc.Name)})
+	}
+	if tc.Spec.TiDB != nil && tc.Spec.TiDB.IsTLSClientEnabled() && !tc.SkipTLSWhenConnectTiDB() {
+		secrets = append(secrets, types.NamespacedName{Namespace: tc.Namespace, Name: util.TiDBClientTLSSecretName(tc.Name, nil)})
+	}
+
+	result, err := m.check(v1alpha1.TiDBDashboardKind, td, secrets)
+	if err != nil {
+		return err
+	}
+	return m.syncCondition(td, &td.Status.Conditions, result)
+}
+
+// syncCondition sets the CertificateExpiringSoon condition of the objects whose status has metav1.Condition
+func (m *certExpiryManager) syncCondition(obj runtime.Object, conditions *[]metav1.Condition, result *certExpiryResult) error {
+	old := apimeta.FindStatusCondition(*conditions, v1alpha1.ConditionTypeCertificateExpiringSoon)
+	if result.checked == 0 && old == nil {
+		return nil
+	}
+	apimeta.SetStatusCondition(conditions, metav1.Condition{
+		Type:    v1alpha1.ConditionTypeCertificateExpiringSoon,
+		Status:  result.status(),
+		Reason:  result.reason(),
+		Message: result.message(),
+	})
+	if old == nil || old.Reason != result.reason() {
+		m.recordEvent(obj, result)
+	}
+	return nil
+}
+
+func (m *certExpiryManager) recordEvent(obj runtime.Object, result *certExpiryResult) {
+	if result.reason() == CertificatesValid {
+		return
+	}
+	m.deps.Recorder.Event(obj, corev1.EventTypeWarning, result.reason(), result.message())
+}
+
+// check parses the certificates in the secrets and exports the time to expiry of them,
+// the secrets that do not exist are skipped as they are reported by the components consuming them
+func (m *certExpiryManager) check(kind string, obj runtime.Object, secrets []types.NamespacedName) (*certExpiryResult, error) {
+	var (
+		accessor  = obj.(metav1.ObjectMetaAccessor).GetObjectMeta()
+		ns        = accessor.GetNamespace()
+		name      = accessor.GetName()
+		threshold = m.deps.CLIConfig.CertExpiryWarningThreshold
+		result    = &certExpiryResult{threshold: threshold}
+		visited   = map[types.NamespacedName]bool{}
+	)
+
+	// the gauges of the secrets that are no longer consumed by the object are removed
+	metrics.CertificateExpirySeconds.DeletePartialMatch(prometheus.Labels{
+		metrics.LabelKind:      kind,
+		metrics.LabelNamespace: ns,
+		metrics.LabelName:      name,
+	})
+
+	for _, key := range secrets {
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+
+		secret, err := m.deps.SecretLister.Secrets(key.Namespace).Get(key.Name)
+		if errors.IsNotFound(err) {
+			klog.V(4).Infof("certExpiryManager.check: secret %s for %s %s/%s does not exist, skip it", key, kind, ns, name)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("certExpiryManager.check: failed to get secret %s for %s %s/%s, error: %s", key, kind, ns, name, err)
+		}
+
+		notAfter, ok := earliestNotAfter(secret.Data[corev1.TLSCertKey], secret.Data[corev1.ServiceAccountRootCAKey])
+		if !ok {
+			klog.Warningf("certExpiryManager.check: no valid certificate is found in secret %s for %s %s/%s", key, kind, ns, name)
+			continue
+		}
+
+		ttl := time.Until(notAfter)
+		metrics.CertificateExpirySeconds.WithLabelValues(kind, ns, name, key.Namespace, key.Name).Set(ttl.Seconds())
+		result.checked++
+		switch {
+		case ttl <= 0:
+			result.expired = append(result.expired, key.String())
+		case ttl <= threshold:
+			result.expiringSoon = append(result.expiringSoon, key.String())
+		}
+	}
+	return result, nil
+}
+
+// earliestNotAfter returns the earliest expiry time of all certificates in the PEM data
+func earliestNotAfter(data ...[]byte) (time.Time, bool) {
+	var (
+		earliest time.Time
+		found    bool
+	)
+	for _, rest := range data {
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				continue
+			}
+			if !found || cert.NotAfter.Before(earliest) {
+				earliest = cert.NotAfter
+				found = true
+			}
+		}
+	}
+	return earliest, found
+}
+
+// tidbClusterTLSSecrets returns the TLS secrets consumed by the components of the TidbCluster
+func tidbClusterTLSSecrets(tc *v1alpha1.TidbCluster) []types.NamespacedName {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	var names []string
+	if tc.IsTLSClusterEnabled() {
+		components := map[string]bool{
+			label.PDLabelVal:      tc.Spec.PD != nil || len(tc.Spec.PDMS) > 0,
+			label.TiKVLabelVal:    tc.Spec.TiKV != nil || len(tc.Spec.TiKVGroups) > 0,
+			label.TiDBLabelVal:    tc.Spec.TiDB != nil || len(tc.Spec.TiDBGroups) > 0,
+			label.TiFlashLabelVal: tc.Spec.TiFlash != nil,
+			label.TiCDCLabelVal:   tc.Spec.TiCDC != nil,
+			label.TiProxyLabelVal: tc.Spec.TiProxy != nil,
+			label.PumpLabelVal:    tc.Spec.Pump != nil,
+		}
+		for component, enabled := range components {
+			if enabled {
+				names = append(names, util.ClusterTLSSecretName(tcName, component))
+			}
+		}
+		names = append(names, util.ClusterClientTLSSecretName(tcName))
+	}
+	if tc.Spec.TiDB != nil && tc.Spec.TiDB.IsTLSClientEnabled() {
+		names = append(names, util.TiDBServerTLSSecretName(tcName))
+		if !tc.SkipTLSWhenConnectTiDB() {
+			if tc.Spec.PD != nil {
+				names = append(names, util.TiDBClientTLSSecretName(tcName, tc.Spec.PD.TLSClientSecretName))
+			}
+			if tc.Spec.TiProxy != nil {
+				names = append(names, util.TiDBClientTLSSecretName(tcName, tc.Spec.TiProxy.TLSClientSecretName))
+			}
+		}
+	}
+	if tc.Spec.TiCDC != nil {
+		names = append(names, tc.Spec.TiCDC.TLSClientSecretNames...)
+	}
+	return namespacedNames(ns, names)
+}
+
+// dmClusterTLSSecrets returns the TLS secrets consumed by the components of the DMCluster
+func dmClusterTLSSecrets(dc *v1alpha1.DMCluster) []types.NamespacedName {
+	var names []string
+	if dc.IsTLSClusterEnabled() {
+		names = append(names,
+			util.ClusterTLSSecretName(dc.GetName(), label.DMMasterLabelVal),
+			util.ClusterTLSSecretName(dc.GetName(), label.DMWorkerLabelVal),
+			util.DMClientTLSSecretName(dc.GetName()),
+		)
+	}
+	names = append(names, dc.Spec.TLSClientSecretNames...)
+	return namespacedNames(dc.GetNamespace(), names)
+}
+
+func namespacedNames(ns string, names []string) []types.NamespacedName {
+	sort.Strings(names)
+	keys := make([]types.NamespacedName, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, types.NamespacedName{Namespace: ns, Name: name})
+	}
+	return keys
+}
+
+type certExpiryResult struct {
+	threshold    time.Duration
+	checked      int
+	expired      []string
+	expiringSoon []string
+}
+
+func (r *certExpiryResult) status() metav1.ConditionStatus {
+	if len(r.expired) > 0 || len(r.expiringSoon) > 0 {
+		return metav1.ConditionTrue
+	}
+	return metav1.ConditionFalse
+}
+
+func (r *certExpiryResult) reason() string {
+	switch {
+	case len(r.expired) > 0:
+		return CertificateExpired
+	case len(r.expiringSoon) > 0:
+		return CertificateExpiringSoon
+	default:
+		return CertificatesValid
+	}
+}
+
+func (r *certExpiryResult) message() string {
+	switch {
+	case len(r.expired) > 0 && len(r.expiringSoon) > 0:
+		return fmt.Sprintf("certificates in secrets %v are expired, certificates in secrets %v expire within %s", r.expired, r.expiringSoon, r.threshold)
+	case len(r.expired) > 0:
+		return fmt.Sprintf("certificates in secrets %v are expired", r.expired)
+	case len(r.expiringSoon) > 0:
+		return fmt.Sprintf("certificates in secrets %v expire within %s", r.expiringSoon, r.threshold)
+	default:
+		return fmt.Sprintf("all certificates are valid for more than %s", r.threshold)
+	}
+}
+
+var _ manager.Manager = &certExpiryManager{}
+
+type FakeCertExpiryManager struct {
+	err error
+}
+
+func NewFakeCertExpiryManager() *FakeCertExpiryManager {
+	return &FakeCertExpiryManager{}
+}
+
+func (m *FakeCertExpiryManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeCertExpiryManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
+
+func (m *FakeCertExpiryManager) SyncDM(_ *v1alpha1.DMCluster) error {
+	return m.err
+}
+
+func (m *FakeCertExpiryManager) SyncMonitor(_ *v1alpha1.TidbMonitor) error {
+	return m.err
+}
+
+func (m *FakeCertExpiryManager) SyncTiDBDashboard(_ *v1alpha1.TidbDashboard, _ *v1alpha1.TidbCluster) error {
+	return m.err
+}
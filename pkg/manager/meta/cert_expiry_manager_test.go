@@ -0,0 +1,129 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"github.com/pingcap/tidb-operator/pkg/util/crypto"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func newCertExpiryManager() (*certExpiryManager, cache.Indexer) {
+	deps := controller.NewFakeDependencies()
+	secretIndexer := deps.KubeInformerFactory.Core().V1().Secrets().Informer().GetIndexer()
+	return NewCertExpiryManager(deps), secretIndexer
+}
+
+// lastEvent returns the last event recorded by the manager
+func lastEvent(m *certExpiryManager) string {
+	var event string
+	events := m.deps.Recorder.(*record.FakeRecorder).Events
+	for len(events) > 0 {
+		event = <-events
+	}
+	return event
+}
+
+func newTLSSecret(g *GomegaWithT, ns, name string, duration time.Duration) *corev1.Secret {
+	cert, _, err := crypto.NewCA(name, duration)
+	g.Expect(err).NotTo(HaveOccurred())
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Data:       map[string][]byte{corev1.TLSCertKey: cert},
+	}
+}
+
+func TestCertExpiryManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForMeta()
+	tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+	tc.Spec.PD = &v1alpha1.PDSpec{}
+	tc.Spec.TiKV = &v1alpha1.TiKVSpec{}
+	tc.Spec.TiCDC = &v1alpha1.TiCDCSpec{TLSClientSecretNames: []string{"downstream"}}
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	pdSecret := util.ClusterTLSSecretName(tcName, label.PDLabelVal)
+
+	m, secretIndexer := newCertExpiryManager()
+	// the secrets of ticdc and the client are not created yet
+	g.Expect(secretIndexer.Add(newTLSSecret(g, ns, pdSecret, 365*24*time.Hour))).To(Succeed())
+	g.Expect(secretIndexer.Add(newTLSSecret(g, ns, util.ClusterTLSSecretName(tcName, label.TiKVLabelVal), 365*24*time.Hour))).To(Succeed())
+
+	g.Expect(m.Sync(tc)).To(Succeed())
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterCertificateExpiringSoon)
+	g.Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+	g.Expect(cond.Reason).To(Equal(CertificatesValid))
+	expiry := testutil.ToFloat64(metrics.CertificateExpirySeconds.WithLabelValues(v1alpha1.TiDBClusterKind, ns, tcName, ns, pdSecret))
+	g.Expect(expiry).To(BeNumerically("~", (365 * 24 * time.Hour).Seconds(), time.Hour.Seconds()))
+
+	// the certificate of the downstream expires soon
+	g.Expect(secretIndexer.Add(newTLSSecret(g, ns, "downstream", 24*time.Hour))).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	cond = utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterCertificateExpiringSoon)
+	g.Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(cond.Reason).To(Equal(CertificateExpiringSoon))
+	g.Expect(cond.Message).To(ContainSubstring("default/downstream"))
+
+	// the expired certificate of pd is reported by the condition and an event
+	g.Expect(secretIndexer.Update(newTLSSecret(g, ns, pdSecret, -time.Minute))).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	cond = utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterCertificateExpiringSoon)
+	g.Expect(cond.Reason).To(Equal(CertificateExpired))
+	g.Expect(tc.IsCertificateExpired()).To(BeTrue())
+	g.Expect(lastEvent(m)).To(HavePrefix(corev1.EventTypeWarning + " " + CertificateExpired))
+	expiry = testutil.ToFloat64(metrics.CertificateExpirySeconds.WithLabelValues(v1alpha1.TiDBClusterKind, ns, tcName, ns, pdSecret))
+	g.Expect(expiry).To(BeNumerically("<", 0))
+
+	// the gauges of the secrets that are no longer consumed are removed
+	tc.Spec.TiCDC = nil
+	g.Expect(secretIndexer.Update(newTLSSecret(g, ns, pdSecret, 365*24*time.Hour))).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(metrics.CertificateExpirySeconds.DeleteLabelValues(v1alpha1.TiDBClusterKind, ns, tcName, ns, "downstream")).To(BeFalse())
+}
+
+func TestCertExpiryManagerSyncTiDBDashboard(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForMeta()
+	tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+	td := &v1alpha1.TidbDashboard{
+		ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "dashboard-ns"},
+	}
+
+	m, secretIndexer := newCertExpiryManager()
+	// no certificate is tracked without the secrets
+	g.Expect(m.SyncTiDBDashboard(td, tc)).To(Succeed())
+	g.Expect(td.Status.Conditions).To(BeEmpty())
+
+	g.Expect(secretIndexer.Add(newTLSSecret(g, tc.Namespace, util.ClusterClientTLSSecretName(tc.Name), -time.Minute))).To(Succeed())
+	g.Expect(m.SyncTiDBDashboard(td, tc)).To(Succeed())
+	cond := apimeta.FindStatusCondition(td.Status.Conditions, v1alpha1.ConditionTypeCertificateExpiringSoon)
+	g.Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(cond.Reason).To(Equal(CertificateExpired))
+}
@@ -0,0 +1,304 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalingschedule
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/robfig/cron"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	scaledEvent              = "Scaled"
+	failedParseScheduleEvent = "FailedParseSchedule"
+
+	defaultHistoryLimit = 10
+)
+
+// component is a component of the TidbCluster that can be scaled by the schedule
+type component struct {
+	memberType v1alpha1.MemberType
+	// target returns the replica target of the component, nil means the component is not scaled
+	target func(*v1alpha1.ScalingTargets) *int32
+	// replicas returns the replicas in the spec of the TidbCluster, nil means the component is not deployed
+	replicas func(*v1alpha1.TidbCluster) *int32
+}
+
+var components = []component{
+	{
+		memberType: v1alpha1.TiDBMemberType,
+		target:     func(t *v1alpha1.ScalingTargets) *int32 { return t.TiDB },
+		replicas: func(tc *v1alpha1.TidbCluster) *int32 {
+			if tc.Spec.TiDB == nil {
+				return nil
+			}
+			return &tc.Spec.TiDB.Replicas
+		},
+	},
+	{
+		memberType: v1alpha1.TiKVMemberType,
+		target:     func(t *v1alpha1.ScalingTargets) *int32 { return t.TiKV },
+		replicas: func(tc *v1alpha1.TidbCluster) *int32 {
+			if tc.Spec.TiKV == nil {
+				return nil
+			}
+			return &tc.Spec.TiKV.Replicas
+		},
+	},
+	{
+		memberType: v1alpha1.TiFlashMemberType,
+		target:     func(t *v1alpha1.ScalingTargets) *int32 { return t.TiFlash },
+		replicas: func(tc *v1alpha1.TidbCluster) *int32 {
+			if tc.Spec.TiFlash == nil {
+				return nil
+			}
+			return &tc.Spec.TiFlash.Replicas
+		},
+	},
+	{
+		memberType: v1alpha1.TiCDCMemberType,
+		target:     func(t *v1alpha1.ScalingTargets) *int32 { return t.TiCDC },
+		replicas: func(tc *v1alpha1.TidbCluster) *int32 {
+			if tc.Spec.TiCDC == nil {
+				return nil
+			}
+			return &tc.Spec.TiCDC.Replicas
+		},
+	},
+	{
+		memberType: v1alpha1.TiProxyMemberType,
+		target:     func(t *v1alpha1.ScalingTargets) *int32 { return t.TiProxy },
+		replicas: func(tc *v1alpha1.TidbCluster) *int32 {
+			if tc.Spec.TiProxy == nil {
+				return nil
+			}
+			return &tc.Spec.TiProxy.Replicas
+		},
+	},
+}
+
+// activeWindow is a window that is active at the evaluation
+type activeWindow struct {
+	*v1alpha1.ScalingWindow
+	start time.Time
+}
+
+// target is the resolved replica target of a component
+type target struct {
+	// window is the name of the window that sets the target, empty means the default targets
+	window   string
+	replicas int32
+}
+
+// Manager evaluates the windows of the TidbClusterScalingSchedule and sets the replicas in the spec
+// of the TidbCluster to the targets. The components are then scaled by the scalers of the TidbCluster
+// controller, so all the checks of scaling in, e.g. the data safety checks of TiKV, still apply.
+type Manager struct {
+	deps *controller.Dependencies
+	// for unit test only
+	now func() time.Time
+}
+
+// NewManager returns a *Manager
+func NewManager(deps *controller.Dependencies) *Manager {
+	return &Manager{
+		deps: deps,
+		now:  time.Now,
+	}
+}
+
+func (m *Manager) Sync(tcss *v1alpha1.TidbClusterScalingSchedule, tc *v1alpha1.TidbCluster) error {
+	ns := tcss.GetNamespace()
+	name := tcss.GetName()
+
+	if tcss.Spec.Suspend {
+		tcss.Status.ActiveWindows = nil
+		tcss.Status.NextEvaluateTime = nil
+		return nil
+	}
+
+	loc := time.UTC
+	if tz := tcss.Spec.Timezone; tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("load timezone %s of scaling schedule %s/%s failed, err: %v", tz, ns, name, err)
+		}
+	}
+	now := m.now().In(loc)
+
+	active, next, err := evaluateWindows(tcss.Spec.Windows, now)
+	if err != nil {
+		m.deps.Recorder.Event(tcss, corev1.EventTypeWarning, failedParseScheduleEvent, err.Error())
+		return fmt.Errorf("evaluate windows of scaling schedule %s/%s failed, err: %v", ns, name, err)
+	}
+	targets := resolveTargets(active, tcss.Spec.Default)
+
+	records, err := m.scale(tcss, tc, targets, now)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, w := range active {
+		names = append(names, w.Name)
+	}
+	tcss.Status.ActiveWindows = names
+	tcss.Status.LastEvaluateTime = &metav1.Time{Time: now}
+	tcss.Status.NextEvaluateTime = nil
+	if next != nil {
+		tcss.Status.NextEvaluateTime = &metav1.Time{Time: *next}
+	}
+	if len(records) > 0 {
+		tcss.Status.LastScaleTime = &metav1.Time{Time: now}
+		tcss.Status.History = append(tcss.Status.History, records...)
+	}
+	limit := defaultHistoryLimit
+	if tcss.Spec.HistoryLimit != nil {
+		limit = int(*tcss.Spec.HistoryLimit)
+	}
+	if len(tcss.Status.History) > limit {
+		tcss.Status.History = tcss.Status.History[len(tcss.Status.History)-limit:]
+	}
+	return nil
+}
+
+// scale sets the replicas of the components in the spec of the TidbCluster to the targets,
+// and returns the records of the changed components
+func (m *Manager) scale(tcss *v1alpha1.TidbClusterScalingSchedule, tc *v1alpha1.TidbCluster,
+	targets map[v1alpha1.MemberType]target, now time.Time) ([]v1alpha1.ScalingRecord, error) {
+	newTC := tc.DeepCopy()
+	var records []v1alpha1.ScalingRecord
+	for _, c := range components {
+		t, ok := targets[c.memberType]
+		if !ok {
+			continue
+		}
+		replicas := c.replicas(newTC)
+		if replicas == nil || *replicas == t.replicas {
+			continue
+		}
+		records = append(records, v1alpha1.ScalingRecord{
+			Time:      metav1.Time{Time: now},
+			Window:    t.window,
+			Component: c.memberType,
+			From:      *replicas,
+			To:        t.replicas,
+		})
+		*replicas = t.replicas
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	if _, err := m.deps.TiDBClusterControl.Update(newTC); err != nil {
+		return nil, fmt.Errorf("scale tc %s/%s for scaling schedule %s/%s failed, err: %v", tc.Namespace, tc.Name, tcss.Namespace, tcss.Name, err)
+	}
+	for _, r := range records {
+		by := "the default targets"
+		if r.Window != "" {
+			by = fmt.Sprintf("window %s", r.Window)
+		}
+		klog.Infof("scaling schedule %s/%s: scale %s of tc %s/%s from %d to %d by %s", tcss.Namespace, tcss.Name, r.Component, tc.Namespace, tc.Name, r.From, r.To, by)
+		m.deps.Recorder.Eventf(tcss, corev1.EventTypeNormal, scaledEvent, "scale %s of tc %s/%s from %d to %d by %s", r.Component, tc.Namespace, tc.Name, r.From, r.To, by)
+	}
+	return records, nil
+}
+
+// evaluateWindows returns the windows that are active at now in the order of resolving conflicts,
+// and the next time any window starts or ends
+func evaluateWindows(windows []v1alpha1.ScalingWindow, now time.Time) ([]activeWindow, *time.Time, error) {
+	var (
+		active []activeWindow
+		next   *time.Time
+	)
+	earliest := func(t time.Time) {
+		if !t.IsZero() && (next == nil || t.Before(*next)) {
+			next = &t
+		}
+	}
+	for i := range windows {
+		w := &windows[i]
+		sched, err := cron.ParseStandard(w.Schedule)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse schedule %q of window %s failed, err: %v", w.Schedule, w.Name, err)
+		}
+
+		// the window is active if it starts in (now - duration, now]
+		var start time.Time
+		for t := sched.Next(now.Add(-w.Duration.Duration)); !t.IsZero() && !t.After(now); t = sched.Next(t) {
+			start = t
+		}
+		if !start.IsZero() {
+			active = append(active, activeWindow{ScalingWindow: w, start: start})
+			earliest(start.Add(w.Duration.Duration))
+		}
+		earliest(sched.Next(now))
+	}
+
+	// the window with the higher priority wins, and then the one started later
+	sort.SliceStable(active, func(i, j int) bool {
+		if active[i].Priority != active[j].Priority {
+			return active[i].Priority > active[j].Priority
+		}
+		if !active[i].start.Equal(active[j].start) {
+			return active[i].start.After(active[j].start)
+		}
+		return active[i].Name < active[j].Name
+	})
+	return active, next, nil
+}
+
+// resolveTargets returns the replica target of each component, which is taken from the first active
+// window that sets it, or from the default targets if no active window sets it
+func resolveTargets(active []activeWindow, def *v1alpha1.ScalingTargets) map[v1alpha1.MemberType]target {
+	targets := map[v1alpha1.MemberType]target{}
+	for _, c := range components {
+		for _, w := range active {
+			if replicas := c.target(&w.Replicas); replicas != nil {
+				targets[c.memberType] = target{window: w.Name, replicas: *replicas}
+				break
+			}
+		}
+		if _, ok := targets[c.memberType]; ok || def == nil {
+			continue
+		}
+		if replicas := c.target(def); replicas != nil {
+			targets[c.memberType] = target{replicas: *replicas}
+		}
+	}
+	return targets
+}
+
+type FakeManager struct {
+	err error
+}
+
+func NewFakeManager() *FakeManager {
+	return &FakeManager{}
+}
+
+func (m *FakeManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeManager) Sync(_ *v1alpha1.TidbClusterScalingSchedule, _ *v1alpha1.TidbCluster) error {
+	return m.err
+}
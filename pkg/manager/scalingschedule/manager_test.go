@@ -0,0 +1,192 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalingschedule
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func newScalingSchedule() *v1alpha1.TidbClusterScalingSchedule {
+	return &v1alpha1.TidbClusterScalingSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "tcss", Namespace: "default"},
+		Spec: v1alpha1.TidbClusterScalingScheduleSpec{
+			Cluster: v1alpha1.TidbClusterRef{Name: "tc", Namespace: "default"},
+			Windows: []v1alpha1.ScalingWindow{
+				{
+					// 09:00 - 18:00 on weekdays
+					Name:     "business-hours",
+					Schedule: "0 9 * * 1-5",
+					Duration: metav1.Duration{Duration: 9 * time.Hour},
+					Replicas: v1alpha1.ScalingTargets{TiDB: pointer.Int32(6), TiKV: pointer.Int32(5)},
+				},
+				{
+					// 12:00 - 13:00 every day
+					Name:     "lunch",
+					Schedule: "0 12 * * *",
+					Duration: metav1.Duration{Duration: time.Hour},
+					Priority: 1,
+					Replicas: v1alpha1.ScalingTargets{TiDB: pointer.Int32(8)},
+				},
+			},
+			Default: &v1alpha1.ScalingTargets{TiDB: pointer.Int32(2), TiKV: pointer.Int32(3)},
+		},
+	}
+}
+
+func newTidbCluster() *v1alpha1.TidbCluster {
+	return &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "tc", Namespace: "default"},
+		Spec: v1alpha1.TidbClusterSpec{
+			PD:   &v1alpha1.PDSpec{Replicas: 3},
+			TiKV: &v1alpha1.TiKVSpec{Replicas: 3},
+			TiDB: &v1alpha1.TiDBSpec{Replicas: 2},
+		},
+	}
+}
+
+func TestEvaluateWindows(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	windows := newScalingSchedule().Spec.Windows
+	date := func(day, hour, min int) time.Time {
+		// 2024-01-01 is Monday
+		return time.Date(2024, 1, day, hour, min, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		now    time.Time
+		active []string
+		next   time.Time
+	}{
+		{date(1, 8, 0), nil, date(1, 9, 0)},
+		{date(1, 9, 0), []string{"business-hours"}, date(1, 12, 0)},
+		{date(1, 12, 30), []string{"lunch", "business-hours"}, date(1, 13, 0)},
+		{date(1, 17, 59), []string{"business-hours"}, date(1, 18, 0)},
+		{date(1, 18, 0), nil, date(2, 9, 0)},
+		// only lunch on Saturday
+		{date(6, 12, 0), []string{"lunch"}, date(6, 13, 0)},
+	}
+	for _, tt := range tests {
+		active, next, err := evaluateWindows(windows, tt.now)
+		g.Expect(err).NotTo(HaveOccurred())
+		var names []string
+		for _, w := range active {
+			names = append(names, w.Name)
+		}
+		g.Expect(names).To(Equal(tt.active), "now: %s", tt.now)
+		g.Expect(next).NotTo(BeNil())
+		g.Expect(*next).To(Equal(tt.next), "now: %s", tt.now)
+	}
+
+	_, _, err := evaluateWindows([]v1alpha1.ScalingWindow{{Name: "bad", Schedule: "bad"}}, date(1, 0, 0))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestResolveTargets(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tcss := newScalingSchedule()
+	active, _, err := evaluateWindows(tcss.Spec.Windows, time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resolveTargets(active, tcss.Spec.Default)).To(Equal(map[v1alpha1.MemberType]target{
+		v1alpha1.TiDBMemberType: {window: "lunch", replicas: 8},
+		v1alpha1.TiKVMemberType: {window: "business-hours", replicas: 5},
+	}))
+	g.Expect(resolveTargets(nil, tcss.Spec.Default)).To(Equal(map[v1alpha1.MemberType]target{
+		v1alpha1.TiDBMemberType: {replicas: 2},
+		v1alpha1.TiKVMemberType: {replicas: 3},
+	}))
+	g.Expect(resolveTargets(nil, nil)).To(BeEmpty())
+}
+
+func TestManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	tcIndexer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer()
+	tc := newTidbCluster()
+	g.Expect(tcIndexer.Add(tc)).To(Succeed())
+	getTC := func() *v1alpha1.TidbCluster {
+		tc, err := deps.TiDBClusterLister.TidbClusters("default").Get("tc")
+		g.Expect(err).NotTo(HaveOccurred())
+		return tc
+	}
+
+	m := NewManager(deps)
+	now := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	// scale out in the windows
+	tcss := newScalingSchedule()
+	tcss.Spec.HistoryLimit = pointer.Int32(3)
+	g.Expect(m.Sync(tcss, getTC())).To(Succeed())
+	g.Expect(getTC().Spec.TiDB.Replicas).To(Equal(int32(8)))
+	g.Expect(getTC().Spec.TiKV.Replicas).To(Equal(int32(5)))
+	g.Expect(tcss.Status.ActiveWindows).To(Equal([]string{"lunch", "business-hours"}))
+	g.Expect(tcss.Status.NextEvaluateTime.Time).To(Equal(time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)))
+	g.Expect(tcss.Status.LastScaleTime.Time).To(Equal(now))
+	g.Expect(tcss.Status.History).To(HaveLen(2))
+	g.Expect(tcss.Status.History[0].Component).To(Equal(v1alpha1.TiDBMemberType))
+	g.Expect(tcss.Status.History[0].Window).To(Equal("lunch"))
+	g.Expect(tcss.Status.History[0].From).To(Equal(int32(2)))
+	g.Expect(tcss.Status.History[0].To).To(Equal(int32(8)))
+
+	// nothing is changed if the replicas are already the targets
+	g.Expect(m.Sync(tcss, getTC())).To(Succeed())
+	g.Expect(tcss.Status.History).To(HaveLen(2))
+
+	// scale in after the lunch window ends, the history is trimmed
+	now = time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)
+	g.Expect(m.Sync(tcss, getTC())).To(Succeed())
+	g.Expect(getTC().Spec.TiDB.Replicas).To(Equal(int32(6)))
+	g.Expect(tcss.Status.ActiveWindows).To(Equal([]string{"business-hours"}))
+	g.Expect(tcss.Status.History).To(HaveLen(3))
+	g.Expect(tcss.Status.History[2].Window).To(Equal("business-hours"))
+
+	// the default targets are applied after all the windows end
+	now = time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	g.Expect(m.Sync(tcss, getTC())).To(Succeed())
+	g.Expect(getTC().Spec.TiDB.Replicas).To(Equal(int32(2)))
+	g.Expect(getTC().Spec.TiKV.Replicas).To(Equal(int32(3)))
+	g.Expect(tcss.Status.ActiveWindows).To(BeEmpty())
+	g.Expect(tcss.Status.History).To(HaveLen(3))
+	g.Expect(tcss.Status.History[2].Window).To(BeEmpty())
+
+	// the windows are evaluated in the timezone, 12:30 in Asia/Shanghai
+	now = time.Date(2024, 1, 1, 4, 30, 0, 0, time.UTC)
+	tcss.Spec.Timezone = "Asia/Shanghai"
+	g.Expect(m.Sync(tcss, getTC())).To(Succeed())
+	g.Expect(getTC().Spec.TiDB.Replicas).To(Equal(int32(8)))
+
+	// nothing is scaled when the schedule is suspended
+	now = time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	tcss.Spec.Suspend = true
+	g.Expect(m.Sync(tcss, getTC())).To(Succeed())
+	g.Expect(getTC().Spec.TiDB.Replicas).To(Equal(int32(8)))
+	g.Expect(tcss.Status.ActiveWindows).To(BeNil())
+	g.Expect(tcss.Status.NextEvaluateTime).To(BeNil())
+
+	// the error of updating the TidbCluster is returned
+	tcss.Spec.Suspend = false
+	deps.TiDBClusterControl.(*controller.FakeTidbClusterControl).SetUpdateTidbClusterError(fmt.Errorf("update failed"), 0)
+	g.Expect(m.Sync(tcss, getTC())).NotTo(Succeed())
+	g.Expect(getTC().Spec.TiDB.Replicas).To(Equal(int32(8)))
+}
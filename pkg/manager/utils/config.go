@@ -14,7 +14,14 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	perrors "github.com/pingcap/errors"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -176,3 +183,191 @@ func confirmNameByData(existing, desired *corev1.ConfigMap, dataEqual bool) {
 		desired.Name = fmt.Sprintf("%s-new", desired.Name)
 	}
 }
+
+// ConfigChange is a config item that is changed between two TOML configs
+type ConfigChange struct {
+	// Key is the dotted path of the config item, e.g. `raftstore.raft-log-gc-threshold`
+	Key string
+	// Value is the new value of the config item, it is nil if the item is removed
+	Value interface{}
+}
+
+// DiffConfig returns the config items that are changed from the old TOML config to the new one, sorted by key.
+// Tables are compared by items, while arrays (including arrays of tables) are compared as a whole.
+func DiffConfig(old, new []byte) ([]ConfigChange, error) {
+	oldItems, err := flattenConfig(old)
+	if err != nil {
+		return nil, err
+	}
+	newItems, err := flattenConfig(new)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []ConfigChange
+	for k, v := range newItems {
+		if oldV, ok := oldItems[k]; !ok || !reflect.DeepEqual(oldV, v) {
+			changes = append(changes, ConfigChange{Key: k, Value: v})
+		}
+	}
+	for k := range oldItems {
+		if _, ok := newItems[k]; !ok {
+			changes = append(changes, ConfigChange{Key: k})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes, nil
+}
+
+func flattenConfig(data []byte) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	if err := toml.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return flattenTable(m), nil
+}
+
+// flattenTable flattens the nested tables to the items with dotted keys
+func flattenTable(m map[string]interface{}) map[string]interface{} {
+	items := map[string]interface{}{}
+	var flatten func(prefix string, m map[string]interface{})
+	flatten = func(prefix string, m map[string]interface{}) {
+		for k, v := range m {
+			if prefix != "" {
+				k = prefix + "." + k
+			}
+			if table, ok := v.(map[string]interface{}); ok {
+				flatten(k, table)
+				continue
+			}
+			items[k] = v
+		}
+	}
+	flatten("", m)
+	return items
+}
+
+// ConfigDrift is a config item whose running value differs from the value in the config
+type ConfigDrift struct {
+	// Key is the dotted path of the config item
+	Key string
+	// Expected is the value of the config item in the config
+	Expected interface{}
+	// Actual is the running value of the config item
+	Actual interface{}
+}
+
+// DetectConfigDrift compares the TOML config with the running config decoded from the JSON returned by the server,
+// and returns the drifted config items sorted by key. The items that are not returned by the server are ignored.
+func DetectConfigDrift(expected []byte, actual map[string]interface{}) ([]ConfigDrift, error) {
+	expectedItems, err := flattenConfig(expected)
+	if err != nil {
+		return nil, err
+	}
+	actualItems := flattenTable(actual)
+
+	var drifts []ConfigDrift
+	for k, v := range expectedItems {
+		actualV, ok := actualItems[k]
+		if !ok || configValueEqual(v, actualV) {
+			continue
+		}
+		drifts = append(drifts, ConfigDrift{Key: k, Expected: v, Actual: actualV})
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].Key < drifts[j].Key
+	})
+	return drifts, nil
+}
+
+var readableSizeUnits = map[string]float64{
+	"":    1,
+	"B":   1,
+	"K":   1 << 10,
+	"KB":  1 << 10,
+	"KIB": 1 << 10,
+	"M":   1 << 20,
+	"MB":  1 << 20,
+	"MIB": 1 << 20,
+	"G":   1 << 30,
+	"GB":  1 << 30,
+	"GIB": 1 << 30,
+	"T":   1 << 40,
+	"TB":  1 << 40,
+	"TIB": 1 << 40,
+	"P":   1 << 50,
+	"PB":  1 << 50,
+	"PIB": 1 << 50,
+}
+
+var readableSizeRegexp = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)\s*([a-zA-Z]*)$`)
+
+// configValueEqual compares the config value in TOML with the running value in JSON,
+// the readable sizes (e.g. 1GB and 1GiB) and durations (e.g. 1h and 60m) are compared by values.
+func configValueEqual(expected, actual interface{}) bool {
+	if reflect.DeepEqual(expected, actual) {
+		return true
+	}
+	// normalize the numbers to float64 as JSON does
+	data, err := json.Marshal(expected)
+	if err != nil {
+		return false
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return false
+	}
+	if reflect.DeepEqual(normalized, actual) {
+		return true
+	}
+
+	expectedStr, actualStr := fmt.Sprint(normalized), fmt.Sprint(actual)
+	if d1, err := parseReadableDuration(expectedStr); err == nil {
+		d2, err := parseReadableDuration(actualStr)
+		return err == nil && d1 == d2
+	}
+	s1, ok := parseReadableSize(expectedStr)
+	if !ok {
+		return false
+	}
+	s2, ok := parseReadableSize(actualStr)
+	return ok && s1 == s2
+}
+
+var readableDurationDayRegexp = regexp.MustCompile(`^([0-9]+)d`)
+
+// parseReadableDuration parses the durations like 1d2h, which are used by TiKV and PD
+func parseReadableDuration(s string) (time.Duration, error) {
+	var days time.Duration
+	if m := readableDurationDayRegexp.FindStringSubmatch(s); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return 0, err
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		s = s[len(m[0]):]
+		if s == "" {
+			return days, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	return days + d, err
+}
+
+func parseReadableSize(s string) (float64, bool) {
+	m := readableSizeRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, false
+	}
+	unit, ok := readableSizeUnits[strings.ToUpper(m[2])]
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	return v * unit, true
+}
@@ -371,3 +371,98 @@ func TestConfirmNameByData(t *testing.T) {
 		testFn(&tests[i], t)
 	}
 }
+
+func TestDiffConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	old := `
+a = 1
+b = "b"
+[raftstore]
+raft-log-gc-threshold = 50
+[server]
+labels = { zone = "z1" }
+[[security.encryption.master-key]]
+type = "file"
+`
+	new := `
+a = 1
+c = true
+[raftstore]
+raft-log-gc-threshold = 100
+[server]
+labels = { zone = "z2" }
+[[security.encryption.master-key]]
+type = "kms"
+`
+	changes, err := DiffConfig([]byte(old), []byte(new))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(changes).To(Equal([]ConfigChange{
+		{Key: "b"},
+		{Key: "c", Value: true},
+		{Key: "raftstore.raft-log-gc-threshold", Value: int64(100)},
+		{Key: "security.encryption.master-key", Value: []map[string]interface{}{{"type": "kms"}}},
+		{Key: "server.labels.zone", Value: "z2"},
+	}))
+
+	changes, err = DiffConfig([]byte(old), []byte(old))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(changes).To(BeEmpty())
+
+	_, err = DiffConfig([]byte(old), []byte("a = "))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestDetectConfigDrift(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	expected := `
+log-level = "info"
+[raftstore]
+raft-log-gc-threshold = 50
+raft-entry-cache-life-time = "30s"
+[storage.block-cache]
+capacity = "1GB"
+[server]
+labels = { zone = "z1" }
+[security]
+cert-allowed-cn = ["tidb"]
+`
+	actual := map[string]interface{}{
+		"log-level": "warn",
+		"raftstore": map[string]interface{}{
+			"raft-log-gc-threshold":      float64(100),
+			"raft-entry-cache-life-time": "30s",
+		},
+		"storage": map[string]interface{}{
+			"block-cache": map[string]interface{}{
+				"capacity": "1GiB",
+			},
+		},
+		"server": map[string]interface{}{
+			"labels": map[string]interface{}{"zone": "z1"},
+		},
+		"security": map[string]interface{}{
+			"cert-allowed-cn": []interface{}{"tidb"},
+		},
+	}
+	drifts, err := DetectConfigDrift([]byte(expected), actual)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(drifts).To(Equal([]ConfigDrift{
+		{Key: "log-level", Expected: "info", Actual: "warn"},
+		{Key: "raftstore.raft-log-gc-threshold", Expected: int64(50), Actual: float64(100)},
+	}))
+
+	// the durations and sizes are compared by values
+	actual["raftstore"] = map[string]interface{}{
+		"raft-log-gc-threshold":      float64(50),
+		"raft-entry-cache-life-time": "30000ms",
+	}
+	actual["log-level"] = "info"
+	drifts, err = DetectConfigDrift([]byte(expected), actual)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(drifts).To(BeEmpty())
+
+	_, err = DetectConfigDrift([]byte("a = "), actual)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -15,6 +15,7 @@ package utils
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -27,6 +28,7 @@ import (
 
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
@@ -105,9 +107,50 @@ func UpdateStatefulSetWithPrecheck(
 		return fmt.Errorf("contains volumeMounts that do not have matched volume: %v", notExistMount)
 	}
 
+	if err := HoldPodSpecIfCertificateExpired(deps, tc, newTiDBSet, oldTiDBSet); err != nil {
+		return err
+	}
+
 	return UpdateStatefulSet(deps.StatefulSetControl, tc, newTiDBSet, oldTiDBSet)
 }
 
+// certificateExpiredObject is the object whose certificates expiry is tracked by the cert expiry manager
+type certificateExpiredObject interface {
+	runtime.Object
+	IsCertificateExpired() bool
+}
+
+// HoldPodSpecIfCertificateExpired keeps the pod spec of the StatefulSet as the last applied one
+// if any certificate consumed by the object is expired, so that the pods are not rolled onto the
+// expired certificate until it is renewed. Other changes of the StatefulSet, e.g. scaling, still proceed.
+func HoldPodSpecIfCertificateExpired(
+	deps *controller.Dependencies,
+	obj certificateExpiredObject,
+	newSet *apps.StatefulSet,
+	oldSet *apps.StatefulSet,
+) error {
+	if !obj.IsCertificateExpired() {
+		return nil
+	}
+
+	podSpec := oldSet.Spec.Template.Spec
+	if lastAppliedConfig, ok := oldSet.Annotations[LastAppliedConfigAnnotation]; ok {
+		spec := apps.StatefulSetSpec{}
+		if err := json.Unmarshal([]byte(lastAppliedConfig), &spec); err != nil {
+			return err
+		}
+		podSpec = spec.Template.Spec
+	}
+	if apiequality.Semantic.DeepEqual(newSet.Spec.Template.Spec, podSpec) {
+		return nil
+	}
+
+	klog.Warningf("statefulset %s/%s: certificates are expired, hold the pod spec until they are renewed", oldSet.Namespace, oldSet.Name)
+	deps.Recorder.Event(obj, corev1.EventTypeWarning, v1alpha1.CertificateExpiredReason, "certificates are expired, the pods are not rolled until they are renewed")
+	newSet.Spec.Template.Spec = podSpec
+	return nil
+}
+
 // UpdateStatefulSet is a template function to update the statefulset of components
 func UpdateStatefulSet(setCtl controller.StatefulSetControlInterface, object runtime.Object, newSet, oldSet *apps.StatefulSet) error {
 	isOrphan := metav1.GetControllerOf(oldSet) == nil
@@ -17,9 +17,13 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 )
 
 func TestStatefulSetIsUpgrading(t *testing.T) {
@@ -167,3 +171,31 @@ func TestNotExistMount(t *testing.T) {
 	mp = notExistMount(newSTS, oldSTS)
 	g.Expect(mp).ShouldNot(BeEmpty())
 }
+
+func TestHoldPodSpecIfCertificateExpired(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	tc := &v1alpha1.TidbCluster{}
+	oldSet := &apps.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "test-pd"}}
+	oldSet.Spec.Template.Spec.Containers = []corev1.Container{{Name: "pd", Image: "pingcap/pd:v7.5.0"}}
+	g.Expect(SetStatefulSetLastAppliedConfigAnnotation(oldSet)).To(Succeed())
+	newSet := oldSet.DeepCopy()
+	newSet.Spec.Replicas = pointer.Int32Ptr(5)
+	newSet.Spec.Template.Spec.Containers[0].Image = "pingcap/pd:v8.1.0"
+
+	// the pod spec is updated when the certificates are valid
+	g.Expect(HoldPodSpecIfCertificateExpired(deps, tc, newSet, oldSet)).To(Succeed())
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("pingcap/pd:v8.1.0"))
+
+	// the pod spec is held when any certificate is expired, but the replicas are still updated
+	tc.Status.Conditions = []v1alpha1.TidbClusterCondition{{
+		Type:   v1alpha1.TidbClusterCertificateExpiringSoon,
+		Status: corev1.ConditionTrue,
+		Reason: v1alpha1.CertificateExpiredReason,
+	}}
+	g.Expect(HoldPodSpecIfCertificateExpired(deps, tc, newSet, oldSet)).To(Succeed())
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("pingcap/pd:v7.5.0"))
+	g.Expect(*newSet.Spec.Replicas).To(Equal(int32(5)))
+	g.Expect(deps.Recorder.(*record.FakeRecorder).Events).To(HaveLen(1))
+}
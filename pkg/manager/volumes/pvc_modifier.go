@@ -27,6 +27,8 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/pingcap/tidb-operator/pkg/util"
 )
 
 const (
@@ -81,6 +83,8 @@ func (p *pvcModifier) Sync(tc *v1alpha1.TidbCluster) error {
 			continue
 		}
 
+		p.recordVolumeMetrics(ctx)
+
 		err = p.modifyVolumes(ctx)
 		if err != nil {
 			errs = append(errs, fmt.Errorf("modify volumes for %s failed: %w", ctx.ComponentID(), err))
@@ -91,6 +95,26 @@ func (p *pvcModifier) Sync(tc *v1alpha1.TidbCluster) error {
 	return errutil.NewAggregate(errs)
 }
 
+// recordVolumeMetrics records the number of the volumes of the component in each modification phase
+func (p *pvcModifier) recordVolumeMetrics(ctx *componentVolumeContext) {
+	counts := map[VolumePhase]int{}
+	for _, pod := range ctx.pods {
+		actual, err := p.pm.GetActualVolumes(pod, ctx.desiredVolumes)
+		if err != nil {
+			klog.Warningf("failed to get the volumes of pod %s/%s for metrics: %v", pod.Namespace, pod.Name, err)
+			return
+		}
+		for i := range actual {
+			counts[actual[i].Phase]++
+		}
+	}
+
+	ns, name, component := ctx.tc.GetNamespace(), ctx.tc.GetName(), ctx.status.MemberType().String()
+	for phase := VolumePhasePending; phase <= VolumePhaseCannotModify; phase++ {
+		metrics.ClusterVolumes.WithLabelValues(ns, name, component, phase.String()).Set(float64(counts[phase]))
+	}
+}
+
 func (p *pvcModifier) modifyVolumes(ctx *componentVolumeContext) error {
 	if ctx.status.GetPhase() != v1alpha1.NormalPhase {
 		return fmt.Errorf("component phase is not Normal")
@@ -135,11 +159,19 @@ func (p *pvcModifier) tryToRecreateSTS(ctx *componentVolumeContext) error {
 }
 
 func (p *pvcModifier) tryToModifyPVC(ctx *componentVolumeContext) error {
+	// the volumes which are pending or can not be modified stay unmodified after this round
+	allModified := true
 	for _, pod := range ctx.pods {
 		actual, err := p.pm.GetActualVolumes(pod, ctx.desiredVolumes)
 		if err != nil {
 			return err
 		}
+		for i := range actual {
+			switch actual[i].Phase {
+			case VolumePhasePending, VolumePhaseCannotModify:
+				allModified = false
+			}
+		}
 
 		isNeed := p.pm.ShouldModify(actual)
 
@@ -182,10 +214,16 @@ func (p *pvcModifier) tryToModifyPVC(ctx *componentVolumeContext) error {
 			continue
 		}
 
+		if ordinal, err := util.GetOrdinalFromPodName(pod.Name); err == nil {
+			ctx.tc.StartOperation(v1alpha1.OperationVolumeResize, ctx.status.MemberType(), v1alpha1.OperationTriggerSpecChange, ordinal)
+		}
 		if err := p.pm.Modify(actual); err != nil {
 			return err
 		}
 	}
+	if allModified {
+		ctx.tc.FinishOperation(v1alpha1.OperationVolumeResize, ctx.status.MemberType(), v1alpha1.OperationSucceeded, "")
+	}
 
 	if ctx.shouldEvict {
 		if ensureTiKVLeaderEvictionCondition(ctx.tc, metav1.ConditionFalse) {
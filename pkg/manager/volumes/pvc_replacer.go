@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	errutil "k8s.io/apimachinery/pkg/util/errors"
@@ -97,6 +98,11 @@ func (p *pvcReplacer) UpdateStatus(tc *v1alpha1.TidbCluster) error {
 		}
 		if status != comp.GetVolReplaceInProgress() {
 			klog.Infof("changing VolReplaceInProgress status to %t for %s/%s/%s", status, tc.GetNamespace(), tc.GetName(), comp.MemberType())
+			if status {
+				tc.StartOperation(v1alpha1.OperationVolumeReplace, comp.MemberType(), v1alpha1.OperationTriggerSpecChange)
+			} else {
+				tc.FinishOperation(v1alpha1.OperationVolumeReplace, comp.MemberType(), v1alpha1.OperationSucceeded, "")
+			}
 		}
 		comp.SetVolReplaceInProgress(status)
 	}
@@ -201,6 +207,9 @@ func (p *pvcReplacer) tryToReplacePVC(ctx *componentVolumeContext) error {
 		if err := p.startVolumeReplace(pod); err != nil {
 			return err
 		}
+		if ordinal, err := util.GetOrdinalFromPodName(pod.Name); err == nil {
+			ctx.tc.StartOperation(v1alpha1.OperationVolumeReplace, ctx.status.MemberType(), v1alpha1.OperationTriggerSpecChange, ordinal)
+		}
 		return fmt.Errorf("started volume replace for pod %s, waiting", pod.Name)
 	}
 	return nil
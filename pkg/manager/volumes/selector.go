@@ -40,6 +40,31 @@ func (sf *selectorFactory) NewSelector(instance string, mt v1alpha1.MemberType)
 	return selector.Add(*r), nil
 }
 
+// NewSelectorForTidbCluster is like NewSelector, but only selects the pods of the
+// default TiDB/TiKV or the TiDB/TiKV group that tc stands for when mt is TiDB/TiKV,
+// and the pods of the TiFlash role that tc stands for when mt is TiFlash.
+func (sf *selectorFactory) NewSelectorForTidbCluster(tc *v1alpha1.TidbCluster, mt v1alpha1.MemberType) (labels.Selector, error) {
+	selector, err := sf.NewSelector(tc.GetInstanceName(), mt)
+	if err != nil {
+		return nil, err
+	}
+	var r *labels.Requirement
+	switch mt {
+	case v1alpha1.TiDBMemberType:
+		r, err = label.TiDBGroupRequirement(tc.TiDBGroupName())
+	case v1alpha1.TiKVMemberType:
+		r, err = label.TiKVGroupRequirement(tc.TiKVGroupName())
+	case v1alpha1.TiFlashMemberType:
+		r, err = label.TiFlashRoleRequirement(tc.TiFlashRole())
+	default:
+		return selector, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return selector.Add(*r), nil
+}
+
 // pd => pd
 // tidb => tidb
 // tikv => tikv
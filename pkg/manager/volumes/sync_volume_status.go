@@ -35,7 +35,7 @@ func SyncVolumeStatus(pvm PodVolumeModifier, podLister corelisterv1.PodLister, t
 		return fmt.Errorf("failed to get desired volumes: %v", err)
 	}
 
-	selector, err := MustNewSelectorFactory().NewSelector(tc.GetInstanceName(), mt)
+	selector, err := MustNewSelectorFactory().NewSelectorForTidbCluster(tc, mt)
 	if err != nil {
 		return fmt.Errorf("failed to get selector: %v", err)
 	}
@@ -116,7 +116,7 @@ func (u *volCompareUtils) BuildContextForTC(tc *v1alpha1.TidbCluster, status v1a
 }
 
 func (u *volCompareUtils) getPodsOfComponent(tc *v1alpha1.TidbCluster, mt v1alpha1.MemberType) ([]*corev1.Pod, error) {
-	selector, err := u.sf.NewSelector(tc.GetInstanceName(), mt)
+	selector, err := u.sf.NewSelectorForTidbCluster(tc, mt)
 	if err != nil {
 		return nil, err
 	}
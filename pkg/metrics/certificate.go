@@ -0,0 +1,40 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	LabelKind            = "kind"
+	LabelSecretNamespace = "secret_namespace"
+	LabelSecret          = "secret"
+)
+
+var (
+	// CertificateExpirySeconds is the time to expiry of the certificates in the TLS secrets consumed
+	// by TidbCluster, DMCluster, TidbMonitor and TidbDashboard, it is negative if the certificate is expired.
+	CertificateExpirySeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "certificate",
+			Name:      "expiry_seconds",
+			Help:      "Seconds until the earliest certificate in each TLS secret consumed by the object expires",
+		}, []string{LabelKind, LabelNamespace, LabelName, LabelSecretNamespace, LabelSecret})
+)
+
+func init() {
+	prometheus.MustRegister(
+		CertificateExpirySeconds,
+	)
+}
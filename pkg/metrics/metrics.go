@@ -79,5 +79,13 @@ func init() {
 
 		ClusterSpecReplicas,
 		ClusterUpdateErrors,
+		ClusterComponentUpgrading,
+		ClusterComponentUpgradeDurationSeconds,
+		ClusterComponentUpgradeRemainingPods,
+		ClusterComponentFailureMembers,
+		ClusterComponentSuspended,
+		ClusterComponentVolumeReplacing,
+		ClusterStores,
+		ClusterVolumes,
 	)
 }
@@ -17,6 +17,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+const (
+	LabelState = "state"
+	LabelPhase = "phase"
+)
+
 var (
 	ClusterSpecReplicas = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -33,4 +38,100 @@ var (
 			Name:      "update_errors",
 			Help:      "Number of errors generated in each stage when updating TiDB Clusters",
 		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	// ClusterComponentUpgrading is 1 if the component of the TidbCluster is being upgraded
+	ClusterComponentUpgrading = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "component_upgrading",
+			Help:      "Whether each component in TidbCluster is being upgraded",
+		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	// ClusterComponentUpgradeDurationSeconds is the time since the ongoing upgrade of the component started
+	ClusterComponentUpgradeDurationSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "component_upgrade_duration_seconds",
+			Help:      "Seconds since the ongoing upgrade of each component in TidbCluster started, 0 if it is not being upgraded",
+		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	// ClusterComponentUpgradeRemainingPods is the number of the pods of the component that are not upgraded yet
+	ClusterComponentUpgradeRemainingPods = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "component_upgrade_remaining_pods",
+			Help:      "Number of the pods to be upgraded of each component in TidbCluster",
+		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	// ClusterComponentFailureMembers is the number of the failure members or stores of the component
+	ClusterComponentFailureMembers = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "component_failure_members",
+			Help:      "Number of the members that are failed over of each component in TidbCluster",
+		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	// ClusterComponentSuspended is 1 if the component of the TidbCluster is suspended
+	ClusterComponentSuspended = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "component_suspended",
+			Help:      "Whether each component in TidbCluster is suspended",
+		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	// ClusterComponentVolumeReplacing is 1 if the volumes of the component are being replaced
+	ClusterComponentVolumeReplacing = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "component_volume_replacing",
+			Help:      "Whether the volumes of each component in TidbCluster are being replaced",
+		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	// ClusterStores is the number of the TiKV or TiFlash stores in each state
+	ClusterStores = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "stores",
+			Help:      "Number of the stores in each state of TiKV and TiFlash in TidbCluster",
+		}, []string{LabelNamespace, LabelName, LabelComponent, LabelState})
+
+	// ClusterVolumes is the number of the volumes in each phase of the modification by the PVC modifier
+	ClusterVolumes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "volumes",
+			Help:      "Number of the volumes in each modification phase of each component in TidbCluster",
+		}, []string{LabelNamespace, LabelName, LabelComponent, LabelPhase})
 )
+
+// DeleteClusterMetrics deletes the metrics of the deleted TidbCluster
+func DeleteClusterMetrics(namespace, name string) {
+	labels := prometheus.Labels{LabelNamespace: namespace, LabelName: name}
+	for _, vec := range []*prometheus.GaugeVec{
+		ClusterSpecReplicas,
+		ClusterComponentUpgrading,
+		ClusterComponentUpgradeDurationSeconds,
+		ClusterComponentUpgradeRemainingPods,
+		ClusterComponentFailureMembers,
+		ClusterComponentSuspended,
+		ClusterComponentVolumeReplacing,
+		ClusterStores,
+		ClusterVolumes,
+		TiKVStoreMigrationRemainingRegions,
+		TiKVStoreMigrationRemainingLeaders,
+		TiKVStoreMigrationRemainingBytes,
+		TiKVStoreMigrationRegionRate,
+		TiKVStoreMigrationETASeconds,
+	} {
+		vec.DeletePartialMatch(labels)
+	}
+	ClusterUpdateErrors.DeletePartialMatch(labels)
+}
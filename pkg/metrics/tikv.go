@@ -0,0 +1,75 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	LabelStore     = "store"
+	LabelDirection = "direction"
+)
+
+var (
+	// TiKVStoreMigrationRemainingRegions is the number of the regions to be migrated out of the stores
+	// that are scaled in, or into the stores that are scaled out.
+	TiKVStoreMigrationRemainingRegions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "tikv_store_migration",
+			Name:      "remaining_regions",
+			Help:      "Number of the regions to be migrated of each TiKV store being scaled in or out",
+		}, []string{LabelNamespace, LabelName, LabelStore, LabelDirection})
+
+	TiKVStoreMigrationRemainingLeaders = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "tikv_store_migration",
+			Name:      "remaining_leaders",
+			Help:      "Number of the leaders to be migrated of each TiKV store being scaled in or out",
+		}, []string{LabelNamespace, LabelName, LabelStore, LabelDirection})
+
+	TiKVStoreMigrationRemainingBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "tikv_store_migration",
+			Name:      "remaining_bytes",
+			Help:      "Approximate bytes to be migrated of each TiKV store being scaled in or out",
+		}, []string{LabelNamespace, LabelName, LabelStore, LabelDirection})
+
+	TiKVStoreMigrationRegionRate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "tikv_store_migration",
+			Name:      "region_rate",
+			Help:      "Moving average of the regions migrated per second of each TiKV store being scaled in or out",
+		}, []string{LabelNamespace, LabelName, LabelStore, LabelDirection})
+
+	TiKVStoreMigrationETASeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "tikv_store_migration",
+			Name:      "eta_seconds",
+			Help:      "Estimated seconds until the migration of each TiKV store being scaled in or out is completed",
+		}, []string{LabelNamespace, LabelName, LabelStore, LabelDirection})
+)
+
+func init() {
+	prometheus.MustRegister(
+		TiKVStoreMigrationRemainingRegions,
+		TiKVStoreMigrationRemainingLeaders,
+		TiKVStoreMigrationRemainingBytes,
+		TiKVStoreMigrationRegionRate,
+		TiKVStoreMigrationETASeconds,
+	)
+}
@@ -45,6 +45,7 @@ import (
 type MonitorManager struct {
 	deps               *controller.Dependencies
 	pvManager          monitor.MonitorManager
+	certExpiryManager  monitor.MonitorManager
 	discoveryInterface discovery.CachedDiscoveryInterface
 }
 
@@ -60,6 +61,7 @@ func NewMonitorManager(deps *controller.Dependencies) *MonitorManager {
 	return &MonitorManager{
 		deps:               deps,
 		pvManager:          meta.NewReclaimPolicyManager(deps),
+		certExpiryManager:  meta.NewCertExpiryManager(deps),
 		discoveryInterface: discoverycachedmemory.NewMemCacheClient(deps.KubeClientset.Discovery()),
 	}
 }
@@ -82,6 +84,12 @@ func (m *MonitorManager) SyncMonitor(monitor *v1alpha1.TidbMonitor) error {
 		return nil // fatal error, no need to retry on invalid object
 	}
 
+	// the client certificates of the clusters are copied to the assets of prometheus,
+	// stop here if any of them is expired, so that prometheus is not rolled onto it
+	if err := m.certExpiryManager.SyncMonitor(monitor); err != nil {
+		return err
+	}
+
 	var firstTc *v1alpha1.TidbCluster
 	assetStore := NewStore(m.deps.SecretLister)
 
@@ -558,14 +566,14 @@ func (m *MonitorManager) smoothMigrationToStatefulSet(monitor *v1alpha1.TidbMoni
 
 	// determine whether there is an old deployment
 	oldDeploymentName := GetMonitorObjectName(monitor)
-	oldDeployment, err := m.deps.DeploymentLister.Deployments(monitor.Namespace).Get(oldDeploymentName)
+	_, err := m.deps.DeploymentLister.ByNamespace(monitor.Namespace).Get(oldDeploymentName)
 	if err == nil {
 		klog.Infof("The old deployment exists, start smooth migration for tm [%s/%s]", monitor.Namespace, monitor.Name)
 		// if deployment exist, delete it and wait next reconcile.
 		err = m.deps.TypedControl.Delete(monitor, &appsv1.Deployment{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      oldDeployment.Name,
-				Namespace: oldDeployment.Namespace,
+				Name:      oldDeploymentName,
+				Namespace: monitor.Namespace,
 			},
 		})
 		if err != nil {
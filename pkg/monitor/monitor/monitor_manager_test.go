@@ -692,6 +692,7 @@ func newFakeTidbMonitorManager() *MonitorManager {
 
 	return &MonitorManager{deps: fakeDeps,
 		pvManager:          meta.NewReclaimPolicyManager(fakeDeps),
+		certExpiryManager:  meta.NewCertExpiryManager(fakeDeps),
 		discoveryInterface: discoverycachedmemory.NewMemCacheClient(discoveryClient),
 	}
 
@@ -38,6 +38,9 @@ const (
 	DeleteMemberActionType                      ActionType = "DeleteMember "
 	SetStoreLabelsActionType                    ActionType = "SetStoreLabels"
 	UpdateReplicationActionType                 ActionType = "UpdateReplicationConfig"
+	UpdateConfigActionType                      ActionType = "UpdateConfig"
+	GetStoreLimitsActionType                    ActionType = "GetStoreLimits"
+	SetStoreLimitActionType                     ActionType = "SetStoreLimit"
 	BeginEvictLeaderActionType                  ActionType = "BeginEvictLeader"
 	EndEvictLeaderActionType                    ActionType = "EndEvictLeader"
 	GetEvictLeaderSchedulersActionType          ActionType = "GetEvictLeaderSchedulers"
@@ -48,6 +51,9 @@ const (
 	GetRecoveringMarkActionType                 ActionType = "GetRecoveringMark"
 	GetReadyActionType                          ActionType = "GetReady"
 	PDMSTransferPrimaryActionType               ActionType = "PDMSTransferPrimary"
+	GetKeyspaceActionType                       ActionType = "GetKeyspace"
+	CreateKeyspaceActionType                    ActionType = "CreateKeyspace"
+	UpdateKeyspaceStateActionType               ActionType = "UpdateKeyspaceState"
 )
 
 type NotFoundReaction struct {
@@ -63,6 +69,13 @@ type Action struct {
 	Name        string
 	Labels      map[string]string
 	Replication PDReplicationConfig
+	Config      map[string]interface{}
+
+	KeyspaceConfig map[string]string
+	KeyspaceState  KeyspaceState
+
+	StoreLimitType StoreLimitType
+	Rate           float64
 }
 
 type Reaction func(action *Action) (interface{}, error)
@@ -231,6 +244,34 @@ func (c *FakePDClient) UpdateReplicationConfig(config PDReplicationConfig) error
 	return nil
 }
 
+// UpdateConfig updates the config
+func (c *FakePDClient) UpdateConfig(config map[string]interface{}) error {
+	if reaction, ok := c.reactions[UpdateConfigActionType]; ok {
+		action := &Action{Config: config}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) GetStoreLimits() (map[uint64]StoreLimit, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(GetStoreLimitsActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[uint64]StoreLimit), nil
+}
+
+func (c *FakePDClient) SetStoreLimit(storeID uint64, limitType StoreLimitType, rate float64) error {
+	if reaction, ok := c.reactions[SetStoreLimitActionType]; ok {
+		action := &Action{ID: storeID, StoreLimitType: limitType, Rate: rate}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
 func (c *FakePDClient) BeginEvictLeader(storeID uint64) error {
 	if reaction, ok := c.reactions[BeginEvictLeaderActionType]; ok {
 		action := &Action{ID: storeID}
@@ -313,6 +354,43 @@ func (c *FakePDClient) GetReady() (bool, error) {
 	return result.(bool), nil
 }
 
+func (c *FakePDClient) GetKeyspace(name string) (*KeyspaceMeta, error) {
+	action := &Action{
+		Name: name,
+	}
+	result, err := c.fakeAPI(GetKeyspaceActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	// the reaction returns nil if the keyspace does not exist
+	meta, _ := result.(*KeyspaceMeta)
+	return meta, nil
+}
+
+func (c *FakePDClient) CreateKeyspace(name string, config map[string]string) (*KeyspaceMeta, error) {
+	action := &Action{
+		Name:           name,
+		KeyspaceConfig: config,
+	}
+	result, err := c.fakeAPI(CreateKeyspaceActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*KeyspaceMeta), nil
+}
+
+func (c *FakePDClient) UpdateKeyspaceState(name string, state KeyspaceState) (*KeyspaceMeta, error) {
+	action := &Action{
+		Name:          name,
+		KeyspaceState: state,
+	}
+	result, err := c.fakeAPI(UpdateKeyspaceStateActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*KeyspaceMeta), nil
+}
+
 // FakePDMSClient implements a fake version of PDMSClient.
 type FakePDMSClient struct {
 	reactions map[ActionType]Reaction
@@ -78,6 +78,9 @@ type PDReplicationConfig struct {
 	// Immutable, change should be made through pd-ctl after cluster creation.
 	// Imported from v3.1.0
 	StrictlyMatchLabel *bool `toml:"strictly-match-label,omitempty" json:"strictly-match-label,string,omitempty"`
+	// IsolationLevel is used to isolate replicas explicitly and forcibly if it's not empty.
+	// Its value must be empty or one of LocationLabels.
+	IsolationLevel string `toml:"isolation-level,omitempty" json:"isolation-level,omitempty"`
 
 	// When PlacementRules feature is enabled. MaxReplicas and LocationLabels are not used anymore.
 	EnablePlacementRules *bool `toml:"enable-placement-rules" json:"enable-placement-rules,string,omitempty"`
@@ -20,6 +20,7 @@ import (
 	"sync"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/tracing"
 	"github.com/pingcap/tidb-operator/pkg/util"
 	"k8s.io/client-go/kubernetes"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
@@ -250,14 +251,28 @@ func (pdc *defaultPDControl) GetPDClient(namespace Namespace, tcName string, tls
 			return &pdClient{url: config.clientURL, httpClient: &http.Client{Timeout: DefaultTimeout}}
 		}
 
-		return NewPDClient(config.clientURL, DefaultTimeout, tlsConfig)
+		return newTracedPDClient(namespace, tcName, config.clientURL, tlsConfig)
 	}
 	if _, ok := pdc.pdClients[config.clientKey]; !ok {
-		pdc.pdClients[config.clientKey] = NewPDClient(config.clientURL, DefaultTimeout, nil)
+		pdc.pdClients[config.clientKey] = newTracedPDClient(namespace, tcName, config.clientURL, nil)
 	}
 	return pdc.pdClients[config.clientKey]
 }
 
+// newTracedPDClient returns a PDClient whose requests are traced as a part of the reconcile of the TidbCluster
+func newTracedPDClient(namespace Namespace, tcName, url string, tlsConfig *tls.Config) PDClient {
+	client := NewPDClient(url, DefaultTimeout, tlsConfig).(*pdClient)
+	client.httpClient.Transport = tracing.WrapTransport(string(namespace), tcName, client.httpClient.Transport)
+	return client
+}
+
+// newTracedPDMSClient returns a PDMSClient whose requests are traced as a part of the reconcile of the TidbCluster
+func newTracedPDMSClient(namespace Namespace, tcName, serviceName, url string, tlsConfig *tls.Config) PDMSClient {
+	client := NewPDMSClient(serviceName, url, DefaultTimeout, tlsConfig)
+	client.httpClient.Transport = tracing.WrapTransport(string(namespace), tcName, client.httpClient.Transport)
+	return client
+}
+
 func checkServiceName(name string) bool {
 	return name == TSOServiceName || name == SchedulingServiceName
 }
@@ -280,11 +295,11 @@ func (pdc *defaultPDControl) GetPDMSClient(namespace Namespace, tcName, serviceN
 			return &pdMSClient{url: config.clientURL, httpClient: &http.Client{Timeout: DefaultTimeout}}
 		}
 
-		return NewPDMSClient(serviceName, config.clientURL, DefaultTimeout, tlsConfig)
+		return newTracedPDMSClient(namespace, tcName, serviceName, config.clientURL, tlsConfig)
 	}
 
 	if _, ok := pdc.pdMSClients[config.clientURL]; !ok {
-		pdc.pdMSClients[config.clientURL] = NewPDMSClient(serviceName, config.clientURL, DefaultTimeout, nil)
+		pdc.pdMSClients[config.clientURL] = newTracedPDMSClient(namespace, tcName, serviceName, config.clientURL, nil)
 	}
 	return pdc.pdMSClients[config.clientURL]
 }
@@ -69,6 +69,13 @@ type PDClient interface {
 	SetStoreLabels(storeID uint64, labels map[string]string) (bool, error)
 	// UpdateReplicationConfig updates the replication config
 	UpdateReplicationConfig(config PDReplicationConfig) error
+	// UpdateConfig changes the config of the PD cluster online, the key of the config is
+	// the dotted path of the config item, e.g. `schedule.leader-schedule-limit`
+	UpdateConfig(config map[string]interface{}) error
+	// GetStoreLimits returns the store limits of all the stores, keyed by store id
+	GetStoreLimits() (map[uint64]StoreLimit, error)
+	// SetStoreLimit sets the rate per minute of the given type of the store limit of a store
+	SetStoreLimit(storeID uint64, limitType StoreLimitType, rate float64) error
 	// DeleteStore deletes a TiKV store from cluster
 	DeleteStore(storeID uint64) error
 	// SetStoreState sets store to specified state.
@@ -103,6 +110,13 @@ type PDClient interface {
 	GetMSMembers(service string) ([]string, error)
 	// GetMSPrimary returns the primary PDMS member service-addr from cluster by specific microservice
 	GetMSPrimary(service string) (string, error)
+
+	// GetKeyspace returns the meta of the keyspace, nil is returned if the keyspace does not exist
+	GetKeyspace(name string) (*KeyspaceMeta, error)
+	// CreateKeyspace creates a keyspace with the given config
+	CreateKeyspace(name string, config map[string]string) (*KeyspaceMeta, error)
+	// UpdateKeyspaceState updates the state of the keyspace
+	UpdateKeyspaceState(name string, state KeyspaceState) (*KeyspaceMeta, error)
 }
 
 var (
@@ -122,7 +136,8 @@ var (
 	autoscalingPrefix                = "autoscaling"
 	recoveringMarkPrefix             = "pd/api/v1/admin/cluster/markers/snapshot-recovering"
 
-	readyPrefix = "pd/api/v2/ready"
+	readyPrefix     = "pd/api/v2/ready"
+	keyspacesPrefix = "pd/api/v2/keyspaces"
 
 	// microservice
 	MicroservicePrefix = "pd/api/v2/ms"
@@ -175,8 +190,10 @@ type MetaStore struct {
 type StoreStatus struct {
 	Capacity           typeutil.ByteSize `json:"capacity"`
 	Available          typeutil.ByteSize `json:"available"`
+	UsedSize           typeutil.ByteSize `json:"used_size"`
 	LeaderCount        int               `json:"leader_count"`
 	RegionCount        int               `json:"region_count"`
+	RegionSize         int64             `json:"region_size"` // in MiB
 	SendingSnapCount   uint32            `json:"sending_snap_count"`
 	ReceivingSnapCount uint32            `json:"receiving_snap_count"`
 	ApplyingSnapCount  uint32            `json:"applying_snap_count"`
@@ -271,6 +288,36 @@ type schedulerInfo struct {
 	StoreID uint64 `json:"store_id"`
 }
 
+// KeyspaceState is the state of a keyspace, the valid transitions are
+// ENABLED <-> DISABLED -> ARCHIVED -> TOMBSTONE
+type KeyspaceState string
+
+const (
+	KeyspaceStateEnabled   KeyspaceState = "ENABLED"
+	KeyspaceStateDisabled  KeyspaceState = "DISABLED"
+	KeyspaceStateArchived  KeyspaceState = "ARCHIVED"
+	KeyspaceStateTombstone KeyspaceState = "TOMBSTONE"
+)
+
+// KeyspaceMeta is the meta of a keyspace
+type KeyspaceMeta struct {
+	ID             uint32            `json:"id"`
+	Name           string            `json:"name"`
+	State          KeyspaceState     `json:"state"`
+	CreatedAt      int64             `json:"created_at,omitempty"`
+	StateChangedAt int64             `json:"state_changed_at,omitempty"`
+	Config         map[string]string `json:"config,omitempty"`
+}
+
+type createKeyspaceParams struct {
+	Name   string            `json:"name"`
+	Config map[string]string `json:"config,omitempty"`
+}
+
+type updateKeyspaceStateParams struct {
+	State KeyspaceState `json:"state"`
+}
+
 type RecoveringMark struct {
 	Mark bool `json:"marked"`
 }
@@ -570,6 +617,76 @@ func (c *pdClient) UpdateReplicationConfig(config PDReplicationConfig) error {
 	return fmt.Errorf("failed %v to update replication: %v", res.StatusCode, err)
 }
 
+func (c *pdClient) UpdateConfig(config map[string]interface{}) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, configPrefix)
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	err = httputil.ReadErrorBody(res.Body)
+	return fmt.Errorf("failed %v to update config: %v", res.StatusCode, err)
+}
+
+// StoreLimitType is the type of the store limit
+type StoreLimitType string
+
+const (
+	// StoreLimitAddPeer limits the rate of adding peers to the store
+	StoreLimitAddPeer StoreLimitType = "add-peer"
+	// StoreLimitRemovePeer limits the rate of removing peers from the store
+	StoreLimitRemovePeer StoreLimitType = "remove-peer"
+)
+
+// StoreLimit is the store limit of a store, the rates are the number of the peers per minute
+type StoreLimit struct {
+	AddPeer    float64 `json:"add-peer"`
+	RemovePeer float64 `json:"remove-peer"`
+}
+
+type storeLimitParams struct {
+	Rate float64        `json:"rate"`
+	Type StoreLimitType `json:"type"`
+}
+
+func (c *pdClient) GetStoreLimits() (map[uint64]StoreLimit, error) {
+	apiURL := fmt.Sprintf("%s/%s/limit", c.url, storesPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	limits := map[uint64]StoreLimit{}
+	if err := json.Unmarshal(body, &limits); err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+func (c *pdClient) SetStoreLimit(storeID uint64, limitType StoreLimitType, rate float64) error {
+	apiURL := fmt.Sprintf("%s/%s/%d/limit", c.url, storePrefix, storeID)
+	data, err := json.Marshal(&storeLimitParams{Rate: rate, Type: limitType})
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	err = httputil.ReadErrorBody(res.Body)
+	return fmt.Errorf("failed %v to set %s limit of store %d: %v", res.StatusCode, limitType, storeID, err)
+}
+
 func (c *pdClient) BeginEvictLeader(storeID uint64) error {
 	leaderEvictInfo := getLeaderEvictSchedulerInfo(storeID)
 	apiURL := fmt.Sprintf("%s/%s", c.url, schedulersPrefix)
@@ -828,6 +945,76 @@ func (c *pdClient) GetReady() (bool, error) {
 	return true, nil
 }
 
+func (c *pdClient) GetKeyspace(name string) (*KeyspaceMeta, error) {
+	apiURL := fmt.Sprintf("%s/%s/%s", c.url, keyspacesPrefix, name)
+	res, err := c.httpClient.Get(apiURL)
+	if err != nil {
+		return nil, err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		err2 := httputil.ReadErrorBody(res.Body)
+		return nil, fmt.Errorf("failed %v to get keyspace %s: %v", res.StatusCode, name, err2)
+	}
+	return readKeyspaceMeta(res)
+}
+
+func (c *pdClient) CreateKeyspace(name string, config map[string]string) (*KeyspaceMeta, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, keyspacesPrefix)
+	data, err := json.Marshal(&createKeyspaceParams{Name: name, Config: config})
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode != http.StatusOK {
+		err2 := httputil.ReadErrorBody(res.Body)
+		return nil, fmt.Errorf("failed %v to create keyspace %s: %v", res.StatusCode, name, err2)
+	}
+	return readKeyspaceMeta(res)
+}
+
+func (c *pdClient) UpdateKeyspaceState(name string, state KeyspaceState) (*KeyspaceMeta, error) {
+	apiURL := fmt.Sprintf("%s/%s/%s/state", c.url, keyspacesPrefix, name)
+	data, err := json.Marshal(&updateKeyspaceStateParams{State: state})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("PUT", apiURL, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode != http.StatusOK {
+		err2 := httputil.ReadErrorBody(res.Body)
+		return nil, fmt.Errorf("failed %v to update the state of keyspace %s to %s: %v", res.StatusCode, name, state, err2)
+	}
+	return readKeyspaceMeta(res)
+}
+
+func readKeyspaceMeta(res *http.Response) (*KeyspaceMeta, error) {
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	meta := &KeyspaceMeta{}
+	if err := json.Unmarshal(body, meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
 func getLeaderEvictSchedulerInfo(storeID uint64) *schedulerInfo {
 	return &schedulerInfo{"evict-leader-scheduler", storeID}
 }
@@ -305,6 +305,94 @@ func TestGetStore(t *testing.T) {
 	}
 }
 
+func TestKeyspace(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	meta := &KeyspaceMeta{
+		ID:     1,
+		Name:   "tenant",
+		State:  KeyspaceStateEnabled,
+		Config: map[string]string{"replica_number": "3"},
+	}
+	metaBytes, err := json.Marshal(meta)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		switch {
+		case request.Method == "GET" && request.URL.Path == fmt.Sprintf("/%s/tenant", keyspacesPrefix):
+		case request.Method == "GET":
+			g.Expect(request.URL.Path).To(Equal(fmt.Sprintf("/%s/not-exist", keyspacesPrefix)))
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case request.Method == "POST":
+			g.Expect(request.URL.Path).To(Equal(fmt.Sprintf("/%s", keyspacesPrefix)))
+			params := &createKeyspaceParams{}
+			g.Expect(readJSON(request.Body, params)).To(Succeed())
+			g.Expect(params.Name).To(Equal(meta.Name))
+			g.Expect(params.Config).To(Equal(meta.Config))
+		case request.Method == "PUT":
+			g.Expect(request.URL.Path).To(Equal(fmt.Sprintf("/%s/tenant/state", keyspacesPrefix)))
+			params := &updateKeyspaceStateParams{}
+			g.Expect(readJSON(request.Body, params)).To(Succeed())
+			g.Expect(params.State).To(Equal(KeyspaceStateDisabled))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		w.Write(metaBytes)
+	})
+	defer svc.Close()
+
+	pdClient := NewPDClient(svc.URL, DefaultTimeout, &tls.Config{})
+	result, err := pdClient.GetKeyspace("tenant")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(meta))
+
+	result, err = pdClient.GetKeyspace("not-exist")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(BeNil())
+
+	result, err = pdClient.CreateKeyspace(meta.Name, meta.Config)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(meta))
+
+	result, err = pdClient.UpdateKeyspaceState(meta.Name, KeyspaceStateDisabled)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(meta))
+}
+
+func TestStoreLimit(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		switch {
+		case request.Method == "GET":
+			g.Expect(request.URL.Path).To(Equal(fmt.Sprintf("/%s/limit", storesPrefix)))
+			w.Header().Set("Content-Type", ContentTypeJSON)
+			w.Write([]byte(`{"1":{"add-peer":15,"remove-peer":15},"4":{"add-peer":30.5,"remove-peer":20}}`))
+		case request.Method == "POST":
+			g.Expect(request.URL.Path).To(Equal(fmt.Sprintf("/%s/1/limit", storePrefix)))
+			params := &storeLimitParams{}
+			g.Expect(readJSON(request.Body, params)).To(Succeed())
+			g.Expect(params).To(Equal(&storeLimitParams{Rate: 60, Type: StoreLimitRemovePeer}))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	defer svc.Close()
+
+	pdClient := NewPDClient(svc.URL, DefaultTimeout, &tls.Config{})
+	limits, err := pdClient.GetStoreLimits()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(limits).To(Equal(map[uint64]StoreLimit{
+		1: {AddPeer: 15, RemovePeer: 15},
+		4: {AddPeer: 30.5, RemovePeer: 20},
+	}))
+
+	g.Expect(pdClient.SetStoreLimit(1, StoreLimitRemovePeer, 60)).To(Succeed())
+}
+
 func TestSetStoreLabels(t *testing.T) {
 	g := NewGomegaWithT(t)
 	id := uint64(1)
@@ -0,0 +1,86 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	"github.com/robfig/cron"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+)
+
+// +k8s:deepcopy-gen=false
+type BackupScheduleStrategy struct{}
+
+func (BackupScheduleStrategy) NewObject() runtime.Object {
+	return &v1alpha1.BackupSchedule{}
+}
+
+func (BackupScheduleStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
+	if bs, ok := castBackupSchedule(obj); ok {
+		defaulting.SetBackupScheduleDefault(bs)
+	}
+}
+
+func (BackupScheduleStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+	// no op to not mutate the fields which are immutable after the BackupSchedule starts
+}
+
+func (BackupScheduleStrategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
+	if bs, ok := castBackupSchedule(obj); ok {
+		allErrs := validation.ValidateBackupSchedule(bs)
+		allErrs = append(allErrs, validateBackupScheduleCron(bs)...)
+		return allErrs
+	}
+	return field.ErrorList{}
+}
+
+func (BackupScheduleStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
+	oldBackupSchedule, oldOk := castBackupSchedule(old)
+	bs, ok := castBackupSchedule(obj)
+	if ok && oldOk {
+		allErrs := validation.ValidateUpdateBackupSchedule(oldBackupSchedule, bs)
+		allErrs = append(allErrs, validateBackupScheduleCron(bs)...)
+		return allErrs
+	}
+	return field.ErrorList{}
+}
+
+// validateBackupScheduleCron validates the cron string of the schedule in the same way as the backup
+// schedule manager, it is not a part of the validation package as the cron library is not available in
+// the apis module.
+func validateBackupScheduleCron(bs *v1alpha1.BackupSchedule) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if bs.Spec.Schedule == "" {
+		return allErrs
+	}
+	if _, err := cron.ParseStandard(bs.Spec.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "schedule"), bs.Spec.Schedule, err.Error()))
+	}
+	return allErrs
+}
+
+func castBackupSchedule(obj runtime.Object) (*v1alpha1.BackupSchedule, bool) {
+	bs, ok := obj.(*v1alpha1.BackupSchedule)
+	if !ok {
+		klog.Errorf("Object %T is not v1alpah1.BackupSchedule, cannot processed by BackupScheduleStrategy", obj)
+		return nil, false
+	}
+	return bs, true
+}
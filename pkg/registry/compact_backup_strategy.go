@@ -0,0 +1,67 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+)
+
+// +k8s:deepcopy-gen=false
+type CompactBackupStrategy struct{}
+
+func (CompactBackupStrategy) NewObject() runtime.Object {
+	return &v1alpha1.CompactBackup{}
+}
+
+func (CompactBackupStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
+	if compact, ok := castCompactBackup(obj); ok {
+		defaulting.SetCompactBackupDefault(compact)
+	}
+}
+
+func (CompactBackupStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+	// no op to not mutate the fields which are immutable after the CompactBackup starts
+}
+
+func (CompactBackupStrategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
+	if compact, ok := castCompactBackup(obj); ok {
+		return validation.ValidateCompactBackup(compact)
+	}
+	return field.ErrorList{}
+}
+
+func (CompactBackupStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
+	oldCompactBackup, oldOk := castCompactBackup(old)
+	compact, ok := castCompactBackup(obj)
+	if ok && oldOk {
+		return validation.ValidateUpdateCompactBackup(oldCompactBackup, compact)
+	}
+	return field.ErrorList{}
+}
+
+func castCompactBackup(obj runtime.Object) (*v1alpha1.CompactBackup, bool) {
+	compact, ok := obj.(*v1alpha1.CompactBackup)
+	if !ok {
+		klog.Errorf("Object %T is not v1alpah1.CompactBackup, cannot processed by CompactBackupStrategy", obj)
+		return nil, false
+	}
+	return compact, true
+}
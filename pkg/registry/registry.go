@@ -16,5 +16,9 @@ package registry
 var (
 	Strategies = []CreateUpdateStrategy{
 		TidbClusterStrategy{},
+		BackupStrategy{},
+		RestoreStrategy{},
+		BackupScheduleStrategy{},
+		CompactBackupStrategy{},
 	}
 )
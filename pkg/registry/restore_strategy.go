@@ -0,0 +1,67 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+)
+
+// +k8s:deepcopy-gen=false
+type RestoreStrategy struct{}
+
+func (RestoreStrategy) NewObject() runtime.Object {
+	return &v1alpha1.Restore{}
+}
+
+func (RestoreStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
+	if restore, ok := castRestore(obj); ok {
+		defaulting.SetRestoreDefault(restore)
+	}
+}
+
+func (RestoreStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+	// no op to not mutate the fields which are immutable after the Restore starts
+}
+
+func (RestoreStrategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
+	if restore, ok := castRestore(obj); ok {
+		return validation.ValidateRestore(restore)
+	}
+	return field.ErrorList{}
+}
+
+func (RestoreStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
+	oldRestore, oldOk := castRestore(old)
+	restore, ok := castRestore(obj)
+	if ok && oldOk {
+		return validation.ValidateUpdateRestore(oldRestore, restore)
+	}
+	return field.ErrorList{}
+}
+
+func castRestore(obj runtime.Object) (*v1alpha1.Restore, bool) {
+	restore, ok := obj.(*v1alpha1.Restore)
+	if !ok {
+		klog.Errorf("Object %T is not v1alpah1.Restore, cannot processed by RestoreStrategy", obj)
+		return nil, false
+	}
+	return restore, true
+}
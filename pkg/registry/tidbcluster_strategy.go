@@ -15,10 +15,14 @@ package registry
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/Masterminds/semver"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	"github.com/pingcap/tidb-operator/pkg/util/cmpver"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
@@ -52,11 +56,93 @@ func (TidbClusterStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.
 	oldTc, oldOk := castTidbCluster(old)
 	tc, ok := castTidbCluster(obj)
 	if ok && oldOk {
-		return validation.ValidateUpdateTidbCluster(oldTc, tc)
+		allErrs := validation.ValidateUpdateTidbCluster(oldTc, tc)
+		allErrs = append(allErrs, validateUpdateVersion(oldTc, tc, field.NewPath("spec"))...)
+		return allErrs
 	}
 	return field.ErrorList{}
 }
 
+// validateUpdateVersion refuses to downgrade the cluster or its components, which is not supported by TiDB.
+// Upgrades are always allowed as TiDB supports upgrading across major versions in one step. It is not a part
+// of validation.ValidateUpdateTidbCluster as pkg/util/cmpver is not available in the apis module.
+func validateUpdateVersion(old, tc *v1alpha1.TidbCluster, fldPath *field.Path) field.ErrorList {
+	allErrs := validateVersionNotDowngraded(tc, old.Spec.Version, tc.Spec.Version, fldPath.Child("version"))
+
+	// the versions of the components default to the version of the cluster, which is validated above
+	validateComponent := func(oldSpec, newSpec *v1alpha1.ComponentSpec, path *field.Path) {
+		if oldSpec == nil || newSpec == nil || (oldSpec.Version == nil && newSpec.Version == nil) {
+			return
+		}
+		oldVersion, version := old.Spec.Version, tc.Spec.Version
+		if oldSpec.Version != nil {
+			oldVersion = *oldSpec.Version
+		}
+		if newSpec.Version != nil {
+			version = *newSpec.Version
+		}
+		allErrs = append(allErrs, validateVersionNotDowngraded(tc, oldVersion, version, path)...)
+	}
+	if old.Spec.PD != nil && tc.Spec.PD != nil {
+		validateComponent(&old.Spec.PD.ComponentSpec, &tc.Spec.PD.ComponentSpec, fldPath.Child("pd", "version"))
+	}
+	if old.Spec.TiKV != nil && tc.Spec.TiKV != nil {
+		validateComponent(&old.Spec.TiKV.ComponentSpec, &tc.Spec.TiKV.ComponentSpec, fldPath.Child("tikv", "version"))
+	}
+	for i, group := range tc.Spec.TiKVGroups {
+		if group == nil {
+			continue
+		}
+		if oldGroup := old.TiKVGroup(group.Name); oldGroup != nil {
+			validateComponent(&oldGroup.ComponentSpec, &group.ComponentSpec, fldPath.Child("tikvGroups").Index(i).Child("version"))
+		}
+	}
+	if old.Spec.TiDB != nil && tc.Spec.TiDB != nil {
+		validateComponent(&old.Spec.TiDB.ComponentSpec, &tc.Spec.TiDB.ComponentSpec, fldPath.Child("tidb", "version"))
+	}
+	for i, group := range tc.Spec.TiDBGroups {
+		if group == nil {
+			continue
+		}
+		if oldGroup := old.TiDBGroup(group.Name); oldGroup != nil {
+			validateComponent(&oldGroup.ComponentSpec, &group.ComponentSpec, fldPath.Child("tidbGroups").Index(i).Child("version"))
+		}
+	}
+	if old.Spec.TiFlash != nil && tc.Spec.TiFlash != nil {
+		validateComponent(&old.Spec.TiFlash.ComponentSpec, &tc.Spec.TiFlash.ComponentSpec, fldPath.Child("tiflash", "version"))
+	}
+	if old.Spec.TiCDC != nil && tc.Spec.TiCDC != nil {
+		validateComponent(&old.Spec.TiCDC.ComponentSpec, &tc.Spec.TiCDC.ComponentSpec, fldPath.Child("ticdc", "version"))
+	}
+	if old.Spec.TiProxy != nil && tc.Spec.TiProxy != nil {
+		validateComponent(&old.Spec.TiProxy.ComponentSpec, &tc.Spec.TiProxy.ComponentSpec, fldPath.Child("tiproxy", "version"))
+	}
+	if old.Spec.Pump != nil && tc.Spec.Pump != nil {
+		validateComponent(&old.Spec.Pump.ComponentSpec, &tc.Spec.Pump.ComponentSpec, fldPath.Child("pump", "version"))
+	}
+	return allErrs
+}
+
+// validateVersionNotDowngraded refuses to change the version to a lower one, the versions like latest or
+// nightly are not comparable and are allowed
+func validateVersionNotDowngraded(tc *v1alpha1.TidbCluster, oldVersion, version string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if oldVersion == version || validation.IsUpdateOverridden(tc, label.AnnAllowVersionDowngrade) {
+		return allErrs
+	}
+	if _, err := semver.NewVersion(oldVersion); err != nil {
+		return allErrs
+	}
+	if _, err := semver.NewVersion(version); err != nil {
+		return allErrs
+	}
+	if downgrade, err := cmpver.Compare(version, cmpver.Less, oldVersion); err == nil && downgrade {
+		allErrs = append(allErrs, field.Invalid(fldPath, version,
+			fmt.Sprintf("version must not be downgraded from %s, or set annotation %s to \"true\"", oldVersion, label.AnnAllowVersionDowngrade)))
+	}
+	return allErrs
+}
+
 func castTidbCluster(obj runtime.Object) (*v1alpha1.TidbCluster, bool) {
 	tc, ok := obj.(*v1alpha1.TidbCluster)
 	if !ok {
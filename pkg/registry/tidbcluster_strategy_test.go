@@ -0,0 +1,122 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+)
+
+func TestValidateUpdateVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		old        string
+		version    string
+		annotation string
+		wantErr    bool
+	}{
+		{name: "unchanged", old: "v7.5.0", version: "v7.5.0"},
+		{name: "upgrade", old: "v7.1.0", version: "v7.5.1"},
+		{name: "upgrade to the next major version", old: "v6.5.0", version: "v7.1.0"},
+		{name: "upgrade across major versions", old: "v6.5.0", version: "v8.5.0"},
+		{name: "upgrade to latest", old: "v7.5.0", version: "latest"},
+		{name: "downgrade from latest", old: "nightly", version: "v7.5.0"},
+		{name: "downgrade", old: "v7.5.0", version: "v7.1.0", annotation: label.AnnAllowVersionDowngrade, wantErr: true},
+		{name: "downgrade dirty version", old: "v7.5.1-dev", version: "v7.5.0", annotation: label.AnnAllowVersionDowngrade, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			old := &v1alpha1.TidbCluster{Spec: v1alpha1.TidbClusterSpec{Version: tt.old}}
+			tc := &v1alpha1.TidbCluster{Spec: v1alpha1.TidbClusterSpec{Version: tt.version}}
+			errs := validateUpdateVersion(old, tc, field.NewPath("spec"))
+			if !tt.wantErr {
+				g.Expect(errs).To(BeEmpty())
+				return
+			}
+			g.Expect(errs).To(HaveLen(1))
+
+			tc.Annotations = map[string]string{tt.annotation: "true"}
+			g.Expect(validateUpdateVersion(old, tc, field.NewPath("spec"))).To(BeEmpty())
+		})
+	}
+}
+
+func TestValidateUpdateComponentVersion(t *testing.T) {
+	newTC := func() *v1alpha1.TidbCluster {
+		return &v1alpha1.TidbCluster{
+			Spec: v1alpha1.TidbClusterSpec{
+				Version: "v7.5.0",
+				PD:      &v1alpha1.PDSpec{},
+				TiKV:    &v1alpha1.TiKVSpec{},
+				TiKVGroups: []*v1alpha1.TiKVGroupSpec{
+					{Name: "hot", TiKVSpec: v1alpha1.TiKVSpec{ComponentSpec: v1alpha1.ComponentSpec{Version: pointer.StringPtr("v7.5.1")}}},
+				},
+				TiDB: &v1alpha1.TiDBSpec{},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		update func(tc *v1alpha1.TidbCluster)
+		errs   []string
+	}{
+		{
+			name:   "upgrade a component across major versions",
+			update: func(tc *v1alpha1.TidbCluster) { tc.Spec.TiDB.Version = pointer.StringPtr("v8.5.0") },
+		},
+		{
+			name:   "downgrade a component",
+			update: func(tc *v1alpha1.TidbCluster) { tc.Spec.PD.Version = pointer.StringPtr("v7.1.0") },
+			errs:   []string{"spec.pd.version"},
+		},
+		{
+			name:   "downgrade a TiKV group to the version of the cluster",
+			update: func(tc *v1alpha1.TidbCluster) { tc.Spec.TiKVGroups[0].Version = nil },
+			errs:   []string{"spec.tikvGroups[0].version"},
+		},
+		{
+			name: "downgrade the cluster",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.Version = "v7.1.0"
+				tc.Spec.TiKVGroups[0].Version = pointer.StringPtr("v7.1.0")
+			},
+			errs: []string{"spec.version", "spec.tikvGroups[0].version"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			old := newTC()
+			tc := newTC()
+			tt.update(tc)
+			fields := []string{}
+			for _, err := range validateUpdateVersion(old, tc, field.NewPath("spec")) {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(ConsistOf(tt.errs))
+
+			tc.Annotations = map[string]string{label.AnnAllowVersionDowngrade: "true"}
+			g.Expect(validateUpdateVersion(old, tc, field.NewPath("spec"))).To(BeEmpty())
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package start implements the start binary of the components used by the start script v3. Instead of
+// rendering shell scripts, the operator renders a Config for each component, and the binary waits for
+// the preconditions of the component, assembles its args and execs it.
+package start
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// The variables that can be referred by ${NAME} in the config besides the environment variables
+const (
+	// VarPodName is the name of the pod, it defaults to the hostname
+	VarPodName = "POD_NAME"
+	// VarPodOrdinal is the ordinal of the pod in its StatefulSet
+	VarPodOrdinal = "POD_ORDINAL"
+	// VarPDAddresses is the PD addresses verified by the discovery service, see VerifyPD
+	VarPDAddresses = "PD_ADDRESSES"
+)
+
+// Config is the config of the start binary for a component
+type Config struct {
+	// Component is the name of the component, it is only used in the logs
+	Component string `json:"component"`
+	// WaitForDNS waits until the domain of the pod is resolved before starting the component
+	WaitForDNS *WaitForDNS `json:"waitForDNS,omitempty"`
+	// VerifyPD gets the PD addresses from the discovery service if the cluster is deployed across
+	// Kubernetes clusters
+	VerifyPD *VerifyPD `json:"verifyPD,omitempty"`
+	// PDJoin decides the args of PD to join an existing cluster or to bootstrap a new one
+	PDJoin *PDJoin `json:"pdJoin,omitempty"`
+	// Files are rendered from templates before starting the component
+	Files []File `json:"files,omitempty"`
+	// Binary is the path of the binary of the component, nothing is executed if it is empty,
+	// e.g. if it is used by an init container
+	Binary string `json:"binary,omitempty"`
+	// Args are the args of the binary, the variables in them are expanded
+	Args []string `json:"args,omitempty"`
+	// OptionalArgs are appended to the args if their variable is not empty
+	OptionalArgs []OptionalArgs `json:"optionalArgs,omitempty"`
+	// SupportedArgs are appended to the args if the binary supports their flag
+	SupportedArgs []SupportedArgs `json:"supportedArgs,omitempty"`
+	// MaxStartDelaySeconds is the upper bound of the random delay before the binary is executed
+	MaxStartDelaySeconds int `json:"maxStartDelaySeconds,omitempty"`
+}
+
+// WaitForDNS is the config to wait for the domain of the pod
+type WaitForDNS struct {
+	// Domain is the domain of the pod
+	Domain string `json:"domain"`
+	// MatchIP waits until one of the resolved IPs matches the IPs of the pod
+	MatchIP bool `json:"matchIP,omitempty"`
+	// InitialDelaySeconds is the delay before the domain is resolved at the first time
+	InitialDelaySeconds int `json:"initialDelaySeconds,omitempty"`
+	// TimeoutSeconds is the timeout of waiting
+	TimeoutSeconds int `json:"timeoutSeconds"`
+}
+
+// VerifyPD is the config to get the PD addresses from the discovery service
+type VerifyPD struct {
+	// DiscoveryAddr is the address of the discovery service
+	DiscoveryAddr string `json:"discoveryAddr"`
+	// PDAddr is the address of PD to verify
+	PDAddr string `json:"pdAddr"`
+	// TrimScheme removes the scheme from the verified addresses
+	TrimScheme bool `json:"trimScheme,omitempty"`
+}
+
+// PDJoin is the config to decide the args of PD to join or to bootstrap the cluster
+type PDJoin struct {
+	// DataDir is the data directory of PD
+	DataDir string `json:"dataDir"`
+	// DiscoveryAddr is the address of the discovery service
+	DiscoveryAddr string `json:"discoveryAddr"`
+	// AdvertisePeerAddr is the advertised peer address of PD without scheme
+	AdvertisePeerAddr string `json:"advertisePeerAddr"`
+}
+
+// File is a file rendered from a template
+type File struct {
+	// Template is the path of the template
+	Template string `json:"template"`
+	// Path is the path of the rendered file
+	Path string `json:"path"`
+	// Replacements replaces the keys in the template with the values, the variables in the values
+	// are expanded
+	Replacements map[string]string `json:"replacements,omitempty"`
+}
+
+// OptionalArgs are the args appended if the variable is not empty
+type OptionalArgs struct {
+	// Var is the name of the variable
+	Var string `json:"var"`
+	// Args are the args to append
+	Args []string `json:"args"`
+}
+
+// SupportedArgs are the args appended if the help message of the binary contains the flag
+type SupportedArgs struct {
+	// Flag is the name of the flag without the leading dashes
+	Flag string `json:"flag"`
+	// Args are the args to append
+	Args []string `json:"args"`
+}
+
+// Marshal encodes the config, it is stored in the ConfigMap of the component
+func (c *Config) Marshal() (string, error) {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the start config of %s: %v", c.Component, err)
+	}
+	return string(data), nil
+}
+
+// Unmarshal decodes the config
+func Unmarshal(data []byte) (*Config, error) {
+	c := &Config{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the start config: %v", err)
+	}
+	return c, nil
+}
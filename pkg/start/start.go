@@ -0,0 +1,424 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package start
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// PodInfoAnnotationsPath is the path of the annotations of the pod exposed by the downward API
+	PodInfoAnnotationsPath = "/etc/podinfo/annotations"
+
+	runModeAnnotation = "runmode"
+	runModeDebug      = "debug"
+
+	discoveryTimeout = 3 * time.Second
+)
+
+type starter struct {
+	cfg  *Config
+	vars map[string]string
+
+	// the following fields are replaced in tests
+	podInfoPath   string
+	dnsPeriod     time.Duration
+	retryInterval func() time.Duration
+	lookupHost    func(ctx context.Context, host string) ([]string, error)
+	podIPs        func() ([]net.IP, error)
+	httpGet       func(ctx context.Context, url string) (string, error)
+	help          func(binary string) (string, error)
+	exec          func(binary string, argv []string, env []string) error
+}
+
+// Run waits for the preconditions of the component with the given config and executes it, it only
+// returns if the component fails to start, or if the config has no binary to execute.
+func Run(ctx context.Context, cfg *Config) error {
+	s, err := newStarter(cfg)
+	if err != nil {
+		return err
+	}
+	return s.run(ctx)
+}
+
+func newStarter(cfg *Config) (*starter, error) {
+	podName := os.Getenv(VarPodName)
+	if podName == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the hostname: %v", err)
+		}
+		podName = hostname
+	}
+	ordinal := ""
+	if i := strings.LastIndex(podName, "-"); i >= 0 {
+		ordinal = podName[i+1:]
+	}
+
+	return &starter{
+		cfg: cfg,
+		vars: map[string]string{
+			VarPodName:    podName,
+			VarPodOrdinal: ordinal,
+		},
+		podInfoPath: PodInfoAnnotationsPath,
+		dnsPeriod:   time.Second,
+		retryInterval: func() time.Duration {
+			return time.Duration(rand.Intn(5000)) * time.Millisecond
+		},
+		lookupHost: net.DefaultResolver.LookupHost,
+		podIPs:     podIPs,
+		httpGet:    httpGet,
+		help: func(binary string) (string, error) {
+			out, err := exec.Command(binary, "--help").CombinedOutput()
+			return string(out), err
+		},
+		exec: syscall.Exec,
+	}, nil
+}
+
+func (s *starter) run(ctx context.Context) error {
+	logger := klog.FromContext(ctx).WithValues("component", s.cfg.Component)
+
+	if s.cfg.Binary != "" {
+		debug, err := s.debugMode()
+		if err != nil {
+			return err
+		}
+		if debug {
+			logger.Info("Entering debug mode, the component is not started")
+			<-ctx.Done()
+			return ctx.Err()
+		}
+	}
+
+	if w := s.cfg.WaitForDNS; w != nil {
+		if err := s.waitForDNS(ctx, w); err != nil {
+			return err
+		}
+	}
+
+	if v := s.cfg.VerifyPD; v != nil {
+		addrs, err := s.verifyPD(ctx, v)
+		if err != nil {
+			return err
+		}
+		s.vars[VarPDAddresses] = addrs
+	}
+
+	args := s.expandAll(s.cfg.Args)
+	if j := s.cfg.PDJoin; j != nil {
+		joinArgs, err := s.pdJoinArgs(ctx, j)
+		if err != nil {
+			return err
+		}
+		args = append(args, joinArgs...)
+	}
+
+	for _, f := range s.cfg.Files {
+		if err := s.renderFile(f); err != nil {
+			return err
+		}
+		logger.Info("Rendered the file", "template", f.Template, "path", f.Path)
+	}
+
+	if s.cfg.Binary == "" {
+		return nil
+	}
+
+	for _, o := range s.cfg.OptionalArgs {
+		if s.lookup(o.Var) != "" {
+			args = append(args, s.expandAll(o.Args)...)
+		}
+	}
+	if len(s.cfg.SupportedArgs) > 0 {
+		// the help message is printed even if the binary exits with an error
+		help, err := s.help(s.cfg.Binary)
+		if help == "" && err != nil {
+			return fmt.Errorf("failed to get the help message of %s: %v", s.cfg.Binary, err)
+		}
+		for _, a := range s.cfg.SupportedArgs {
+			if strings.Contains(help, a.Flag) {
+				args = append(args, s.expandAll(a.Args)...)
+			}
+		}
+	}
+
+	if s.cfg.MaxStartDelaySeconds > 0 {
+		delay := time.Duration(rand.Intn(s.cfg.MaxStartDelaySeconds)) * time.Second
+		logger.Info("Delaying the start of the component", "delay", delay)
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Starting the component", "binary", s.cfg.Binary, "args", args)
+	if err := s.exec(s.cfg.Binary, append([]string{s.cfg.Binary}, args...), os.Environ()); err != nil {
+		return fmt.Errorf("failed to execute %s: %v", s.cfg.Binary, err)
+	}
+	return nil
+}
+
+// debugMode returns whether the pod runs in the debug mode, in which the component is not started
+func (s *starter) debugMode() (bool, error) {
+	data, err := os.ReadFile(s.podInfoPath)
+	if os.IsNotExist(err) {
+		// the annotations are not mounted into the pod
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read the annotations of the pod from %s: %v", s.podInfoPath, err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok || key != runModeAnnotation {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		return value == runModeDebug, nil
+	}
+	return false, scanner.Err()
+}
+
+// waitForDNS waits until the domain is resolved, and optionally until one of the resolved IPs is
+// the IP of the pod, which avoids starting with the stale DNS record of the previous pod.
+func (s *starter) waitForDNS(ctx context.Context, w *WaitForDNS) error {
+	logger := klog.FromContext(ctx).WithValues("component", s.cfg.Component)
+	domain := s.expand(w.Domain)
+
+	if err := sleep(ctx, time.Duration(w.InitialDelaySeconds)*time.Second); err != nil {
+		return err
+	}
+	timeout := time.Duration(w.TimeoutSeconds) * time.Second
+	start := time.Now()
+	for {
+		if err := sleep(ctx, s.dnsPeriod); err != nil {
+			return err
+		}
+		if time.Since(start) >= timeout {
+			return fmt.Errorf("timed out waiting for domain %s to be resolved after %v", domain, timeout)
+		}
+
+		resolved, err := s.lookupHost(ctx, domain)
+		if err != nil || len(resolved) == 0 {
+			logger.Info("Waiting for the domain to be resolved", "domain", domain, "error", err)
+			continue
+		}
+		if !w.MatchIP {
+			logger.Info("The domain is resolved", "domain", domain, "ips", resolved)
+			return nil
+		}
+
+		ips, err := s.podIPs()
+		if err != nil {
+			return fmt.Errorf("failed to get the IPs of the pod: %v", err)
+		}
+		for _, r := range resolved {
+			for _, ip := range ips {
+				if ip.Equal(net.ParseIP(r)) {
+					logger.Info("The resolved IP of the domain matches the IP of the pod", "domain", domain, "ip", r)
+					return nil
+				}
+			}
+		}
+		logger.Info("Waiting for the resolved IPs of the domain to match the IPs of the pod", "domain", domain, "resolved", resolved, "ips", ips)
+	}
+}
+
+// verifyPD returns the PD addresses verified by the discovery service
+func (s *starter) verifyPD(ctx context.Context, v *VerifyPD) (string, error) {
+	url := fmt.Sprintf("http://%s/verify/%s", v.DiscoveryAddr, base64.StdEncoding.EncodeToString([]byte(s.expand(v.PDAddr))))
+	result, err := s.getFromDiscovery(ctx, url, "Waiting for the verification of PD endpoints")
+	if err != nil {
+		return "", err
+	}
+	if v.TrimScheme {
+		result = strings.ReplaceAll(result, "http://", "")
+		result = strings.ReplaceAll(result, "https://", "")
+	}
+	return result, nil
+}
+
+// pdJoinArgs returns the args of PD to join an existing cluster if it has joined before, or the args
+// given by the discovery service if it has no data.
+func (s *starter) pdJoinArgs(ctx context.Context, j *PDJoin) ([]string, error) {
+	dataDir := s.expand(j.DataDir)
+	// the join file is written by PD and contains the members in the form of name=url,name=url
+	data, err := os.ReadFile(filepath.Join(dataDir, "join"))
+	if err == nil {
+		var urls []string
+		for _, member := range strings.Split(strings.TrimSpace(string(data)), ",") {
+			if _, url, ok := strings.Cut(member, "="); ok {
+				urls = append(urls, url)
+			}
+		}
+		return []string{"--join=" + strings.Join(urls, ",")}, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read the join file of PD: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dataDir, "member", "wal")); err == nil {
+		// PD restarts with its data
+		return nil, nil
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to check the data of PD: %v", err)
+	}
+
+	url := fmt.Sprintf("http://%s/new/%s", j.DiscoveryAddr, base64.StdEncoding.EncodeToString([]byte(s.expand(j.AdvertisePeerAddr))))
+	result, err := s.getFromDiscovery(ctx, url, "Waiting for the discovery service to return the start args")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(result), nil
+}
+
+func (s *starter) getFromDiscovery(ctx context.Context, url, msg string) (string, error) {
+	logger := klog.FromContext(ctx).WithValues("component", s.cfg.Component)
+	for {
+		result, err := s.httpGet(ctx, url)
+		if err == nil {
+			logger.Info("Got the result from the discovery service", "url", url, "result", result)
+			return result, nil
+		}
+		logger.Info(msg, "url", url, "error", err)
+		if err := sleep(ctx, s.retryInterval()); err != nil {
+			return "", err
+		}
+	}
+}
+
+func (s *starter) renderFile(f File) error {
+	data, err := os.ReadFile(f.Template)
+	if err != nil {
+		return fmt.Errorf("failed to read the template %s: %v", f.Template, err)
+	}
+	content := string(data)
+	for old, value := range f.Replacements {
+		content = strings.ReplaceAll(content, old, s.expand(value))
+	}
+	if err := os.WriteFile(f.Path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write the file %s: %v", f.Path, err)
+	}
+	return nil
+}
+
+// Install copies the running binary to dst, it is used by the init container to share the binary with
+// the containers of the components
+func Install(dst string) error {
+	src, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get the path of the binary: %v", err)
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("failed to read the binary %s: %v", src, err)
+	}
+	// write to a temporary file first so that a partial binary is never executed
+	tmp := dst + ".tmp"
+	if err := os.WriteFile(tmp, data, 0755); err != nil {
+		return fmt.Errorf("failed to write the binary %s: %v", tmp, err)
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		return fmt.Errorf("failed to install the binary to %s: %v", dst, err)
+	}
+	return nil
+}
+
+func (s *starter) lookup(name string) string {
+	if value, ok := s.vars[name]; ok {
+		return value
+	}
+	return os.Getenv(name)
+}
+
+func (s *starter) expand(str string) string {
+	return os.Expand(str, s.lookup)
+}
+
+func (s *starter) expandAll(strs []string) []string {
+	res := make([]string, 0, len(strs))
+	for _, str := range strs {
+		res = append(res, s.expand(str))
+	}
+	return res
+}
+
+func podIPs() ([]net.IP, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+	var ips []net.IP
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() {
+			ips = append(ips, ipNet.IP)
+		}
+	}
+	return ips, nil
+}
+
+func httpGet(ctx context.Context, url string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, discoveryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
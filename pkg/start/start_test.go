@@ -0,0 +1,249 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package start
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+type fakeExec struct {
+	binary string
+	argv   []string
+}
+
+func newFakeStarter(t *testing.T, cfg *Config) (*starter, *fakeExec) {
+	e := &fakeExec{}
+	s := &starter{
+		cfg: cfg,
+		vars: map[string]string{
+			VarPodName:    "basic-pd-1",
+			VarPodOrdinal: "1",
+		},
+		podInfoPath:   filepath.Join(t.TempDir(), "annotations"),
+		dnsPeriod:     time.Millisecond,
+		retryInterval: func() time.Duration { return time.Millisecond },
+		lookupHost: func(ctx context.Context, host string) ([]string, error) {
+			return []string{"10.0.0.1"}, nil
+		},
+		podIPs: func() ([]net.IP, error) {
+			return []net.IP{net.ParseIP("10.0.0.1")}, nil
+		},
+		httpGet: func(ctx context.Context, url string) (string, error) {
+			return "", errors.New("unexpected request " + url)
+		},
+		help: func(binary string) (string, error) {
+			return "", errors.New("unexpected help of " + binary)
+		},
+		exec: func(binary string, argv []string, env []string) error {
+			e.binary = binary
+			e.argv = argv
+			return nil
+		},
+	}
+	return s, e
+}
+
+func TestRun(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	t.Setenv("STORE_LABELS", "zone=z1")
+	t.Setenv("SLOW_LOG_FILE", "")
+	s, e := newFakeStarter(t, &Config{
+		Component: "tikv",
+		Binary:    "/tikv-server",
+		Args:      []string{"--advertise-addr=${POD_NAME}.basic-tikv-peer:20160"},
+		OptionalArgs: []OptionalArgs{
+			{Var: "STORE_LABELS", Args: []string{"--labels", "${STORE_LABELS}"}},
+			{Var: "SLOW_LOG_FILE", Args: []string{"--log-slow-query=${SLOW_LOG_FILE}"}},
+		},
+		SupportedArgs: []SupportedArgs{
+			{Flag: "advertise-status-addr", Args: []string{"--advertise-status-addr=${POD_NAME}:20180"}},
+			{Flag: "unknown-flag", Args: []string{"--unknown-flag"}},
+		},
+	})
+	s.help = func(binary string) (string, error) {
+		// the help message is used even if the binary exits with an error
+		return "--advertise-status-addr <ADDR>", errors.New("exit status 1")
+	}
+
+	g.Expect(s.run(context.Background())).Should(gomega.Succeed())
+	g.Expect(e.binary).Should(gomega.Equal("/tikv-server"))
+	g.Expect(e.argv).Should(gomega.Equal([]string{
+		"/tikv-server",
+		"--advertise-addr=basic-pd-1.basic-tikv-peer:20160",
+		"--labels", "zone=z1",
+		"--advertise-status-addr=basic-pd-1:20180",
+	}))
+}
+
+func TestRunDebugMode(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	s, e := newFakeStarter(t, &Config{Component: "tidb", Binary: "/tidb-server"})
+	g.Expect(os.WriteFile(s.podInfoPath, []byte("a=\"b\"\nrunmode=\"debug\"\n"), 0644)).Should(gomega.Succeed())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	g.Expect(s.run(ctx)).Should(gomega.MatchError(context.DeadlineExceeded))
+	g.Expect(e.binary).Should(gomega.BeEmpty())
+
+	g.Expect(os.WriteFile(s.podInfoPath, []byte("runmode=\"normal\"\n"), 0644)).Should(gomega.Succeed())
+	g.Expect(s.run(context.Background())).Should(gomega.Succeed())
+	g.Expect(e.binary).Should(gomega.Equal("/tidb-server"))
+}
+
+func TestWaitForDNS(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	s, _ := newFakeStarter(t, &Config{Component: "pd"})
+	var lookups []string
+	resolved := [][]string{nil, {"10.0.0.2"}, {"10.0.0.2", "10.0.0.1"}}
+	s.lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		lookups = append(lookups, host)
+		if len(lookups) > len(resolved) {
+			return resolved[len(resolved)-1], nil
+		}
+		return resolved[len(lookups)-1], nil
+	}
+
+	// the domain is resolved to the stale IP first
+	g.Expect(s.waitForDNS(context.Background(), &WaitForDNS{Domain: "${POD_NAME}.basic-pd-peer", MatchIP: true, TimeoutSeconds: 10})).Should(gomega.Succeed())
+	g.Expect(lookups).Should(gomega.HaveLen(3))
+	g.Expect(lookups[0]).Should(gomega.Equal("basic-pd-1.basic-pd-peer"))
+
+	lookups = nil
+	g.Expect(s.waitForDNS(context.Background(), &WaitForDNS{Domain: "basic-pd-peer", TimeoutSeconds: 10})).Should(gomega.Succeed())
+	g.Expect(lookups).Should(gomega.HaveLen(2))
+
+	s.lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		return nil, errors.New("no such host")
+	}
+	g.Expect(s.waitForDNS(context.Background(), &WaitForDNS{Domain: "basic-pd-peer", TimeoutSeconds: 0})).Should(gomega.MatchError(gomega.ContainSubstring("timed out")))
+}
+
+func TestVerifyPD(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	s, e := newFakeStarter(t, &Config{
+		Component: "tidb",
+		VerifyPD: &VerifyPD{
+			DiscoveryAddr: "basic-discovery.ns:10261",
+			PDAddr:        "basic-pd:2379",
+			TrimScheme:    true,
+		},
+		Binary: "/tidb-server",
+		Args:   []string{"--path=${PD_ADDRESSES}"},
+	})
+	requests := 0
+	s.httpGet = func(ctx context.Context, url string) (string, error) {
+		requests++
+		g.Expect(url).Should(gomega.Equal("http://basic-discovery.ns:10261/verify/YmFzaWMtcGQ6MjM3OQ=="))
+		if requests == 1 {
+			return "", errors.New("connection refused")
+		}
+		return "http://basic-pd:2379,https://pd-0.other:2379", nil
+	}
+
+	g.Expect(s.run(context.Background())).Should(gomega.Succeed())
+	g.Expect(requests).Should(gomega.Equal(2))
+	g.Expect(e.argv).Should(gomega.Equal([]string{"/tidb-server", "--path=basic-pd:2379,pd-0.other:2379"}))
+}
+
+func TestPDJoinArgs(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	dataDir := t.TempDir()
+	s, _ := newFakeStarter(t, &Config{Component: "pd"})
+	j := &PDJoin{
+		DataDir:           dataDir,
+		DiscoveryAddr:     "basic-discovery.ns:10261",
+		AdvertisePeerAddr: "${POD_NAME}.basic-pd-peer:2380",
+	}
+
+	// a new member gets the args from the discovery service
+	s.httpGet = func(ctx context.Context, url string) (string, error) {
+		g.Expect(url).Should(gomega.Equal("http://basic-discovery.ns:10261/new/YmFzaWMtcGQtMS5iYXNpYy1wZC1wZWVyOjIzODA="))
+		return "--initial-cluster=basic-pd-1=http://basic-pd-1.basic-pd-peer:2380", nil
+	}
+	args, err := s.pdJoinArgs(context.Background(), j)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(args).Should(gomega.Equal([]string{"--initial-cluster=basic-pd-1=http://basic-pd-1.basic-pd-peer:2380"}))
+
+	// a member with data restarts without args
+	g.Expect(os.MkdirAll(filepath.Join(dataDir, "member", "wal"), 0755)).Should(gomega.Succeed())
+	s.httpGet = func(ctx context.Context, url string) (string, error) {
+		return "", errors.New("unexpected request " + url)
+	}
+	args, err = s.pdJoinArgs(context.Background(), j)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(args).Should(gomega.BeEmpty())
+
+	// a member that has joined the cluster joins with the members in the join file
+	g.Expect(os.WriteFile(filepath.Join(dataDir, "join"), []byte("pd-0=http://pd-0:2380,pd-1=http://pd-1:2380\n"), 0644)).Should(gomega.Succeed())
+	args, err = s.pdJoinArgs(context.Background(), j)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(args).Should(gomega.Equal([]string{"--join=http://pd-0:2380,http://pd-1:2380"}))
+}
+
+func TestRenderFiles(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	dir := t.TempDir()
+	tmpl := filepath.Join(dir, "config_templ.toml")
+	g.Expect(os.WriteFile(tmpl, []byte("path = \"/data0/db-POD_NUM\"\npd_addr = \"PD_ADDR\"\n"), 0644)).Should(gomega.Succeed())
+	path := filepath.Join(dir, "config.toml")
+
+	s, e := newFakeStarter(t, &Config{
+		Component: "tiflash-init",
+		Files: []File{{
+			Template:     tmpl,
+			Path:         path,
+			Replacements: map[string]string{"POD_NUM": "${POD_ORDINAL}"},
+		}},
+	})
+
+	// nothing is executed without a binary
+	g.Expect(s.run(context.Background())).Should(gomega.Succeed())
+	g.Expect(e.binary).Should(gomega.BeEmpty())
+	data, err := os.ReadFile(path)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(string(data)).Should(gomega.Equal("path = \"/data0/db-1\"\npd_addr = \"PD_ADDR\"\n"))
+}
+
+func TestConfigMarshal(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg := &Config{
+		Component:  "pd",
+		WaitForDNS: &WaitForDNS{Domain: "${POD_NAME}.basic-pd-peer", TimeoutSeconds: 30},
+		Binary:     "/pd-server",
+		Args:       []string{"--name=${POD_NAME}"},
+	}
+	data, err := cfg.Marshal()
+	g.Expect(err).Should(gomega.Succeed())
+	decoded, err := Unmarshal([]byte(data))
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(decoded).Should(gomega.Equal(cfg))
+
+	_, err = Unmarshal([]byte("#!/bin/sh"))
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
@@ -21,6 +21,7 @@ type ActionType string
 
 const (
 	GetStoreStatusActionType ActionType = "GetStoreStatus"
+	GetConfigActionType      ActionType = "GetConfig"
 )
 
 type NotFoundReaction struct {
@@ -74,3 +75,12 @@ func (c *FakeTiFlashClient) GetStoreStatus() (Status, error) {
 	}
 	return result.(Status), nil
 }
+
+func (c *FakeTiFlashClient) GetConfig() (map[string]interface{}, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(GetConfigActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]interface{}), nil
+}
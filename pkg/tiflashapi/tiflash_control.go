@@ -16,6 +16,7 @@ package tiflashapi
 import (
 	"crypto/tls"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -75,7 +76,12 @@ func tiflashPodClientKey(schema, namespace, clusterName, podName string) string
 
 // TiFlashPodClientURL builds the url of tiflash pod client
 func TiFlashPodClientURL(namespace, clusterName, podName, scheme string) string {
-	return fmt.Sprintf("%s://%s.%s-tiflash-peer.%s:%d", scheme, podName, clusterName, namespace, v1alpha1.DefaultTiFlashProxyStatusPort)
+	peerSvcName := fmt.Sprintf("%s-tiflash-peer", clusterName)
+	// the pods of TiFlash compute nodes are served by their own headless service
+	if computeMemberName := fmt.Sprintf("%s-tiflash-compute", clusterName); strings.HasPrefix(podName, computeMemberName+"-") {
+		peerSvcName = computeMemberName + "-peer"
+	}
+	return fmt.Sprintf("%s://%s.%s.%s:%d", scheme, podName, peerSvcName, namespace, v1alpha1.DefaultTiFlashProxyStatusPort)
 }
 
 // FakeTiFlashControl implements a fake version of TiFlashControlInterface.
@@ -23,6 +23,8 @@ type ActionType string
 const (
 	GetLeaderCountActionType      ActionType = "GetLeaderCount"
 	FlushLogBackupTasksActionType ActionType = "FlushLogBackupTasks"
+	UpdateConfigActionType        ActionType = "UpdateConfig"
+	GetConfigActionType           ActionType = "GetConfig"
 )
 
 type NotFoundReaction struct {
@@ -37,6 +39,7 @@ type Action struct {
 	ID     uint64
 	Name   string
 	Labels map[string]string
+	Config map[string]string
 }
 
 type Reaction func(action *Action) (interface{}, error)
@@ -81,3 +84,20 @@ func (c *FakeTiKVClient) FlushLogBackupTasks(ctx context.Context) error {
 	_, err := c.fakeAPI(FlushLogBackupTasksActionType, action)
 	return err
 }
+
+// UpdateConfig implements TiKVClient.
+func (c *FakeTiKVClient) UpdateConfig(config map[string]string) error {
+	action := &Action{Config: config}
+	_, err := c.fakeAPI(UpdateConfigActionType, action)
+	return err
+}
+
+// GetConfig implements TiKVClient.
+func (c *FakeTiKVClient) GetConfig() (map[string]interface{}, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(GetConfigActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]interface{}), nil
+}
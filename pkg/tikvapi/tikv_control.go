@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/tracing"
 	"github.com/pingcap/tidb-operator/pkg/util"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
@@ -27,8 +28,8 @@ import (
 
 // TiKVControlInterface is an interface that knows how to manage and get client for TiKV
 type TiKVControlInterface interface {
-	// GetTiKVPodClient provides TiKVClient of the TiKV cluster.
-	GetTiKVPodClient(namespace string, tcName string, podName, clusterDomain string, tlsEnabled bool) TiKVClient
+	// GetTiKVPodClient provides TiKVClient of the TiKV pod, which is addressed by the given peer service.
+	GetTiKVPodClient(namespace string, tcName string, peerServiceName, podName, clusterDomain string, tlsEnabled bool) TiKVClient
 }
 
 // defaultTiKVControl is the default implementation of TiKVControlInterface.
@@ -43,7 +44,7 @@ func NewDefaultTiKVControl(secretLister corelisterv1.SecretLister) TiKVControlIn
 	return &defaultTiKVControl{secretLister: secretLister, tikvClients: map[string]TiKVClient{}}
 }
 
-func (tc *defaultTiKVControl) GetTiKVPodClient(namespace string, tcName string, podName, clusterDomain string, tlsEnabled bool) TiKVClient {
+func (tc *defaultTiKVControl) GetTiKVPodClient(namespace string, tcName string, peerServiceName, podName, clusterDomain string, tlsEnabled bool) TiKVClient {
 	tc.mutex.Lock()
 	defer tc.mutex.Unlock()
 
@@ -51,25 +52,32 @@ func (tc *defaultTiKVControl) GetTiKVPodClient(namespace string, tcName string,
 	var err error
 	var configOfSchema = func(scheme string) TiKVClientOpts {
 		return TiKVClientOpts{
-			HTTPEndpoint:      TiKVPodClientURL(namespace, tcName, podName, scheme, clusterDomain),
-			GRPCEndpoint:      TiKVGRPCClientURL(namespace, tcName, podName, scheme, clusterDomain),
+			HTTPEndpoint:      TiKVPodClientURL(namespace, peerServiceName, podName, scheme, clusterDomain),
+			GRPCEndpoint:      TiKVGRPCClientURL(namespace, peerServiceName, podName, scheme, clusterDomain),
 			Timeout:           DefaultTimeout,
 			TLSConfig:         tlsConfig,
 			DisableKeepAlives: true,
 		}
 	}
 
+	var newClient = func(scheme string) TiKVClient {
+		client := NewTiKVClient(configOfSchema(scheme)).(*tikvClient)
+		// the requests are traced as a part of the reconcile of the TidbCluster
+		client.httpClient.Transport = tracing.WrapTransport(namespace, tcName, client.httpClient.Transport)
+		return client
+	}
+
 	if tlsEnabled {
 		tlsConfig, err = pdapi.GetTLSConfig(tc.secretLister, pdapi.Namespace(namespace), util.ClusterClientTLSSecretName(tcName))
 		if err != nil {
 			klog.Errorf("Unable to get tls config for TiKV cluster %q, tikv client may not work: %v", tcName, err)
-			return NewTiKVClient(configOfSchema("https"))
+			return newClient("https")
 		}
 
-		return NewTiKVClient(configOfSchema("https"))
+		return newClient("https")
 	}
 
-	return NewTiKVClient(configOfSchema("http"))
+	return newClient("http")
 }
 
 func tikvPodClientKey(schema, namespace, clusterName, podName string) string {
@@ -77,20 +85,20 @@ func tikvPodClientKey(schema, namespace, clusterName, podName string) string {
 }
 
 // TiKVPodClientURL builds the url of tikv pod client
-func TiKVPodClientURL(namespace, clusterName, podName, scheme, clusterDomain string) string {
-	return attachPort(tiKVBaseURL(namespace, clusterName, podName, scheme, clusterDomain), v1alpha1.DefaultTiKVStatusPort)
+func TiKVPodClientURL(namespace, peerServiceName, podName, scheme, clusterDomain string) string {
+	return attachPort(tiKVBaseURL(namespace, peerServiceName, podName, scheme, clusterDomain), v1alpha1.DefaultTiKVStatusPort)
 }
 
 // TiKVGRPCURL builds the url of tikv grpc client
-func TiKVGRPCClientURL(namespace, clusterName, podName, scheme, clusterDomain string) string {
-	return attachPort(tiKVBaseURL(namespace, clusterName, podName, scheme, clusterDomain), v1alpha1.DefaultTiKVServerPort)
+func TiKVGRPCClientURL(namespace, peerServiceName, podName, scheme, clusterDomain string) string {
+	return attachPort(tiKVBaseURL(namespace, peerServiceName, podName, scheme, clusterDomain), v1alpha1.DefaultTiKVServerPort)
 }
 
-func tiKVBaseURL(namespace, clusterName, podName, scheme, clusterDomain string) string {
+func tiKVBaseURL(namespace, peerServiceName, podName, scheme, clusterDomain string) string {
 	if clusterDomain != "" {
-		return fmt.Sprintf("%s://%s.%s-tikv-peer.%s.svc.%s", scheme, podName, clusterName, namespace, clusterDomain)
+		return fmt.Sprintf("%s://%s.%s.%s.svc.%s", scheme, podName, peerServiceName, namespace, clusterDomain)
 	}
-	return fmt.Sprintf("%s://%s.%s-tikv-peer.%s", scheme, podName, clusterName, namespace)
+	return fmt.Sprintf("%s://%s.%s.%s", scheme, podName, peerServiceName, namespace)
 }
 
 func attachPort(path string, port int32) string {
@@ -114,6 +122,6 @@ func (ftc *FakeTiKVControl) SetTiKVPodClient(namespace, tcName, podName string,
 	ftc.tikvPodClients[tikvPodClientKey("http", namespace, tcName, podName)] = tikvPodClient
 }
 
-func (ftc *FakeTiKVControl) GetTiKVPodClient(namespace, tcName, podName, clusterDomain string, tlsEnabled bool) TiKVClient {
+func (ftc *FakeTiKVControl) GetTiKVPodClient(namespace, tcName, peerServiceName, podName, clusterDomain string, tlsEnabled bool) TiKVClient {
 	return ftc.tikvPodClients[tikvPodClientKey("http", namespace, tcName, podName)]
 }
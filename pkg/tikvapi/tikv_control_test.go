@@ -0,0 +1,32 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tikvapi
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTiKVPodClientURL(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(TiKVPodClientURL("ns", "demo-tikv-peer", "demo-tikv-0", "http", "")).
+		To(Equal("http://demo-tikv-0.demo-tikv-peer.ns:20180"))
+	// the pods of a TiKV group are addressed by the peer service of the group
+	g.Expect(TiKVPodClientURL("ns", "demo-tikv-g1-peer", "demo-tikv-g1-0", "https", "cluster.local")).
+		To(Equal("https://demo-tikv-g1-0.demo-tikv-g1-peer.ns.svc.cluster.local:20180"))
+	g.Expect(TiKVGRPCClientURL("ns", "demo-tikv-g1-peer", "demo-tikv-g1-0", "http", "")).
+		To(Equal("http://demo-tikv-g1-0.demo-tikv-g1-peer.ns:20160"))
+}
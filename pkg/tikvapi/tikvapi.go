@@ -14,8 +14,10 @@
 package tikvapi
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -25,6 +27,8 @@ import (
 
 	"github.com/pingcap/errors"
 	logbackup "github.com/pingcap/kvproto/pkg/logbackuppb"
+	"github.com/pingcap/tidb-operator/pkg/tracing"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/prom2json"
 	"google.golang.org/grpc"
@@ -38,12 +42,18 @@ const (
 	metricNameRegionCount = "tikv_raftstore_region_count"
 	labelNameLeaderCount  = "leader"
 	metricsPrefix         = "metrics"
+	configPrefix          = "config"
 )
 
 // TiKVClient provides tikv server's api
 type TiKVClient interface {
 	GetLeaderCount() (int, error)
 	FlushLogBackupTasks(ctx context.Context) error
+	// UpdateConfig changes the config of the tikv server online, the key of the config is
+	// the dotted path of the config item, e.g. `raftstore.raft-log-gc-threshold`.
+	UpdateConfig(config map[string]string) error
+	// GetConfig returns the running config of the tikv server
+	GetConfig() (map[string]interface{}, error)
 }
 
 type lazyGRPCConn struct {
@@ -136,6 +146,31 @@ func (c *tikvClient) GetLeaderCount() (int, error) {
 	return 0, fmt.Errorf("metric %s{type=\"%s\"} not found for %s", metricNameRegionCount, labelNameLeaderCount, apiURL)
 }
 
+// UpdateConfig changes the config of the tikv server online
+func (c *tikvClient) UpdateConfig(config map[string]string) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, configPrefix)
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	_, err = httputil.PostBodyOK(c.httpClient, apiURL, bytes.NewBuffer(data))
+	return err
+}
+
+// GetConfig returns the running config of the tikv server
+func (c *tikvClient) GetConfig() (map[string]interface{}, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, configPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	config := map[string]interface{}{}
+	if err := json.Unmarshal(body, &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 type TiKVClientOpts struct {
 	HTTPEndpoint      string
 	GRPCEndpoint      string
@@ -152,6 +187,7 @@ func NewTiKVClient(opts TiKVClientOpts) TiKVClient {
 	} else {
 		conn.opts = append(conn.opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
+	conn.opts = append(conn.opts, tracing.GRPCDialOptions()...)
 	conn.target = opts.GRPCEndpoint
 	for _, prefix := range []string{"http://", "https://"} {
 		conn.target = strings.TrimPrefix(conn.target, prefix)
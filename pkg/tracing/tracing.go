@@ -0,0 +1,199 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing exports the traces of the reconciles of the controller-manager by OTLP.
+//
+// The interfaces of the member managers and the clients of the components do not accept a context,
+// so the context of the ongoing reconcile of an object is kept in a registry keyed by the namespace
+// and the name of the object. It is safe because an object is never reconciled by two workers at the
+// same time. All functions are no-ops if tracing is not enabled.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	tracerName = "github.com/pingcap/tidb-operator"
+
+	// DefaultServiceName is the service name of the traces of the controller-manager
+	DefaultServiceName = "tidb-controller-manager"
+)
+
+var (
+	enabled atomic.Bool
+	// contexts maps the key of an object to the context of its ongoing reconcile
+	contexts sync.Map
+)
+
+// Config is the configuration of the OTLP exporter
+type Config struct {
+	// Endpoint is the address of the OTLP gRPC receiver, tracing is disabled if it is empty
+	Endpoint string
+	// Insecure disables the TLS of the connection to the receiver
+	Insecure bool
+	// SampleRatio is the ratio of the reconciles to trace
+	SampleRatio float64
+	// ServiceName is the service name of the traces
+	ServiceName string
+}
+
+// Init enables tracing with the given config and returns a function that flushes the remaining
+// spans and stops the exporter.
+func Init(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	if cfg.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return nil, fmt.Errorf("tracing sample ratio %v is not in [0, 1]", cfg.SampleRatio)
+	}
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = DefaultServiceName
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP exporter for %s: %v", cfg.Endpoint, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	enabled.Store(true)
+
+	return func(ctx context.Context) error {
+		enabled.Store(false)
+		return provider.Shutdown(ctx)
+	}, nil
+}
+
+// Enabled returns whether tracing is enabled
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// StartReconcile starts the root span of a reconcile of the object and returns the function
+// to end it with the result of the reconcile.
+func StartReconcile(kind string, obj metav1.Object) func(error) {
+	if !Enabled() {
+		return func(error) {}
+	}
+
+	key := objectKey(obj.GetNamespace(), obj.GetName())
+	ctx, span := otel.Tracer(tracerName).Start(context.Background(), "Reconcile "+kind,
+		trace.WithAttributes(
+			attribute.String("k8s.kind", kind),
+			attribute.String("k8s.namespace.name", obj.GetNamespace()),
+			attribute.String("k8s.object.name", obj.GetName()),
+		))
+	contexts.Store(key, ctx)
+	return func(err error) {
+		contexts.Delete(key)
+		endSpan(span, err)
+	}
+}
+
+// StartSpan starts a child span of the ongoing reconcile of the object and returns the function to end it
+// with the result of the step. The steps started after it and before it ends become its children.
+func StartSpan(obj metav1.Object, name string, attrs ...attribute.KeyValue) func(error) {
+	if !Enabled() {
+		return func(error) {}
+	}
+
+	key := objectKey(obj.GetNamespace(), obj.GetName())
+	parent, ok := contexts.Load(key)
+	if !ok {
+		// the object is not reconciled by a traced controller
+		return func(error) {}
+	}
+	ctx, span := otel.Tracer(tracerName).Start(parent.(context.Context), name, trace.WithAttributes(attrs...))
+	contexts.Store(key, ctx)
+	return func(err error) {
+		contexts.Store(key, parent)
+		endSpan(span, err)
+	}
+}
+
+// WrapTransport instruments the round tripper of a client of the TidbCluster with the given namespace
+// and name, the requests become children of the ongoing step of the reconcile of the TidbCluster.
+func WrapTransport(namespace, name string, rt http.RoundTripper) http.RoundTripper {
+	if !Enabled() {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &transport{
+		key:  objectKey(namespace, name),
+		next: otelhttp.NewTransport(rt),
+	}
+}
+
+// GRPCDialOptions returns the options to instrument a gRPC client
+func GRPCDialOptions() []grpc.DialOption {
+	if !Enabled() {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithStatsHandler(otelgrpc.NewClientHandler())}
+}
+
+type transport struct {
+	key  string
+	next http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if ctx, ok := contexts.Load(t.key); ok {
+		span := trace.SpanFromContext(ctx.(context.Context))
+		req = req.WithContext(trace.ContextWithSpan(req.Context(), span))
+	}
+	return t.next.RoundTrip(req)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func objectKey(namespace, name string) string {
+	return namespace + "/" + name
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDisabled(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	shutdown, err := Init(context.Background(), Config{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(Enabled()).To(BeFalse())
+	g.Expect(shutdown(context.Background())).To(Succeed())
+
+	_, err = Init(context.Background(), Config{Endpoint: "localhost:4317", SampleRatio: 2})
+	g.Expect(err).To(HaveOccurred())
+
+	obj := &metav1.ObjectMeta{Namespace: "ns", Name: "basic"}
+	StartReconcile("TidbCluster", obj)(nil)
+	StartSpan(obj, "step")(nil)
+	g.Expect(WrapTransport("ns", "basic", http.DefaultTransport)).To(BeIdenticalTo(http.DefaultTransport))
+	g.Expect(GRPCDialOptions()).To(BeEmpty())
+}
+
+func TestSpans(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	enabled.Store(true)
+	defer enabled.Store(false)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	client := &http.Client{Transport: WrapTransport("ns", "basic", nil)}
+
+	obj := &metav1.ObjectMeta{Namespace: "ns", Name: "basic"}
+	endReconcile := StartReconcile("TidbCluster", obj)
+	endManager := StartSpan(obj, "PDMemberManager.Sync")
+	endScaler := StartSpan(obj, "Scaler.Scale")
+	resp, err := client.Get(server.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+	resp.Body.Close()
+	endScaler(fmt.Errorf("scale failed"))
+	endManager(nil)
+	// the span started after the scaler ends is a child of the root span
+	StartSpan(obj, "TiKVMemberManager.Sync")(nil)
+	endReconcile(nil)
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	g.Expect(spans).To(HaveLen(5))
+	root := spans["Reconcile TidbCluster"]
+	g.Expect(root.Parent.IsValid()).To(BeFalse())
+	g.Expect(spans["PDMemberManager.Sync"].Parent.SpanID()).To(Equal(root.SpanContext.SpanID()))
+	g.Expect(spans["TiKVMemberManager.Sync"].Parent.SpanID()).To(Equal(root.SpanContext.SpanID()))
+	g.Expect(spans["Scaler.Scale"].Parent.SpanID()).To(Equal(spans["PDMemberManager.Sync"].SpanContext.SpanID()))
+	g.Expect(spans["Scaler.Scale"].Status.Code).To(Equal(codes.Error))
+	g.Expect(spans["HTTP GET"].Parent.SpanID()).To(Equal(spans["Scaler.Scale"].SpanContext.SpanID()))
+
+	// the steps of an object that is not reconciled are not traced
+	exporter.Reset()
+	StartSpan(obj, "PDMemberManager.Sync")(nil)
+	g.Expect(exporter.GetSpans()).To(BeEmpty())
+}
@@ -18,6 +18,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -52,6 +53,41 @@ func TestNewCSR(t *testing.T) {
 	g.Expect(csrObj.IPAddresses[1].String()).Should(Equal("fe80:2333::dead:beef"))
 }
 
+func TestNewSignedCert(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	caCert, caKey, err := NewCA("test-ca", 24*time.Hour)
+	g.Expect(err).Should(BeNil())
+	ca, err := ParseCertificate(caCert)
+	g.Expect(err).Should(BeNil())
+	g.Expect(ca.IsCA).Should(BeTrue())
+	g.Expect(ca.Subject.CommonName).Should(Equal("test-ca"))
+
+	certPEM, keyPEM, err := NewSignedCert(caCert, caKey, "pd", []string{"test-pd", "*.test-pd-peer"}, []string{"127.0.0.1"}, 48*time.Hour)
+	g.Expect(err).Should(BeNil())
+	cert, err := ParseCertificate(certPEM)
+	g.Expect(err).Should(BeNil())
+	g.Expect(cert.Subject.CommonName).Should(Equal("pd"))
+	g.Expect(cert.DNSNames).Should(Equal([]string{"test-pd", "*.test-pd-peer"}))
+	g.Expect(cert.IPAddresses[0].String()).Should(Equal("127.0.0.1"))
+	g.Expect(cert.ExtKeyUsage).Should(ConsistOf(x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth))
+	// the certificate does not outlive the CA
+	g.Expect(cert.NotAfter).Should(Equal(ca.NotAfter))
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	_, err = cert.Verify(x509.VerifyOptions{DNSName: "test-pd-0.test-pd-peer", Roots: pool})
+	g.Expect(err).Should(BeNil())
+
+	// the key pair can be loaded as a tls config
+	_, err = LoadTlsConfigFromSecret(&corev1.Secret{Data: map[string][]byte{
+		corev1.ServiceAccountRootCAKey: caCert,
+		corev1.TLSCertKey:              certPEM,
+		corev1.TLSPrivateKeyKey:        keyPEM,
+	}})
+	g.Expect(err).Should(BeNil())
+}
+
 var certData = []byte(`-----BEGIN CERTIFICATE-----
 MIIEMDCCAxigAwIBAgIQUJRs7Bjq1ZxN1ZfvdY+grTANBgkqhkiG9w0BAQUFADCB
 gjELMAkGA1UEBhMCVVMxHjAcBgNVBAsTFXd3dy54cmFtcHNlY3VyaXR5LmNvbTEk
@@ -128,6 +128,9 @@ func GetPodOrdinals(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) (s
 	} else if memberType == v1alpha1.TiDBMemberType {
 		ann = label.AnnTiDBDeleteSlots
 		replicas = tc.Spec.TiDB.Replicas
+		if tc.TiDBIdle() {
+			replicas = 0
+		}
 	} else if memberType == v1alpha1.TiFlashMemberType {
 		ann = label.AnnTiFlashDeleteSlots
 		replicas = tc.Spec.TiFlash.Replicas
@@ -216,6 +219,11 @@ func ClusterTLSSecretName(tcName, component string) string {
 	return fmt.Sprintf("%s-%s-cluster-secret", tcName, component)
 }
 
+// ClusterTLSCASecretName returns the name of the Secret that stores the CA generated by the operator
+func ClusterTLSCASecretName(tcName string) string {
+	return fmt.Sprintf("%s-cluster-ca-secret", tcName)
+}
+
 func TiDBClientTLSSecretName(tcName string, secretName *string) string {
 	if secretName != nil {
 		return *secretName
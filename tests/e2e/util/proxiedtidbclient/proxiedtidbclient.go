@@ -42,6 +42,18 @@ func (p *proxiedTiDBClient) SetServerLabels(tc *v1alpha1.TidbCluster, ordinal in
 	panic("implement when necessary")
 }
 
+func (p *proxiedTiDBClient) SetVariables(tc *v1alpha1.TidbCluster, ordinal int32, variables map[string]string) error {
+	panic("implement when necessary")
+}
+
+func (p *proxiedTiDBClient) GetConfig(tc *v1alpha1.TidbCluster, ordinal int32) (map[string]interface{}, error) {
+	panic("implement when necessary")
+}
+
+func (p *proxiedTiDBClient) GetStatus(tc *v1alpha1.TidbCluster, ordinal int32) (*controller.DBStatus, error) {
+	panic("implement when necessary")
+}
+
 func NewProxiedTiDBClient(fw portforward.PortForward, caCert []byte) controller.TiDBControlInterface {
 	return &proxiedTiDBClient{fw: fw, httpClient: &http.Client{Timeout: 5 * time.Second}, caCert: caCert}
 }